@@ -17,16 +17,39 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
 
 	"github.com/google/sbom-conformance/pkg/checkers/base"
 	"github.com/openconfig/security-services/cli/cmd/sbom"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+	"github.com/spdx/tools-golang/spdx"
 	"github.com/spf13/cobra"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	spdxjson "github.com/spdx/tools-golang/json"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"google.golang.org/protobuf/proto"
 )
 
+// Version is the build version of the CLI. It can be set at build time with
+// -ldflags "-X github.com/openconfig/security-services/cli/cmd.Version=v1.0.0"
+// and defaults to the module version recorded in the binary.
+var Version = ""
+
+// toolName identifies the CLI as an SPDX creator.
+const toolName = "openconfig-sbom-cli"
+
+const formatUsage = "Format of the SBOM (auto, cyclonedx-v16-proto, cyclonedx-v16-json, cyclonedx-xml, " +
+	"spdx-v23-json or spdx-v23-tagvalue)"
+
 func New() *cobra.Command {
 	root := &cobra.Command{
 		Use:          "sbom",
@@ -44,20 +67,49 @@ func newShowCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show <SBOM file name>",
 		Short: "show <SBOM file name>",
+		Long:  `Show an SBOM. Use "-" to read it from stdin.`,
 		RunE:  showSBOM,
 	}
-	cmd.Flags().String("format", "cyclonedx-v16-proto", "Format of the SBOM")
+	cmd.Flags().String("format", "auto", formatUsage)
 	return cmd
 }
 
 func newConvertCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "convert <input SBOM file name> <output SPDX filename",
-		Short: "convert <input SBOM file name> <output SPDX filename>",
+		Use:   "convert <input SBOM file name> <output SBOM filename>",
+		Short: "convert <input SBOM file name> <output SBOM filename>",
+		Long:  `Convert an SBOM. Use "-" as the input or output file name for stdin or stdout.`,
 		RunE:  convertSBOM,
 	}
-	cmd.Flags().String("format", "cyclonedx-v16-proto", "Format of the SBOM")
+	cmd.Flags().String("format", "auto", formatUsage)
+	cmd.Flags().String("to", "spdx-v23-json",
+		"Format of the output SBOM (spdx-v23-json, spdx-v23-tagvalue, spdx-v30-jsonld or cyclonedx-v16-json), "+
+			"defaults to spdx-v23-tagvalue for .spdx output files")
 	cmd.Flags().Bool("validate", false, "Provide sbom conformance validation")
+	cmd.Flags().StringToString("package-purpose", nil,
+		"Override the SPDX primary package purpose for CycloneDX component types, "+
+			"e.g. data=FILE,device-driver=; an empty purpose skips the component type")
+	cmd.Flags().StringToString("scope-relationship", nil,
+		"Override the SPDX relationship for dependencies on components of a CycloneDX scope, "+
+			"e.g. excluded=DEV_DEPENDENCY_OF; an empty relationship skips components of the scope "+
+			"(default required=DEPENDS_ON,optional=OPTIONAL_DEPENDENCY_OF,excluded=)")
+	cmd.Flags().Bool("cpe-both-bindings", false,
+		"Add each CPE in both the CPE 2.2 URI and CPE 2.3 formatted string binding")
+	cmd.Flags().String("artifact-dir", "",
+		"Compute OmniBOR IDs of CycloneDX file components whose artifacts are found under this directory")
+	cmd.Flags().String("namespace-base", sbom.DefaultNamespaceBase,
+		"URI prefix of the generated SPDX document namespace")
+	cmd.Flags().Bool("deterministic-namespace", false,
+		"Derive the SPDX document namespace from a hash of the input instead of its serial number")
+	cmd.Flags().String("id-map", "",
+		"Write the mapping from CycloneDX bom-refs to SPDX IDs to this JSON file")
+	cmd.Flags().String("loss-report", "",
+		"Write a JSON report of the CycloneDX data dropped or degraded by the conversion to this file")
+	cmd.Flags().String("vex", "",
+		"Write a companion VEX document of the CycloneDX vulnerabilities to this file")
+	cmd.Flags().String("vex-format", "openvex", "Format of the VEX document (openvex or csaf)")
+	cmd.Flags().String("csaf-publisher-namespace", "",
+		"URL of the CSAF publisher namespace, required when the CycloneDX BOM has no supplier or manufacturer URL")
 	return cmd
 }
 
@@ -65,16 +117,13 @@ func showSBOM(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("SBOM arg required")
 	}
-	sbomFileName := args[0]
-	format, err := cmd.Flags().GetString("format")
+	input, format, err := readSBOM(cmd, args[0])
 	if err != nil {
 		return err
 	}
 	switch format {
-	case "cyclonedx-v16-proto":
-		return fmt.Errorf("unimplemented format: cyclonedx-v16-proto")
-	case "cyclonedx-v16-json":
-		sbom, err := loadCycloneDXJSON(sbomFileName)
+	case "cyclonedx-v16-proto", "cyclonedx-v16-json", "cyclonedx-xml":
+		sbom, err := loadCycloneDX(input, format)
 		if err != nil {
 			return err
 		}
@@ -82,22 +131,96 @@ func showSBOM(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Fprintln(cmd.OutOrStderr(), "SBOM:")
+		fmt.Fprintln(cmd.ErrOrStderr(), "SBOM:")
 		fmt.Fprintln(cmd.OutOrStdout(), string(b))
 		return nil
 
-	case "spdx-v23-json":
-		return fmt.Errorf("unimplemented format: spdx-v23-json")
+	case "spdx-v23-json", "spdx-v23-tagvalue":
+		spdxDoc, err := loadSPDX(input, format)
+		if err != nil {
+			return err
+		}
+		var b []byte
+		if format == "spdx-v23-tagvalue" {
+			b, err = sbom.SPDXToTagValue(spdxDoc)
+		} else {
+			b, err = sbom.SPDXToJSON(spdxDoc)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "SBOM:")
+		fmt.Fprintln(cmd.OutOrStdout(), string(b))
+		return nil
 	}
 	return fmt.Errorf("Invalid format: %q", format)
 }
 
-func loadCycloneDXJSON(filename string) (*cdx.BOM, error) {
-	b, err := os.ReadFile(filename)
+// readSBOM reads an input SBOM, from stdin when filename is "-", and
+// resolves its --format. In auto mode the format is detected from the
+// content and reported on stderr.
+func readSBOM(cmd *cobra.Command, filename string) ([]byte, string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return nil, "", err
+	}
+	var b []byte
+	if filename == "-" {
+		b, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		b, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if format == "auto" {
+		var specVersion string
+		format, specVersion, err = detectFormat(b)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to detect format of %q: %w", filename, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Detected format %s (spec version %s)\n", format, specVersion)
+	}
+	return b, format, nil
+}
+
+// loadCycloneDX loads a CycloneDX SBOM in the given input format and
+// upgrades it to the CycloneDX 1.6 model.
+func loadCycloneDX(b []byte, format string) (*cdx.BOM, error) {
+	var bom *cdx.BOM
+	var err error
+	switch format {
+	case "cyclonedx-v16-proto":
+		bom, err = sbom.ParseCycloneDXProto(b)
+	case "cyclonedx-v16-json":
+		bom, err = loadCycloneDXJSON(b)
+	case "cyclonedx-xml":
+		bom, err = sbom.ParseCycloneDXXML(b)
+	default:
+		return nil, fmt.Errorf("Invalid format: %q", format)
+	}
 	if err != nil {
 		return nil, err
 	}
+	sbom.UpgradeCycloneDX(bom)
+	return bom, nil
+}
+
+// loadCycloneDXProvides loads the CycloneDX 1.6 dependency provides lists,
+// which the cyclonedx-go BOM does not hold.
+func loadCycloneDXProvides(b []byte, format string) (map[string][]string, error) {
+	switch format {
+	case "cyclonedx-v16-proto":
+		return sbom.ParseCycloneDXProtoProvides(b)
+	case "cyclonedx-v16-json":
+		return sbom.ParseCycloneDXProvides(b, cdx.BOMFileFormatJSON)
+	case "cyclonedx-xml":
+		return sbom.ParseCycloneDXProvides(b, cdx.BOMFileFormatXML)
+	}
+	return nil, fmt.Errorf("Invalid format: %q", format)
+}
 
+func loadCycloneDXJSON(b []byte) (*cdx.BOM, error) {
 	d := cdx.NewBOMDecoder(bytes.NewBuffer(b), cdx.BOMFileFormatJSON)
 	bom := cdx.NewBOM()
 	if err := d.Decode(bom); err != nil {
@@ -106,6 +229,19 @@ func loadCycloneDXJSON(filename string) (*cdx.BOM, error) {
 	return bom, nil
 }
 
+// loadSPDX loads an SPDX document in the given input format. Documents
+// written against older SPDX 2.x versions are upgraded to the SPDX 2.3 model
+// by tools-golang.
+func loadSPDX(b []byte, format string) (*spdx.Document, error) {
+	switch format {
+	case "spdx-v23-json":
+		return spdxjson.Read(bytes.NewReader(b))
+	case "spdx-v23-tagvalue":
+		return spdxtv.Read(bytes.NewReader(b))
+	}
+	return nil, fmt.Errorf("Invalid format: %q", format)
+}
+
 func printCycloneDX(sbom *cdx.BOM) ([]byte, error) {
 	return json.MarshalIndent(sbom, "", "  ")
 }
@@ -115,50 +251,363 @@ func convertSBOM(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("SBOM input and output arg required")
 	}
 	sbomFileName := args[0]
-	spdxFileName := args[1]
+	outFileName := args[1]
 	validate, err := cmd.Flags().GetBool("validate")
 	if err != nil {
 		return err
 	}
-	format, err := cmd.Flags().GetString("format")
+	to, err := outputFormat(cmd, outFileName)
+	if err != nil {
+		return err
+	}
+	switch to {
+	case "spdx-v23-json", "spdx-v23-tagvalue":
+	case "spdx-v30-jsonld", "cyclonedx-v16-json":
+		if validate {
+			return fmt.Errorf("--validate is only supported for SPDX output")
+		}
+	default:
+		return fmt.Errorf("Invalid output format: %q", to)
+	}
+
+	opts, err := convertOptions(cmd)
+	if err != nil {
+		return err
+	}
+	idMapFileName, err := cmd.Flags().GetString("id-map")
+	if err != nil {
+		return err
+	}
+	lossReportFileName, err := cmd.Flags().GetString("loss-report")
+	if err != nil {
+		return err
+	}
+	artifactDir, err := cmd.Flags().GetString("artifact-dir")
 	if err != nil {
 		return err
 	}
+	vexFileName, err := cmd.Flags().GetString("vex")
+	if err != nil {
+		return err
+	}
+	vexFormat, err := cmd.Flags().GetString("vex-format")
+	if err != nil {
+		return err
+	}
+	if vexFormat != "openvex" && vexFormat != "csaf" {
+		return fmt.Errorf("Invalid VEX format: %q", vexFormat)
+	}
+	csafPublisherNamespace, err := cmd.Flags().GetString("csaf-publisher-namespace")
+	if err != nil {
+		return err
+	}
+	if vexFileName != "" {
+		opts = append(opts, sbom.WithVEXFile(vexFileName))
+	}
+
+	input, format, err := readSBOM(cmd, sbomFileName)
+	if err != nil {
+		return err
+	}
+	var bom *cdx.BOM
+	var spdxDoc *spdx.Document
 	switch format {
-	case "cyclonedx-v16-proto":
-		return fmt.Errorf("unimplemented format: cyclonedx-v16-proto")
-	case "cyclonedx-v16-json":
-		bom, err := loadCycloneDXJSON(sbomFileName)
+	case "cyclonedx-v16-proto", "cyclonedx-v16-json", "cyclonedx-xml":
+		bom, err = loadCycloneDX(input, format)
+	case "spdx-v23-json", "spdx-v23-tagvalue":
+		spdxDoc, err = loadSPDX(input, format)
+	default:
+		return fmt.Errorf("Invalid format: %q", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if artifactDir != "" {
+		if bom == nil {
+			return fmt.Errorf("--artifact-dir requires CycloneDX input")
+		}
+		if err := sbom.AddOmniBORIDs(bom, artifactDir); err != nil {
+			return err
+		}
+	}
+
+	var conversion *sbom.SPDXConversion
+	if spdxDoc == nil && to != "cyclonedx-v16-json" {
+		provides, err := loadCycloneDXProvides(input, format)
 		if err != nil {
 			return err
 		}
-		spdxDoc, err := sbom.ConvertToGoogleSPDX(bom)
+		opts = append(opts, sbom.WithProvides(provides))
+		conversion, err = sbom.ConvertCycloneDXToSPDX(bom, opts...)
 		if err != nil {
 			return err
 		}
+		spdxDoc = conversion.Document
+	}
+	if idMapFileName != "" && conversion == nil {
+		return fmt.Errorf("--id-map requires CycloneDX input and SPDX output")
+	}
+	if lossReportFileName != "" && conversion == nil {
+		return fmt.Errorf("--loss-report requires CycloneDX input and SPDX output")
+	}
+	if vexFileName != "" && conversion == nil {
+		return fmt.Errorf("--vex requires CycloneDX input and SPDX output")
+	}
 
-		b, err := sbom.SPDXToJSON(spdxDoc)
+	var b []byte
+	switch to {
+	case "spdx-v23-json", "spdx-v23-tagvalue":
+		if to == "spdx-v23-tagvalue" {
+			b, err = sbom.SPDXToTagValue(spdxDoc)
+		} else {
+			b, err = sbom.SPDXToJSON(spdxDoc)
+		}
+	case "spdx-v30-jsonld":
+		var spdx3Doc *sbom.SPDX3Document
+		spdx3Doc, err = sbom.ConvertToSPDX3(spdxDoc)
 		if err != nil {
 			return err
 		}
-		if validate {
-			checker, err := base.NewChecker(base.WithEOChecker(), base.WithSPDXChecker())
+		b, err = sbom.SPDX3ToJSON(spdx3Doc)
+	case "cyclonedx-v16-json":
+		if bom == nil {
+			bom, err = sbom.ConvertToCycloneDX(spdxDoc)
 			if err != nil {
 				return err
 			}
-			checker.SetSBOM(bytes.NewBuffer(b))
-			checker.RunChecks()
-			results := checker.Results()
-			fmt.Fprintf(cmd.OutOrStdout(), "Conformance Results:\n")
-			fmt.Fprintln(cmd.OutOrStdout(), results.TextSummary)
 		}
-		if err := os.WriteFile(spdxFileName, b, 0600); err != nil {
+		b, err = sbom.CycloneDXToJSON(bom)
+	}
+	if err != nil {
+		return err
+	}
+
+	if validate {
+		// The conformance checker only reads SPDX JSON.
+		jsonBytes, err := sbom.SPDXToJSON(spdxDoc)
+		if err != nil {
 			return err
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Wrote output to %q\n", spdxFileName)
-		return nil
-	case "spdx-v23-json":
-		return fmt.Errorf("unimplemented format: spdx-v23-json")
+		checker, err := base.NewChecker(base.WithEOChecker(), base.WithSPDXChecker())
+		if err != nil {
+			return err
+		}
+		checker.SetSBOM(bytes.NewBuffer(jsonBytes))
+		checker.RunChecks()
+		results := checker.Results()
+		fmt.Fprintf(cmd.ErrOrStderr(), "Conformance Results:\n")
+		fmt.Fprintln(cmd.ErrOrStderr(), results.TextSummary)
 	}
-	return fmt.Errorf("Invalid format: %q", format)
+	if idMapFileName != "" {
+		if err := writeJSON(cmd, idMapFileName, conversion.SPDXIDs); err != nil {
+			return err
+		}
+	}
+	if lossReportFileName != "" {
+		if err := writeJSON(cmd, lossReportFileName, conversion.LossReport); err != nil {
+			return err
+		}
+	}
+	if vexFileName != "" {
+		vex, err := vexDocument(bom, conversion, vexFormat, csafPublisherNamespace)
+		if err != nil {
+			return err
+		}
+		if err := writeSideOutput(cmd, vexFileName, vex); err != nil {
+			return err
+		}
+	}
+	if outFileName == "-" {
+		_, err := cmd.OutOrStdout().Write(b)
+		return err
+	}
+	if err := os.WriteFile(outFileName, b, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote output to %q\n", outFileName)
+	return nil
+}
+
+// writeJSON writes v as indented JSON to a side output file of convert.
+func writeJSON(cmd *cobra.Command, filename string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeSideOutput(cmd, filename, b)
+}
+
+// writeSideOutput writes a side output file of convert.
+func writeSideOutput(cmd *cobra.Command, filename string, b []byte) error {
+	if err := os.WriteFile(filename, b, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %q\n", filename)
+	return nil
+}
+
+// vexDocument returns the encoded VEX document of the BOM vulnerabilities
+// in the given format.
+func vexDocument(bom *cdx.BOM, conversion *sbom.SPDXConversion, format, csafPublisherNamespace string) ([]byte, error) {
+	if format == "csaf" {
+		doc, err := sbom.ConvertCycloneDXToCSAF(bom, conversion, csafPublisherNamespace)
+		if err != nil {
+			return nil, err
+		}
+		return sbom.CSAFToJSON(doc)
+	}
+	doc, err := sbom.ConvertCycloneDXToOpenVEX(bom, conversion)
+	if err != nil {
+		return nil, err
+	}
+	return sbom.OpenVEXToJSON(doc)
+}
+
+// convertOptions returns the CycloneDX to SPDX conversion options set by
+// the convert flags.
+func convertOptions(cmd *cobra.Command) ([]sbom.ConvertOption, error) {
+	opts := []sbom.ConvertOption{sbom.WithTool(toolName, buildVersion())}
+	purposes, err := cmd.Flags().GetStringToString("package-purpose")
+	if err != nil {
+		return nil, err
+	}
+	if len(purposes) > 0 {
+		m, err := sbom.ParsePackagePurposes(purposes)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sbom.WithPackagePurposes(m))
+	}
+	scopes, err := cmd.Flags().GetStringToString("scope-relationship")
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 {
+		m, err := sbom.ParseDependencyRelationships(scopes)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sbom.WithDependencyRelationships(m))
+	}
+	bothBindings, err := cmd.Flags().GetBool("cpe-both-bindings")
+	if err != nil {
+		return nil, err
+	}
+	if bothBindings {
+		opts = append(opts, sbom.WithCPEBothBindings())
+	}
+	base, err := cmd.Flags().GetString("namespace-base")
+	if err != nil {
+		return nil, err
+	}
+	if u, err := url.Parse(base); err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid namespace base URI: %q", base)
+	}
+	opts = append(opts, sbom.WithNamespaceBase(base))
+	deterministic, err := cmd.Flags().GetBool("deterministic-namespace")
+	if err != nil {
+		return nil, err
+	}
+	if deterministic {
+		opts = append(opts, sbom.WithDeterministicNamespace())
+	}
+	return opts, nil
+}
+
+// buildVersion returns the CLI version, "devel" for local builds.
+func buildVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
+
+// outputFormat returns the --to output format. When the flag is not set, an
+// output file with the .spdx extension selects SPDX tag-value.
+func outputFormat(cmd *cobra.Command, filename string) (string, error) {
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return "", err
+	}
+	if !cmd.Flags().Changed("to") && strings.EqualFold(filepath.Ext(filename), ".spdx") {
+		return "spdx-v23-tagvalue", nil
+	}
+	return to, nil
+}
+
+// detectFormat sniffs the input format of an SBOM and returns it with the
+// spec version declared by the document.
+func detectFormat(b []byte) (string, string, error) {
+	// A CycloneDX proto BOM starts with its spec_version, field 1, which is a
+	// length delimited "1.x" string. The tag byte is a newline, so this is
+	// checked before any whitespace is trimmed.
+	if len(b) > 0 && b[0] == 0x0a {
+		pb := &cdxpb.Bom{}
+		if err := proto.Unmarshal(b, pb); err == nil && protoSpecVersionRE.MatchString(pb.GetSpecVersion()) {
+			return "cyclonedx-v16-proto", pb.GetSpecVersion(), nil
+		}
+	}
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 {
+		return "", "", fmt.Errorf("empty input")
+	}
+	switch trimmed[0] {
+	case '{':
+		var header struct {
+			BOMFormat   string `json:"bomFormat"`
+			SpecVersion string `json:"specVersion"`
+			SPDXVersion string `json:"spdxVersion"`
+			Context     any    `json:"@context"`
+		}
+		if err := json.Unmarshal(trimmed, &header); err != nil {
+			return "", "", fmt.Errorf("invalid JSON: %w", err)
+		}
+		switch {
+		case header.BOMFormat == "CycloneDX":
+			return "cyclonedx-v16-json", header.SpecVersion, nil
+		case strings.HasPrefix(header.SPDXVersion, "SPDX-2."):
+			return "spdx-v23-json", header.SPDXVersion, nil
+		case header.Context != nil:
+			return "", "", fmt.Errorf("JSON-LD input is not supported")
+		}
+		return "", "", fmt.Errorf("JSON document is neither CycloneDX nor SPDX")
+	case '<':
+		d := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return "", "", fmt.Errorf("invalid XML: %w", err)
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				if v, ok := strings.CutPrefix(start.Name.Space, "http://cyclonedx.org/schema/bom/"); ok && start.Name.Local == "bom" {
+					return "cyclonedx-xml", v, nil
+				}
+				return "", "", fmt.Errorf("XML document is not CycloneDX: <%s xmlns=%q>", start.Name.Local, start.Name.Space)
+			}
+		}
+	}
+	if v, ok := tagValueSPDXVersion(trimmed); ok {
+		return "spdx-v23-tagvalue", v, nil
+	}
+	return "", "", fmt.Errorf("unrecognized SBOM format")
+}
+
+var protoSpecVersionRE = regexp.MustCompile(`^1\.[0-9]+$`)
+
+// tagValueSPDXVersion returns the SPDXVersion of a tag-value document,
+// which must be its first tag.
+func tagValueSPDXVersion(b []byte) (string, bool) {
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		v, ok := strings.CutPrefix(line, "SPDXVersion:")
+		return strings.TrimSpace(v), ok
+	}
+	return "", false
 }
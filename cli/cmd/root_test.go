@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/security-services/cli/cmd/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spdxJSONInput = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "network-os",
+  "documentNamespace": "https://example.com/spdxdocs/network-os-3e671687-395b-41f5-a30f-a58921a69b79",
+  "creationInfo": {
+    "created": "2025-01-02T03:04:05Z",
+    "creators": ["Tool: sbom-generator-1.2.3", "Organization: Example Networks"]
+  },
+  "packages": [{
+    "SPDXID": "SPDXRef-root",
+    "name": "network-os",
+    "versionInfo": "4.33.0",
+    "supplier": "Organization: Example Networks",
+    "downloadLocation": "NOASSERTION",
+    "filesAnalyzed": false,
+    "primaryPackagePurpose": "OPERATING-SYSTEM"
+  }, {
+    "SPDXID": "SPDXRef-lib",
+    "name": "lib",
+    "versionInfo": "v1.0.0",
+    "downloadLocation": "https://example.com/lib-1.0.0.tgz",
+    "filesAnalyzed": false,
+    "checksums": [{"algorithm": "SHA256", "checksumValue": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}],
+    "licenseDeclared": "Apache-2.0",
+    "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/example.com/lib@v1.0.0"}],
+    "primaryPackagePurpose": "LIBRARY"
+  }],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-root", "relationshipType": "DESCRIBES"},
+    {"spdxElementId": "SPDXRef-root", "relatedSpdxElement": "SPDXRef-lib", "relationshipType": "DEPENDS_ON"}
+  ]
+}
+`
+
+const spdxTagValueInput = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: network-os
+DocumentNamespace: https://example.com/spdxdocs/network-os-3e671687-395b-41f5-a30f-a58921a69b79
+Creator: Tool: sbom-generator-1.2.3
+Creator: Organization: Example Networks
+Created: 2025-01-02T03:04:05Z
+
+PackageName: network-os
+SPDXID: SPDXRef-root
+PackageVersion: 4.33.0
+PackageSupplier: Organization: Example Networks
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PrimaryPackagePurpose: OPERATING-SYSTEM
+
+PackageName: lib
+SPDXID: SPDXRef-lib
+PackageVersion: v1.0.0
+PackageDownloadLocation: https://example.com/lib-1.0.0.tgz
+FilesAnalyzed: false
+PackageChecksum: SHA256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+PackageLicenseDeclared: Apache-2.0
+ExternalRef: PACKAGE-MANAGER purl pkg:golang/example.com/lib@v1.0.0
+PrimaryPackagePurpose: LIBRARY
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-root
+Relationship: SPDXRef-root DEPENDS_ON SPDXRef-lib
+`
+
+// runCLI runs the CLI with args and returns its stdout.
+func runCLI(t *testing.T, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	root := New()
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(args)
+	require.NoError(t, root.Execute(), stderr.String())
+	return stdout.String()
+}
+
+func TestSPDXInputRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		file   string
+		output string
+		format string
+		input  string
+	}{
+		{"json", "input.spdx.json", "output.spdx.json", "spdx-v23-json", spdxJSONInput},
+		{"tag-value", "input.spdx", "output.spdx", "spdx-v23-tagvalue", spdxTagValueInput},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, tc.file)
+			require.NoError(t, os.WriteFile(input, []byte(tc.input), 0600))
+			want, err := loadSPDX([]byte(tc.input), tc.format)
+			require.NoError(t, err)
+			require.Len(t, want.Packages, 2)
+
+			// show prints the document in its own format.
+			var shown []byte
+			if tc.format == "spdx-v23-tagvalue" {
+				shown, err = sbom.SPDXToTagValue(want)
+			} else {
+				shown, err = sbom.SPDXToJSON(want)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, string(shown)+"\n", runCLI(t, "show", input))
+
+			// convert re-emits the document in the format the output file
+			// name defaults to, and it loads back to the same document.
+			output := filepath.Join(dir, tc.output)
+			runCLI(t, "convert", input, output)
+			b, err := os.ReadFile(output)
+			require.NoError(t, err)
+			format, _, err := detectFormat(b)
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, format)
+			got, err := loadSPDX(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
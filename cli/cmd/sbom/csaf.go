@@ -0,0 +1,379 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// CSAFVersion is the CSAF version written by ConvertCycloneDXToCSAF.
+const CSAFVersion = "2.0"
+
+// CSAFDocument is a CSAF document in the VEX profile.
+type CSAFDocument struct {
+	Document        CSAFDocumentMetadata `json:"document"`
+	ProductTree     CSAFProductTree      `json:"product_tree"`
+	Vulnerabilities []CSAFVulnerability  `json:"vulnerabilities"`
+}
+
+// CSAFDocumentMetadata holds the document level metadata of a CSAF document.
+type CSAFDocumentMetadata struct {
+	Category    string        `json:"category"`
+	CSAFVersion string        `json:"csaf_version"`
+	Publisher   CSAFPublisher `json:"publisher"`
+	Title       string        `json:"title"`
+	Tracking    CSAFTracking  `json:"tracking"`
+}
+
+// CSAFPublisher is the publisher of a CSAF document.
+type CSAFPublisher struct {
+	Category  string `json:"category"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// CSAFTracking identifies a CSAF document and its revisions.
+type CSAFTracking struct {
+	ID                 string         `json:"id"`
+	Status             string         `json:"status"`
+	Version            string         `json:"version"`
+	InitialReleaseDate string         `json:"initial_release_date"`
+	CurrentReleaseDate string         `json:"current_release_date"`
+	RevisionHistory    []CSAFRevision `json:"revision_history"`
+	Generator          *CSAFGenerator `json:"generator,omitempty"`
+}
+
+// CSAFRevision is an entry of the revision history.
+type CSAFRevision struct {
+	Date    string `json:"date"`
+	Number  string `json:"number"`
+	Summary string `json:"summary"`
+}
+
+// CSAFGenerator is the tool that generated a CSAF document.
+type CSAFGenerator struct {
+	Engine CSAFEngine `json:"engine"`
+}
+
+// CSAFEngine names the generating tool.
+type CSAFEngine struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// CSAFProductTree lists the products of a CSAF document.
+type CSAFProductTree struct {
+	FullProductNames []CSAFProduct `json:"full_product_names"`
+}
+
+// CSAFProduct is a product whose ID is the SPDX ID of its package or file.
+type CSAFProduct struct {
+	Name                        string                           `json:"name"`
+	ProductID                   string                           `json:"product_id"`
+	ProductIdentificationHelper *CSAFProductIdentificationHelper `json:"product_identification_helper,omitempty"`
+}
+
+// CSAFProductIdentificationHelper holds the purl and CPE of a product.
+type CSAFProductIdentificationHelper struct {
+	CPE  string `json:"cpe,omitempty"`
+	PURL string `json:"purl,omitempty"`
+}
+
+// CSAFVulnerability is the status of a vulnerability in the products.
+type CSAFVulnerability struct {
+	CVE           string            `json:"cve,omitempty"`
+	IDs           []CSAFID          `json:"ids,omitempty"`
+	Notes         []CSAFNote        `json:"notes,omitempty"`
+	ProductStatus CSAFProductStatus `json:"product_status"`
+	Flags         []CSAFFlag        `json:"flags,omitempty"`
+	Threats       []CSAFThreat      `json:"threats,omitempty"`
+	Remediations  []CSAFRemediation `json:"remediations,omitempty"`
+}
+
+// CSAFID is a vulnerability ID of a tracking system other than CVE.
+type CSAFID struct {
+	SystemName string `json:"system_name"`
+	Text       string `json:"text"`
+}
+
+// CSAFNote is a note about a vulnerability.
+type CSAFNote struct {
+	Category string `json:"category"`
+	Text     string `json:"text"`
+}
+
+// CSAFProductStatus lists the product IDs by status.
+type CSAFProductStatus struct {
+	Fixed              []string `json:"fixed,omitempty"`
+	KnownAffected      []string `json:"known_affected,omitempty"`
+	KnownNotAffected   []string `json:"known_not_affected,omitempty"`
+	UnderInvestigation []string `json:"under_investigation,omitempty"`
+}
+
+// CSAFFlag justifies why products are not affected.
+type CSAFFlag struct {
+	Label      string   `json:"label"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+// CSAFThreat states the impact of a vulnerability on products.
+type CSAFThreat struct {
+	Category   string   `json:"category"`
+	Details    string   `json:"details"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+// CSAFRemediation is a remediation of a vulnerability in products.
+type CSAFRemediation struct {
+	Category   string   `json:"category"`
+	Details    string   `json:"details"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+// ConvertCycloneDXToCSAF returns a CSAF VEX document with the
+// vulnerabilities of a BOM, for the SPDX document the BOM was converted to.
+// The product IDs are the SPDX IDs of the packages and files of the
+// affected components, and the statuses are those of
+// ConvertCycloneDXToOpenVEX.
+//
+// The publisher namespace is publisherNamespace if set, or else the URL of
+// the supplier or manufacturer of the BOM. It is an error if neither is
+// available.
+func ConvertCycloneDXToCSAF(bom *cdx.BOM, conversion *SPDXConversion, publisherNamespace string) (*CSAFDocument, error) {
+	spdxDoc := conversion.Document
+	if spdxDoc == nil || spdxDoc.CreationInfo == nil {
+		return nil, fmt.Errorf("SPDX document has no creation info")
+	}
+	if publisherNamespace == "" {
+		publisherNamespace = csafPublisherNamespace(bom)
+		if publisherNamespace == "" {
+			return nil, fmt.Errorf("CSAF publisher namespace required: the BOM has no supplier or manufacturer URL")
+		}
+	} else if u, err := url.Parse(publisherNamespace); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid CSAF publisher namespace %q", publisherNamespace)
+	}
+	namespace, err := url.Parse(spdxDoc.DocumentNamespace)
+	if err != nil || namespace.Host == "" {
+		return nil, fmt.Errorf("invalid SPDX document namespace %q", spdxDoc.DocumentNamespace)
+	}
+	created := spdxDoc.CreationInfo.Created
+	doc := &CSAFDocument{
+		Document: CSAFDocumentMetadata{
+			Category:    "csaf_vex",
+			CSAFVersion: CSAFVersion,
+			Publisher: CSAFPublisher{
+				Category:  "other",
+				Name:      vexAuthor(spdxDoc),
+				Namespace: publisherNamespace,
+			},
+			Title: "VEX for " + spdxDoc.DocumentName,
+			Tracking: CSAFTracking{
+				ID:                 path.Base(namespace.Path),
+				Status:             "final",
+				Version:            "1",
+				InitialReleaseDate: created,
+				CurrentReleaseDate: created,
+				RevisionHistory:    []CSAFRevision{{Date: created, Number: "1", Summary: "Initial version"}},
+			},
+		},
+		ProductTree:     CSAFProductTree{FullProductNames: []CSAFProduct{}},
+		Vulnerabilities: []CSAFVulnerability{},
+	}
+	if tool := vexTool(spdxDoc); tool != "" {
+		name, version := splitToolCreator(tool)
+		doc.Document.Tracking.Generator = &CSAFGenerator{Engine: CSAFEngine{Name: name, Version: version}}
+	}
+
+	seenProducts := map[string]bool{}
+	// A BOM may list the same vulnerability more than once, and CSAF allows a
+	// single entry per vulnerability.
+	vulnerabilities := map[string]int{}
+	for _, s := range vexStatements(bom, conversion) {
+		i, ok := vulnerabilities[s.vulnerability.ID]
+		if !ok {
+			i = len(doc.Vulnerabilities)
+			vulnerabilities[s.vulnerability.ID] = i
+			doc.Vulnerabilities = append(doc.Vulnerabilities, csafVulnerability(s.vulnerability))
+		}
+
+		var ids []string
+		for _, p := range s.products {
+			ids = append(ids, p.spdxID)
+			if seenProducts[p.spdxID] {
+				continue
+			}
+			seenProducts[p.spdxID] = true
+			doc.ProductTree.FullProductNames = append(doc.ProductTree.FullProductNames, csafProduct(p))
+		}
+		addCSAFStatus(&doc.Vulnerabilities[i], s, ids)
+	}
+	// The VEX profile requires notes on every vulnerability.
+	for id, i := range vulnerabilities {
+		cv := &doc.Vulnerabilities[i]
+		cv.Notes = append([]CSAFNote{csafSummary(id, cv.ProductStatus)}, cv.Notes...)
+	}
+	return doc, nil
+}
+
+// CSAFToJSON encodes a CSAF document as indented JSON.
+func CSAFToJSON(doc *CSAFDocument) ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// csafPublisherNamespace returns the scheme and host of the first URL of
+// the BOM supplier or manufacturer, or of those of the BOM component.
+func csafPublisherNamespace(bom *cdx.BOM) string {
+	if bom.Metadata == nil {
+		return ""
+	}
+	entities := []*cdx.OrganizationalEntity{bom.Metadata.Supplier, bom.Metadata.Manufacturer}
+	if c := bom.Metadata.Component; c != nil {
+		entities = append(entities, c.Supplier, c.Manufacturer)
+	}
+	for _, e := range entities {
+		if e == nil || e.URL == nil {
+			continue
+		}
+		for _, raw := range *e.URL {
+			if u, err := url.Parse(raw); err == nil && u.Scheme != "" && u.Host != "" {
+				return u.Scheme + "://" + u.Host
+			}
+		}
+	}
+	return ""
+}
+
+// csafVulnerability returns a CSAF vulnerability with the IDs and
+// description of a CycloneDX one. A CVE ID, or else a CVE alias, is the CVE
+// of the vulnerability.
+func csafVulnerability(v cdx.Vulnerability) CSAFVulnerability {
+	var cv CSAFVulnerability
+	system := "unknown"
+	if v.Source != nil && v.Source.Name != "" {
+		system = v.Source.Name
+	}
+	if cveRE.MatchString(v.ID) {
+		cv.CVE = v.ID
+	} else {
+		cv.IDs = append(cv.IDs, CSAFID{SystemName: system, Text: v.ID})
+	}
+	if v.References != nil {
+		for _, ref := range *v.References {
+			switch {
+			case ref.ID == "" || ref.ID == v.ID:
+			case cv.CVE == "" && cveRE.MatchString(ref.ID):
+				cv.CVE = ref.ID
+			default:
+				refSystem := "unknown"
+				if ref.Source != nil && ref.Source.Name != "" {
+					refSystem = ref.Source.Name
+				}
+				cv.IDs = append(cv.IDs, CSAFID{SystemName: refSystem, Text: ref.ID})
+			}
+		}
+	}
+	if v.Description != "" {
+		cv.Notes = append(cv.Notes, CSAFNote{Category: "description", Text: v.Description})
+	}
+	return cv
+}
+
+// csafSummary returns a summary note stating the status of a vulnerability
+// in the products.
+func csafSummary(id string, ps CSAFProductStatus) CSAFNote {
+	var parts []string
+	for _, status := range []struct {
+		name string
+		ids  []string
+	}{
+		{"fixed", ps.Fixed},
+		{"known affected", ps.KnownAffected},
+		{"known not affected", ps.KnownNotAffected},
+		{"under investigation", ps.UnderInvestigation},
+	} {
+		if len(status.ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s in %s", status.name, strings.Join(status.ids, ", ")))
+		}
+	}
+	return CSAFNote{Category: "summary", Text: fmt.Sprintf("%s is %s.", id, strings.Join(parts, "; "))}
+}
+
+// addCSAFStatus adds the products of a statement to a CSAF vulnerability.
+// Products that are not affected get a flag for their justification or an
+// impact threat, and affected products a remediation, as the VEX profile
+// requires.
+func addCSAFStatus(cv *CSAFVulnerability, s vexStatement, ids []string) {
+	if s.notes != "" {
+		cv.Notes = append(cv.Notes, CSAFNote{Category: "details", Text: s.notes})
+	}
+	switch s.status {
+	case "fixed":
+		cv.ProductStatus.Fixed = appendNew(cv.ProductStatus.Fixed, ids...)
+	case "affected":
+		cv.ProductStatus.KnownAffected = appendNew(cv.ProductStatus.KnownAffected, ids...)
+		category, ok := csafRemediationCategories[s.response]
+		if !ok {
+			category = "none_available"
+			if s.vulnerability.Recommendation != "" || s.vulnerability.Workaround != "" {
+				category = "mitigation"
+			}
+		}
+		cv.Remediations = append(cv.Remediations, CSAFRemediation{Category: category, Details: s.action, ProductIDs: ids})
+	case "not_affected":
+		cv.ProductStatus.KnownNotAffected = appendNew(cv.ProductStatus.KnownNotAffected, ids...)
+		if s.justification != "" {
+			cv.Flags = append(cv.Flags, CSAFFlag{Label: s.justification, ProductIDs: ids})
+		}
+		if s.impact != "" {
+			cv.Threats = append(cv.Threats, CSAFThreat{Category: "impact", Details: s.impact, ProductIDs: ids})
+		}
+	default:
+		cv.ProductStatus.UnderInvestigation = appendNew(cv.ProductStatus.UnderInvestigation, ids...)
+	}
+}
+
+// csafProduct returns the product tree entry of a product.
+func csafProduct(p vexProduct) CSAFProduct {
+	name := p.name
+	if p.version != "" {
+		name += " " + p.version
+	}
+	if name == "" {
+		name = p.spdxID
+	}
+	product := CSAFProduct{Name: name, ProductID: p.spdxID}
+	if p.purl != "" || p.cpe != "" {
+		product.ProductIdentificationHelper = &CSAFProductIdentificationHelper{CPE: p.cpe, PURL: p.purl}
+	}
+	return product
+}
+
+// appendNew appends the product IDs that are not yet listed.
+func appendNew(ids []string, add ...string) []string {
+	for _, id := range add {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+var cveRE = regexp.MustCompile(`^CVE-[0-9]{4}-[0-9]{4,}$`)
+
+// ========== Enum mappings =============
+
+// csafRemediationCategories maps CycloneDX analysis responses to CSAF
+// remediation categories.
+var csafRemediationCategories = map[cdx.ImpactAnalysisResponse]string{
+	cdx.IARCanNotFix:           "none_available",
+	cdx.IARWillNotFix:          "no_fix_planned",
+	cdx.IARUpdate:              "vendor_fix",
+	cdx.IARRollback:            "vendor_fix",
+	cdx.IARWorkaroundAvailable: "workaround",
+}
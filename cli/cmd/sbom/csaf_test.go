@@ -0,0 +1,151 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCycloneDXToCSAF(t *testing.T) {
+	bom := vexBOM()
+	bom.Metadata.Component.Supplier = &cdx.OrganizationalEntity{
+		Name: "Example Inc",
+		URL:  &[]string{"https://vendor.example.com/security"},
+	}
+	conversion, err := ConvertCycloneDXToSPDX(bom, WithNamespaceBase("https://example.com/spdxdocs"))
+	require.NoError(t, err)
+	spdxDoc := conversion.Document
+
+	doc, err := ConvertCycloneDXToCSAF(bom, conversion, "")
+	require.NoError(t, err)
+	assert.Equal(t, "csaf_vex", doc.Document.Category)
+	assert.Equal(t, CSAFVersion, doc.Document.CSAFVersion)
+	assert.Equal(t, "https://vendor.example.com", doc.Document.Publisher.Namespace)
+	assert.Equal(t, spdxDoc.CreationInfo.Created, doc.Document.Tracking.InitialReleaseDate)
+
+	libA := "SPDXRef-" + string(conversion.SPDXIDs["lib-a"])
+	libB := "SPDXRef-" + string(conversion.SPDXIDs["lib-b"])
+	assert.Equal(t, []CSAFProduct{{
+		Name:                        "lib-a 1.0.0",
+		ProductID:                   libA,
+		ProductIdentificationHelper: &CSAFProductIdentificationHelper{PURL: "pkg:generic/lib-a@1.0.0"},
+	}, {
+		Name:                        "lib-b 2.0.0",
+		ProductID:                   libB,
+		ProductIdentificationHelper: &CSAFProductIdentificationHelper{CPE: "cpe:2.3:a:example:lib-b:2.0.0:*:*:*:*:*:*:*"},
+	}}, doc.ProductTree.FullProductNames)
+
+	assert.Equal(t, []CSAFVulnerability{{
+		CVE: "CVE-2024-0001",
+		IDs: []CSAFID{{SystemName: "unknown", Text: "GHSA-aaaa-bbbb-cccc"}},
+		Notes: []CSAFNote{
+			{Category: "summary", Text: "CVE-2024-0001 is known not affected in " + libA + ", " + libB + "."},
+			{Category: "description", Text: "Buffer overflow in lib-a."},
+		},
+		ProductStatus: CSAFProductStatus{KnownNotAffected: []string{libA, libB}},
+		Flags:         []CSAFFlag{{Label: "vulnerable_code_not_in_execute_path", ProductIDs: []string{libA, libB}}},
+	}, {
+		CVE:           "CVE-2024-0002",
+		Notes:         []CSAFNote{{Category: "summary", Text: "CVE-2024-0002 is known affected in " + libB + "."}},
+		ProductStatus: CSAFProductStatus{KnownAffected: []string{libB}},
+		Remediations:  []CSAFRemediation{{Category: "vendor_fix", Details: "Upgrade lib-b to 2.0.1.", ProductIDs: []string{libB}}},
+	}, {
+		IDs: []CSAFID{{SystemName: "unknown", Text: "OSV-2024-3"}},
+		Notes: []CSAFNote{
+			{Category: "summary", Text: "OSV-2024-3 is known affected in " + libA + "; under investigation in " + libB + "."},
+			{Category: "details", Text: "Affected version ranges: vers:generic/<2.0.0 (affected)"},
+		},
+		ProductStatus: CSAFProductStatus{
+			KnownAffected:      []string{libA},
+			UnderInvestigation: []string{libB},
+		},
+		Remediations: []CSAFRemediation{{Category: "none_available", Details: "No remediation information is available.", ProductIDs: []string{libA}}},
+	}}, doc.Vulnerabilities)
+}
+
+func TestConvertCycloneDXToCSAFDuplicateVulnerability(t *testing.T) {
+	bom := vexBOM()
+	*bom.Vulnerabilities = append(*bom.Vulnerabilities,
+		cdx.Vulnerability{
+			ID:       "CVE-2024-0009",
+			Analysis: &cdx.VulnerabilityAnalysis{State: cdx.IASResolved},
+			Affects:  &[]cdx.Affects{{Ref: "lib-a"}},
+		},
+		cdx.Vulnerability{
+			ID:      "CVE-2024-0002",
+			Affects: &[]cdx.Affects{{Ref: "lib-a"}},
+		},
+		cdx.Vulnerability{
+			ID:       "CVE-2024-0009",
+			Analysis: &cdx.VulnerabilityAnalysis{State: cdx.IASResolved},
+			Affects:  &[]cdx.Affects{{Ref: "lib-a"}, {Ref: "lib-b"}},
+		},
+	)
+	conversion, err := ConvertCycloneDXToSPDX(bom, WithNamespaceBase("https://example.com/spdxdocs"))
+	require.NoError(t, err)
+
+	doc, err := ConvertCycloneDXToCSAF(bom, conversion, "https://vendor.example.com")
+	require.NoError(t, err)
+
+	libA := "SPDXRef-" + string(conversion.SPDXIDs["lib-a"])
+	libB := "SPDXRef-" + string(conversion.SPDXIDs["lib-b"])
+	var ids []string
+	statuses := map[string]CSAFProductStatus{}
+	for _, v := range doc.Vulnerabilities {
+		id := v.CVE
+		if id == "" {
+			id = v.IDs[0].Text
+		}
+		ids = append(ids, id)
+		statuses[id] = v.ProductStatus
+	}
+	assert.Equal(t, []string{"CVE-2024-0001", "CVE-2024-0002", "OSV-2024-3", "CVE-2024-0009"}, ids)
+	assert.Equal(t, CSAFProductStatus{
+		KnownAffected:      []string{libB},
+		UnderInvestigation: []string{libA},
+	}, statuses["CVE-2024-0002"])
+	assert.Equal(t, CSAFProductStatus{Fixed: []string{libA, libB}}, statuses["CVE-2024-0009"])
+}
+
+func TestConvertCycloneDXToCSAFPublisherNamespace(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		supplier  *cdx.OrganizationalEntity
+		namespace string
+		want      string
+		wantErr   string
+	}{{
+		desc:     "metadata supplier",
+		supplier: &cdx.OrganizationalEntity{URL: &[]string{"not a url", "https://supplier.example.com/psirt"}},
+		want:     "https://supplier.example.com",
+	}, {
+		desc:      "option overrides the supplier",
+		supplier:  &cdx.OrganizationalEntity{URL: &[]string{"https://supplier.example.com"}},
+		namespace: "https://publisher.example.org",
+		want:      "https://publisher.example.org",
+	}, {
+		desc:    "no supplier or manufacturer URL",
+		wantErr: "CSAF publisher namespace required",
+	}, {
+		desc:      "invalid option",
+		namespace: "example.org",
+		wantErr:   `invalid CSAF publisher namespace "example.org"`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bom := vexBOM()
+			bom.Metadata.Supplier = tc.supplier
+			conversion, err := ConvertCycloneDXToSPDX(bom)
+			require.NoError(t, err)
+
+			doc, err := ConvertCycloneDXToCSAF(bom, conversion, tc.namespace)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, doc.Document.Publisher.Namespace)
+		})
+	}
+}
@@ -0,0 +1,682 @@
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// ConvertToCycloneDX converts an SPDX 2.3 document into a CycloneDX 1.6 BOM.
+//
+// Packages and files become components keyed by their SPDX identifier,
+// CONTAINS relationships nest components, DEPENDS_ON and the other
+// dependency relationships become dependencies, with the scope of the
+// dependency set from the relationship type, and the package described by
+// the document becomes the metadata component. Annotations become
+// properties or annotations as described for annotationsFromSPDX.
+// Relationships without a CycloneDX equivalent are dropped with a warning.
+func ConvertToCycloneDX(spdxDoc *spdx.Document) (*cdx.BOM, error) {
+	if spdxDoc == nil {
+		return nil, fmt.Errorf("missing SPDX document")
+	}
+	b := &cycloneDXBuilder{
+		components: map[string]*cdx.Component{},
+		parents:    map[string]string{},
+		children:   map[string][]string{},
+		deps:       map[string][]string{},
+		scopes:     map[string]cdx.Scope{},
+	}
+
+	for _, p := range spdxDoc.Packages {
+		if p == nil {
+			continue
+		}
+		if err := b.add(packageToCycloneDX(p)); err != nil {
+			return nil, fmt.Errorf("failed to add package %q: %w", p.PackageName, err)
+		}
+		for _, f := range p.Files {
+			if f == nil {
+				continue
+			}
+			ref := fromSPDXElementID(f.FileSPDXIdentifier)
+			if _, ok := b.components[ref]; !ok {
+				if err := b.add(fileToCycloneDX(f)); err != nil {
+					return nil, fmt.Errorf("failed to add file %q: %w", f.FileName, err)
+				}
+			}
+			b.contains(fromSPDXElementID(p.PackageSPDXIdentifier), ref)
+		}
+	}
+	for _, f := range spdxDoc.Files {
+		if f == nil {
+			continue
+		}
+		if _, ok := b.components[fromSPDXElementID(f.FileSPDXIdentifier)]; ok {
+			continue
+		}
+		if err := b.add(fileToCycloneDX(f)); err != nil {
+			return nil, fmt.Errorf("failed to add file %q: %w", f.FileName, err)
+		}
+	}
+
+	docRef := fromSPDXElementID(spdxDoc.SPDXIdentifier)
+	var described []string
+	for _, r := range spdxDoc.Relationships {
+		if r == nil {
+			continue
+		}
+		refA, okA := b.localRef(r.RefA)
+		refB, okB := b.localRef(r.RefB)
+		switch r.Relationship {
+		case common.TypeRelationshipDescribe:
+			if refA == docRef && okB {
+				described = append(described, refB)
+				continue
+			}
+		case common.TypeRelationshipDescribeBy:
+			if refB == docRef && okA {
+				described = append(described, refA)
+				continue
+			}
+		case common.TypeRelationshipContains:
+			if okA && okB {
+				b.contains(refA, refB)
+				continue
+			}
+		case common.TypeRelationshipContainedBy:
+			if okA && okB {
+				b.contains(refB, refA)
+				continue
+			}
+		case common.TypeRelationshipDependsOn:
+			if okA && okB {
+				b.dependsOn(refA, refB, cdx.ScopeRequired)
+				continue
+			}
+		case common.TypeRelationshipDependencyOf:
+			if okA && okB {
+				b.dependsOn(refB, refA, cdx.ScopeRequired)
+				continue
+			}
+		default:
+			if scope, ok := cycloneDXDependencyScopes[r.Relationship]; ok && okA && okB {
+				b.dependsOn(refB, refA, scope)
+				continue
+			}
+		}
+		log.Warningf("dropping relationship %s %s %s", common.RenderDocElementID(r.RefA),
+			r.Relationship, common.RenderDocElementID(r.RefB))
+	}
+
+	bom := cdx.NewBOM()
+	bom.SerialNumber = serialNumberFromNamespace(spdxDoc.DocumentNamespace)
+	bom.Metadata = metadataFromSPDX(spdxDoc)
+	b.annotationsFromSPDX(spdxDoc, bom)
+
+	// Promote the described package to the metadata component. Older
+	// documents without a DESCRIBES relationship fall back to the package
+	// named after the document.
+	metaRef := ""
+	if len(described) > 0 {
+		metaRef = described[0]
+		if len(described) > 1 {
+			log.Warningf("document describes %d elements, using %q as the metadata component",
+				len(described), metaRef)
+		}
+	} else if p := namedRootPackage(spdxDoc); p != nil {
+		metaRef = fromSPDXElementID(p.PackageSPDXIdentifier)
+	}
+	if _, isChild := b.parents[metaRef]; metaRef != "" && !isChild {
+		if bom.Metadata == nil {
+			bom.Metadata = &cdx.Metadata{}
+		}
+		c := b.assemble(metaRef)
+		bom.Metadata.Component = &c
+	}
+
+	var components []cdx.Component
+	for _, ref := range b.order {
+		if _, isChild := b.parents[ref]; isChild || ref == metaRef {
+			continue
+		}
+		components = append(components, b.assemble(ref))
+	}
+	if len(components) > 0 {
+		bom.Components = &components
+	}
+
+	var dependencies []cdx.Dependency
+	for _, ref := range b.depOrder {
+		deps := b.deps[ref]
+		dependencies = append(dependencies, cdx.Dependency{
+			Ref:          ref,
+			Dependencies: &deps,
+		})
+	}
+	if len(dependencies) > 0 {
+		bom.Dependencies = &dependencies
+	}
+
+	log.Infof("Loaded %d elements from SPDX document", len(b.order))
+	return bom, nil
+}
+
+// CycloneDXToJSON encodes a CycloneDX BOM as indented JSON.
+func CycloneDXToJSON(bom *cdx.BOM) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).SetPretty(true).Encode(bom); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UpgradeCycloneDX moves fields deprecated by earlier CycloneDX 1.x versions
+// to their 1.6 replacements and marks the BOM as 1.6, so every input is
+// converted from the same model:
+//   - legacy metadata tools become application components,
+//   - metadata.manufacture becomes metadata.manufacturer,
+//   - component author becomes a component authors entry.
+func UpgradeCycloneDX(bom *cdx.BOM) {
+	if bom.SpecVersion != cdx.SpecVersion1_6 {
+		log.Infof("Upgrading CycloneDX %s BOM to %s", bom.SpecVersion, cdx.SpecVersion1_6)
+	}
+	if m := bom.Metadata; m != nil {
+		if m.Tools != nil && m.Tools.Tools != nil {
+			var components []cdx.Component
+			if m.Tools.Components != nil {
+				components = *m.Tools.Components
+			}
+			for _, t := range *m.Tools.Tools {
+				components = append(components, legacyToolComponent(t))
+			}
+			m.Tools.Tools = nil
+			m.Tools.Components = &components
+		}
+		if m.Manufacture != nil {
+			if m.Manufacturer == nil {
+				m.Manufacturer = m.Manufacture
+			}
+			m.Manufacture = nil
+		}
+		if m.Component != nil {
+			upgradeComponent(m.Component)
+		}
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			upgradeComponent(&(*bom.Components)[i])
+		}
+	}
+	latest := cdx.NewBOM()
+	bom.SpecVersion = latest.SpecVersion
+	bom.JSONSchema = latest.JSONSchema
+	bom.XMLNS = latest.XMLNS
+}
+
+func upgradeComponent(c *cdx.Component) {
+	if c.Author != "" {
+		if c.Authors == nil {
+			c.Authors = &[]cdx.OrganizationalContact{{Name: c.Author}}
+		}
+		c.Author = ""
+	}
+	if c.Components != nil {
+		for i := range *c.Components {
+			upgradeComponent(&(*c.Components)[i])
+		}
+	}
+}
+
+// legacyToolComponent returns the application component for a pre 1.5
+// metadata tool.
+func legacyToolComponent(t cdx.Tool) cdx.Component {
+	return cdx.Component{
+		Type:               cdx.ComponentTypeApplication,
+		Group:              t.Vendor,
+		Name:               t.Name,
+		Version:            t.Version,
+		Hashes:             t.Hashes,
+		ExternalReferences: t.ExternalReferences,
+	}
+}
+
+// cycloneDXBuilder collects components and their relationships so the
+// component tree can be assembled once every relationship has been seen.
+type cycloneDXBuilder struct {
+	components map[string]*cdx.Component
+	order      []string
+	// parents and children record CONTAINS relationships. A component has
+	// at most one parent, as CycloneDX can only nest it in one place.
+	parents  map[string]string
+	children map[string][]string
+	deps     map[string][]string
+	depOrder []string
+	// scopes records the widest scope a component is depended on with.
+	scopes map[string]cdx.Scope
+}
+
+func (b *cycloneDXBuilder) add(c cdx.Component) error {
+	if _, ok := b.components[c.BOMRef]; ok {
+		return fmt.Errorf("duplicate SPDX identifier: %q", c.BOMRef)
+	}
+	b.components[c.BOMRef] = &c
+	b.order = append(b.order, c.BOMRef)
+	return nil
+}
+
+// localRef returns the component reference for an SPDX element in this
+// document, and false for external, special or unknown elements.
+func (b *cycloneDXBuilder) localRef(id common.DocElementID) (string, bool) {
+	if id.DocumentRefID != "" || id.SpecialID != "" {
+		return "", false
+	}
+	ref := fromSPDXElementID(id.ElementRefID)
+	_, ok := b.components[ref]
+	return ref, ok
+}
+
+func (b *cycloneDXBuilder) contains(parent, child string) {
+	if parent == child {
+		return
+	}
+	if p, ok := b.parents[child]; ok {
+		if p != parent {
+			log.Warningf("%q is contained by both %q and %q, keeping it under %q", child, p, parent, p)
+		}
+		return
+	}
+	for p, ok := parent, true; ok; p, ok = b.parents[p] {
+		if p == child {
+			log.Warningf("ignoring cyclic CONTAINS relationship %q -> %q", parent, child)
+			return
+		}
+	}
+	b.parents[child] = parent
+	b.children[parent] = append(b.children[parent], child)
+}
+
+// dependsOn records a dependency of ref on dep with the given scope. The
+// scope of a component depended on several times is the widest one: a
+// component that is required anywhere is required.
+func (b *cycloneDXBuilder) dependsOn(ref, dep string, scope cdx.Scope) {
+	if current, ok := b.scopes[dep]; !ok || scopeRanks[scope] > scopeRanks[current] {
+		b.scopes[dep] = scope
+	}
+
+	deps, ok := b.deps[ref]
+	if !ok {
+		b.depOrder = append(b.depOrder, ref)
+	}
+	for _, d := range deps {
+		if d == dep {
+			return
+		}
+	}
+	b.deps[ref] = append(deps, dep)
+}
+
+// assemble returns the component for ref with its contained components
+// nested below it.
+func (b *cycloneDXBuilder) assemble(ref string) cdx.Component {
+	c := *b.components[ref]
+	if scope := b.scopes[ref]; scope != cdx.ScopeRequired {
+		c.Scope = scope
+	}
+	if children := b.children[ref]; len(children) > 0 {
+		nested := make([]cdx.Component, 0, len(children))
+		for _, child := range children {
+			nested = append(nested, b.assemble(child))
+		}
+		c.Components = &nested
+	}
+	return c
+}
+
+func packageToCycloneDX(p *spdx.Package) cdx.Component {
+	c := cdx.Component{
+		BOMRef:      fromSPDXElementID(p.PackageSPDXIdentifier),
+		Type:        cdx.ComponentTypeLibrary,
+		Name:        p.PackageName,
+		Version:     p.PackageVersion,
+		Description: p.PackageDescription,
+		Hashes:      hashesFromSPDX(p.PackageChecksums),
+		Licenses:    licensesFromSPDX(p.PackageLicenseDeclared, p.PackageLicenseConcluded),
+	}
+	if t, ok := spdxPurposeComponentTypes[p.PrimaryPackagePurpose]; ok {
+		c.Type = t
+	}
+	if s := p.PackageSupplier; s != nil && isSPDXValue(s.Supplier) {
+		c.Supplier = entityFromSPDXActor(s.Supplier, s.SupplierType)
+	}
+	if o := p.PackageOriginator; o != nil && isSPDXValue(o.Originator) {
+		if o.OriginatorType == "Person" {
+			name, email := splitNameEmail(o.Originator)
+			c.Authors = &[]cdx.OrganizationalContact{{Name: name, Email: email}}
+		} else {
+			c.Manufacturer = entityFromSPDXActor(o.Originator, o.OriginatorType)
+		}
+	}
+	if isSPDXValue(p.PackageCopyrightText) {
+		c.Copyright = p.PackageCopyrightText
+	}
+
+	var refs []cdx.ExternalReference
+	for _, ref := range p.PackageExternalReferences {
+		if ref == nil {
+			continue
+		}
+		switch ref.RefType {
+		case "purl":
+			if c.PackageURL == "" {
+				c.PackageURL = ref.Locator
+				continue
+			}
+		case "cpe22Type", "cpe23Type":
+			if c.CPE == "" {
+				c.CPE = ref.Locator
+				continue
+			}
+			if sameCPE(c.CPE, ref.Locator) {
+				// The same CPE in the other binding.
+				continue
+			}
+		case "gitoid", "swh":
+			cycloneDXPersistentID(&c, ref.Locator)
+			continue
+		}
+		if t, ok := cycloneDXExternalReferenceType(ref); ok {
+			refs = append(refs, cdx.ExternalReference{
+				Type:    t,
+				URL:     ref.Locator,
+				Comment: ref.ExternalRefComment,
+			})
+			continue
+		}
+		refs = append(refs, cdx.ExternalReference{
+			Type:    cdx.ERTypeOther,
+			URL:     ref.Locator,
+			Comment: fmt.Sprintf("SPDX %s %s", ref.Category, ref.RefType),
+		})
+	}
+	vcs := vcsFromSourceInfo(p.PackageSourceInfo)
+	if isSPDXValue(p.PackageDownloadLocation) {
+		// A download location in VCS syntax was derived from the first
+		// version control reference when there was no distribution.
+		isVCS := false
+		if len(vcs) > 0 {
+			location, _ := spdxVCSLocation(vcs[0])
+			isVCS = location == p.PackageDownloadLocation
+		}
+		if !isVCS {
+			refs = append([]cdx.ExternalReference{{
+				Type: cdx.ERTypeDistribution,
+				URL:  p.PackageDownloadLocation,
+			}}, refs...)
+		}
+	}
+	if isSPDXValue(p.PackageHomePage) {
+		refs = append(refs, cdx.ExternalReference{
+			Type: cdx.ERTypeWebsite,
+			URL:  p.PackageHomePage,
+		})
+	}
+	for _, u := range vcs {
+		refs = append(refs, cdx.ExternalReference{Type: cdx.ERTypeVCS, URL: u})
+	}
+	if len(refs) > 0 {
+		c.ExternalReferences = &refs
+	}
+	return c
+}
+
+func fileToCycloneDX(f *spdx.File) cdx.Component {
+	c := cdx.Component{
+		BOMRef:   fromSPDXElementID(f.FileSPDXIdentifier),
+		Type:     cdx.ComponentTypeFile,
+		Name:     f.FileName,
+		Hashes:   hashesFromSPDX(f.Checksums),
+		Licenses: licensesFromSPDX("", f.LicenseConcluded),
+	}
+	if isSPDXValue(f.FileCopyrightText) {
+		c.Copyright = f.FileCopyrightText
+	}
+	return c
+}
+
+func metadataFromSPDX(spdxDoc *spdx.Document) *cdx.Metadata {
+	if spdxDoc.CreationInfo == nil {
+		return nil
+	}
+	m := &cdx.Metadata{Timestamp: spdxDoc.CreationInfo.Created}
+	var tools []cdx.Component
+	var authors []cdx.OrganizationalContact
+	for _, creator := range spdxDoc.CreationInfo.Creators {
+		switch creator.CreatorType {
+		case "Tool":
+			name, version := splitToolCreator(creator.Creator)
+			tools = append(tools, cdx.Component{
+				Type:    cdx.ComponentTypeApplication,
+				Name:    name,
+				Version: version,
+			})
+		case "Person":
+			name, email := splitNameEmail(creator.Creator)
+			authors = append(authors, cdx.OrganizationalContact{Name: name, Email: email})
+		case "Organization":
+			if m.Supplier != nil {
+				log.Warningf("dropping additional organization creator %q", creator.Creator)
+				continue
+			}
+			m.Supplier = entityFromSPDX(creator.Creator)
+		default:
+			log.Warningf("dropping creator %q of unknown type %q", creator.Creator, creator.CreatorType)
+		}
+	}
+	if len(tools) > 0 {
+		m.Tools = &cdx.ToolsChoice{Components: &tools}
+	}
+	if len(authors) > 0 {
+		m.Authors = &authors
+	}
+	return m
+}
+
+func hashesFromSPDX(checksums []common.Checksum) *[]cdx.Hash {
+	var hashes []cdx.Hash
+	for _, cs := range checksums {
+		alg, ok := spdxChecksumAlgorithms[cs.Algorithm]
+		if !ok {
+			log.Warningf("dropping %s checksum, CycloneDX has no equivalent algorithm", cs.Algorithm)
+			continue
+		}
+		hashes = append(hashes, cdx.Hash{Algorithm: alg, Value: cs.Value})
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return &hashes
+}
+
+// licensesFromSPDX maps the declared and concluded SPDX license fields.
+// Single identifiers are kept as licenses with their acknowledgement, while
+// compound expressions become a single license expression, as CycloneDX
+// does not allow mixing the two.
+func licensesFromSPDX(declared, concluded string) *cdx.Licenses {
+	hasDeclared, hasConcluded := isSPDXValue(declared), isSPDXValue(concluded)
+	switch {
+	case !hasDeclared && !hasConcluded:
+		return nil
+	case hasDeclared && isCompoundLicense(declared), hasConcluded && isCompoundLicense(concluded):
+		expression := declared
+		if !hasDeclared {
+			expression = concluded
+		} else if hasConcluded && concluded != declared {
+			log.Warningf("dropping concluded license %q in favour of declared %q", concluded, declared)
+		}
+		return &cdx.Licenses{{Expression: expression}}
+	}
+	var licenses cdx.Licenses
+	if hasDeclared {
+		licenses = append(licenses, cdx.LicenseChoice{License: licenseFromSPDX(declared, cdx.LicenseAcknowledgementDeclared)})
+	}
+	if hasConcluded {
+		licenses = append(licenses, cdx.LicenseChoice{License: licenseFromSPDX(concluded, cdx.LicenseAcknowledgementConcluded)})
+	}
+	return &licenses
+}
+
+func licenseFromSPDX(id string, ack cdx.LicenseAcknowledgement) *cdx.License {
+	// CycloneDX license IDs must come from the SPDX license list.
+	if strings.HasPrefix(id, "LicenseRef-") || strings.Contains(id, ":") {
+		return &cdx.License{Name: id, Acknowledgement: ack}
+	}
+	return &cdx.License{ID: id, Acknowledgement: ack}
+}
+
+// ========== Helper methods =============
+
+// fromSPDXElementID reverses toSPDXElementID, also accepting identifiers
+// that still carry the "SPDXRef-" prefix.
+func fromSPDXElementID(id common.ElementID) string {
+	return strings.TrimPrefix(string(id), spdxRefPrefix)
+}
+
+// namedRootPackage returns the package named after the document, which
+// ConvertToGoogleSPDX creates from the CycloneDX metadata component.
+func namedRootPackage(spdxDoc *spdx.Document) *spdx.Package {
+	for _, p := range spdxDoc.Packages {
+		if p != nil && p.PackageName == spdxDoc.DocumentName {
+			return p
+		}
+	}
+	return nil
+}
+
+// isSPDXValue reports whether an SPDX field holds an actual value rather than
+// being empty, NONE or NOASSERTION.
+func isSPDXValue(v string) bool {
+	return v != "" && v != "NONE" && v != "NOASSERTION"
+}
+
+func isCompoundLicense(license string) bool {
+	return strings.ContainsAny(license, " ()")
+}
+
+// serialNumberFromNamespace reuses a UUID at the end of the document
+// namespace, as produced by ConvertToGoogleSPDX, and otherwise derives a
+// stable one from the namespace.
+func serialNumberFromNamespace(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	if len(namespace) >= 36 {
+		if id, err := uuid.Parse(namespace[len(namespace)-36:]); err == nil {
+			return id.URN()
+		}
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(namespace)).URN()
+}
+
+var nameEmailRE = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)$`)
+
+// splitNameEmail splits an SPDX "Name (email)" actor.
+func splitNameEmail(actor string) (string, string) {
+	if m := nameEmailRE.FindStringSubmatch(actor); m != nil {
+		return m[1], m[2]
+	}
+	return actor, ""
+}
+
+var toolVersionRE = regexp.MustCompile(`^(.+)-(v?[0-9][^-]*)$`)
+
+// splitToolCreator splits an SPDX "name-version" tool creator.
+func splitToolCreator(tool string) (string, string) {
+	if m := toolVersionRE.FindStringSubmatch(tool); m != nil {
+		return m[1], m[2]
+	}
+	return tool, ""
+}
+
+func entityFromSPDX(actor string) *cdx.OrganizationalEntity {
+	name, email := splitNameEmail(actor)
+	e := &cdx.OrganizationalEntity{Name: name}
+	if email != "" {
+		e.Contact = &[]cdx.OrganizationalContact{{Email: email}}
+	}
+	return e
+}
+
+// cycloneDXExternalReferenceType returns the CycloneDX type of an SPDX
+// external reference: advisory and url SECURITY references are advisories
+// and security contacts, and OTHER references named after a CycloneDX type
+// have that type.
+func cycloneDXExternalReferenceType(ref *spdx.PackageExternalReference) (cdx.ExternalReferenceType, bool) {
+	switch {
+	case ref.Category == "SECURITY" && ref.RefType == "advisory":
+		return cdx.ERTypeAdvisories, true
+	case ref.Category == "SECURITY" && ref.RefType == "url":
+		return cdx.ERTypeSecurityContact, true
+	case ref.Category == "OTHER":
+		for _, t := range externalReferenceTypes {
+			if string(t) == ref.RefType && t != cdx.ERTypeOther {
+				return t, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ========== Enum mappings =============
+
+var spdxPurposeComponentTypes = map[string]cdx.ComponentType{
+	"APPLICATION":      cdx.ComponentTypeApplication,
+	"FRAMEWORK":        cdx.ComponentTypeFramework,
+	"LIBRARY":          cdx.ComponentTypeLibrary,
+	"CONTAINER":        cdx.ComponentTypeContainer,
+	"OPERATING-SYSTEM": cdx.ComponentTypeOS,
+	"DEVICE":           cdx.ComponentTypeDevice,
+	"FIRMWARE":         cdx.ComponentTypeFirmware,
+	"FILE":             cdx.ComponentTypeFile,
+}
+
+// cycloneDXDependencyScopes maps the SPDX *_DEPENDENCY_OF relationships
+// other than DEPENDENCY_OF to the scope of the dependency.
+var cycloneDXDependencyScopes = map[string]cdx.Scope{
+	common.TypeRelationshipOptionalDependencyOf: cdx.ScopeOptional,
+	common.TypeRelationshipDevDependencyOf:      cdx.ScopeExcluded,
+	common.TypeRelationshipBuildDependencyOf:    cdx.ScopeExcluded,
+	common.TypeRelationshipTestDependencyOf:     cdx.ScopeExcluded,
+	common.TypeRelationshipRuntimeDependencyOf:  cdx.ScopeRequired,
+	common.TypeRelationshipProvidedDependencyOf: cdx.ScopeRequired,
+}
+
+// scopeRanks orders the scopes from the narrowest to the widest.
+var scopeRanks = map[cdx.Scope]int{
+	cdx.ScopeExcluded: 1,
+	cdx.ScopeOptional: 2,
+	cdx.ScopeRequired: 3,
+}
+
+// spdxChecksumAlgorithms maps SPDX checksum algorithms to CycloneDX hash
+// algorithms, and is used in both conversion directions.
+var spdxChecksumAlgorithms = map[common.ChecksumAlgorithm]cdx.HashAlgorithm{
+	common.MD5:         cdx.HashAlgoMD5,
+	common.SHA1:        cdx.HashAlgoSHA1,
+	common.SHA256:      cdx.HashAlgoSHA256,
+	common.SHA384:      cdx.HashAlgoSHA384,
+	common.SHA512:      cdx.HashAlgoSHA512,
+	common.SHA3_256:    cdx.HashAlgoSHA3_256,
+	common.SHA3_384:    cdx.HashAlgoSHA3_384,
+	common.SHA3_512:    cdx.HashAlgoSHA3_512,
+	common.BLAKE2b_256: cdx.HashAlgoBlake2b_256,
+	common.BLAKE2b_384: cdx.HashAlgoBlake2b_384,
+	common.BLAKE2b_512: cdx.HashAlgoBlake2b_512,
+	common.BLAKE3:      cdx.HashAlgoBlake3,
+}
@@ -0,0 +1,150 @@
+package sbom
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// annotationsFromSPDX restores the properties and annotations of a BOM from
+// the annotations of an SPDX document, reversing addPropertyAnnotations and
+// addCycloneDXBOMAnnotations.
+//
+// Annotations that encode a property become properties of the component or
+// BOM. All others become CycloneDX annotations about the component, or about
+// the BOM's serial number for document annotations. SPDX annotations that
+// only differ in their subject are merged into one annotation.
+func (b *cycloneDXBuilder) annotationsFromSPDX(spdxDoc *spdx.Document, bom *cdx.BOM) {
+	var annotations []cdx.Annotation
+	merged := map[spdx.Annotation]int{}
+	annotate := func(subject string, a spdx.Annotation) {
+		key := a
+		key.AnnotationSPDXIdentifier = common.DocElementID{}
+		if i, ok := merged[key]; ok {
+			subjects := append(*annotations[i].Subjects, cdx.BOMReference(subject))
+			annotations[i].Subjects = &subjects
+			return
+		}
+		merged[key] = len(annotations)
+		annotations = append(annotations, cdx.Annotation{
+			Subjects:  &[]cdx.BOMReference{cdx.BOMReference(subject)},
+			Annotator: cycloneDXAnnotator(a.Annotator),
+			Timestamp: a.AnnotationDate,
+			Text:      a.AnnotationComment,
+		})
+	}
+
+	var bomProperties, metadataProperties []cdx.Property
+	for _, a := range spdxAnnotations(spdxDoc) {
+		ref := fromSPDXElementID(a.AnnotationSPDXIdentifier.ElementRefID)
+		if ref == "" || ref == fromSPDXElementID(spdxDoc.SPDXIdentifier) {
+			if p, ok := propertyFromAnnotation(bomPropertyAnnotationPrefix, a.AnnotationComment); ok {
+				bomProperties = append(bomProperties, p)
+			} else if p, ok := propertyFromAnnotation(metadataPropertyAnnotationPrefix, a.AnnotationComment); ok {
+				metadataProperties = append(metadataProperties, p)
+			} else if bom.SerialNumber != "" {
+				annotate(bom.SerialNumber, a)
+			} else {
+				log.Warningf("dropping document annotation %q, the BOM has no serial number", a.AnnotationComment)
+			}
+			continue
+		}
+
+		c, ok := b.components[ref]
+		if !ok {
+			log.Warningf("dropping annotation of unknown element %q", ref)
+			continue
+		}
+		if id, ok := strings.CutPrefix(a.AnnotationComment, persistentIDAnnotationPrefix); ok {
+			cycloneDXPersistentID(c, id)
+			continue
+		}
+		if p, ok := propertyFromAnnotation(propertyAnnotationPrefix, a.AnnotationComment); ok {
+			var properties []cdx.Property
+			if c.Properties != nil {
+				properties = *c.Properties
+			}
+			properties = append(properties, p)
+			c.Properties = &properties
+			continue
+		}
+		annotate(ref, a)
+	}
+
+	if len(bomProperties) > 0 {
+		bom.Properties = &bomProperties
+	}
+	if len(metadataProperties) > 0 {
+		if bom.Metadata == nil {
+			bom.Metadata = &cdx.Metadata{}
+		}
+		bom.Metadata.Properties = &metadataProperties
+	}
+	if len(annotations) > 0 {
+		bom.Annotations = &annotations
+	}
+}
+
+// spdxAnnotations returns the annotations of a document with the element
+// they are about, whether kept with the package or file as in SPDX JSON or
+// on the document as in tag-value. Document annotations from SPDX JSON have
+// no element.
+func spdxAnnotations(spdxDoc *spdx.Document) []spdx.Annotation {
+	var annotations []spdx.Annotation
+	for _, a := range spdxDoc.Annotations {
+		if a != nil {
+			annotations = append(annotations, *a)
+		}
+	}
+	seenFiles := map[common.ElementID]bool{}
+	addFile := func(f *spdx.File) {
+		if f == nil || seenFiles[f.FileSPDXIdentifier] {
+			return
+		}
+		seenFiles[f.FileSPDXIdentifier] = true
+		for _, a := range f.Annotations {
+			a.AnnotationSPDXIdentifier = common.DocElementID{ElementRefID: f.FileSPDXIdentifier}
+			annotations = append(annotations, a)
+		}
+	}
+	for _, p := range spdxDoc.Packages {
+		if p == nil {
+			continue
+		}
+		for _, a := range p.Annotations {
+			a.AnnotationSPDXIdentifier = common.DocElementID{ElementRefID: p.PackageSPDXIdentifier}
+			annotations = append(annotations, a)
+		}
+		for _, f := range p.Files {
+			addFile(f)
+		}
+	}
+	for _, f := range spdxDoc.Files {
+		addFile(f)
+	}
+	return annotations
+}
+
+// cycloneDXAnnotator maps an SPDX annotator to a CycloneDX one. Tools become
+// application components.
+func cycloneDXAnnotator(a common.Annotator) *cdx.Annotator {
+	switch a.AnnotatorType {
+	case "Person":
+		name, email := splitNameEmail(a.Annotator)
+		return &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: name, Email: email}}
+	case "Organization":
+		return &cdx.Annotator{Organization: entityFromSPDX(a.Annotator)}
+	case "Tool":
+		name, version := splitToolCreator(a.Annotator)
+		return &cdx.Annotator{Component: &cdx.Component{
+			Type:    cdx.ComponentTypeApplication,
+			Name:    name,
+			Version: version,
+		}}
+	}
+	log.Warningf("annotator %q has unknown type %q", a.Annotator, a.AnnotatorType)
+	return nil
+}
@@ -0,0 +1,121 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "k8s.io/klog"
+)
+
+// AddOmniBORIDs computes the OmniBOR artifact IDs of the file components of
+// a BOM whose artifacts are found under dir, and adds them to the omniborId
+// lists of the components. The artifact of a file component is its name,
+// relative to dir. Components whose artifact is missing are skipped.
+//
+// Artifact IDs are SHA-256 gitoids of the file content as a git blob, such
+// as "gitoid:blob:sha256:<hex>".
+func AddOmniBORIDs(bom *cdx.BOM, dir string) error {
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		if err := addComponentOmniBORIDs(bom.Metadata.Component, dir); err != nil {
+			return err
+		}
+	}
+	if bom.Components == nil {
+		return nil
+	}
+	for i := range *bom.Components {
+		if err := addComponentOmniBORIDs(&(*bom.Components)[i], dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addComponentOmniBORIDs adds the OmniBOR IDs of a component and its nested
+// components.
+func addComponentOmniBORIDs(c *cdx.Component, dir string) error {
+	if err := addOmniBORID(c, dir); err != nil {
+		return err
+	}
+	if c.Components == nil {
+		return nil
+	}
+	for i := range *c.Components {
+		if err := addComponentOmniBORIDs(&(*c.Components)[i], dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addOmniBORID(c *cdx.Component, dir string) error {
+	if c.Type != cdx.ComponentTypeFile || c.Name == "" {
+		return nil
+	}
+	name := filepath.FromSlash(strings.TrimLeft(c.Name, "/"))
+	if !filepath.IsLocal(name) {
+		log.Warningf("file component %q: not computing OmniBOR ID, name is not a local path", c.Name)
+		return nil
+	}
+	gitoid, err := fileGitoid(filepath.Join(dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Infof("file component %q: not computing OmniBOR ID, artifact not found", c.Name)
+		return nil
+	}
+	if errors.Is(err, errNotRegularFile) {
+		log.Warningf("file component %q: not computing OmniBOR ID, artifact is not a regular file", c.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compute OmniBOR ID of %q: %w", c.Name, err)
+	}
+
+	var ids []string
+	if c.OmniborID != nil {
+		ids = *c.OmniborID
+	}
+	if !slices.Contains(ids, gitoid) {
+		ids = append(ids, gitoid)
+		c.OmniborID = &ids
+	}
+	return nil
+}
+
+var errNotRegularFile = errors.New("not a regular file")
+
+// fileGitoid returns the SHA-256 gitoid of a regular file: the hash of
+// "blob <size>\x00" followed by the file content.
+func fileGitoid(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", errNotRegularFile
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "blob %d\x00", info.Size())
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	if n != info.Size() {
+		return "", fmt.Errorf("%s changed while hashing", path)
+	}
+	return "gitoid:blob:sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
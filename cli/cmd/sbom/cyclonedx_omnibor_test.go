@@ -0,0 +1,67 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	helloGitoid = "gitoid:blob:sha256:0bd69098bd9b9cc5934a610ab65da429b525361147faa7b5b922919e9a23143d"
+	// emptyGitoid is the SHA-256 git ID of the empty blob.
+	emptyGitoid = "gitoid:blob:sha256:473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813"
+)
+
+func TestAddOmniBORIDs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "etc"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "tool"), []byte("hello world\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "etc", "empty"), nil, 0o644))
+
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type:       cdx.ComponentTypeFile,
+		Name:       "bin/tool",
+		Components: &[]cdx.Component{{Type: cdx.ComponentTypeFile, Name: "etc/empty"}},
+	}}
+	bom.Components = &[]cdx.Component{{
+		Type:      cdx.ComponentTypeFile,
+		Name:      "/etc/empty",
+		OmniborID: &[]string{emptyGitoid},
+	}, {
+		Type: cdx.ComponentTypeFirmware,
+		Name: "bin",
+		Components: &[]cdx.Component{
+			{Type: cdx.ComponentTypeFile, Name: "bin/tool", OmniborID: &[]string{"gitoid:blob:sha1:0000000000000000000000000000000000000000"}},
+			{Type: cdx.ComponentTypeFile, Name: "bin/missing"},
+		},
+	}, {
+		Type: cdx.ComponentTypeFile,
+		Name: "etc",
+	}, {
+		Type: cdx.ComponentTypeFile,
+		Name: "../bin/tool",
+	}, {
+		Type: cdx.ComponentTypeLibrary,
+		Name: "bin/tool",
+	}}
+
+	require.NoError(t, AddOmniBORIDs(bom, dir))
+
+	assert.Equal(t, &[]string{helloGitoid}, bom.Metadata.Component.OmniborID)
+	assert.Equal(t, &[]string{emptyGitoid}, (*bom.Metadata.Component.Components)[0].OmniborID)
+	components := *bom.Components
+	assert.Equal(t, &[]string{emptyGitoid}, components[0].OmniborID)
+	assert.Nil(t, components[1].OmniborID)
+	nested := *components[1].Components
+	assert.Equal(t, &[]string{"gitoid:blob:sha1:0000000000000000000000000000000000000000", helloGitoid}, nested[0].OmniborID)
+	assert.Nil(t, nested[1].OmniborID)
+	assert.Nil(t, components[2].OmniborID)
+	assert.Nil(t, components[3].OmniborID)
+	assert.Nil(t, components[4].OmniborID)
+}
@@ -0,0 +1,1035 @@
+package sbom
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	log "k8s.io/klog"
+)
+
+// ParseCycloneDXProto decodes a protobuf encoded CycloneDX 1.6 BOM.
+func ParseCycloneDXProto(b []byte) (*cdx.BOM, error) {
+	pb := &cdxpb.Bom{}
+	if err := proto.Unmarshal(b, pb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CycloneDX proto: %w", err)
+	}
+	unknown := map[protoreflect.FullName]map[protowire.Number]bool{}
+	collectUnknownFields(pb.ProtoReflect(), unknown)
+	for name, fields := range unknown {
+		log.Warningf("%s: ignoring unsupported fields %v", name, sortedFieldNumbers(fields))
+	}
+	return CycloneDXFromProto(pb)
+}
+
+// CycloneDXFromProto converts a CycloneDX 1.6 proto BOM into its
+// cyclonedx-go representation.
+func CycloneDXFromProto(pb *cdxpb.Bom) (*cdx.BOM, error) {
+	bom := cdx.NewBOM()
+	if v := pb.GetSpecVersion(); v != "" {
+		if err := bom.SpecVersion.UnmarshalJSON([]byte(strconv.Quote(v))); err != nil {
+			return nil, fmt.Errorf("invalid spec version %q: %w", v, err)
+		}
+	}
+	if pb.Version != nil {
+		bom.Version = int(pb.GetVersion())
+	}
+	bom.SerialNumber = pb.GetSerialNumber()
+	if pb.Metadata != nil {
+		bom.Metadata = metadataFromProto(pb.GetMetadata())
+	}
+	bom.Components = convertSlice(pb.GetComponents(), componentFromProto)
+	bom.Services = convertSlice(pb.GetServices(), serviceFromProto)
+	bom.ExternalReferences = convertSlice(pb.GetExternalReferences(), externalReferenceFromProto)
+	bom.Dependencies = dependenciesFromProto(pb.GetDependencies())
+	bom.Compositions = convertSlice(pb.GetCompositions(), compositionFromProto)
+	bom.Vulnerabilities = convertSlice(pb.GetVulnerabilities(), vulnerabilityFromProto)
+	bom.Annotations = convertSlice(pb.GetAnnotations(), annotationFromProto)
+	bom.Properties = convertSlice(pb.GetProperties(), propertyFromProto)
+	bom.Formulation = convertSlice(pb.GetFormulation(), formulaFromProto)
+	if pb.Declarations != nil {
+		bom.Declarations = declarationsFromProto(pb.GetDeclarations())
+	}
+	if pb.Definitions != nil {
+		bom.Definitions = definitionsFromProto(pb.GetDefinitions())
+	}
+	return bom, nil
+}
+
+func metadataFromProto(pm *cdxpb.Metadata) *cdx.Metadata {
+	m := &cdx.Metadata{
+		Timestamp:    timestampFromProto(pm.GetTimestamp()),
+		Lifecycles:   convertSlice(pm.GetLifecycles(), lifecycleFromProto),
+		Tools:        toolsFromProto(pm.GetTools()),
+		Authors:      convertSlice(pm.GetAuthors(), contactFromProto),
+		Manufacture:  entityFromProto(pm.GetManufacture()),
+		Manufacturer: entityFromProto(pm.GetManufacturer()),
+		Supplier:     entityFromProto(pm.GetSupplier()),
+		Licenses:     licensesFromProto(pm.GetLicenses()),
+		Properties:   convertSlice(pm.GetProperties(), propertyFromProto),
+	}
+	if pm.Component != nil {
+		c := componentFromProto(pm.GetComponent())
+		m.Component = &c
+	}
+	return m
+}
+
+func lifecycleFromProto(pl *cdxpb.Lifecycles) cdx.Lifecycle {
+	l := cdx.Lifecycle{Description: pl.GetDescription()}
+	switch pl.GetChoice().(type) {
+	case *cdxpb.Lifecycles_Phase:
+		l.Phase = lifecyclePhases[pl.GetPhase()]
+	case *cdxpb.Lifecycles_Name:
+		l.Name = pl.GetName()
+	}
+	return l
+}
+
+// toolsFromProto maps the proto tools list, which carries both the legacy
+// (pre 1.5) tool fields and the components/services form, into a ToolsChoice.
+// cyclonedx-go does not allow both forms at once, so legacy tools are
+// promoted to application components when the newer form is present.
+func toolsFromProto(pts []*cdxpb.Tool) *cdx.ToolsChoice {
+	if len(pts) == 0 {
+		return nil
+	}
+	var legacy []cdx.Tool
+	var components []cdx.Component
+	var services []cdx.Service
+	for _, pt := range pts {
+		if pt.GetName() != "" || pt.GetVendor() != "" || pt.GetVersion() != "" {
+			legacy = append(legacy, cdx.Tool{
+				Vendor:             pt.GetVendor(),
+				Name:               pt.GetName(),
+				Version:            pt.GetVersion(),
+				Hashes:             convertSlice(pt.GetHashes(), hashFromProto),
+				ExternalReferences: convertSlice(pt.GetExternalReferences(), externalReferenceFromProto),
+			})
+		}
+		for _, c := range pt.GetComponents() {
+			components = append(components, componentFromProto(c))
+		}
+		for _, s := range pt.GetServices() {
+			services = append(services, serviceFromProto(s))
+		}
+	}
+	if len(components) == 0 && len(services) == 0 {
+		return &cdx.ToolsChoice{Tools: &legacy}
+	}
+	for _, t := range legacy {
+		components = append(components, legacyToolComponent(t))
+	}
+	tc := &cdx.ToolsChoice{}
+	if len(components) != 0 {
+		tc.Components = &components
+	}
+	if len(services) != 0 {
+		tc.Services = &services
+	}
+	return tc
+}
+
+func componentFromProto(pc *cdxpb.Component) cdx.Component {
+	c := cdx.Component{
+		BOMRef:             pc.GetBomRef(),
+		MIMEType:           pc.GetMimeType(),
+		Type:               componentTypes[pc.GetType()],
+		Supplier:           entityFromProto(pc.GetSupplier()),
+		Manufacturer:       entityFromProto(pc.GetManufacturer()),
+		Author:             pc.GetAuthor(),
+		Authors:            convertSlice(pc.GetAuthors(), contactFromProto),
+		Publisher:          pc.GetPublisher(),
+		Group:              pc.GetGroup(),
+		Name:               pc.GetName(),
+		Version:            pc.GetVersion(),
+		Description:        pc.GetDescription(),
+		Scope:              scopes[pc.GetScope()],
+		Hashes:             convertSlice(pc.GetHashes(), hashFromProto),
+		Licenses:           licensesFromProto(pc.GetLicenses()),
+		Copyright:          pc.GetCopyright(),
+		CPE:                pc.GetCpe(),
+		PackageURL:         pc.GetPurl(),
+		OmniborID:          stringSlice(pc.GetOmniborId()),
+		SWHID:              stringSlice(pc.GetSwhid()),
+		ExternalReferences: convertSlice(pc.GetExternalReferences(), externalReferenceFromProto),
+		Properties:         convertSlice(pc.GetProperties(), propertyFromProto),
+		Components:         convertSlice(pc.GetComponents(), componentFromProto),
+	}
+	if pc.Modified != nil {
+		c.Modified = cdx.Bool(pc.GetModified())
+	}
+	if ps := pc.GetSwid(); ps != nil {
+		c.SWID = &cdx.SWID{
+			Text:    attachedTextFromProto(ps.GetText()),
+			URL:     ps.GetUrl(),
+			TagID:   ps.GetTagId(),
+			Name:    ps.GetName(),
+			Version: ps.GetVersion(),
+		}
+		if ps.TagVersion != nil {
+			v := int(ps.GetTagVersion())
+			c.SWID.TagVersion = &v
+		}
+		if ps.Patch != nil {
+			c.SWID.Patch = cdx.Bool(ps.GetPatch())
+		}
+	}
+	if pc.Pedigree != nil {
+		c.Pedigree = pedigreeFromProto(pc.GetPedigree())
+	}
+	if pc.Evidence != nil {
+		c.Evidence = evidenceFromProto(pc.GetEvidence())
+	}
+	if pc.ReleaseNotes != nil {
+		c.ReleaseNotes = releaseNotesFromProto(pc.GetReleaseNotes())
+	}
+	if pc.ModelCard != nil {
+		c.ModelCard = modelCardFromProto(pc.GetModelCard())
+	}
+	// cyclonedx-go holds a single data entry per component.
+	if data := pc.GetData(); len(data) != 0 {
+		d := componentDataFromProto(data[0])
+		c.Data = &d
+		if len(data) > 1 {
+			log.Warningf("component %q: only the first of %d data entries is supported", c.BOMRef, len(data))
+		}
+	}
+	if pc.CryptoProperties != nil {
+		c.CryptoProperties = cryptoPropertiesFromProto(pc.GetCryptoProperties())
+	}
+	if len(pc.GetTags()) != 0 {
+		log.Warningf("component %q: tags are not supported and were dropped", c.BOMRef)
+	}
+	return c
+}
+
+func pedigreeFromProto(pp *cdxpb.Pedigree) *cdx.Pedigree {
+	return &cdx.Pedigree{
+		Ancestors:   convertSlice(pp.GetAncestors(), componentFromProto),
+		Descendants: convertSlice(pp.GetDescendants(), componentFromProto),
+		Variants:    convertSlice(pp.GetVariants(), componentFromProto),
+		Commits:     convertSlice(pp.GetCommits(), commitFromProto),
+		Patches:     convertSlice(pp.GetPatches(), patchFromProto),
+		Notes:       pp.GetNotes(),
+	}
+}
+
+func commitFromProto(pc *cdxpb.Commit) cdx.Commit {
+	return cdx.Commit{
+		UID:       pc.GetUid(),
+		URL:       pc.GetUrl(),
+		Author:    identifiableActionFromProto(pc.GetAuthor()),
+		Committer: identifiableActionFromProto(pc.GetCommitter()),
+		Message:   pc.GetMessage(),
+	}
+}
+
+func identifiableActionFromProto(pa *cdxpb.IdentifiableAction) *cdx.IdentifiableAction {
+	if pa == nil {
+		return nil
+	}
+	return &cdx.IdentifiableAction{
+		Timestamp: timestampFromProto(pa.GetTimestamp()),
+		Name:      pa.GetName(),
+		Email:     pa.GetEmail(),
+	}
+}
+
+func patchFromProto(pp *cdxpb.Patch) cdx.Patch {
+	p := cdx.Patch{
+		Resolves: convertSlice(pp.GetResolves(), issueFromProto),
+		Type:     patchTypes[pp.GetType()],
+	}
+	if pd := pp.GetDiff(); pd != nil {
+		p.Diff = &cdx.Diff{
+			Text: attachedTextFromProto(pd.GetText()),
+			URL:  pd.GetUrl(),
+		}
+	}
+	return p
+}
+
+func issueFromProto(pi *cdxpb.Issue) cdx.Issue {
+	return cdx.Issue{
+		ID:          pi.GetId(),
+		Name:        pi.GetName(),
+		Description: pi.GetDescription(),
+		Source:      sourceFromProto(pi.GetSource()),
+		References:  stringSlice(pi.GetReferences()),
+		Type:        issueTypes[pi.GetType()],
+	}
+}
+
+func evidenceFromProto(pe *cdxpb.Evidence) *cdx.Evidence {
+	e := &cdx.Evidence{
+		Identity:    convertSlice(pe.GetIdentity(), evidenceIdentityFromProto),
+		Occurrences: convertSlice(pe.GetOccurrences(), occurrenceFromProto),
+		Licenses:    licensesFromProto(pe.GetLicenses()),
+		Copyright: convertSlice(pe.GetCopyright(), func(pc *cdxpb.EvidenceCopyright) cdx.Copyright {
+			return cdx.Copyright{Text: pc.GetText()}
+		}),
+	}
+	if pc := pe.GetCallstack(); pc != nil {
+		e.Callstack = &cdx.Callstack{
+			Frames: convertSlice(pc.GetFrames(), callstackFrameFromProto),
+		}
+	}
+	return e
+}
+
+func evidenceIdentityFromProto(pi *cdxpb.EvidenceIdentity) cdx.EvidenceIdentity {
+	i := cdx.EvidenceIdentity{
+		Field: evidenceFields[pi.GetField()],
+		Methods: convertSlice(pi.GetMethods(), func(pm *cdxpb.EvidenceMethods) cdx.EvidenceIdentityMethod {
+			confidence := pm.GetConfidence()
+			return cdx.EvidenceIdentityMethod{
+				Technique:  evidenceTechniques[pm.GetTechnique()],
+				Confidence: &confidence,
+				Value:      pm.GetValue(),
+			}
+		}),
+		Tools: convertSlice(pi.GetTools(), func(ref string) cdx.BOMReference {
+			return cdx.BOMReference(ref)
+		}),
+	}
+	if pi.Confidence != nil {
+		confidence := pi.GetConfidence()
+		i.Confidence = &confidence
+	}
+	if pi.ConcludedValue != nil {
+		log.Warningf("evidence identity %q: concluded value is not supported and was dropped", i.Field)
+	}
+	return i
+}
+
+func occurrenceFromProto(po *cdxpb.EvidenceOccurrences) cdx.EvidenceOccurrence {
+	return cdx.EvidenceOccurrence{
+		BOMRef:            po.GetBomRef(),
+		Location:          po.GetLocation(),
+		Line:              optionalInt(po.Line),
+		Offset:            optionalInt(po.Offset),
+		Symbol:            po.GetSymbol(),
+		AdditionalContext: po.GetAdditionalContext(),
+	}
+}
+
+func callstackFrameFromProto(pf *cdxpb.Callstack_Frames) cdx.CallstackFrame {
+	return cdx.CallstackFrame{
+		Package:      pf.GetPackage(),
+		Module:       pf.GetModule(),
+		Function:     pf.GetFunction(),
+		Parameters:   stringSlice(pf.GetParameters()),
+		Line:         optionalInt(pf.Line),
+		Column:       optionalInt(pf.Column),
+		FullFilename: pf.GetFullFilename(),
+	}
+}
+
+func releaseNotesFromProto(pr *cdxpb.ReleaseNotes) *cdx.ReleaseNotes {
+	return &cdx.ReleaseNotes{
+		Type:          pr.GetType(),
+		Title:         pr.GetTitle(),
+		FeaturedImage: pr.GetFeaturedImage(),
+		SocialImage:   pr.GetSocialImage(),
+		Description:   pr.GetDescription(),
+		Timestamp:     timestampFromProto(pr.GetTimestamp()),
+		Aliases:       stringSlice(pr.GetAliases()),
+		Tags:          stringSlice(pr.GetTags()),
+		Resolves:      convertSlice(pr.GetResolves(), issueFromProto),
+		Notes: convertSlice(pr.GetNotes(), func(pn *cdxpb.ReleaseNotes_Note) cdx.Note {
+			n := cdx.Note{Locale: pn.GetLocale()}
+			if t := attachedTextFromProto(pn.GetText()); t != nil {
+				n.Text = *t
+			}
+			return n
+		}),
+		Properties: convertSlice(pr.GetProperties(), propertyFromProto),
+	}
+}
+
+func serviceFromProto(ps *cdxpb.Service) cdx.Service {
+	s := cdx.Service{
+		BOMRef:      ps.GetBomRef(),
+		Provider:    entityFromProto(ps.GetProvider()),
+		Group:       ps.GetGroup(),
+		Name:        ps.GetName(),
+		Version:     ps.GetVersion(),
+		Description: ps.GetDescription(),
+		Endpoints:   stringSlice(ps.GetEndpoints()),
+		Data: convertSlice(ps.GetData(), func(pd *cdxpb.DataClassification) cdx.DataClassification {
+			return cdx.DataClassification{
+				Flow:           dataFlows[pd.GetFlow()],
+				Classification: pd.GetValue(),
+			}
+		}),
+		Licenses:           licensesFromProto(ps.GetLicenses()),
+		ExternalReferences: convertSlice(ps.GetExternalReferences(), externalReferenceFromProto),
+		Properties:         convertSlice(ps.GetProperties(), propertyFromProto),
+		Services:           convertSlice(ps.GetServices(), serviceFromProto),
+	}
+	if ps.Authenticated != nil {
+		s.Authenticated = cdx.Bool(ps.GetAuthenticated())
+	}
+	if ps.XTrustBoundary != nil {
+		s.CrossesTrustBoundary = cdx.Bool(ps.GetXTrustBoundary())
+	}
+	if ps.ReleaseNotes != nil {
+		s.ReleaseNotes = releaseNotesFromProto(ps.GetReleaseNotes())
+	}
+	if ps.TrustZone != nil || len(ps.GetTags()) != 0 {
+		log.Warningf("service %q: trust zone and tags are not supported and were dropped", s.BOMRef)
+	}
+	return s
+}
+
+// dependenciesFromProto flattens the nested proto dependency graph into the
+// list of dependency objects used by the JSON and XML bindings. Entries for
+// the same ref, whether top level or nested, are merged into one. cyclonedx-go
+// cannot hold provides lists, see ParseCycloneDXProtoProvides.
+func dependenciesFromProto(pds []*cdxpb.Dependency) *[]cdx.Dependency {
+	var refs []string
+	dependsOn := map[string][]string{}
+	seen := map[string]map[string]bool{}
+	var walk func(pd *cdxpb.Dependency)
+	walk = func(pd *cdxpb.Dependency) {
+		ref := pd.GetRef()
+		if seen[ref] == nil {
+			refs = append(refs, ref)
+			seen[ref] = map[string]bool{}
+		}
+		for _, child := range pd.GetDependencies() {
+			if !seen[ref][child.GetRef()] {
+				seen[ref][child.GetRef()] = true
+				dependsOn[ref] = append(dependsOn[ref], child.GetRef())
+			}
+		}
+		for _, child := range pd.GetDependencies() {
+			if len(child.GetDependencies()) != 0 || len(child.GetProvides()) != 0 {
+				walk(child)
+			}
+		}
+	}
+	for _, pd := range pds {
+		walk(pd)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	deps := make([]cdx.Dependency, 0, len(refs))
+	for _, ref := range refs {
+		deps = append(deps, cdx.Dependency{Ref: ref, Dependencies: stringSlice(dependsOn[ref])})
+	}
+	return &deps
+}
+
+func compositionFromProto(pc *cdxpb.Composition) cdx.Composition {
+	toRefs := func(refs []string) *[]cdx.BOMReference {
+		return convertSlice(refs, func(ref string) cdx.BOMReference {
+			return cdx.BOMReference(ref)
+		})
+	}
+	return cdx.Composition{
+		BOMRef:          pc.GetBomRef(),
+		Aggregate:       compositionAggregates[pc.GetAggregate()],
+		Assemblies:      toRefs(pc.GetAssemblies()),
+		Dependencies:    toRefs(pc.GetDependencies()),
+		Vulnerabilities: toRefs(pc.GetVulnerabilities()),
+	}
+}
+
+func vulnerabilityFromProto(pv *cdxpb.Vulnerability) cdx.Vulnerability {
+	v := cdx.Vulnerability{
+		BOMRef: pv.GetBomRef(),
+		ID:     pv.GetId(),
+		Source: sourceFromProto(pv.GetSource()),
+		References: convertSlice(pv.GetReferences(), func(pr *cdxpb.VulnerabilityReference) cdx.VulnerabilityReference {
+			return cdx.VulnerabilityReference{
+				ID:     pr.GetId(),
+				Source: sourceFromProto(pr.GetSource()),
+			}
+		}),
+		Ratings: convertSlice(pv.GetRatings(), vulnerabilityRatingFromProto),
+		CWEs: convertSlice(pv.GetCwes(), func(cwe int32) int {
+			return int(cwe)
+		}),
+		Description:    pv.GetDescription(),
+		Detail:         pv.GetDetail(),
+		Recommendation: pv.GetRecommendation(),
+		Workaround:     pv.GetWorkaround(),
+		Advisories: convertSlice(pv.GetAdvisories(), func(pa *cdxpb.Advisory) cdx.Advisory {
+			return cdx.Advisory{Title: pa.GetTitle(), URL: pa.GetUrl()}
+		}),
+		Created:    timestampFromProto(pv.GetCreated()),
+		Published:  timestampFromProto(pv.GetPublished()),
+		Updated:    timestampFromProto(pv.GetUpdated()),
+		Rejected:   timestampFromProto(pv.GetRejected()),
+		Tools:      toolsFromProto(pv.GetTools()),
+		Affects:    convertSlice(pv.GetAffects(), affectsFromProto),
+		Properties: convertSlice(pv.GetProperties(), propertyFromProto),
+	}
+	if pp := pv.GetProofOfConcept(); pp != nil {
+		v.ProofOfConcept = &cdx.ProofOfConcept{
+			ReproductionSteps: pp.GetReproductionSteps(),
+			Environment:       pp.GetEnvironment(),
+			SupportingMaterial: convertSlice(pp.GetSupportingMaterial(), func(pt *cdxpb.AttachedText) cdx.AttachedText {
+				return valueOrZero(attachedTextFromProto(pt))
+			}),
+		}
+	}
+	if pc := pv.GetCredits(); pc != nil {
+		v.Credits = &cdx.Credits{
+			Organizations: convertSlice(pc.GetOrganizations(), func(pe *cdxpb.OrganizationalEntity) cdx.OrganizationalEntity {
+				return valueOrZero(entityFromProto(pe))
+			}),
+			Individuals: convertSlice(pc.GetIndividuals(), contactFromProto),
+		}
+	}
+	if pa := pv.GetAnalysis(); pa != nil {
+		v.Analysis = &cdx.VulnerabilityAnalysis{
+			State:         impactAnalysisStates[pa.GetState()],
+			Justification: impactAnalysisJustifications[pa.GetJustification()],
+			Response: convertSlice(pa.GetResponse(), func(r cdxpb.VulnerabilityResponse) cdx.ImpactAnalysisResponse {
+				return impactAnalysisResponses[r]
+			}),
+			Detail:      pa.GetDetail(),
+			FirstIssued: timestampFromProto(pa.GetFirstIssued()),
+			LastUpdated: timestampFromProto(pa.GetLastUpdated()),
+		}
+	}
+	return v
+}
+
+func vulnerabilityRatingFromProto(pr *cdxpb.VulnerabilityRating) cdx.VulnerabilityRating {
+	r := cdx.VulnerabilityRating{
+		Source:        sourceFromProto(pr.GetSource()),
+		Method:        scoringMethods[pr.GetMethod()],
+		Vector:        pr.GetVector(),
+		Justification: pr.GetJustification(),
+	}
+	if pr.Score != nil {
+		score := pr.GetScore()
+		r.Score = &score
+	}
+	if pr.Severity != nil {
+		r.Severity = severities[pr.GetSeverity()]
+	}
+	return r
+}
+
+func affectsFromProto(pa *cdxpb.VulnerabilityAffects) cdx.Affects {
+	return cdx.Affects{
+		Ref: pa.GetRef(),
+		Range: convertSlice(pa.GetVersions(), func(pv *cdxpb.VulnerabilityAffectedVersions) cdx.AffectedVersions {
+			return cdx.AffectedVersions{
+				Version: pv.GetVersion(),
+				Range:   pv.GetRange(),
+				Status:  vulnerabilityStatuses[pv.GetStatus()],
+			}
+		}),
+	}
+}
+
+func annotationFromProto(pa *cdxpb.Annotation) cdx.Annotation {
+	a := cdx.Annotation{
+		BOMRef: pa.GetBomRef(),
+		Subjects: convertSlice(pa.GetSubjects(), func(ref string) cdx.BOMReference {
+			return cdx.BOMReference(ref)
+		}),
+		Timestamp: timestampFromProto(pa.GetTimestamp()),
+		Text:      pa.GetText(),
+	}
+	if pan := pa.GetAnnotator(); pan != nil {
+		a.Annotator = &cdx.Annotator{
+			Organization: entityFromProto(pan.GetOrganization()),
+		}
+		if pan.GetIndividual() != nil {
+			c := contactFromProto(pan.GetIndividual())
+			a.Annotator.Individual = &c
+		}
+		if pan.GetComponent() != nil {
+			c := componentFromProto(pan.GetComponent())
+			a.Annotator.Component = &c
+		}
+		if pan.GetService() != nil {
+			s := serviceFromProto(pan.GetService())
+			a.Annotator.Service = &s
+		}
+	}
+	return a
+}
+
+func licensesFromProto(pls []*cdxpb.LicenseChoice) *cdx.Licenses {
+	if len(pls) == 0 {
+		return nil
+	}
+	licenses := make(cdx.Licenses, 0, len(pls))
+	for _, pl := range pls {
+		switch pl.GetChoice().(type) {
+		case *cdxpb.LicenseChoice_License:
+			l := licenseFromProto(pl.GetLicense())
+			if l.Acknowledgement == "" {
+				l.Acknowledgement = licenseAcknowledgements[pl.GetAcknowledgement()]
+			}
+			licenses = append(licenses, cdx.LicenseChoice{License: l})
+		case *cdxpb.LicenseChoice_Expression:
+			if pl.Acknowledgement != nil {
+				log.Warningf("license expression %q: acknowledgement is not supported and was dropped",
+					pl.GetExpression())
+			}
+			licenses = append(licenses, cdx.LicenseChoice{Expression: pl.GetExpression()})
+		}
+	}
+	return &licenses
+}
+
+func licenseFromProto(pl *cdxpb.License) *cdx.License {
+	l := &cdx.License{
+		BOMRef:          pl.GetBomRef(),
+		ID:              pl.GetId(),
+		Name:            pl.GetName(),
+		Acknowledgement: licenseAcknowledgements[pl.GetAcknowledgement()],
+		Text:            attachedTextFromProto(pl.GetText()),
+		URL:             pl.GetUrl(),
+		Properties:      convertSlice(pl.GetProperties(), propertyFromProto),
+	}
+	if pll := pl.GetLicensing(); pll != nil {
+		l.Licensing = &cdx.Licensing{
+			AltIDs:        stringSlice(pll.GetAltIds()),
+			Licensor:      entityOrContactFromProto(pll.GetLicensor()),
+			Licensee:      entityOrContactFromProto(pll.GetLicensee()),
+			Purchaser:     entityOrContactFromProto(pll.GetPurchaser()),
+			PurchaseOrder: pll.GetPurchaseOrder(),
+			LicenseTypes: convertSlice(pll.GetLicenseTypes(), func(t cdxpb.LicensingTypeEnum) cdx.LicenseType {
+				return licenseTypes[t]
+			}),
+			LastRenewal: timestampFromProto(pll.GetLastRenewal()),
+			Expiration:  timestampFromProto(pll.GetExpiration()),
+		}
+	}
+	return l
+}
+
+func entityOrContactFromProto(p *cdxpb.Licensing_OrganizationalEntityOrContact) *cdx.OrganizationalEntityOrContact {
+	if p == nil {
+		return nil
+	}
+	ec := &cdx.OrganizationalEntityOrContact{
+		Organization: entityFromProto(p.GetOrganization()),
+	}
+	if p.GetIndividual() != nil {
+		c := contactFromProto(p.GetIndividual())
+		ec.Individual = &c
+	}
+	return ec
+}
+
+func entityFromProto(pe *cdxpb.OrganizationalEntity) *cdx.OrganizationalEntity {
+	if pe == nil {
+		return nil
+	}
+	e := &cdx.OrganizationalEntity{
+		BOMRef:  pe.GetBomRef(),
+		Name:    pe.GetName(),
+		URL:     stringSlice(pe.GetUrl()),
+		Contact: convertSlice(pe.GetContact(), contactFromProto),
+	}
+	if pa := pe.GetAddress(); pa != nil {
+		e.Address = &cdx.PostalAddress{
+			BOMRef:              pa.GetBomRef(),
+			Country:             pa.GetCountry(),
+			Region:              pa.GetRegion(),
+			Locality:            pa.GetLocality(),
+			PostOfficeBoxNumber: pa.GetPostOfficeBoxNumber(),
+			PostalCode:          pa.GetPostalCode(),
+			StreetAddress:       pa.GetStreetAddress(),
+		}
+	}
+	return e
+}
+
+func contactFromProto(pc *cdxpb.OrganizationalContact) cdx.OrganizationalContact {
+	return cdx.OrganizationalContact{
+		BOMRef: pc.GetBomRef(),
+		Name:   pc.GetName(),
+		Email:  pc.GetEmail(),
+		Phone:  pc.GetPhone(),
+	}
+}
+
+func externalReferenceFromProto(pr *cdxpb.ExternalReference) cdx.ExternalReference {
+	return cdx.ExternalReference{
+		URL:     pr.GetUrl(),
+		Comment: pr.GetComment(),
+		Hashes:  convertSlice(pr.GetHashes(), hashFromProto),
+		Type:    externalReferenceTypes[pr.GetType()],
+	}
+}
+
+func hashFromProto(ph *cdxpb.Hash) cdx.Hash {
+	return cdx.Hash{
+		Algorithm: hashAlgorithms[ph.GetAlg()],
+		Value:     ph.GetValue(),
+	}
+}
+
+func propertyFromProto(pp *cdxpb.Property) cdx.Property {
+	return cdx.Property{Name: pp.GetName(), Value: pp.GetValue()}
+}
+
+func sourceFromProto(ps *cdxpb.Source) *cdx.Source {
+	if ps == nil {
+		return nil
+	}
+	return &cdx.Source{Name: ps.GetName(), URL: ps.GetUrl()}
+}
+
+func attachedTextFromProto(pt *cdxpb.AttachedText) *cdx.AttachedText {
+	if pt == nil {
+		return nil
+	}
+	return &cdx.AttachedText{
+		Content:     pt.GetValue(),
+		ContentType: pt.GetContentType(),
+		Encoding:    pt.GetEncoding(),
+	}
+}
+
+func timestampFromProto(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+func optionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func optionalFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := *v
+	return &f
+}
+
+func bomReferences(refs []string) *[]cdx.BOMReference {
+	return convertSlice(refs, func(ref string) cdx.BOMReference { return cdx.BOMReference(ref) })
+}
+
+func stringSlice(in []string) *[]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]string(nil), in...)
+	return &out
+}
+
+func convertSlice[P, T any](in []P, convert func(P) T) *[]T {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]T, 0, len(in))
+	for _, p := range in {
+		out = append(out, convert(p))
+	}
+	return &out
+}
+
+// valueOrZero returns *p, or the zero value if p is nil. Nil repeated
+// entries convert to zero values, as they do in contactFromProto.
+func valueOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// collectUnknownFields records, per message type, the field numbers that were
+// present on the wire but are not declared in the bundled schema.
+func collectUnknownFields(m protoreflect.Message, unknown map[protoreflect.FullName]map[protowire.Number]bool) {
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			break
+		}
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			break
+		}
+		b = b[n:]
+		name := m.Descriptor().FullName()
+		if unknown[name] == nil {
+			unknown[name] = map[protowire.Number]bool{}
+		}
+		unknown[name][num] = true
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			for i := 0; i < v.List().Len(); i++ {
+				collectUnknownFields(v.List().Get(i).Message(), unknown)
+			}
+			return true
+		}
+		collectUnknownFields(v.Message(), unknown)
+		return true
+	})
+}
+
+func sortedFieldNumbers(fields map[protowire.Number]bool) []protowire.Number {
+	nums := make([]protowire.Number, 0, len(fields))
+	for num := range fields {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums
+}
+
+// ========== Enum mappings =============
+
+var componentTypes = map[cdxpb.Classification]cdx.ComponentType{
+	cdxpb.Classification_CLASSIFICATION_APPLICATION:            cdx.ComponentTypeApplication,
+	cdxpb.Classification_CLASSIFICATION_FRAMEWORK:              cdx.ComponentTypeFramework,
+	cdxpb.Classification_CLASSIFICATION_LIBRARY:                cdx.ComponentTypeLibrary,
+	cdxpb.Classification_CLASSIFICATION_OPERATING_SYSTEM:       cdx.ComponentTypeOS,
+	cdxpb.Classification_CLASSIFICATION_DEVICE:                 cdx.ComponentTypeDevice,
+	cdxpb.Classification_CLASSIFICATION_FILE:                   cdx.ComponentTypeFile,
+	cdxpb.Classification_CLASSIFICATION_CONTAINER:              cdx.ComponentTypeContainer,
+	cdxpb.Classification_CLASSIFICATION_FIRMWARE:               cdx.ComponentTypeFirmware,
+	cdxpb.Classification_CLASSIFICATION_DEVICE_DRIVER:          cdx.ComponentTypeDeviceDriver,
+	cdxpb.Classification_CLASSIFICATION_PLATFORM:               cdx.ComponentTypePlatform,
+	cdxpb.Classification_CLASSIFICATION_MACHINE_LEARNING_MODEL: cdx.ComponentTypeMachineLearningModel,
+	cdxpb.Classification_CLASSIFICATION_DATA:                   cdx.ComponentTypeData,
+	cdxpb.Classification_CLASSIFICATION_CRYPTOGRAPHIC_ASSET:    cdx.ComponentTypeCryptographicAsset,
+}
+
+var scopes = map[cdxpb.Scope]cdx.Scope{
+	cdxpb.Scope_SCOPE_REQUIRED: cdx.ScopeRequired,
+	cdxpb.Scope_SCOPE_OPTIONAL: cdx.ScopeOptional,
+	cdxpb.Scope_SCOPE_EXCLUDED: cdx.ScopeExcluded,
+}
+
+var hashAlgorithms = map[cdxpb.HashAlg]cdx.HashAlgorithm{
+	cdxpb.HashAlg_HASH_ALG_MD_5:          cdx.HashAlgoMD5,
+	cdxpb.HashAlg_HASH_ALG_SHA_1:         cdx.HashAlgoSHA1,
+	cdxpb.HashAlg_HASH_ALG_SHA_256:       cdx.HashAlgoSHA256,
+	cdxpb.HashAlg_HASH_ALG_SHA_384:       cdx.HashAlgoSHA384,
+	cdxpb.HashAlg_HASH_ALG_SHA_512:       cdx.HashAlgoSHA512,
+	cdxpb.HashAlg_HASH_ALG_SHA_3_256:     cdx.HashAlgoSHA3_256,
+	cdxpb.HashAlg_HASH_ALG_SHA_3_384:     cdx.HashAlgoSHA3_384,
+	cdxpb.HashAlg_HASH_ALG_SHA_3_512:     cdx.HashAlgoSHA3_512,
+	cdxpb.HashAlg_HASH_ALG_BLAKE_2_B_256: cdx.HashAlgoBlake2b_256,
+	cdxpb.HashAlg_HASH_ALG_BLAKE_2_B_384: cdx.HashAlgoBlake2b_384,
+	cdxpb.HashAlg_HASH_ALG_BLAKE_2_B_512: cdx.HashAlgoBlake2b_512,
+	cdxpb.HashAlg_HASH_ALG_BLAKE_3:       cdx.HashAlgoBlake3,
+}
+
+var externalReferenceTypes = map[cdxpb.ExternalReferenceType]cdx.ExternalReferenceType{
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_OTHER:                     cdx.ERTypeOther,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_VCS:                       cdx.ERTypeVCS,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ISSUE_TRACKER:             cdx.ERTypeIssueTracker,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_WEBSITE:                   cdx.ERTypeWebsite,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ADVISORIES:                cdx.ERTypeAdvisories,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BOM:                       cdx.ERTypeBOM,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MAILING_LIST:              cdx.ERTypeMailingList,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SOCIAL:                    cdx.ERTypeSocial,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CHAT:                      cdx.ERTypeChat,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DOCUMENTATION:             cdx.ERTypeDocumentation,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SUPPORT:                   cdx.ERTypeSupport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DISTRIBUTION:              cdx.ERTypeDistribution,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_LICENSE:                   cdx.ERTypeLicense,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BUILD_META:                cdx.ERTypeBuildMeta,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BUILD_SYSTEM:              cdx.ERTypeBuildSystem,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RELEASE_NOTES:             cdx.ERTypeReleaseNotes,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SECURITY_CONTACT:          cdx.ERTypeSecurityContact,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MODEL_CARD:                cdx.ERTypeModelCard,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_LOG:                       cdx.ERTypeLog,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CONFIGURATION:             cdx.ERTypeConfiguration,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_EVIDENCE:                  cdx.ERTypeEvidence,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_FORMULATION:               cdx.ERTypeFormulation,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ATTESTATION:               cdx.ERTypeAttestation,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_THREAT_MODEL:              cdx.ERTypeThreatModel,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ADVERSARY_MODEL:           cdx.ERTypeAdversaryModel,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RISK_ASSESSMENT:           cdx.ERTypeRiskAssessment,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_VULNERABILITY_ASSERTION:   cdx.ERTypeVulnerabilityAssertion,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_EXPLOITABILITY_STATEMENT:  cdx.ERTypeExploitabilityStatement,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_PENTEST_REPORT:            cdx.ERTypePentestReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_STATIC_ANALYSIS_REPORT:    cdx.ERTypeStaticAnalysisReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DYNAMIC_ANALYSIS_REPORT:   cdx.ERTypeDynamicAnalysisReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RUNTIME_ANALYSIS_REPORT:   cdx.ERTypeRuntimeAnalysisReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_COMPONENT_ANALYSIS_REPORT: cdx.ERTypeComponentAnalysisReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MATURITY_REPORT:           cdx.ERTypeMaturityReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CERTIFICATION_REPORT:      cdx.ERTypeCertificationReport,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_QUALITY_METRICS:           cdx.ERTypeQualityMetrics,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CODIFIED_INFRASTRUCTURE:   cdx.ERTypeCodifiedInfrastructure,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_POAM:                      "poam",
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DISTRIBUTION_INTAKE:       cdx.ERTypeDistributionIntake,
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ELECTRONIC_SIGNATURE:      "electronic-signature",
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DIGITAL_SIGNATURE:         "digital-signature",
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RFC_9116:                  "rfc-9116",
+	cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SOURCE_DISTRIBUTION:       "source-distribution",
+}
+
+var issueTypes = map[cdxpb.IssueClassification]cdx.IssueType{
+	cdxpb.IssueClassification_ISSUE_CLASSIFICATION_DEFECT:      cdx.IssueTypeDefect,
+	cdxpb.IssueClassification_ISSUE_CLASSIFICATION_ENHANCEMENT: cdx.IssueTypeEnhancement,
+	cdxpb.IssueClassification_ISSUE_CLASSIFICATION_SECURITY:    cdx.IssueTypeSecurity,
+}
+
+var patchTypes = map[cdxpb.PatchClassification]cdx.PatchType{
+	cdxpb.PatchClassification_PATCH_CLASSIFICATION_UNOFFICIAL:  cdx.PatchTypeUnofficial,
+	cdxpb.PatchClassification_PATCH_CLASSIFICATION_MONKEY:      cdx.PatchTypeMonkey,
+	cdxpb.PatchClassification_PATCH_CLASSIFICATION_BACKPORT:    cdx.PatchTypeBackport,
+	cdxpb.PatchClassification_PATCH_CLASSIFICATION_CHERRY_PICK: cdx.PatchTypeCherryPick,
+}
+
+var licenseAcknowledgements = map[cdxpb.LicenseAcknowledgementEnumeration]cdx.LicenseAcknowledgement{
+	cdxpb.LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_DECLARED:  cdx.LicenseAcknowledgementDeclared,
+	cdxpb.LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_CONCLUDED: cdx.LicenseAcknowledgementConcluded,
+}
+
+var licenseTypes = map[cdxpb.LicensingTypeEnum]cdx.LicenseType{
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_ACADEMIC:         cdx.LicenseTypeAcademic,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_APPLIANCE:        cdx.LicenseTypeAppliance,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_CLIENT_ACCESS:    cdx.LicenseTypeClientAccess,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_CONCURRENT_USER:  cdx.LicenseTypeConcurrentUser,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_CORE_POINTS:      cdx.LicenseTypeCorePoints,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_CUSTOM_METRIC:    cdx.LicenseTypeCustomMetric,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_DEVICE:           cdx.LicenseTypeDevice,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_EVALUATION:       cdx.LicenseTypeEvaluation,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_NAMED_USER:       cdx.LicenseTypeNamedUser,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_NODE_LOCKED:      cdx.LicenseTypeNodeLocked,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_OEM:              cdx.LicenseTypeOEM,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_PERPETUAL:        cdx.LicenseTypePerpetual,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_PROCESSOR_POINTS: cdx.LicenseTypeProcessorPoints,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_SUBSCRIPTION:     cdx.LicenseTypeSubscription,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_USER:             cdx.LicenseTypeUser,
+	cdxpb.LicensingTypeEnum_LICENSING_TYPE_OTHER:            cdx.LicenseTypeOther,
+}
+
+var lifecyclePhases = map[cdxpb.LifecyclePhase]cdx.LifecyclePhase{
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_DESIGN:       cdx.LifecyclePhaseDesign,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_PRE_BUILD:    cdx.LifecyclePhasePreBuild,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_BUILD:        cdx.LifecyclePhaseBuild,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_POST_BUILD:   cdx.LifecyclePhasePostBuild,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_OPERATIONS:   cdx.LifecyclePhaseOperations,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_DISCOVERY:    cdx.LifecyclePhaseDiscovery,
+	cdxpb.LifecyclePhase_LIFECYCLE_PHASE_DECOMMISSION: cdx.LifecyclePhaseDecommission,
+}
+
+var dataFlows = map[cdxpb.DataFlow]cdx.DataFlow{
+	cdxpb.DataFlow_DATA_FLOW_INBOUND:        cdx.DataFlowInbound,
+	cdxpb.DataFlow_DATA_FLOW_OUTBOUND:       cdx.DataFlowOutbound,
+	cdxpb.DataFlow_DATA_FLOW_BI_DIRECTIONAL: cdx.DataFlowBidirectional,
+	cdxpb.DataFlow_DATA_FLOW_UNKNOWN:        cdx.DataFlowUnknown,
+}
+
+var compositionAggregates = map[cdxpb.Aggregate]cdx.CompositionAggregate{
+	cdxpb.Aggregate_AGGREGATE_NOT_SPECIFIED:                           cdx.CompositionAggregateNotSpecified,
+	cdxpb.Aggregate_AGGREGATE_COMPLETE:                                cdx.CompositionAggregateComplete,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE:                              cdx.CompositionAggregateIncomplete,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_ONLY:             cdx.CompositionAggregateIncompleteFirstPartyOnly,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_ONLY:             cdx.CompositionAggregateIncompleteThirdPartyOnly,
+	cdxpb.Aggregate_AGGREGATE_UNKNOWN:                                 cdx.CompositionAggregateUnknown,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY: cdx.CompositionAggregateIncompleteFirstPartyProprietaryOnly,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY:  cdx.CompositionAggregateIncompleteFirstPartyOpenSourceOnly,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY: cdx.CompositionAggregateIncompleteThirdPartyProprietaryOnly,
+	cdxpb.Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY:  cdx.CompositionAggregateIncompleteThirdPartyOpenSourceOnly,
+}
+
+var evidenceFields = map[cdxpb.EvidenceFieldType]cdx.EvidenceIdentityFieldType{
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_NAME:       cdx.EvidenceIdentityFieldTypeName,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_GROUP:      cdx.EvidenceIdentityFieldTypeGroup,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_VERSION:    cdx.EvidenceIdentityFieldTypeVersion,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_PURL:       cdx.EvidenceIdentityFieldTypePURL,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_CPE:        cdx.EvidenceIdentityFieldTypeCPE,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_SWID:       cdx.EvidenceIdentityFieldTypeSWID,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_HASH:       cdx.EvidenceIdentityFieldTypeHash,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_OMNIBOR_ID: cdx.EvidenceIdentityFieldTypeOmniborID,
+	cdxpb.EvidenceFieldType_EVIDENCE_FIELD_SWHID:      cdx.EvidenceIdentityFieldTypeSWHID,
+}
+
+var evidenceTechniques = map[cdxpb.EvidenceTechnique]cdx.EvidenceIdentityTechnique{
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS: cdx.EvidenceIdentityTechniqueSourceCodeAnalysis,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_BINARY_ANALYSIS:      cdx.EvidenceIdentityTechniqueBinaryAnalysis,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_MANIFEST_ANALYSIS:    cdx.EvidenceIdentityTechniqueManifestAnalysis,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_AST_FINGERPRINT:      cdx.EvidenceIdentityTechniqueASTFingerprint,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_HASH_COMPARISON:      cdx.EvidenceIdentityTechniqueHashComparison,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_INSTRUMENTATION:      cdx.EvidenceIdentityTechniqueInstrumentation,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_DYNAMIC_ANALYSIS:     cdx.EvidenceIdentityTechniqueDynamicAnalysis,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_FILENAME:             cdx.EvidenceIdentityTechniqueFilename,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_ATTESTATION:          cdx.EvidenceIdentityTechniqueAttestation,
+	cdxpb.EvidenceTechnique_EVIDENCE_TECHNIQUE_OTHER:                cdx.EvidenceIdentityTechniqueOther,
+}
+
+var severities = map[cdxpb.Severity]cdx.Severity{
+	cdxpb.Severity_SEVERITY_UNKNOWN:  cdx.SeverityUnknown,
+	cdxpb.Severity_SEVERITY_CRITICAL: cdx.SeverityCritical,
+	cdxpb.Severity_SEVERITY_HIGH:     cdx.SeverityHigh,
+	cdxpb.Severity_SEVERITY_MEDIUM:   cdx.SeverityMedium,
+	cdxpb.Severity_SEVERITY_LOW:      cdx.SeverityLow,
+	cdxpb.Severity_SEVERITY_INFO:     cdx.SeverityInfo,
+	cdxpb.Severity_SEVERITY_NONE:     cdx.SeverityNone,
+}
+
+var scoringMethods = map[cdxpb.ScoreMethod]cdx.ScoringMethod{
+	cdxpb.ScoreMethod_SCORE_METHOD_CVSSV2:  cdx.ScoringMethodCVSSv2,
+	cdxpb.ScoreMethod_SCORE_METHOD_CVSSV3:  cdx.ScoringMethodCVSSv3,
+	cdxpb.ScoreMethod_SCORE_METHOD_CVSSV31: cdx.ScoringMethodCVSSv31,
+	cdxpb.ScoreMethod_SCORE_METHOD_OWASP:   cdx.ScoringMethodOWASP,
+	cdxpb.ScoreMethod_SCORE_METHOD_OTHER:   cdx.ScoringMethodOther,
+	cdxpb.ScoreMethod_SCORE_METHOD_CVSSV4:  cdx.ScoringMethodCVSSv4,
+	cdxpb.ScoreMethod_SCORE_METHOD_SSVC:    cdx.ScoringMethodSSVC,
+}
+
+var impactAnalysisStates = map[cdxpb.ImpactAnalysisState]cdx.ImpactAnalysisState{
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_RESOLVED:               cdx.IASResolved,
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_RESOLVED_WITH_PEDIGREE: cdx.IASResolvedWithPedigree,
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_EXPLOITABLE:            cdx.IASExploitable,
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_IN_TRIAGE:              cdx.IASInTriage,
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_FALSE_POSITIVE:         cdx.IASFalsePositive,
+	cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_NOT_AFFECTED:           cdx.IASNotAffected,
+}
+
+var impactAnalysisJustifications = map[cdxpb.ImpactAnalysisJustification]cdx.ImpactAnalysisJustification{
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_PRESENT:                cdx.IAJCodeNotPresent,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_REACHABLE:              cdx.IAJCodeNotReachable,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_CONFIGURATION:          cdx.IAJRequiresConfiguration,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_DEPENDENCY:             cdx.IAJRequiresDependency,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_ENVIRONMENT:            cdx.IAJRequiresEnvironment,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_COMPILER:           cdx.IAJProtectedByCompiler,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_RUNTIME:            cdx.IAJProtectedAtRuntime,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_PERIMETER:          cdx.IAJProtectedAtPerimeter,
+	cdxpb.ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_MITIGATING_CONTROL: cdx.IAJProtectedByMitigatingControl,
+}
+
+var impactAnalysisResponses = map[cdxpb.VulnerabilityResponse]cdx.ImpactAnalysisResponse{
+	cdxpb.VulnerabilityResponse_VULNERABILITY_RESPONSE_CAN_NOT_FIX:          cdx.IARCanNotFix,
+	cdxpb.VulnerabilityResponse_VULNERABILITY_RESPONSE_WILL_NOT_FIX:         cdx.IARWillNotFix,
+	cdxpb.VulnerabilityResponse_VULNERABILITY_RESPONSE_UPDATE:               cdx.IARUpdate,
+	cdxpb.VulnerabilityResponse_VULNERABILITY_RESPONSE_ROLLBACK:             cdx.IARRollback,
+	cdxpb.VulnerabilityResponse_VULNERABILITY_RESPONSE_WORKAROUND_AVAILABLE: cdx.IARWorkaroundAvailable,
+}
+
+var vulnerabilityStatuses = map[cdxpb.VulnerabilityAffectedStatus]cdx.VulnerabilityStatus{
+	cdxpb.VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_UNKNOWN:      cdx.VulnerabilityStatusUnknown,
+	cdxpb.VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_AFFECTED:     cdx.VulnerabilityStatusAffected,
+	cdxpb.VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_NOT_AFFECTED: cdx.VulnerabilityStatusNotAffected,
+}
@@ -0,0 +1,265 @@
+package sbom
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+)
+
+func cryptoPropertiesFromProto(pc *cdxpb.CryptoProperties) *cdx.CryptoProperties {
+	c := &cdx.CryptoProperties{
+		AssetType: cryptoAssetTypes[pc.GetAssetType()],
+		OID:       pc.GetOid(),
+	}
+	if pa := pc.GetAlgorithmProperties(); pa != nil {
+		c.AlgorithmProperties = &cdx.CryptoAlgorithmProperties{
+			Primitive:              cryptoPrimitives[pa.GetPrimitive()],
+			ParameterSetIdentifier: pa.GetParameterSetIdentifier(),
+			Curve:                  pa.GetCurve(),
+			ExecutionEnvironment:   cryptoExecutionEnvironments[pa.GetExecutionEnvironment()],
+			ImplementationPlatform: cryptoImplementationPlatforms[pa.GetImplementationPlatform()],
+			CertificationLevel: convertSlice(pa.GetCertificationLevel(),
+				func(l cdxpb.CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) cdx.CryptoCertificationLevel {
+					return cryptoCertificationLevels[l]
+				}),
+			Mode:    cryptoAlgorithmModes[pa.GetMode()],
+			Padding: cryptoPaddings[pa.GetPadding()],
+			CryptoFunctions: convertSlice(pa.GetCryptoFunctions(),
+				func(f cdxpb.CryptoProperties_AlgorithmProperties_CryptoFunction) cdx.CryptoFunction {
+					return cryptoFunctions[f]
+				}),
+			ClassicalSecurityLevel:   optionalInt(pa.ClassicalSecurityLevel),
+			NistQuantumSecurityLevel: optionalInt(pa.NistQuantumSecurityLevel),
+		}
+	}
+	if pp := pc.GetCertificateProperties(); pp != nil {
+		c.CertificateProperties = &cdx.CertificateProperties{
+			SubjectName:           pp.GetSubjectName(),
+			IssuerName:            pp.GetIssuerName(),
+			NotValidBefore:        timestampFromProto(pp.GetNotValidBefore()),
+			NotValidAfter:         timestampFromProto(pp.GetNotValidAfter()),
+			SignatureAlgorithmRef: cdx.BOMReference(pp.GetSignatureAlgorithmRef()),
+			SubjectPublicKeyRef:   cdx.BOMReference(pp.GetSubjectPublicKeyRef()),
+			CertificateFormat:     pp.GetCertificateFormat(),
+			CertificateExtension:  pp.GetCertificateExtension(),
+		}
+	}
+	if pm := pc.GetRelatedCryptoMaterialProperties(); pm != nil {
+		m := &cdx.RelatedCryptoMaterialProperties{
+			Type:           relatedCryptoMaterialTypes[pm.GetType()],
+			ID:             pm.GetId(),
+			State:          cryptoKeyStates[pm.GetState()],
+			AlgorithmRef:   cdx.BOMReference(pm.GetAlgorithmRef()),
+			CreationDate:   timestampFromProto(pm.GetCreationDate()),
+			ActivationDate: timestampFromProto(pm.GetActivationDate()),
+			UpdateDate:     timestampFromProto(pm.GetUpdateDate()),
+			ExpirationDate: timestampFromProto(pm.GetExpirationDate()),
+			Value:          pm.GetValue(),
+			Format:         pm.GetFormat(),
+		}
+		if pm.Size != nil {
+			size := int(pm.GetSize())
+			m.Size = &size
+		}
+		if ps := pm.GetSecuredBy(); ps != nil {
+			m.SecuredBy = &cdx.SecuredBy{
+				Mechanism:    ps.GetMechanism(),
+				AlgorithmRef: cdx.BOMReference(ps.GetAlgorithmRef()),
+			}
+		}
+		c.RelatedCryptoMaterialProperties = m
+	}
+	if pp := pc.GetProtocolProperties(); pp != nil {
+		c.ProtocolProperties = &cdx.CryptoProtocolProperties{
+			Type:    cryptoProtocolTypes[pp.GetType()],
+			Version: pp.GetVersion(),
+			CipherSuites: convertSlice(pp.GetCipherSuites(), func(ps *cdxpb.CryptoProperties_CipherSuite) cdx.CipherSuite {
+				return cdx.CipherSuite{
+					Name:        ps.GetName(),
+					Algorithms:  bomReferences(ps.GetAlgorithms()),
+					Identifiers: stringSlice(ps.GetIdentifiers()),
+				}
+			}),
+			CryptoRefArray: bomReferences(pp.GetCryptoRefArray()),
+		}
+		if pt := pp.GetIkev2TransformTypes(); pt != nil {
+			c.ProtocolProperties.IKEv2TransformTypes = &cdx.IKEv2TransformTypes{
+				Encr:  bomReferences(pt.GetEncr()),
+				PRF:   bomReferences(pt.GetPrf()),
+				Integ: bomReferences(pt.GetInteg()),
+				KE:    bomReferences(pt.GetKe()),
+				ESN:   pt.GetEsn(),
+				Auth:  bomReferences(pt.GetAuth()),
+			}
+		}
+	}
+	return c
+}
+
+// ========== Enum mappings =============
+
+var cryptoAssetTypes = map[cdxpb.CryptoProperties_CryptoAssetType]cdx.CryptoAssetType{
+	cdxpb.CryptoProperties_CRYPTO_ASSET_TYPE_ALGORITHM:               cdx.CryptoAssetTypeAlgorithm,
+	cdxpb.CryptoProperties_CRYPTO_ASSET_TYPE_CERTIFICATE:             cdx.CryptoAssetTypeCertificate,
+	cdxpb.CryptoProperties_CRYPTO_ASSET_TYPE_PROTOCOL:                cdx.CryptoAssetTypeProtocol,
+	cdxpb.CryptoProperties_CRYPTO_ASSET_TYPE_RELATED_CRYPTO_MATERIAL: cdx.CryptoAssetTypeRelatedCryptoMaterial,
+}
+
+var cryptoPrimitives = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoPrimitive]cdx.CryptoPrimitive{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_UNKNOWN:       cdx.CryptoPrimitiveUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_OTHER:         cdx.CryptoPrimitiveOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_DRBG:          cdx.CryptoPrimitiveDRBG,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_MAC:           cdx.CryptoPrimitiveMAC,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_BLOCK_CIPHER:  cdx.CryptoPrimitiveBlockCipher,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_STREAM_CIPHER: cdx.CryptoPrimitiveStreamCipher,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_SIGNATURE:     cdx.CryptoPrimitiveSignature,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_HASH:          cdx.CryptoPrimitiveHash,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_PKE:           cdx.CryptoPrimitivePKE,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_XOF:           cdx.CryptoPrimitiveXOF,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KDF:           cdx.CryptoPrimitiveKDF,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KEY_AGREE:     cdx.CryptoPrimitiveKeyAgree,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KEM:           cdx.CryptoPrimitiveKEM,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_AE:            cdx.CryptoPrimitiveAE,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_COMBINER:      cdx.CryptoPrimitiveCombiner,
+}
+
+var cryptoExecutionEnvironments = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment]cdx.CryptoExecutionEnvironment{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_UNKNOWN:                cdx.CryptoExecutionEnvironmentUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_OTHER:                  cdx.CryptoExecutionEnvironmentOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_PLAIN_RAM:     cdx.CryptoExecutionEnvironmentSoftwarePlainRAM,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_ENCRYPTED_RAM: cdx.CryptoExecutionEnvironmentSoftwareEncryptedRAM,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_TEE:           cdx.CryptoExecutionEnvironmentSoftwareTEE,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_HARDWARE:               cdx.CryptoExecutionEnvironmentHardware,
+}
+
+// cryptoImplementationPlatforms maps the platforms without a cyclonedx-go
+// constant to their CycloneDX JSON values.
+var cryptoImplementationPlatforms = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform]cdx.ImplementationPlatform{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_UNKNOWN: cdx.ImplementationPlatformUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_OTHER:   cdx.ImplementationPlatformOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_GENERIC: cdx.ImplementationPlatformGeneric,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X86_32:  "x86_32",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X86_64:  "x86_64",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7A:  cdx.ImplementationPlatformARMv7A,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7M:  cdx.ImplementationPlatformARMv7M,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8A:  cdx.ImplementationPlatformARMv8A,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8M:  cdx.ImplementationPlatformARMv8M,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9A:  cdx.ImplementationPlatformARMv9A,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9M:  cdx.ImplementationPlatformARMv9M,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X390X:   cdx.ImplementationPlatformS390x,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_PPC64:   cdx.ImplementationPlatformPPC64,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_PPC64LE: cdx.ImplementationPlatformPPC64LE,
+}
+
+// cryptoCertificationLevels maps the FIPS 140 levels, which have no
+// cyclonedx-go constant, to their CycloneDX JSON values.
+var cryptoCertificationLevels = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoCertificationLevel]cdx.CryptoCertificationLevel{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_UNKNOWN:      cdx.CryptoCertificationLevelUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_OTHER:        cdx.CryptoCertificationLevelOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_NONE:         cdx.CryptoCertificationLevelNone,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L1: "fips140-1-l1",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L2: "fips140-1-l2",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L3: "fips140-1-l3",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L4: "fips140-1-l4",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L1: "fips140-2-l1",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L2: "fips140-2-l2",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L3: "fips140-2-l3",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L4: "fips140-2-l4",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1: "fips140-3-l1",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L2: "fips140-3-l2",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L3: "fips140-3-l3",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L4: "fips140-3-l4",
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL1:      cdx.CryptoCertificationLevelCCEAL1,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL1_PLUS: cdx.CryptoCertificationLevelCCEAL1Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL2:      cdx.CryptoCertificationLevelCCEAL2,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL2_PLUS: cdx.CryptoCertificationLevelCCEAL2Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL3:      cdx.CryptoCertificationLevelCCEAL3,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL3_PLUS: cdx.CryptoCertificationLevelCCEAL3Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL4:      cdx.CryptoCertificationLevelCCEAL4,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL4_PLUS: cdx.CryptoCertificationLevelCCEAL4Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL5:      cdx.CryptoCertificationLevelCCEAL5,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL5_PLUS: cdx.CryptoCertificationLevelCCEAL5Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL6:      cdx.CryptoCertificationLevelCCEAL6,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL6_PLUS: cdx.CryptoCertificationLevelCCEAL6Plus,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL7:      cdx.CryptoCertificationLevelCCEAL7,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL7_PLUS: cdx.CryptoCertificationLevelCCEAL7Plus,
+}
+
+var cryptoAlgorithmModes = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode]cdx.CryptoAlgorithmMode{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_UNKNOWN: cdx.CryptoAlgorithmModeUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_OTHER:   cdx.CryptoAlgorithmModeOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CBC:     cdx.CryptoAlgorithmModeCBC,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_ECB:     cdx.CryptoAlgorithmModeECB,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CCM:     cdx.CryptoAlgorithmModeCCM,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_GCM:     cdx.CryptoAlgorithmModeGCM,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CFB:     cdx.CryptoAlgorithmModeCFB,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_OFB:     cdx.CryptoAlgorithmModeOFB,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CTR:     cdx.CryptoAlgorithmModeCTR,
+}
+
+var cryptoPaddings = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding]cdx.CryptoPadding{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_UNKNOWN:  cdx.CryptoPaddingUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_OTHER:    cdx.CryptoPaddingOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS5:    cdx.CryptoPaddingPKCS5,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS7:    cdx.CryptoPaddingPKCS7,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS1V15: cdx.CryptoPaddingPKCS1v15,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_OAEP:     cdx.CryptoPaddingOAEP,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_RAW:      cdx.CryptoPaddingRaw,
+}
+
+var cryptoFunctions = map[cdxpb.CryptoProperties_AlgorithmProperties_CryptoFunction]cdx.CryptoFunction{
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_UNKNOWN:     cdx.CryptoFunctionUnknown,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_OTHER:       cdx.CryptoFunctionOther,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_GENERATE:    cdx.CryptoFunctionGenerate,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_KEYGEN:      cdx.CryptoFunctionKeygen,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_ENCRYPT:     cdx.CryptoFunctionEncrypt,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DECRYPT:     cdx.CryptoFunctionDecrypt,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DIGEST:      cdx.CryptoFunctionDigest,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_TAG:         cdx.CryptoFunctionTag,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_KEYDERIVE:   cdx.CryptoFunctionKeyderive,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_SIGN:        cdx.CryptoFunctionSign,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_VERIFY:      cdx.CryptoFunctionVerify,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_ENCAPSULATE: cdx.CryptoFunctionEncapsulate,
+	cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DECAPSULATE: cdx.CryptoFunctionDecapsulate,
+}
+
+var relatedCryptoMaterialTypes = map[cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType]cdx.RelatedCryptoMaterialType{
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_UNKNOWN:               cdx.RelatedCryptoMaterialTypeUnknown,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_OTHER:                 cdx.RelatedCryptoMaterialTypeOther,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PRIVATE_KEY:           cdx.RelatedCryptoMaterialTypePrivateKey,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PUBLIC_KEY:            cdx.RelatedCryptoMaterialTypePublicKey,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SECRET_KEY:            cdx.RelatedCryptoMaterialTypeSecretKey,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_KEY:                   cdx.RelatedCryptoMaterialTypeKey,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_CIPHERTEXT:            cdx.RelatedCryptoMaterialTypeCiphertext,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SIGNATURE:             cdx.RelatedCryptoMaterialTypeSignature,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_DIGEST:                cdx.RelatedCryptoMaterialTypeDigest,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_INITIALIZATION_VECTOR: cdx.RelatedCryptoMaterialTypeInitializationVector,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_NONCE:                 cdx.RelatedCryptoMaterialTypeNonce,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SEED:                  cdx.RelatedCryptoMaterialTypeSeed,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SALT:                  cdx.RelatedCryptoMaterialTypeSalt,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SHARED_SECRET:         cdx.RelatedCryptoMaterialTypeSharedSecret,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_TAG:                   cdx.RelatedCryptoMaterialTypeTag,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_ADDITIONAL_DATA:       cdx.RelatedCryptoMaterialTypeAdditionalData,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PASSWORD:              cdx.RelatedCryptoMaterialTypePassword,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_CREDENTIAL:            cdx.RelatedCryptoMaterialTypeCredential,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_TOKEN:                 cdx.RelatedCryptoMaterialTypeToken,
+}
+
+var cryptoKeyStates = map[cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState]cdx.CryptoKeyState{
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_PRE_ACTIVATION: cdx.CryptoKeyStatePreActivation,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_ACTIVE:         cdx.CryptoKeyStateActive,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_SUSPENDED:      cdx.CryptoKeyStateSuspended,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_DEACTIVATED:    cdx.CryptoKeyStateDeactivated,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_COMPROMISED:    cdx.CryptoKeyStateCompromised,
+	cdxpb.CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_DESTROYED:      cdx.CryptoKeyStateDestroyed,
+}
+
+var cryptoProtocolTypes = map[cdxpb.CryptoProperties_ProtocolProperties_CryptoProtocolType]cdx.CryptoProtocolType{
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_UNKNOWN: cdx.CryptoProtocolTypeUnknown,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_OTHER:   cdx.CryptoProtocolTypeOther,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_TLS:     cdx.CryptoProtocolTypeTLS,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_SSH:     cdx.CryptoProtocolTypeSSH,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_IPSEC:   cdx.CryptoProtocolTypeIPSec,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_IKE:     cdx.CryptoProtocolTypeIKE,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_SSTP:    cdx.CryptoProtocolTypeSSTP,
+	cdxpb.CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_WPA:     cdx.CryptoProtocolTypeWPA,
+}
@@ -0,0 +1,171 @@
+package sbom
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+)
+
+func declarationsFromProto(pd *cdxpb.Declarations) *cdx.Declarations {
+	d := &cdx.Declarations{
+		Assessors:    convertSlice(pd.GetAssessors(), assessorFromProto),
+		Attestations: convertSlice(pd.GetAttestations(), attestationFromProto),
+		Claims:       convertSlice(pd.GetClaims(), claimFromProto),
+		Evidence:     convertSlice(pd.GetEvidence(), declarationEvidenceFromProto),
+	}
+	if pt := pd.GetTargets(); pt != nil {
+		d.Targets = &cdx.Targets{
+			Organizations: convertSlice(pt.GetOrganizations(), func(pe *cdxpb.OrganizationalEntity) cdx.OrganizationalEntity {
+				return *entityFromProto(pe)
+			}),
+			Components: convertSlice(pt.GetComponents(), componentFromProto),
+			Services:   convertSlice(pt.GetServices(), serviceFromProto),
+		}
+	}
+	if pa := pd.GetAffirmation(); pa != nil {
+		d.Affirmation = &cdx.Affirmation{
+			Statement:   pa.GetStatement(),
+			Signatories: convertSlice(pa.GetSignatories(), signatoryFromProto),
+		}
+	}
+	return d
+}
+
+func assessorFromProto(pa *cdxpb.Declarations_Assessor) cdx.Assessor {
+	return cdx.Assessor{
+		BOMRef:       cdx.BOMReference(pa.GetBomRef()),
+		ThirdParty:   pa.GetThirdParty(),
+		Organization: entityFromProto(pa.GetOrganization()),
+	}
+}
+
+func attestationFromProto(pa *cdxpb.Declarations_Attestation) cdx.Attestation {
+	return cdx.Attestation{
+		Summary:  pa.GetSummary(),
+		Assessor: cdx.BOMReference(pa.GetAssessor()),
+		Map: convertSlice(pa.GetMap(), func(pm *cdxpb.Declarations_Attestation_AttestationMap) cdx.AttestationMap {
+			m := cdx.AttestationMap{
+				Requirement:   pm.GetRequirement(),
+				Claims:        bomReferences(pm.GetClaims()),
+				CounterClaims: bomReferences(pm.GetCounterClaims()),
+			}
+			if pc := pm.GetConformance(); pc != nil {
+				m.Conformance = &cdx.AttestationConformance{
+					Score:                optionalFloat(pc.Score),
+					Rationale:            pc.GetRationale(),
+					MitigationStrategies: bomReferences(pc.GetMitigationStrategies()),
+				}
+			}
+			if pc := pm.GetConfidence(); pc != nil {
+				m.Confidence = &cdx.AttestationConfidence{
+					Score:     optionalFloat(pc.Score),
+					Rationale: pc.GetRationale(),
+				}
+			}
+			return m
+		}),
+	}
+}
+
+func claimFromProto(pc *cdxpb.Declarations_Claim) cdx.Claim {
+	return cdx.Claim{
+		BOMRef:               pc.GetBomRef(),
+		Target:               cdx.BOMReference(pc.GetTarget()),
+		Predicate:            pc.GetPredicate(),
+		MitigationStrategies: bomReferences(pc.GetMitigationStrategies()),
+		Reasoning:            pc.GetReasoning(),
+		Evidence:             bomReferences(pc.GetEvidence()),
+		CounterEvidence:      bomReferences(pc.GetCounterEvidence()),
+		ExternalReferences:   convertSlice(pc.GetExternalReferences(), externalReferenceFromProto),
+	}
+}
+
+func declarationEvidenceFromProto(pe *cdxpb.Declarations_Evidence) cdx.DeclarationEvidence {
+	e := cdx.DeclarationEvidence{
+		BOMRef:       pe.GetBomRef(),
+		PropertyName: pe.GetPropertyName(),
+		Description:  pe.GetDescription(),
+		Data: convertSlice(pe.GetData(), func(pd *cdxpb.Declarations_Evidence_Data) cdx.EvidenceData {
+			d := cdx.EvidenceData{
+				Name:          pd.GetName(),
+				SensitiveData: stringSlice(pd.GetSensitiveData()),
+				Governance:    dataGovernanceFromProto(pd.GetGovernance()),
+			}
+			if pc := pd.GetContents(); pc != nil {
+				d.Contents = &cdx.EvidenceDataContents{
+					Attachment: attachedTextFromProto(pc.GetAttachment()),
+					URL:        pc.GetUrl(),
+				}
+			}
+			if pc := pd.GetClassification(); pc != nil {
+				d.Classification = &cdx.DataClassification{
+					Flow:           dataFlows[pc.GetFlow()],
+					Classification: pc.GetValue(),
+				}
+			}
+			return d
+		}),
+		Created: timestampFromProto(pe.GetCreated()),
+		Expires: timestampFromProto(pe.GetExpires()),
+	}
+	if pe.Author != nil {
+		a := contactFromProto(pe.GetAuthor())
+		e.Author = &a
+	}
+	if pe.Reviewer != nil {
+		r := contactFromProto(pe.GetReviewer())
+		e.Reviewer = &r
+	}
+	return e
+}
+
+func signatoryFromProto(ps *cdxpb.Declarations_Affirmation_Signatory) cdx.Signatory {
+	s := cdx.Signatory{
+		Name:         ps.GetName(),
+		Role:         ps.GetRole(),
+		Organization: entityFromProto(ps.GetOrganization()),
+	}
+	if ps.ExternalReference != nil {
+		ref := externalReferenceFromProto(ps.GetExternalReference())
+		s.ExternalReference = &ref
+	}
+	return s
+}
+
+func definitionsFromProto(pd *cdxpb.Definition) *cdx.Definitions {
+	return &cdx.Definitions{
+		Standards: convertSlice(pd.GetStandards(), standardFromProto),
+	}
+}
+
+func standardFromProto(ps *cdxpb.Definition_Standard) cdx.StandardDefinition {
+	return cdx.StandardDefinition{
+		BOMRef:      ps.GetBomRef(),
+		Name:        ps.GetName(),
+		Version:     ps.GetVersion(),
+		Description: ps.GetDescription(),
+		Owner:       ps.GetOwner(),
+		Requirements: convertSlice(ps.GetRequirements(), func(pr *cdxpb.Definition_Standard_Requirement) cdx.StandardRequirement {
+			return cdx.StandardRequirement{
+				BOMRef:             pr.GetBomRef(),
+				Identifier:         pr.GetIdentifier(),
+				Title:              pr.GetTitle(),
+				Text:               pr.GetText(),
+				Descriptions:       stringSlice(pr.GetDescriptions()),
+				OpenCRE:            stringSlice(pr.GetOpenCre()),
+				Parent:             pr.GetParent(),
+				Properties:         convertSlice(pr.GetProperties(), propertyFromProto),
+				ExternalReferences: convertSlice(pr.GetExternalReferences(), externalReferenceFromProto),
+			}
+		}),
+		Levels: convertSlice(ps.GetLevels(), func(pl *cdxpb.Definition_Standard_Level) cdx.StandardLevel {
+			return cdx.StandardLevel{
+				BOMRef:       pl.GetBomRef(),
+				Identifier:   pl.GetIdentifier(),
+				Title:        pl.GetTitle(),
+				Description:  pl.GetDescription(),
+				Requirements: stringSlice(pl.GetRequirements()),
+			}
+		}),
+		ExternalReferences: convertSlice(ps.GetExternalReferences(), externalReferenceFromProto),
+	}
+}
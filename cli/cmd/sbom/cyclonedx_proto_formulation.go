@@ -0,0 +1,266 @@
+package sbom
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+)
+
+func formulaFromProto(pf *cdxpb.Formula) cdx.Formula {
+	return cdx.Formula{
+		BOMRef:     pf.GetBomRef(),
+		Components: convertSlice(pf.GetComponents(), componentFromProto),
+		Services:   convertSlice(pf.GetServices(), serviceFromProto),
+		Workflows:  convertSlice(pf.GetWorkflows(), workflowFromProto),
+		Properties: convertSlice(pf.GetProperties(), propertyFromProto),
+	}
+}
+
+func workflowFromProto(pw *cdxpb.Workflow) cdx.Workflow {
+	return cdx.Workflow{
+		BOMRef:             pw.GetBomRef(),
+		UID:                pw.GetUid(),
+		Name:               pw.GetName(),
+		Description:        pw.GetDescription(),
+		ResourceReferences: convertSlice(pw.GetResourceReferences(), resourceReferenceValueFromProto),
+		Tasks:              convertSlice(pw.GetTasks(), taskFromProto),
+		TaskDependencies:   dependenciesFromProto(pw.GetTaskDependencies()),
+		TaskTypes:          convertSlice(pw.GetTaskTypes(), taskTypeFromProto),
+		Trigger:            triggerFromProto(pw.GetTrigger()),
+		Steps:              convertSlice(pw.GetSteps(), stepFromProto),
+		Inputs:             convertSlice(pw.GetInputs(), taskInputFromProto),
+		Outputs:            convertSlice(pw.GetOutputs(), taskOutputFromProto),
+		TimeStart:          timestampFromProto(pw.GetTimeStart()),
+		TimeEnd:            timestampFromProto(pw.GetTimeEnd()),
+		Workspaces:         convertSlice(pw.GetWorkspaces(), workspaceFromProto),
+		RuntimeTopology:    dependenciesFromProto(pw.GetRuntimeTopology()),
+		Properties:         convertSlice(pw.GetProperties(), propertyFromProto),
+	}
+}
+
+func taskFromProto(pt *cdxpb.Task) cdx.Task {
+	return cdx.Task{
+		BOMRef:             pt.GetBomRef(),
+		UID:                pt.GetUid(),
+		Name:               pt.GetName(),
+		Description:        pt.GetDescription(),
+		ResourceReferences: convertSlice(pt.GetResourceReferences(), resourceReferenceValueFromProto),
+		TaskTypes:          convertSlice(pt.GetTaskTypes(), taskTypeFromProto),
+		Trigger:            triggerFromProto(pt.GetTrigger()),
+		Steps:              convertSlice(pt.GetSteps(), stepFromProto),
+		Inputs:             convertSlice(pt.GetInputs(), taskInputFromProto),
+		Outputs:            convertSlice(pt.GetOutputs(), taskOutputFromProto),
+		TimeStart:          timestampFromProto(pt.GetTimeStart()),
+		TimeEnd:            timestampFromProto(pt.GetTimeEnd()),
+		Workspaces:         convertSlice(pt.GetWorkspaces(), workspaceFromProto),
+		RuntimeTopology:    dependenciesFromProto(pt.GetRuntimeTopology()),
+		Properties:         convertSlice(pt.GetProperties(), propertyFromProto),
+	}
+}
+
+func taskTypeFromProto(pt cdxpb.TaskType) cdx.TaskType {
+	return taskTypes[pt]
+}
+
+func stepFromProto(ps *cdxpb.Step) cdx.TaskStep {
+	return cdx.TaskStep{
+		Name:        ps.GetName(),
+		Description: ps.GetDescription(),
+		Commands: convertSlice(ps.GetCommands(), func(pc *cdxpb.Command) cdx.TaskCommand {
+			return cdx.TaskCommand{
+				Executed:   pc.GetExecuted(),
+				Properties: convertSlice(pc.GetProperties(), propertyFromProto),
+			}
+		}),
+		Properties: convertSlice(ps.GetProperties(), propertyFromProto),
+	}
+}
+
+func triggerFromProto(pt *cdxpb.Trigger) *cdx.TaskTrigger {
+	if pt == nil {
+		return nil
+	}
+	t := &cdx.TaskTrigger{
+		BOMRef:             pt.GetBomRef(),
+		UID:                pt.GetUid(),
+		Name:               pt.GetName(),
+		Description:        pt.GetDescription(),
+		ResourceReferences: convertSlice(pt.GetResourceReferences(), resourceReferenceValueFromProto),
+		Type:               triggerTypes[pt.GetType()],
+		Conditions: convertSlice(pt.GetConditions(), func(pc *cdxpb.Condition) cdx.TaskTriggerCondition {
+			return cdx.TaskTriggerCondition{
+				Description: pc.GetDescription(),
+				Expression:  pc.GetExpression(),
+				Properties:  convertSlice(pc.GetProperties(), propertyFromProto),
+			}
+		}),
+		TimeActivated: timestampFromProto(pt.GetTimeActivated()),
+		Inputs:        convertSlice(pt.GetInputs(), taskInputFromProto),
+		Outputs:       convertSlice(pt.GetOutputs(), taskOutputFromProto),
+		Properties:    convertSlice(pt.GetProperties(), propertyFromProto),
+	}
+	if pe := pt.GetEvent(); pe != nil {
+		t.Event = &cdx.Event{
+			UID:          pe.GetUid(),
+			Description:  pe.GetDescription(),
+			TimeReceived: timestampFromProto(pe.GetTimeReceived()),
+			Data:         attachedTextFromProto(pe.GetData()),
+			Source:       resourceReferenceFromProto(pe.GetSource()),
+			Target:       resourceReferenceFromProto(pe.GetTarget()),
+			Properties:   convertSlice(pe.GetProperties(), propertyFromProto),
+		}
+	}
+	return t
+}
+
+func taskInputFromProto(pi *cdxpb.InputType) cdx.TaskInput {
+	return cdx.TaskInput{
+		Source:   resourceReferenceFromProto(pi.GetSource()),
+		Target:   resourceReferenceFromProto(pi.GetTarget()),
+		Resource: resourceReferenceFromProto(pi.GetResource()),
+		Parameters: convertSlice(pi.GetParameters(), func(pp *cdxpb.Parameter) cdx.Parameter {
+			return cdx.Parameter{
+				Name:     pp.GetName(),
+				Value:    pp.GetValue(),
+				DataType: pp.GetDataType(),
+			}
+		}),
+		EnvironmentVars: environmentVarsFromProto(pi.GetEnvironmentVars()),
+		Data:            attachedTextFromProto(pi.GetData()),
+		Properties:      convertSlice(pi.GetProperties(), propertyFromProto),
+	}
+}
+
+func taskOutputFromProto(po *cdxpb.OutputType) cdx.TaskOutput {
+	o := cdx.TaskOutput{
+		Source:          resourceReferenceFromProto(po.GetSource()),
+		Target:          resourceReferenceFromProto(po.GetTarget()),
+		Resource:        resourceReferenceFromProto(po.GetResource()),
+		Data:            attachedTextFromProto(po.GetData()),
+		EnvironmentVars: environmentVarsFromProto(po.GetEnvironmentVars()),
+		Properties:      convertSlice(po.GetProperties(), propertyFromProto),
+	}
+	if po.Type != nil {
+		o.Type = taskOutputTypes[po.GetType()]
+	}
+	return o
+}
+
+func environmentVarsFromProto(pvs []*cdxpb.EnvironmentVars) *cdx.EnvironmentVariables {
+	if len(pvs) == 0 {
+		return nil
+	}
+	vars := make(cdx.EnvironmentVariables, 0, len(pvs))
+	for _, pv := range pvs {
+		switch pv.GetChoice().(type) {
+		case *cdxpb.EnvironmentVars_Property:
+			p := propertyFromProto(pv.GetProperty())
+			vars = append(vars, cdx.EnvironmentVariableChoice{Property: &p})
+		case *cdxpb.EnvironmentVars_Value:
+			vars = append(vars, cdx.EnvironmentVariableChoice{Value: pv.GetValue()})
+		}
+	}
+	return &vars
+}
+
+func workspaceFromProto(pw *cdxpb.Workspace) cdx.TaskWorkspace {
+	w := cdx.TaskWorkspace{
+		BOMRef:             pw.GetBomRef(),
+		UID:                pw.GetUid(),
+		Name:               pw.GetName(),
+		Aliases:            stringSlice(pw.GetAliases()),
+		Description:        pw.GetDescription(),
+		ResourceReferences: convertSlice(pw.GetResourceReferences(), resourceReferenceValueFromProto),
+		MountPath:          pw.GetMountPath(),
+		ManagedDataType:    pw.GetManagedDataType(),
+		VolumeRequest:      pw.GetVolumeRequest(),
+		Properties:         convertSlice(pw.GetProperties(), propertyFromProto),
+	}
+	if pw.AccessMode != nil {
+		w.AccessMode = workspaceAccessModes[pw.GetAccessMode()]
+	}
+	if pv := pw.GetVolume(); pv != nil {
+		w.Volume = &cdx.Volume{
+			UID:           pv.GetUid(),
+			Name:          pv.GetName(),
+			Path:          pv.GetPath(),
+			SizeAllocated: pv.GetSizeAllocated(),
+			Properties:    convertSlice(pv.GetProperties(), propertyFromProto),
+		}
+		if pv.Mode != nil {
+			w.Volume.Mode = volumeModes[pv.GetMode()]
+		}
+		if pv.Persistent != nil {
+			w.Volume.Persistent = cdx.Bool(pv.GetPersistent())
+		}
+		if pv.Remote != nil {
+			w.Volume.Remote = cdx.Bool(pv.GetRemote())
+		}
+	}
+	return w
+}
+
+func resourceReferenceFromProto(pr *cdxpb.ResourceReferenceChoice) *cdx.ResourceReferenceChoice {
+	if pr == nil {
+		return nil
+	}
+	r := resourceReferenceValueFromProto(pr)
+	return &r
+}
+
+func resourceReferenceValueFromProto(pr *cdxpb.ResourceReferenceChoice) cdx.ResourceReferenceChoice {
+	var r cdx.ResourceReferenceChoice
+	switch pr.GetChoice().(type) {
+	case *cdxpb.ResourceReferenceChoice_Ref:
+		r.Ref = pr.GetRef()
+	case *cdxpb.ResourceReferenceChoice_ExternalReference:
+		ref := externalReferenceFromProto(pr.GetExternalReference())
+		r.ExternalReference = &ref
+	}
+	return r
+}
+
+// ========== Enum mappings =============
+
+var taskTypes = map[cdxpb.TaskType]cdx.TaskType{
+	cdxpb.TaskType_TASK_TYPE_COPY:    cdx.TaskTypeCopy,
+	cdxpb.TaskType_TASK_TYPE_CLONE:   cdx.TaskTypeClone,
+	cdxpb.TaskType_TASK_TYPE_LINT:    cdx.TaskTypeLint,
+	cdxpb.TaskType_TASK_TYPE_SCAN:    cdx.TaskTypeScan,
+	cdxpb.TaskType_TASK_TYPE_MERGE:   cdx.TaskTypeMerge,
+	cdxpb.TaskType_TASK_TYPE_BUILD:   cdx.TaskTypeBuild,
+	cdxpb.TaskType_TASK_TYPE_TEST:    cdx.TaskTypeTest,
+	cdxpb.TaskType_TASK_TYPE_DELIVER: cdx.TaskTypeDeliver,
+	cdxpb.TaskType_TASK_TYPE_DEPLOY:  cdx.TaskTypeDeploy,
+	cdxpb.TaskType_TASK_TYPE_RELEASE: cdx.TaskTypeRelease,
+	cdxpb.TaskType_TASK_TYPE_CLEAN:   cdx.TaskTypeClean,
+	cdxpb.TaskType_TASK_TYPE_OTHER:   cdx.TaskTypeOther,
+}
+
+var triggerTypes = map[cdxpb.Trigger_TriggerType]cdx.TaskTriggerType{
+	cdxpb.Trigger_TRIGGER_TYPE_MANUAL:    cdx.TaskTriggerTypeManual,
+	cdxpb.Trigger_TRIGGER_TYPE_API:       cdx.TaskTriggerTypeAPI,
+	cdxpb.Trigger_TRIGGER_TYPE_WEBHOOK:   cdx.TaskTriggerTypeWebhook,
+	cdxpb.Trigger_TRIGGER_TYPE_SCHEDULED: cdx.TaskTriggerTypeScheduled,
+}
+
+var taskOutputTypes = map[cdxpb.OutputType_OutputTypeType]cdx.TaskOutputType{
+	cdxpb.OutputType_OUTPUT_TYPE_ARTIFACT:    cdx.TaskOutputTypeArtifact,
+	cdxpb.OutputType_OUTPUT_TYPE_ATTESTATION: cdx.TaskOutputTypeAttestation,
+	cdxpb.OutputType_OUTPUT_TYPE_LOG:         cdx.TaskOutputTypeLog,
+	cdxpb.OutputType_OUTPUT_TYPE_EVIDENCE:    cdx.TaskOutputTypeEvidence,
+	cdxpb.OutputType_OUTPUT_TYPE_METRICS:     cdx.TaskOutputTypeMetrics,
+	cdxpb.OutputType_OUTPUT_TYPE_OTHER:       cdx.TaskOutputTypeOther,
+}
+
+var workspaceAccessModes = map[cdxpb.Workspace_AccessMode]cdx.TaskWorkspaceAccessMode{
+	cdxpb.Workspace_ACCESS_MODE_READ_ONLY:       cdx.TaskWorkspaceAccessModeReadOnly,
+	cdxpb.Workspace_ACCESS_MODE_READ_WRITE:      cdx.TaskWorkspaceAccessModeReadWrite,
+	cdxpb.Workspace_ACCESS_MODE_READ_WRITE_ONCE: cdx.TaskWorkspaceAccessModeReadWriteOnce,
+	cdxpb.Workspace_ACCESS_MODE_WRITE_ONCE:      cdx.TaskWorkspaceAccessModeWriteOnce,
+	cdxpb.Workspace_ACCESS_MODE_WRITE_ONLY:      cdx.TaskWorkspaceAccessModeWriteOnly,
+}
+
+var volumeModes = map[cdxpb.Volume_VolumeMode]cdx.VolumeMode{
+	cdxpb.Volume_VOLUME_MODE_FILESYSTEM: cdx.VolumeModeFilesystem,
+	cdxpb.Volume_VOLUME_MODE_BLOCK:      cdx.VolumeModeBlock,
+}
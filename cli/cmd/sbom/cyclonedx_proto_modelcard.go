@@ -0,0 +1,235 @@
+package sbom
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+)
+
+func modelCardFromProto(pm *cdxpb.ModelCard) *cdx.MLModelCard {
+	m := &cdx.MLModelCard{BOMRef: pm.GetBomRef()}
+	if pp := pm.GetModelParameters(); pp != nil {
+		m.ModelParameters = &cdx.MLModelParameters{
+			Task:               pp.GetTask(),
+			ArchitectureFamily: pp.GetArchitectureFamily(),
+			ModelArchitecture:  pp.GetModelArchitecture(),
+			Datasets:           convertSlice(pp.GetDatasets(), datasetFromProto),
+			Inputs:             convertSlice(pp.GetInputs(), mlParametersFromProto),
+			Outputs:            convertSlice(pp.GetOutputs(), mlParametersFromProto),
+		}
+		if pa := pp.GetApproach(); pa != nil {
+			m.ModelParameters.Approach = &cdx.MLModelParametersApproach{}
+			if pa.Type != nil {
+				m.ModelParameters.Approach.Type = modelApproachTypes[pa.GetType()]
+			}
+		}
+	}
+	if pq := pm.GetQuantitativeAnalysis(); pq != nil {
+		m.QuantitativeAnalysis = &cdx.MLQuantitativeAnalysis{
+			PerformanceMetrics: convertSlice(pq.GetPerformanceMetrics(), performanceMetricFromProto),
+			Graphics:           graphicsFromProto(pq.GetGraphics()),
+		}
+	}
+	if pc := pm.GetConsiderations(); pc != nil {
+		m.Considerations = &cdx.MLModelCardConsiderations{
+			Users:                stringSlice(pc.GetUsers()),
+			UseCases:             stringSlice(pc.GetUseCases()),
+			TechnicalLimitations: stringSlice(pc.GetTechnicalLimitations()),
+			PerformanceTradeoffs: stringSlice(pc.GetPerformanceTradeoffs()),
+			EthicalConsiderations: convertSlice(pc.GetEthicalConsiderations(),
+				func(pe *cdxpb.ModelCard_ModelCardConsiderations_EthicalConsiderations) cdx.MLModelCardEthicalConsideration {
+					return cdx.MLModelCardEthicalConsideration{
+						Name:               pe.GetName(),
+						MitigationStrategy: pe.GetMitigationStrategy(),
+					}
+				}),
+			FairnessAssessments: convertSlice(pc.GetFairnessAssessments(),
+				func(pf *cdxpb.ModelCard_ModelCardConsiderations_FairnessAssessments) cdx.MLModelCardFairnessAssessment {
+					return cdx.MLModelCardFairnessAssessment{
+						GroupAtRisk:        pf.GetGroupAtRisk(),
+						Benefits:           pf.GetBenefits(),
+						Harms:              pf.GetHarms(),
+						MitigationStrategy: pf.GetMitigationStrategy(),
+					}
+				}),
+		}
+		if pe := pc.GetEnvironmentalConsiderations(); pe != nil {
+			m.Considerations.EnvironmentalConsiderations = &cdx.MLModelCardEnvironmentalConsiderations{
+				EnergyConsumptions: convertSlice(pe.GetEnergyConsumptions(), energyConsumptionFromProto),
+				Properties:         convertSlice(pe.GetProperties(), propertyFromProto),
+			}
+		}
+	}
+	return m
+}
+
+func datasetFromProto(pd *cdxpb.ModelCard_ModelParameters_Datasets) cdx.MLDatasetChoice {
+	var d cdx.MLDatasetChoice
+	switch pd.GetChoice().(type) {
+	case *cdxpb.ModelCard_ModelParameters_Datasets_Ref:
+		d.Ref = pd.GetRef()
+	case *cdxpb.ModelCard_ModelParameters_Datasets_Dataset:
+		data := componentDataFromProto(pd.GetDataset())
+		d.ComponentData = &data
+	}
+	return d
+}
+
+func mlParametersFromProto(pp *cdxpb.ModelCard_ModelParameters_MachineLearningInputOutputParameters) cdx.MLInputOutputParameters {
+	return cdx.MLInputOutputParameters{Format: pp.GetFormat()}
+}
+
+func performanceMetricFromProto(pm *cdxpb.ModelCard_QuantitativeAnalysis_PerformanceMetrics) cdx.MLPerformanceMetric {
+	m := cdx.MLPerformanceMetric{
+		Type:  pm.GetType(),
+		Value: pm.GetValue(),
+		Slice: pm.GetSlice(),
+	}
+	if pc := pm.GetConfidenceInterval(); pc != nil {
+		m.ConfidenceInterval = &cdx.MLPerformanceMetricConfidenceInterval{
+			LowerBound: pc.GetLowerBound(),
+			UpperBound: pc.GetUpperBound(),
+		}
+	}
+	return m
+}
+
+func energyConsumptionFromProto(pe *cdxpb.ModelCard_ModelCardConsiderations_EnergyConsumption) cdx.MLModelEnergyConsumption {
+	return cdx.MLModelEnergyConsumption{
+		Activity: energyActivities[pe.GetActivity()],
+		EnergyProviders: convertSlice(pe.GetEnergyProviders(),
+			func(pp *cdxpb.ModelCard_ModelCardConsiderations_EnergyProviderType) cdx.MLModelEnergyProvider {
+				p := cdx.MLModelEnergyProvider{
+					BOMRef:             pp.GetBomRef(),
+					Description:        pp.GetDescription(),
+					Organization:       entityFromProto(pp.GetOrganization()),
+					EnergySource:       energySources[pp.GetEnergySource()],
+					ExternalReferences: convertSlice(pp.GetExternalReferences(), externalReferenceFromProto),
+				}
+				if pp.EnergyProvided != nil {
+					measure := energyMeasureFromProto(pp.GetEnergyProvided())
+					p.EnergyProvided = &measure
+				}
+				return p
+			}),
+		ActivityEnergyCost: energyMeasureFromProto(pe.GetActivityEnergyCost()),
+		CO2CostEquivalent:  co2MeasureFromProto(pe.GetCo2CostEquivalent()),
+		CO2CostOffset:      co2MeasureFromProto(pe.GetCo2CostOffset()),
+		Properties:         convertSlice(pe.GetProperties(), propertyFromProto),
+	}
+}
+
+// energyMeasureFromProto maps an energy measure. kWh is the only unit.
+func energyMeasureFromProto(pm *cdxpb.ModelCard_ModelCardConsiderations_EnergyMeasure) cdx.MLModelEnergyMeasure {
+	return cdx.MLModelEnergyMeasure{Value: pm.GetValue(), Unit: cdx.MLModelEnergyUnitKWH}
+}
+
+// co2MeasureFromProto maps a CO2 measure. tCO2eq is the only unit.
+func co2MeasureFromProto(pm *cdxpb.ModelCard_ModelCardConsiderations_Co2Measure) *cdx.MLModelCO2Measure {
+	if pm == nil {
+		return nil
+	}
+	return &cdx.MLModelCO2Measure{Value: pm.GetValue(), Unit: cdx.MLModelCO2UnitTCO2Eq}
+}
+
+func componentDataFromProto(pd *cdxpb.ComponentData) cdx.ComponentData {
+	d := cdx.ComponentData{
+		BOMRef:         pd.GetBomRef(),
+		Type:           componentDataTypes[pd.GetType()],
+		Name:           pd.GetName(),
+		Classification: pd.GetClassification(),
+		SensitiveData:  stringSlice(pd.GetSensitiveData()),
+		Graphics:       graphicsFromProto(pd.GetGraphics()),
+		Description:    pd.GetDescription(),
+		Governance:     dataGovernanceFromProto(pd.GetGovernance()),
+	}
+	if pc := pd.GetContents(); pc != nil {
+		d.Contents = &cdx.ComponentDataContents{
+			Attachment: attachedTextFromProto(pc.GetAttachment()),
+			URL:        pc.GetUrl(),
+			Properties: convertSlice(pc.GetProperties(), propertyFromProto),
+		}
+	}
+	return d
+}
+
+func graphicsFromProto(pg *cdxpb.GraphicsCollection) *cdx.ComponentDataGraphics {
+	if pg == nil {
+		return nil
+	}
+	return &cdx.ComponentDataGraphics{
+		Description: pg.GetDescription(),
+		Collection: convertSlice(pg.GetCollection(), func(pi *cdxpb.GraphicsCollection_Graphic) cdx.ComponentDataGraphic {
+			return cdx.ComponentDataGraphic{
+				Name:  pi.GetName(),
+				Image: attachedTextFromProto(pi.GetImage()),
+			}
+		}),
+	}
+}
+
+func dataGovernanceFromProto(pg *cdxpb.DataGovernance) *cdx.DataGovernance {
+	if pg == nil {
+		return nil
+	}
+	return &cdx.DataGovernance{
+		Custodians: convertSlice(pg.GetCustodians(), responsiblePartyFromProto),
+		Stewards:   convertSlice(pg.GetStewards(), responsiblePartyFromProto),
+		Owners:     convertSlice(pg.GetOwners(), responsiblePartyFromProto),
+	}
+}
+
+func responsiblePartyFromProto(pp *cdxpb.DataGovernance_DataGovernanceResponsibleParty) cdx.ComponentDataGovernanceResponsibleParty {
+	var p cdx.ComponentDataGovernanceResponsibleParty
+	switch pp.GetChoice().(type) {
+	case *cdxpb.DataGovernance_DataGovernanceResponsibleParty_Organization:
+		p.Organization = entityFromProto(pp.GetOrganization())
+	case *cdxpb.DataGovernance_DataGovernanceResponsibleParty_Contact:
+		c := contactFromProto(pp.GetContact())
+		p.Contact = &c
+	}
+	return p
+}
+
+// ========== Enum mappings =============
+
+var componentDataTypes = map[cdxpb.ComponentDataType]cdx.ComponentDataType{
+	cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_SOURCE_CODE:   cdx.ComponentDataTypeSourceCode,
+	cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_CONFIGURATION: cdx.ComponentDataTypeConfiguration,
+	cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_DATASET:       cdx.ComponentDataTypeDataset,
+	cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_DEFINITION:    cdx.ComponentDataTypeDefinition,
+	cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_OTHER:         cdx.ComponentDataTypeOther,
+}
+
+var modelApproachTypes = map[cdxpb.ModelParameterApproachType]cdx.MLModelParametersApproachType{
+	cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED:          cdx.MLModelParametersApproachTypeSupervised,
+	cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_UNSUPERVISED:        cdx.MLModelParametersApproachTypeUnsupervised,
+	cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_REINFORCED_LEARNING: cdx.MLModelParametersApproachTypeReinforcementLearning,
+	cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SEMI_SUPERVISED:     cdx.MLModelParametersApproachTypeSemiSupervised,
+	cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SELF_SUPERVISED:     cdx.MLModelParametersApproachTypeSelfSupervised,
+}
+
+var energyActivities = map[cdxpb.ModelCard_ModelCardConsiderations_EnergyActivity]cdx.MLModelEnergyConsumptionActivity{
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_OTHER:            cdx.MLModelEnergyConsumptionActivityOther,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DESIGN:           cdx.MLModelEnergyConsumptionActivityDesign,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DATA_COLLECTION:  cdx.MLModelEnergyConsumptionActivityDataCollection,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DATA_PREPARATION: cdx.MLModelEnergyConsumptionActivityDataPreparation,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_TRAINING:         cdx.MLModelEnergyConsumptionActivityTraining,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_FINE_TUNING:      cdx.MLModelEnergyConsumptionActivityFineTuning,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_VALIDATION:       cdx.MLModelEnergyConsumptionActivityValidation,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DEPLOYMENT:       cdx.MLModelEnergyConsumptionActivityDeployment,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_INFERENCE:        cdx.MLModelEnergyConsumptionActivityInference,
+}
+
+var energySources = map[cdxpb.ModelCard_ModelCardConsiderations_EnergySource]cdx.MLModelEnergySource{
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_COAL:        cdx.MLModelEnergySourceCoal,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_OIL:         cdx.MLModelEnergySourceOil,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_NATURAL_GAS: cdx.MLModelEnergySourceNaturalGas,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_NUCLEAR:     cdx.MLModelEnergySourceNuclear,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_WIND:        cdx.MLModelEnergySourceWind,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_SOLAR:       cdx.MLModelEnergySourceSolar,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_GEOTHERMAL:  cdx.MLModelEnergySourceGeothermal,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_HYDROPOWER:  cdx.MLModelEnergySourceHydropower,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_BIOFUEL:     cdx.MLModelEnergySourceBiofuel,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_UNKNOWN:     cdx.MLModelEnergySourceUnknown,
+	cdxpb.ModelCard_ModelCardConsiderations_ENERGY_SOURCE_OTHER:       cdx.MLModelEnergySourceOther,
+}
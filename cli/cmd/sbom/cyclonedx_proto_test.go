@@ -0,0 +1,381 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testProtoBOM() *cdxpb.Bom {
+	return &cdxpb.Bom{
+		SpecVersion:  "1.6",
+		Version:      proto.Int32(2),
+		SerialNumber: proto.String("urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"),
+		Metadata: &cdxpb.Metadata{
+			Timestamp: timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Tools: []*cdxpb.Tool{{
+				Components: []*cdxpb.Component{{
+					Type: cdxpb.Classification_CLASSIFICATION_APPLICATION,
+					Name: "sbom-generator",
+				}},
+			}},
+			Component: &cdxpb.Component{
+				Type:     cdxpb.Classification_CLASSIFICATION_LIBRARY,
+				BomRef:   proto.String("root"),
+				Name:     "network-os",
+				Version:  proto.String("4.33.0"),
+				Supplier: &cdxpb.OrganizationalEntity{Name: proto.String("Example Networks")},
+			},
+		},
+		Components: []*cdxpb.Component{{
+			Type:    cdxpb.Classification_CLASSIFICATION_LIBRARY,
+			BomRef:  proto.String("pkg:golang/example.com/lib@v1.0.0"),
+			Name:    "lib",
+			Version: proto.String("v1.0.0"),
+			Scope:   cdxpb.Scope_SCOPE_REQUIRED.Enum(),
+			Purl:    proto.String("pkg:golang/example.com/lib@v1.0.0"),
+			Hashes: []*cdxpb.Hash{{
+				Alg:   cdxpb.HashAlg_HASH_ALG_SHA_256,
+				Value: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			}},
+			Licenses: []*cdxpb.LicenseChoice{
+				{Choice: &cdxpb.LicenseChoice_License{License: &cdxpb.License{
+					License: &cdxpb.License_Id{Id: "Apache-2.0"},
+				}}},
+				{Choice: &cdxpb.LicenseChoice_Expression{Expression: "MIT OR BSD-3-Clause"}},
+			},
+			ExternalReferences: []*cdxpb.ExternalReference{{
+				Type: cdxpb.ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DISTRIBUTION,
+				Url:  "https://example.com/lib.tar.gz",
+			}},
+			Components: []*cdxpb.Component{{
+				Type:   cdxpb.Classification_CLASSIFICATION_LIBRARY,
+				BomRef: proto.String("sub"),
+				Name:   "sub",
+			}},
+		}},
+		Dependencies: []*cdxpb.Dependency{{
+			Ref:          "root",
+			Dependencies: []*cdxpb.Dependency{{Ref: "pkg:golang/example.com/lib@v1.0.0"}},
+		}},
+		Vulnerabilities: []*cdxpb.Vulnerability{{
+			Id: proto.String("CVE-2025-0001"),
+			Ratings: []*cdxpb.VulnerabilityRating{{
+				Score:    proto.Float64(9.8),
+				Severity: cdxpb.Severity_SEVERITY_CRITICAL.Enum(),
+				Method:   cdxpb.ScoreMethod_SCORE_METHOD_CVSSV31.Enum(),
+			}},
+			Analysis: &cdxpb.VulnerabilityAnalysis{
+				State: cdxpb.ImpactAnalysisState_IMPACT_ANALYSIS_STATE_NOT_AFFECTED.Enum(),
+			},
+			Affects: []*cdxpb.VulnerabilityAffects{{
+				Ref: "pkg:golang/example.com/lib@v1.0.0",
+			}},
+		}},
+	}
+}
+
+func TestParseCycloneDXProto(t *testing.T) {
+	b, err := proto.Marshal(testProtoBOM())
+	require.NoError(t, err)
+
+	bom, err := ParseCycloneDXProto(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	assert.Equal(t, 2, bom.Version)
+	assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", bom.SerialNumber)
+
+	require.NotNil(t, bom.Metadata)
+	assert.Equal(t, "2025-01-02T03:04:05Z", bom.Metadata.Timestamp)
+	require.NotNil(t, bom.Metadata.Tools)
+	require.NotNil(t, bom.Metadata.Tools.Components)
+	assert.Equal(t, "sbom-generator", (*bom.Metadata.Tools.Components)[0].Name)
+	require.NotNil(t, bom.Metadata.Component)
+	assert.Equal(t, "network-os", bom.Metadata.Component.Name)
+	assert.Equal(t, "Example Networks", bom.Metadata.Component.Supplier.Name)
+
+	require.NotNil(t, bom.Components)
+	lib := (*bom.Components)[0]
+	assert.Equal(t, cdx.ComponentTypeLibrary, lib.Type)
+	assert.Equal(t, cdx.ScopeRequired, lib.Scope)
+	assert.Equal(t, "pkg:golang/example.com/lib@v1.0.0", lib.PackageURL)
+	assert.Equal(t, &[]cdx.Hash{{
+		Algorithm: cdx.HashAlgoSHA256,
+		Value:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}}, lib.Hashes)
+	assert.Equal(t, &cdx.Licenses{
+		{License: &cdx.License{ID: "Apache-2.0"}},
+		{Expression: "MIT OR BSD-3-Clause"},
+	}, lib.Licenses)
+	assert.Equal(t, &[]cdx.ExternalReference{{
+		Type: cdx.ERTypeDistribution,
+		URL:  "https://example.com/lib.tar.gz",
+	}}, lib.ExternalReferences)
+	require.NotNil(t, lib.Components)
+	assert.Equal(t, "sub", (*lib.Components)[0].BOMRef)
+
+	assert.Equal(t, &[]cdx.Dependency{{
+		Ref:          "root",
+		Dependencies: &[]string{"pkg:golang/example.com/lib@v1.0.0"},
+	}}, bom.Dependencies)
+
+	require.NotNil(t, bom.Vulnerabilities)
+	vuln := (*bom.Vulnerabilities)[0]
+	assert.Equal(t, "CVE-2025-0001", vuln.ID)
+	assert.Equal(t, cdx.SeverityCritical, (*vuln.Ratings)[0].Severity)
+	assert.Equal(t, cdx.ScoringMethodCVSSv31, (*vuln.Ratings)[0].Method)
+	assert.Equal(t, cdx.IASNotAffected, vuln.Analysis.State)
+
+	// The decoded BOM must be usable by the SPDX conversion unchanged.
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+	assert.Len(t, spdxDoc.Packages, 3)
+	assert.Equal(t, "Apache-2.0 AND (MIT OR BSD-3-Clause)", spdxDoc.Packages[1].PackageLicenseDeclared)
+}
+
+func TestCycloneDXFromProto(t *testing.T) {
+	t.Run("legacy tools", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			SpecVersion: "1.4",
+			Metadata: &cdxpb.Metadata{
+				Tools: []*cdxpb.Tool{{
+					Vendor:  proto.String("Example"),
+					Name:    proto.String("scanner"),
+					Version: proto.String("1.2.3"),
+				}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, cdx.SpecVersion1_4, bom.SpecVersion)
+		assert.Equal(t, &cdx.ToolsChoice{Tools: &[]cdx.Tool{{
+			Vendor:  "Example",
+			Name:    "scanner",
+			Version: "1.2.3",
+		}}}, bom.Metadata.Tools)
+	})
+
+	t.Run("nested dependencies are flattened", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			Dependencies: []*cdxpb.Dependency{{
+				Ref: "a",
+				Dependencies: []*cdxpb.Dependency{{
+					Ref:          "b",
+					Dependencies: []*cdxpb.Dependency{{Ref: "c"}},
+				}},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &[]cdx.Dependency{
+			{Ref: "a", Dependencies: &[]string{"b"}},
+			{Ref: "b", Dependencies: &[]string{"c"}},
+		}, bom.Dependencies)
+	})
+
+	t.Run("entries for the same ref are merged", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			Dependencies: []*cdxpb.Dependency{
+				{
+					Ref: "a",
+					Dependencies: []*cdxpb.Dependency{{
+						Ref:          "b",
+						Dependencies: []*cdxpb.Dependency{{Ref: "c"}},
+					}},
+				},
+				{
+					Ref:          "b",
+					Dependencies: []*cdxpb.Dependency{{Ref: "c"}, {Ref: "d"}},
+				},
+				{Ref: "e"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &[]cdx.Dependency{
+			{Ref: "a", Dependencies: &[]string{"b"}},
+			{Ref: "b", Dependencies: &[]string{"c", "d"}},
+			{Ref: "e"},
+		}, bom.Dependencies)
+	})
+
+	t.Run("formulation, declarations and definitions", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			Formulation: []*cdxpb.Formula{{
+				BomRef: proto.String("build"),
+				Workflows: []*cdxpb.Workflow{{
+					BomRef:    "release",
+					Uid:       "release-1",
+					TaskTypes: []cdxpb.TaskType{cdxpb.TaskType_TASK_TYPE_BUILD},
+					Trigger: &cdxpb.Trigger{
+						BomRef: "tag",
+						Uid:    "tag-1",
+						Type:   cdxpb.Trigger_TRIGGER_TYPE_MANUAL,
+					},
+				}},
+			}},
+			Declarations: &cdxpb.Declarations{
+				Assessors: []*cdxpb.Declarations_Assessor{{
+					BomRef:     proto.String("assessor"),
+					ThirdParty: proto.Bool(true),
+				}},
+				Claims: []*cdxpb.Declarations_Claim{{
+					BomRef:    proto.String("claim"),
+					Target:    proto.String("root"),
+					Predicate: proto.String("is signed"),
+					Evidence:  []string{"evidence"},
+				}},
+			},
+			Definitions: &cdxpb.Definition{
+				Standards: []*cdxpb.Definition_Standard{{
+					BomRef: proto.String("ssdf"),
+					Name:   proto.String("SSDF"),
+					Levels: []*cdxpb.Definition_Standard_Level{{
+						Identifier:   proto.String("L1"),
+						Requirements: []string{"PO.1.1"},
+					}},
+				}},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, bom.Formulation)
+		formula := (*bom.Formulation)[0]
+		assert.Equal(t, "build", formula.BOMRef)
+		require.NotNil(t, formula.Workflows)
+		workflow := (*formula.Workflows)[0]
+		assert.Equal(t, "release-1", workflow.UID)
+		assert.Equal(t, &[]cdx.TaskType{cdx.TaskTypeBuild}, workflow.TaskTypes)
+		assert.Equal(t, cdx.TaskTriggerTypeManual, workflow.Trigger.Type)
+
+		require.NotNil(t, bom.Declarations)
+		assert.Equal(t, &[]cdx.Assessor{{BOMRef: "assessor", ThirdParty: true}}, bom.Declarations.Assessors)
+		assert.Equal(t, &[]cdx.Claim{{
+			BOMRef:    "claim",
+			Target:    "root",
+			Predicate: "is signed",
+			Evidence:  &[]cdx.BOMReference{"evidence"},
+		}}, bom.Declarations.Claims)
+
+		require.NotNil(t, bom.Definitions)
+		assert.Equal(t, &[]cdx.StandardDefinition{{
+			BOMRef: "ssdf",
+			Name:   "SSDF",
+			Levels: &[]cdx.StandardLevel{{Identifier: "L1", Requirements: &[]string{"PO.1.1"}}},
+		}}, bom.Definitions.Standards)
+	})
+
+	t.Run("model card, data and crypto properties", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			Components: []*cdxpb.Component{
+				{
+					Type: cdxpb.Classification_CLASSIFICATION_MACHINE_LEARNING_MODEL,
+					Name: "classifier",
+					ModelCard: &cdxpb.ModelCard{
+						ModelParameters: &cdxpb.ModelCard_ModelParameters{
+							Approach: &cdxpb.ModelCard_ModelParameters_Approach{
+								Type: cdxpb.ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED.Enum(),
+							},
+							Datasets: []*cdxpb.ModelCard_ModelParameters_Datasets{{
+								Choice: &cdxpb.ModelCard_ModelParameters_Datasets_Ref{Ref: "training-data"},
+							}},
+						},
+					},
+				},
+				{
+					Type: cdxpb.Classification_CLASSIFICATION_DATA,
+					Name: "training-data",
+					Data: []*cdxpb.ComponentData{
+						{Type: cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_DATASET, Name: proto.String("images")},
+						{Type: cdxpb.ComponentDataType_COMPONENT_DATA_TYPE_OTHER, Name: proto.String("labels")},
+					},
+				},
+				{
+					Type: cdxpb.Classification_CLASSIFICATION_CRYPTOGRAPHIC_ASSET,
+					Name: "AES-128-GCM",
+					CryptoProperties: &cdxpb.CryptoProperties{
+						AssetType: cdxpb.CryptoProperties_CRYPTO_ASSET_TYPE_ALGORITHM,
+						AlgorithmProperties: &cdxpb.CryptoProperties_AlgorithmProperties{
+							Primitive: cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_AE.Enum(),
+							Mode:      cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_GCM.Enum(),
+							CertificationLevel: []cdxpb.CryptoProperties_AlgorithmProperties_CryptoCertificationLevel{
+								cdxpb.CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1,
+							},
+							ClassicalSecurityLevel: proto.Int32(128),
+						},
+						Oid: proto.String("2.16.840.1.101.3.4.1.6"),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, bom.Components)
+		components := *bom.Components
+
+		model := components[0].ModelCard
+		require.NotNil(t, model)
+		assert.Equal(t, cdx.MLModelParametersApproachTypeSupervised, model.ModelParameters.Approach.Type)
+		assert.Equal(t, &[]cdx.MLDatasetChoice{{Ref: "training-data"}}, model.ModelParameters.Datasets)
+
+		// Only the first data entry is kept.
+		assert.Equal(t, &cdx.ComponentData{Type: cdx.ComponentDataTypeDataset, Name: "images"}, components[1].Data)
+
+		crypto := components[2].CryptoProperties
+		require.NotNil(t, crypto)
+		assert.Equal(t, cdx.CryptoAssetTypeAlgorithm, crypto.AssetType)
+		assert.Equal(t, "2.16.840.1.101.3.4.1.6", crypto.OID)
+		require.NotNil(t, crypto.AlgorithmProperties)
+		assert.Equal(t, cdx.CryptoPrimitiveAE, crypto.AlgorithmProperties.Primitive)
+		assert.Equal(t, cdx.CryptoAlgorithmModeGCM, crypto.AlgorithmProperties.Mode)
+		assert.Equal(t, &[]cdx.CryptoCertificationLevel{"fips140-3-l1"}, crypto.AlgorithmProperties.CertificationLevel)
+		assert.Equal(t, 128, *crypto.AlgorithmProperties.ClassicalSecurityLevel)
+	})
+
+	t.Run("nil repeated entries", func(t *testing.T) {
+		bom, err := CycloneDXFromProto(&cdxpb.Bom{
+			Vulnerabilities: []*cdxpb.Vulnerability{{
+				Id: proto.String("CVE-2024-0001"),
+				ProofOfConcept: &cdxpb.ProofOfConcept{
+					SupportingMaterial: []*cdxpb.AttachedText{nil, {Value: "poc.py"}},
+				},
+				Credits: &cdxpb.VulnerabilityCredits{
+					Organizations: []*cdxpb.OrganizationalEntity{{Name: proto.String("Example")}, nil},
+					Individuals:   []*cdxpb.OrganizationalContact{nil},
+				},
+			}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, bom.Vulnerabilities)
+		vuln := (*bom.Vulnerabilities)[0]
+		assert.Equal(t, &[]cdx.AttachedText{{}, {Content: "poc.py"}}, vuln.ProofOfConcept.SupportingMaterial)
+		assert.Equal(t, &cdx.Credits{
+			Organizations: &[]cdx.OrganizationalEntity{{Name: "Example"}, {}},
+			Individuals:   &[]cdx.OrganizationalContact{{}},
+		}, vuln.Credits)
+	})
+
+	t.Run("invalid spec version", func(t *testing.T) {
+		_, err := CycloneDXFromProto(&cdxpb.Bom{SpecVersion: "2.0"})
+		assert.ErrorContains(t, err, `invalid spec version "2.0"`)
+	})
+}
+
+func TestCollectUnknownFields(t *testing.T) {
+	c := &cdxpb.Component{Name: "model"}
+	// Field 99 stands in for a field from a newer schema.
+	c.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 99, protowire.BytesType), nil))
+	bom := &cdxpb.Bom{Components: []*cdxpb.Component{c}}
+
+	unknown := map[protoreflect.FullName]map[protowire.Number]bool{}
+	collectUnknownFields(bom.ProtoReflect(), unknown)
+
+	assert.Equal(t, map[protoreflect.FullName]map[protowire.Number]bool{
+		"cyclonedx.v1_6.Component": {99: true},
+	}, unknown)
+}
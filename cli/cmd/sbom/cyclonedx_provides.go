@@ -0,0 +1,101 @@
+package sbom
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+	"google.golang.org/protobuf/proto"
+)
+
+// CycloneDX 1.6 dependency objects can list the specifications, such as
+// cryptographic algorithms, that a component provides. cyclonedx-go has no
+// field for them, so they are read from the encoded BOM separately and
+// passed to the conversion with WithProvides.
+
+// ParseCycloneDXProvides returns the provides lists of a JSON or XML encoded
+// CycloneDX BOM, keyed by the bom-ref of the providing component.
+func ParseCycloneDXProvides(b []byte, format cdx.BOMFileFormat) (map[string][]string, error) {
+	provides := map[string][]string{}
+	switch format {
+	case cdx.BOMFileFormatJSON:
+		var doc struct {
+			Dependencies []struct {
+				Ref      string   `json:"ref"`
+				Provides []string `json:"provides"`
+			} `json:"dependencies"`
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("failed to decode CycloneDX JSON dependencies: %w", err)
+		}
+		for _, dep := range doc.Dependencies {
+			if len(dep.Provides) > 0 {
+				provides[dep.Ref] = append(provides[dep.Ref], dep.Provides...)
+			}
+		}
+	case cdx.BOMFileFormatXML:
+		var doc struct {
+			Dependencies []xmlDependency `xml:"dependencies>dependency"`
+		}
+		if err := xml.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("failed to decode CycloneDX XML dependencies: %w", err)
+		}
+		for _, dep := range doc.Dependencies {
+			dep.collectProvides(provides)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported CycloneDX file format %d", format)
+	}
+	if len(provides) == 0 {
+		return nil, nil
+	}
+	return provides, nil
+}
+
+// ParseCycloneDXProtoProvides returns the provides lists of a protobuf
+// encoded CycloneDX 1.6 BOM, keyed by the bom-ref of the providing
+// component.
+func ParseCycloneDXProtoProvides(b []byte) (map[string][]string, error) {
+	pb := &cdxpb.Bom{}
+	if err := proto.Unmarshal(b, pb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CycloneDX proto: %w", err)
+	}
+	provides := map[string][]string{}
+	var walk func(pd *cdxpb.Dependency)
+	walk = func(pd *cdxpb.Dependency) {
+		if len(pd.GetProvides()) > 0 {
+			provides[pd.GetRef()] = append(provides[pd.GetRef()], pd.GetProvides()...)
+		}
+		for _, child := range pd.GetDependencies() {
+			walk(child)
+		}
+	}
+	for _, pd := range pb.GetDependencies() {
+		walk(pd)
+	}
+	if len(provides) == 0 {
+		return nil, nil
+	}
+	return provides, nil
+}
+
+// xmlDependency is a CycloneDX XML dependency element, which can nest the
+// dependency elements of its dependencies.
+type xmlDependency struct {
+	Ref          string          `xml:"ref,attr"`
+	Dependencies []xmlDependency `xml:"dependency"`
+	Provides     []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"provides"`
+}
+
+func (d xmlDependency) collectProvides(provides map[string][]string) {
+	for _, p := range d.Provides {
+		provides[d.Ref] = append(provides[d.Ref], p.Ref)
+	}
+	for _, child := range d.Dependencies {
+		child.collectProvides(provides)
+	}
+}
@@ -0,0 +1,170 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxpb "github.com/openconfig/security-services/proto/cyclonedx/v1_6"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseCycloneDXProvides(t *testing.T) {
+	want := map[string][]string{"openssl": {"aes-128-gcm", "sha-256"}}
+
+	t.Run("json", func(t *testing.T) {
+		got, err := ParseCycloneDXProvides([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["openssl"]},
+    {"ref": "openssl", "provides": ["aes-128-gcm", "sha-256"]}
+  ]
+}`), cdx.BOMFileFormatJSON)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		got, err := ParseCycloneDXProvides([]byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.6">
+  <dependencies>
+    <dependency ref="app">
+      <dependency ref="openssl">
+        <provides ref="aes-128-gcm"/>
+        <provides ref="sha-256"/>
+      </dependency>
+    </dependency>
+  </dependencies>
+</bom>`), cdx.BOMFileFormatXML)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("proto", func(t *testing.T) {
+		b, err := proto.Marshal(&cdxpb.Bom{
+			SpecVersion: "1.6",
+			Dependencies: []*cdxpb.Dependency{{
+				Ref: "app",
+				Dependencies: []*cdxpb.Dependency{{
+					Ref:      "openssl",
+					Provides: []string{"aes-128-gcm", "sha-256"},
+				}},
+			}},
+		})
+		require.NoError(t, err)
+		got, err := ParseCycloneDXProtoProvides(b)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		got, err := ParseCycloneDXProvides([]byte(`{"dependencies": [{"ref": "app"}]}`), cdx.BOMFileFormatJSON)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func testScopeBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Component: &cdx.Component{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "app"},
+	}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "openssl", Type: cdx.ComponentTypeLibrary, Name: "openssl", Scope: cdx.ScopeRequired},
+		{BOMRef: "zlib", Type: cdx.ComponentTypeLibrary, Name: "zlib", Scope: cdx.ScopeOptional},
+		{BOMRef: "testify", Type: cdx.ComponentTypeLibrary, Name: "testify", Scope: cdx.ScopeExcluded},
+		{BOMRef: "crypto/algorithm/aes-128-gcm", Type: cdx.ComponentTypeCryptographicAsset, Name: "AES-128-GCM"},
+	}
+	bom.Dependencies = &[]cdx.Dependency{
+		{Ref: "app", Dependencies: &[]string{"openssl", "zlib", "testify"}},
+	}
+	return bom
+}
+
+func TestConvertDependencyScopes(t *testing.T) {
+	provides := WithProvides(map[string][]string{"openssl": {"crypto/algorithm/aes-128-gcm"}})
+
+	t.Run("default relationships", func(t *testing.T) {
+		conversion, err := ConvertCycloneDXToSPDX(testScopeBOM(), provides)
+		require.NoError(t, err)
+		spdxDoc := conversion.Document
+
+		assert.NotContains(t, packagePurposes(spdxDoc), "testify")
+		assert.NotContains(t, conversion.SPDXIDs, "testify")
+		assert.Equal(t, []*v2_3.Relationship{{
+			RefA:         toSPDXDocElementID("DOCUMENT"),
+			RefB:         toSPDXDocElementID("app"),
+			Relationship: "DESCRIBES",
+		}, {
+			RefA:         toSPDXDocElementID("app"),
+			RefB:         toSPDXDocElementID("openssl"),
+			Relationship: "DEPENDS_ON",
+		}, {
+			RefA:         toSPDXDocElementID("zlib"),
+			RefB:         toSPDXDocElementID("app"),
+			Relationship: "OPTIONAL_DEPENDENCY_OF",
+		}, {
+			RefA:         toSPDXDocElementID("crypto-algorithm-aes-128-gcm"),
+			RefB:         toSPDXDocElementID("openssl"),
+			Relationship: "SPECIFICATION_FOR",
+		}}, spdxDoc.Relationships)
+	})
+
+	t.Run("excluded as dev dependencies", func(t *testing.T) {
+		spdxDoc, err := ConvertToGoogleSPDX(testScopeBOM(), provides, WithDependencyRelationships(map[cdx.Scope]string{
+			cdx.ScopeExcluded: "DEV_DEPENDENCY_OF",
+		}))
+		require.NoError(t, err)
+
+		assert.Contains(t, packagePurposes(spdxDoc), "testify")
+		assert.Contains(t, spdxDoc.Relationships, &v2_3.Relationship{
+			RefA:         toSPDXDocElementID("testify"),
+			RefB:         toSPDXDocElementID("app"),
+			Relationship: "DEV_DEPENDENCY_OF",
+		})
+	})
+
+	t.Run("dependency roots", func(t *testing.T) {
+		bom := testScopeBOM()
+		bom.Metadata = nil
+		bom.Components = &[]cdx.Component{
+			{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "app"},
+			{BOMRef: "zlib", Type: cdx.ComponentTypeLibrary, Name: "zlib", Scope: cdx.ScopeOptional},
+		}
+		bom.Dependencies = &[]cdx.Dependency{{Ref: "app", Dependencies: &[]string{"zlib"}}}
+
+		spdxDoc, err := ConvertToGoogleSPDX(bom)
+		require.NoError(t, err)
+		assert.Equal(t, &v2_3.Relationship{
+			RefA:         toSPDXDocElementID("DOCUMENT"),
+			RefB:         toSPDXDocElementID("app"),
+			Relationship: "DESCRIBES",
+		}, spdxDoc.Relationships[0])
+		assert.Len(t, spdxDoc.Relationships, 2)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		spdxDoc, err := ConvertToGoogleSPDX(testScopeBOM(), WithDependencyRelationships(map[cdx.Scope]string{
+			cdx.ScopeExcluded: "TEST_DEPENDENCY_OF",
+		}))
+		require.NoError(t, err)
+		bom, err := ConvertToCycloneDX(spdxDoc)
+		require.NoError(t, err)
+
+		scopes := map[string]cdx.Scope{}
+		for _, c := range *bom.Components {
+			scopes[c.BOMRef] = c.Scope
+		}
+		assert.Equal(t, map[string]cdx.Scope{
+			"openssl":                      "",
+			"zlib":                         cdx.ScopeOptional,
+			"testify":                      cdx.ScopeExcluded,
+			"crypto-algorithm-aes-128-gcm": "",
+		}, scopes)
+		assert.Equal(t, &[]cdx.Dependency{
+			{Ref: "app", Dependencies: &[]string{"openssl", "zlib", "testify"}},
+		}, bom.Dependencies)
+	})
+}
@@ -0,0 +1,197 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToCycloneDX(t *testing.T) {
+	spdxDoc := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       "CC0-1.0",
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "network-os",
+		DocumentNamespace: "http://spdx.org/spdxdocs/network-os-3e671687-395b-41f5-a30f-a58921a69b79",
+		CreationInfo: &spdx.CreationInfo{
+			Created: "2025-01-02T03:04:05Z",
+			Creators: []common.Creator{
+				{Creator: "sbom-generator-1.2.3", CreatorType: "Tool"},
+				{Creator: "Jane Doe (jane@example.com)", CreatorType: "Person"},
+				{Creator: "Example Networks", CreatorType: "Organization"},
+			},
+		},
+		Packages: []*spdx.Package{
+			{
+				PackageSPDXIdentifier:   "root",
+				PackageName:             "network-os",
+				PackageVersion:          "4.33.0",
+				PackageDownloadLocation: "NOASSERTION",
+				PrimaryPackagePurpose:   "OPERATING-SYSTEM",
+				PackageSupplier:         &common.Supplier{Supplier: "Example Networks", SupplierType: "Organization"},
+			},
+			{
+				PackageSPDXIdentifier:   "lib",
+				PackageName:             "lib",
+				PackageVersion:          "v1.0.0",
+				PackageDownloadLocation: "https://example.com/lib.tar.gz",
+				PackageLicenseDeclared:  "Apache-2.0",
+				PackageLicenseConcluded: "NOASSERTION",
+				PackageChecksums: []common.Checksum{
+					{Algorithm: common.SHA256, Value: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+					{Algorithm: common.MD2, Value: "8350e5a3e24c153df2275c9f80692773"},
+				},
+				PackageExternalReferences: []*spdx.PackageExternalReference{
+					{Category: "PACKAGE-MANAGER", RefType: "purl", Locator: "pkg:golang/example.com/lib@v1.0.0"},
+					{Category: "SECURITY", RefType: "cpe23Type", Locator: "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*"},
+				},
+			},
+			{
+				PackageSPDXIdentifier:   "SPDXRef-sub",
+				PackageName:             "sub",
+				PackageDownloadLocation: "NOASSERTION",
+			},
+		},
+		Files: []*spdx.File{{
+			FileSPDXIdentifier: "file",
+			FileName:           "./lib/LICENSE",
+			Checksums:          []common.Checksum{{Algorithm: common.SHA1, Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+		}},
+		Relationships: []*spdx.Relationship{
+			{RefA: common.MakeDocElementID("", "DOCUMENT"), RefB: common.MakeDocElementID("", "root"), Relationship: common.TypeRelationshipDescribe},
+			{RefA: common.MakeDocElementID("", "lib"), RefB: common.MakeDocElementID("", "sub"), Relationship: common.TypeRelationshipContains},
+			{RefA: common.MakeDocElementID("", "file"), RefB: common.MakeDocElementID("", "lib"), Relationship: common.TypeRelationshipContainedBy},
+			{RefA: common.MakeDocElementID("", "root"), RefB: common.MakeDocElementID("", "lib"), Relationship: common.TypeRelationshipDependsOn},
+			{RefA: common.MakeDocElementID("", "sub"), RefB: common.MakeDocElementID("", "lib"), Relationship: common.TypeRelationshipDependencyOf},
+			{RefA: common.MakeDocElementID("", "lib"), RefB: common.MakeDocElementID("", "sub"), Relationship: common.TypeRelationshipGeneratedFrom},
+		},
+	}
+
+	bom, err := ConvertToCycloneDX(spdxDoc)
+	require.NoError(t, err)
+
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", bom.SerialNumber)
+
+	require.NotNil(t, bom.Metadata)
+	assert.Equal(t, "2025-01-02T03:04:05Z", bom.Metadata.Timestamp)
+	assert.Equal(t, &cdx.ToolsChoice{Components: &[]cdx.Component{{
+		Type:    cdx.ComponentTypeApplication,
+		Name:    "sbom-generator",
+		Version: "1.2.3",
+	}}}, bom.Metadata.Tools)
+	assert.Equal(t, &[]cdx.OrganizationalContact{{Name: "Jane Doe", Email: "jane@example.com"}}, bom.Metadata.Authors)
+	assert.Equal(t, &cdx.OrganizationalEntity{Name: "Example Networks"}, bom.Metadata.Supplier)
+
+	require.NotNil(t, bom.Metadata.Component)
+	root := bom.Metadata.Component
+	assert.Equal(t, "root", root.BOMRef)
+	assert.Equal(t, cdx.ComponentTypeOS, root.Type)
+	assert.Equal(t, "Example Networks", root.Supplier.Name)
+	assert.Nil(t, root.ExternalReferences)
+
+	require.NotNil(t, bom.Components)
+	require.Len(t, *bom.Components, 1)
+	lib := (*bom.Components)[0]
+	assert.Equal(t, "lib", lib.BOMRef)
+	assert.Equal(t, cdx.ComponentTypeLibrary, lib.Type)
+	assert.Equal(t, "pkg:golang/example.com/lib@v1.0.0", lib.PackageURL)
+	assert.Equal(t, "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*", lib.CPE)
+	assert.Equal(t, &[]cdx.Hash{{
+		Algorithm: cdx.HashAlgoSHA256,
+		Value:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}}, lib.Hashes)
+	assert.Equal(t, &cdx.Licenses{{License: &cdx.License{
+		ID:              "Apache-2.0",
+		Acknowledgement: cdx.LicenseAcknowledgementDeclared,
+	}}}, lib.Licenses)
+	assert.Equal(t, &[]cdx.ExternalReference{{
+		Type: cdx.ERTypeDistribution,
+		URL:  "https://example.com/lib.tar.gz",
+	}}, lib.ExternalReferences)
+
+	require.NotNil(t, lib.Components)
+	require.Len(t, *lib.Components, 2)
+	assert.Equal(t, "sub", (*lib.Components)[0].BOMRef)
+	file := (*lib.Components)[1]
+	assert.Equal(t, "file", file.BOMRef)
+	assert.Equal(t, cdx.ComponentTypeFile, file.Type)
+	assert.Equal(t, "./lib/LICENSE", file.Name)
+
+	assert.Equal(t, &[]cdx.Dependency{
+		{Ref: "root", Dependencies: &[]string{"lib"}},
+		{Ref: "lib", Dependencies: &[]string{"sub"}},
+	}, bom.Dependencies)
+}
+
+func TestConvertToCycloneDXRoundTrip(t *testing.T) {
+	bom, err := CycloneDXFromProto(testProtoBOM())
+	require.NoError(t, err)
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	got, err := ConvertToCycloneDX(spdxDoc)
+	require.NoError(t, err)
+
+	assert.Equal(t, bom.SerialNumber, got.SerialNumber)
+	require.NotNil(t, got.Metadata.Component)
+	assert.Equal(t, "root", got.Metadata.Component.BOMRef)
+	require.NotNil(t, got.Components)
+	lib := (*got.Components)[0]
+	// The purl bom-ref is not a valid SPDX ID, so it comes back sanitized.
+	assert.Equal(t, "pkg-golang-example.com-lib-v1.0.0", lib.BOMRef)
+	assert.Equal(t, "pkg:golang/example.com/lib@v1.0.0", lib.PackageURL)
+	require.NotNil(t, lib.Components)
+	assert.Equal(t, "sub", (*lib.Components)[0].BOMRef)
+	normalized, _ := normalizeBOMRefs(bom)
+	assert.Equal(t, normalized.Dependencies, got.Dependencies)
+}
+
+func TestContainsCycle(t *testing.T) {
+	b := &cycloneDXBuilder{
+		components: map[string]*cdx.Component{},
+		parents:    map[string]string{},
+		children:   map[string][]string{},
+	}
+	b.contains("a", "b")
+	b.contains("b", "c")
+	b.contains("c", "a")
+	b.contains("d", "c")
+
+	assert.Equal(t, map[string]string{"b": "a", "c": "b"}, b.parents)
+}
+
+func TestLicensesFromSPDX(t *testing.T) {
+	tests := []struct {
+		desc      string
+		declared  string
+		concluded string
+		want      *cdx.Licenses
+	}{{
+		desc:      "no assertion",
+		declared:  "NOASSERTION",
+		concluded: "NONE",
+	}, {
+		desc:      "identifiers",
+		declared:  "MIT",
+		concluded: "LicenseRef-custom",
+		want: &cdx.Licenses{
+			{License: &cdx.License{ID: "MIT", Acknowledgement: cdx.LicenseAcknowledgementDeclared}},
+			{License: &cdx.License{Name: "LicenseRef-custom", Acknowledgement: cdx.LicenseAcknowledgementConcluded}},
+		},
+	}, {
+		desc:      "expression",
+		declared:  "NOASSERTION",
+		concluded: "MIT OR Apache-2.0",
+		want:      &cdx.Licenses{{Expression: "MIT OR Apache-2.0"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, licensesFromSPDX(tt.declared, tt.concluded))
+		})
+	}
+}
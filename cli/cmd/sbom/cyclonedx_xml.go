@@ -0,0 +1,42 @@
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const cycloneDXXMLNamespacePrefix = "http://cyclonedx.org/schema/bom/"
+
+// ParseCycloneDXXML decodes an XML encoded CycloneDX 1.x BOM. The spec
+// version is taken from the document's XML namespace.
+func ParseCycloneDXXML(b []byte) (*cdx.BOM, error) {
+	bom := &cdx.BOM{}
+	if err := cdx.NewBOMDecoder(bytes.NewReader(b), cdx.BOMFileFormatXML).Decode(bom); err != nil {
+		return nil, fmt.Errorf("failed to decode CycloneDX XML: %w", err)
+	}
+	specVersion, err := cycloneDXXMLSpecVersion(bom.XMLNS)
+	if err != nil {
+		return nil, err
+	}
+	bom.SpecVersion = specVersion
+	bom.BOMFormat = cdx.BOMFormat
+	return bom, nil
+}
+
+// cycloneDXXMLSpecVersion returns the spec version for a CycloneDX XML
+// namespace such as "http://cyclonedx.org/schema/bom/1.4".
+func cycloneDXXMLSpecVersion(xmlns string) (cdx.SpecVersion, error) {
+	v, ok := strings.CutPrefix(xmlns, cycloneDXXMLNamespacePrefix)
+	if !ok {
+		return 0, fmt.Errorf("not a CycloneDX XML namespace: %q", xmlns)
+	}
+	var specVersion cdx.SpecVersion
+	if err := specVersion.UnmarshalJSON([]byte(strconv.Quote(v))); err != nil {
+		return 0, fmt.Errorf("unsupported CycloneDX spec version %q: %w", v, err)
+	}
+	return specVersion, nil
+}
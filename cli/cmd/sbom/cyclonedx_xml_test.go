@@ -0,0 +1,89 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCycloneDX14XML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="1">
+  <metadata>
+    <timestamp>2025-01-02T03:04:05Z</timestamp>
+    <tools>
+      <tool>
+        <vendor>Example</vendor>
+        <name>scanner</name>
+        <version>1.2.3</version>
+      </tool>
+    </tools>
+    <component type="application" bom-ref="root">
+      <author>Jane Doe</author>
+      <name>network-os</name>
+      <version>4.33.0</version>
+    </component>
+    <manufacture>
+      <name>Example Networks</name>
+    </manufacture>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="lib">
+      <name>lib</name>
+      <version>1.0.0</version>
+      <purl>pkg:golang/example.com/lib@v1.0.0</purl>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="root">
+      <dependency ref="lib"/>
+    </dependency>
+  </dependencies>
+</bom>`
+
+func TestParseCycloneDXXML(t *testing.T) {
+	t.Run("detects the spec version", func(t *testing.T) {
+		bom, err := ParseCycloneDXXML([]byte(testCycloneDX14XML))
+		require.NoError(t, err)
+		assert.Equal(t, cdx.SpecVersion1_4, bom.SpecVersion)
+		assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", bom.SerialNumber)
+		require.NotNil(t, bom.Components)
+		assert.Equal(t, "pkg:golang/example.com/lib@v1.0.0", (*bom.Components)[0].PackageURL)
+		assert.Equal(t, &[]cdx.Dependency{{Ref: "root", Dependencies: &[]string{"lib"}}}, bom.Dependencies)
+	})
+
+	t.Run("rejects other namespaces", func(t *testing.T) {
+		_, err := ParseCycloneDXXML([]byte(`<bom xmlns="http://example.com/bom"/>`))
+		assert.ErrorContains(t, err, `not a CycloneDX XML namespace: "http://example.com/bom"`)
+	})
+
+	t.Run("rejects unknown spec versions", func(t *testing.T) {
+		_, err := ParseCycloneDXXML([]byte(`<bom xmlns="http://cyclonedx.org/schema/bom/2.0"/>`))
+		assert.ErrorContains(t, err, `unsupported CycloneDX spec version "2.0"`)
+	})
+}
+
+func TestUpgradeCycloneDX(t *testing.T) {
+	bom, err := ParseCycloneDXXML([]byte(testCycloneDX14XML))
+	require.NoError(t, err)
+
+	UpgradeCycloneDX(bom)
+
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	assert.Equal(t, "http://cyclonedx.org/schema/bom/1.6", bom.XMLNS)
+	assert.Equal(t, &cdx.ToolsChoice{Components: &[]cdx.Component{{
+		Type:    cdx.ComponentTypeApplication,
+		Group:   "Example",
+		Name:    "scanner",
+		Version: "1.2.3",
+	}}}, bom.Metadata.Tools)
+	assert.Nil(t, bom.Metadata.Manufacture)
+	assert.Equal(t, &cdx.OrganizationalEntity{Name: "Example Networks"}, bom.Metadata.Manufacturer)
+	assert.Empty(t, bom.Metadata.Component.Author)
+	assert.Equal(t, &[]cdx.OrganizationalContact{{Name: "Jane Doe"}}, bom.Metadata.Component.Authors)
+
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+	assert.Len(t, spdxDoc.Packages, 2)
+}
@@ -0,0 +1,312 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+)
+
+// ConvertOptions configures the CycloneDX to SPDX conversion.
+type ConvertOptions struct {
+	// PackagePurposes maps CycloneDX component types to the SPDX primary
+	// package purpose of the packages created for them. Types mapped to ""
+	// are not converted, and other types missing from the map become OTHER
+	// packages. File components become SPDX files unless the file type is
+	// listed.
+	PackagePurposes map[cdx.ComponentType]string
+
+	// DependencyRelationships maps CycloneDX component scopes to the SPDX
+	// relationship used for dependencies on components of that scope.
+	// Components of a scope mapped to "" are not converted. Unscoped
+	// components are required.
+	DependencyRelationships map[cdx.Scope]string
+
+	// Provides maps the bom-ref of a component to the bom-refs of the
+	// specifications it provides, from the CycloneDX 1.6 dependency provides
+	// lists that cyclonedx-go does not model. See ParseCycloneDXProvides.
+	Provides map[string][]string
+
+	// CPEBothBindings adds each CPE as both a cpe22Type and a cpe23Type
+	// reference, in the CPE 2.2 URI and 2.3 formatted string bindings. By
+	// default a CPE is only added in the binding it is written in.
+	CPEBothBindings bool
+
+	// Tool is appended to the document creators as a tool, in the SPDX
+	// "name-version" form. Empty adds no tool.
+	Tool string
+
+	// CreationTime is the creation time of documents converted from BOMs
+	// without a timestamp. The zero time means the time of the conversion.
+	CreationTime time.Time
+
+	// NamespaceBase is the URI prefix of the document namespace. Empty means
+	// DefaultNamespaceBase.
+	NamespaceBase string
+
+	// DeterministicNamespace derives the document namespace from a hash of
+	// the BOM, so that converting the same BOM again yields the same
+	// namespace.
+	DeterministicNamespace bool
+
+	// VEXFile is the companion VEX document the BOM vulnerabilities are
+	// written to. The loss report lists them as degraded rather than
+	// dropped. Empty means no VEX document is written.
+	VEXFile string
+
+	// losses collects the loss report of ConvertCycloneDXToSPDX.
+	losses *lossCollector
+}
+
+// ConvertOption overrides a default of ConvertOptions.
+type ConvertOption func(*ConvertOptions)
+
+// DefaultConvertOptions returns the options used by ConvertToGoogleSPDX
+// when no ConvertOption is given.
+func DefaultConvertOptions() *ConvertOptions {
+	purposes := make(map[cdx.ComponentType]string, len(defaultPackagePurposes))
+	for t, p := range defaultPackagePurposes {
+		purposes[t] = p
+	}
+	relationships := make(map[cdx.Scope]string, len(defaultDependencyRelationships))
+	for scope, r := range defaultDependencyRelationships {
+		relationships[scope] = r
+	}
+	return &ConvertOptions{PackagePurposes: purposes, DependencyRelationships: relationships}
+}
+
+// NewConvertOptions returns the default options with opts applied.
+func NewConvertOptions(opts ...ConvertOption) *ConvertOptions {
+	o := DefaultConvertOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithPackagePurposes overrides entries of the default component type to
+// package purpose mapping.
+func WithPackagePurposes(purposes map[cdx.ComponentType]string) ConvertOption {
+	return func(o *ConvertOptions) {
+		for t, p := range purposes {
+			o.PackagePurposes[t] = p
+		}
+	}
+}
+
+// WithDependencyRelationships overrides entries of the default component
+// scope to dependency relationship mapping.
+func WithDependencyRelationships(relationships map[cdx.Scope]string) ConvertOption {
+	return func(o *ConvertOptions) {
+		for scope, r := range relationships {
+			o.DependencyRelationships[scope] = r
+		}
+	}
+}
+
+// WithProvides sets the CycloneDX 1.6 provides lists of the BOM, keyed by
+// the bom-ref of the providing component.
+func WithProvides(provides map[string][]string) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.Provides = provides
+	}
+}
+
+// WithCPEBothBindings adds CPEs in both the CPE 2.2 URI and the CPE 2.3
+// formatted string binding.
+func WithCPEBothBindings() ConvertOption {
+	return func(o *ConvertOptions) {
+		o.CPEBothBindings = true
+	}
+}
+
+// WithTool records the converting tool and its version as a document
+// creator.
+func WithTool(name, version string) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.Tool = spdxTool(name, version)
+	}
+}
+
+// WithCreationTime sets the creation time of documents converted from BOMs
+// without a timestamp.
+func WithCreationTime(t time.Time) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.CreationTime = t
+	}
+}
+
+// WithNamespaceBase sets the URI prefix of the document namespace.
+func WithNamespaceBase(base string) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.NamespaceBase = base
+	}
+}
+
+// WithDeterministicNamespace derives the document namespace from a hash of
+// the BOM instead of its serial number or a random UUID.
+func WithDeterministicNamespace() ConvertOption {
+	return func(o *ConvertOptions) {
+		o.DeterministicNamespace = true
+	}
+}
+
+// WithVEXFile records that the BOM vulnerabilities are written to a
+// companion VEX document.
+func WithVEXFile(name string) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.VEXFile = name
+	}
+}
+
+// ParsePackagePurposes parses "component type=PURPOSE" pairs, such as those
+// given on the command line, for WithPackagePurposes. An empty purpose
+// excludes the component type from the conversion. Listing the file type
+// converts file components to packages.
+func ParsePackagePurposes(pairs map[string]string) (map[cdx.ComponentType]string, error) {
+	purposes := make(map[cdx.ComponentType]string, len(pairs))
+	for t, p := range pairs {
+		ct := cdx.ComponentType(strings.ToLower(strings.TrimSpace(t)))
+		if _, ok := defaultPackagePurposes[ct]; !ok && ct != cdx.ComponentTypeFile {
+			return nil, fmt.Errorf("invalid component type %q", t)
+		}
+		p = strings.ToUpper(strings.TrimSpace(p))
+		if p != "" && !validPackagePurposes[p] {
+			return nil, fmt.Errorf("invalid package purpose %q for component type %q", p, t)
+		}
+		purposes[ct] = p
+	}
+	return purposes, nil
+}
+
+// ParseDependencyRelationships parses "scope=RELATIONSHIP" pairs, such as
+// those given on the command line, for WithDependencyRelationships. An empty
+// relationship excludes components of the scope from the conversion.
+func ParseDependencyRelationships(pairs map[string]string) (map[cdx.Scope]string, error) {
+	relationships := make(map[cdx.Scope]string, len(pairs))
+	for scope, r := range pairs {
+		s := cdx.Scope(strings.ToLower(strings.TrimSpace(scope)))
+		if _, ok := defaultDependencyRelationships[s]; !ok {
+			return nil, fmt.Errorf("invalid component scope %q", scope)
+		}
+		r = strings.ToUpper(strings.TrimSpace(r))
+		if r != "" && r != common.TypeRelationshipDependsOn && !spdxDependencyOfRelationships[r] {
+			return nil, fmt.Errorf("invalid dependency relationship %q for scope %q", r, scope)
+		}
+		relationships[s] = r
+	}
+	return relationships, nil
+}
+
+// packagePurpose returns the SPDX primary package purpose for a component
+// type, and false if components of the type are not converted to packages.
+func (o *ConvertOptions) packagePurpose(t cdx.ComponentType) (string, bool) {
+	if o == nil {
+		o = defaultConvertOptions
+	}
+	p, ok := o.PackagePurposes[t]
+	if !ok {
+		if t == cdx.ComponentTypeFile {
+			return "", false
+		}
+		return "OTHER", true
+	}
+	return p, p != ""
+}
+
+// isSPDXFile reports whether the component is converted to an SPDX file.
+func (o *ConvertOptions) isSPDXFile(c cdx.Component) bool {
+	if o == nil {
+		o = defaultConvertOptions
+	}
+	_, ok := o.PackagePurposes[c.Type]
+	return c.Type == cdx.ComponentTypeFile && !ok && o.isScopeConverted(c.Scope)
+}
+
+// isSPDXPackage reports whether the component is converted to an SPDX
+// package, and returns its primary package purpose.
+func (o *ConvertOptions) isSPDXPackage(c cdx.Component) (string, bool) {
+	purpose, ok := o.packagePurpose(c.Type)
+	return purpose, ok && o.isScopeConverted(c.Scope)
+}
+
+// isSPDXElement reports whether the component is converted to an SPDX
+// element that relationships can refer to.
+func (o *ConvertOptions) isSPDXElement(c cdx.Component) bool {
+	_, ok := o.isSPDXPackage(c)
+	return ok || o.isSPDXFile(c)
+}
+
+// dependencyRelationship returns the SPDX relationship for dependencies on
+// components of a scope, and false if such components are not converted.
+func (o *ConvertOptions) dependencyRelationship(scope cdx.Scope) (string, bool) {
+	if o == nil {
+		o = defaultConvertOptions
+	}
+	if scope == "" {
+		scope = cdx.ScopeRequired
+	}
+	r, ok := o.DependencyRelationships[scope]
+	if !ok {
+		return common.TypeRelationshipDependsOn, true
+	}
+	return r, r != ""
+}
+
+// isScopeConverted reports whether components of a scope are converted.
+func (o *ConvertOptions) isScopeConverted(scope cdx.Scope) bool {
+	_, ok := o.dependencyRelationship(scope)
+	return ok
+}
+
+var defaultConvertOptions = DefaultConvertOptions()
+
+var defaultPackagePurposes = map[cdx.ComponentType]string{
+	cdx.ComponentTypeApplication:          "APPLICATION",
+	cdx.ComponentTypeContainer:            "CONTAINER",
+	cdx.ComponentTypeCryptographicAsset:   "OTHER",
+	cdx.ComponentTypeData:                 "OTHER",
+	cdx.ComponentTypeDevice:               "DEVICE",
+	cdx.ComponentTypeDeviceDriver:         "OTHER",
+	cdx.ComponentTypeFirmware:             "FIRMWARE",
+	cdx.ComponentTypeFramework:            "FRAMEWORK",
+	cdx.ComponentTypeLibrary:              "LIBRARY",
+	cdx.ComponentTypeMachineLearningModel: "OTHER",
+	cdx.ComponentTypeOS:                   "OPERATING-SYSTEM",
+	cdx.ComponentTypePlatform:             "OTHER",
+}
+
+var defaultDependencyRelationships = map[cdx.Scope]string{
+	cdx.ScopeRequired: common.TypeRelationshipDependsOn,
+	cdx.ScopeOptional: common.TypeRelationshipOptionalDependencyOf,
+	cdx.ScopeExcluded: "",
+}
+
+// spdxDependencyOfRelationships are the SPDX relationships that point from
+// a dependency to the element depending on it.
+var spdxDependencyOfRelationships = map[string]bool{
+	common.TypeRelationshipDependencyOf:         true,
+	common.TypeRelationshipOptionalDependencyOf: true,
+	common.TypeRelationshipDevDependencyOf:      true,
+	common.TypeRelationshipBuildDependencyOf:    true,
+	common.TypeRelationshipTestDependencyOf:     true,
+	common.TypeRelationshipRuntimeDependencyOf:  true,
+	common.TypeRelationshipProvidedDependencyOf: true,
+}
+
+var validPackagePurposes = map[string]bool{
+	"APPLICATION":      true,
+	"FRAMEWORK":        true,
+	"LIBRARY":          true,
+	"CONTAINER":        true,
+	"OPERATING-SYSTEM": true,
+	"DEVICE":           true,
+	"FIRMWARE":         true,
+	"SOURCE":           true,
+	"ARCHIVE":          true,
+	"FILE":             true,
+	"INSTALL":          true,
+	"OTHER":            true,
+}
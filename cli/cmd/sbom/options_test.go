@@ -0,0 +1,123 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPurposeBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Component: &cdx.Component{
+			BOMRef: "switch",
+			Type:   cdx.ComponentTypeDevice,
+			Name:   "switch",
+			Components: &[]cdx.Component{
+				{BOMRef: "fw", Type: cdx.ComponentTypeFirmware, Name: "fw"},
+				{BOMRef: "os", Type: cdx.ComponentTypeOS, Name: "os"},
+			},
+		},
+	}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "app"},
+		{BOMRef: "model", Type: cdx.ComponentTypeMachineLearningModel, Name: "model"},
+		{BOMRef: "config", Type: cdx.ComponentTypeData, Name: "config"},
+	}
+	bom.Dependencies = &[]cdx.Dependency{
+		{Ref: "app", Dependencies: &[]string{"model", "config"}},
+	}
+	return bom
+}
+
+func packagePurposes(spdxDoc *spdx.Document) map[string]string {
+	purposes := map[string]string{}
+	for _, p := range spdxDoc.Packages {
+		purposes[string(p.PackageSPDXIdentifier)] = p.PrimaryPackagePurpose
+	}
+	return purposes
+}
+
+func TestPackagePurposes(t *testing.T) {
+	t.Run("default mapping", func(t *testing.T) {
+		spdxDoc, err := ConvertToGoogleSPDX(testPurposeBOM())
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"switch": "DEVICE",
+			"fw":     "FIRMWARE",
+			"os":     "OPERATING-SYSTEM",
+			"app":    "APPLICATION",
+			"model":  "OTHER",
+			"config": "OTHER",
+		}, packagePurposes(spdxDoc))
+		assert.Len(t, spdxDoc.Relationships, 5)
+	})
+
+	t.Run("configured mapping", func(t *testing.T) {
+		spdxDoc, err := ConvertToGoogleSPDX(testPurposeBOM(), WithPackagePurposes(map[cdx.ComponentType]string{
+			cdx.ComponentTypeMachineLearningModel: "FILE",
+			cdx.ComponentTypeData:                 "",
+			cdx.ComponentTypeOS:                   "",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"switch": "DEVICE",
+			"fw":     "FIRMWARE",
+			"app":    "APPLICATION",
+			"model":  "FILE",
+		}, packagePurposes(spdxDoc))
+		assert.Equal(t, []*v2_3.Relationship{{
+			RefA:         toSPDXDocElementID("DOCUMENT"),
+			RefB:         toSPDXDocElementID("switch"),
+			Relationship: "DESCRIBES",
+		}, {
+			RefA:         toSPDXDocElementID("switch"),
+			RefB:         toSPDXDocElementID("fw"),
+			Relationship: "CONTAINS",
+		}, {
+			RefA:         toSPDXDocElementID("app"),
+			RefB:         toSPDXDocElementID("model"),
+			Relationship: "DEPENDS_ON",
+		}}, spdxDoc.Relationships)
+	})
+
+	t.Run("options are not shared", func(t *testing.T) {
+		NewConvertOptions(WithPackagePurposes(map[cdx.ComponentType]string{cdx.ComponentTypeLibrary: ""}))
+		assert.True(t, IsComponentSPDXPackage(cdx.Component{Type: cdx.ComponentTypeLibrary}))
+	})
+}
+
+func TestParsePackagePurposes(t *testing.T) {
+	got, err := ParsePackagePurposes(map[string]string{"firmware": "install", "data": "", "file": "FILE"})
+	require.NoError(t, err)
+	assert.Equal(t, map[cdx.ComponentType]string{
+		cdx.ComponentTypeFirmware: "INSTALL",
+		cdx.ComponentTypeData:     "",
+		cdx.ComponentTypeFile:     "FILE",
+	}, got)
+
+	_, err = ParsePackagePurposes(map[string]string{"firmware": "BLOB"})
+	assert.EqualError(t, err, `invalid package purpose "BLOB" for component type "firmware"`)
+	_, err = ParsePackagePurposes(map[string]string{"firmwre": "FIRMWARE"})
+	assert.EqualError(t, err, `invalid component type "firmwre"`)
+}
+
+func TestParseDependencyRelationships(t *testing.T) {
+	got, err := ParseDependencyRelationships(map[string]string{"excluded": "dev_dependency_of", "optional": ""})
+	require.NoError(t, err)
+	assert.Equal(t, map[cdx.Scope]string{
+		cdx.ScopeExcluded: "DEV_DEPENDENCY_OF",
+		cdx.ScopeOptional: "",
+	}, got)
+
+	_, err = ParseDependencyRelationships(map[string]string{"excluded": "CONTAINS"})
+	assert.EqualError(t, err, `invalid dependency relationship "CONTAINS" for scope "excluded"`)
+	_, err = ParseDependencyRelationships(map[string]string{"dev": "DEV_DEPENDENCY_OF"})
+	assert.EqualError(t, err, `invalid component scope "dev"`)
+}
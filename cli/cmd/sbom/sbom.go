@@ -1,18 +1,48 @@
 package sbom
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
 	log "k8s.io/klog"
 )
 
-func ConvertToGoogleSPDX(bom *cdx.BOM) (*spdx.Document, error) {
+// SPDXConversion is the result of converting a CycloneDX BOM to SPDX.
+type SPDXConversion struct {
+	Document *spdx.Document
+	// SPDXIDs maps the bom-refs of converted components to the IDs of their
+	// SPDX elements. Components without a bom-ref are not listed.
+	SPDXIDs map[string]common.ElementID
+	// LossReport lists the BOM data the conversion dropped or degraded.
+	LossReport *LossReport
+}
+
+// ConvertToGoogleSPDX converts a CycloneDX BOM to an SPDX 2.3 document. Use
+// ConvertCycloneDXToSPDX for the bom-ref mapping and the loss report.
+func ConvertToGoogleSPDX(bom *cdx.BOM, opts ...ConvertOption) (*spdx.Document, error) {
+	conversion, err := ConvertCycloneDXToSPDX(bom, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return conversion.Document, nil
+}
+
+// ConvertCycloneDXToSPDX converts a CycloneDX BOM to an SPDX 2.3 document,
+// returning it with the bom-ref to SPDX ID mapping and a report of the data
+// that did not survive the conversion. Bom-refs are turned into
+// valid, unique SPDX IDs as described for normalizeBOMRefs.
+func ConvertCycloneDXToSPDX(bom *cdx.BOM, opts ...ConvertOption) (*SPDXConversion, error) {
+	options := NewConvertOptions(opts...)
+	bom, bomRefs := normalizeBOMRefs(bom)
+	options.losses = newLossCollector(bomRefs)
 	spdxDoc := spdx.Document{
 		SPDXVersion:    spdx.Version,
 		DataLicense:    "CC0-1.0",
@@ -22,24 +52,17 @@ func ConvertToGoogleSPDX(bom *cdx.BOM) (*spdx.Document, error) {
 	refMap := map[string]cdx.Component{}
 	typeMap := map[string]int{}
 
+	spdxDoc.CreationInfo = spdxCreationInfo(bom.Metadata, options)
 	if bom.Metadata != nil {
-		spdxDoc.CreationInfo = &v2_3.CreationInfo{
-			Created: bom.Metadata.Timestamp,
-		}
 		if bom.Metadata.Component != nil {
 			metaComp := bom.Metadata.Component
 			spdxDoc.DocumentName = metaComp.Name
-			if metaComp.Supplier != nil {
-				spdxDoc.CreationInfo.Creators = []common.Creator{{
-					Creator:     metaComp.Supplier.Name,
-					CreatorType: "Organization",
-				}}
-			}
 			if err := AddCycloneDXComponent(
 				*metaComp,
 				refMap,
 				typeMap,
 				&spdxDoc,
+				options,
 			); err != nil {
 				return nil, fmt.Errorf("failed to add metadata component: %w", err)
 			}
@@ -47,46 +70,90 @@ func ConvertToGoogleSPDX(bom *cdx.BOM) (*spdx.Document, error) {
 	}
 
 	// Build SPDX document namespace.
-	if spdxDoc.DocumentNamespace == "" {
-		serialNumber := strings.TrimPrefix(bom.SerialNumber, "urn:uuid:")
-		if serialNumber != "" && spdxDoc.DocumentName != "" {
-			spdxDoc.DocumentNamespace = fmt.Sprintf("http://spdx.org/spdxdocs/%s-%s",
-				spdxDoc.DocumentName, serialNumber)
-		}
-	}
+	spdxDoc.DocumentNamespace = spdxNamespace(bom, spdxDoc.DocumentName, options)
 
 	if bom.Components != nil {
 		for _, component := range *bom.Components {
-			if err := AddCycloneDXComponent(component, refMap, typeMap, &spdxDoc); err != nil {
+			if err := AddCycloneDXComponent(component, refMap, typeMap, &spdxDoc, options); err != nil {
 				return nil, fmt.Errorf("failed to add component %q: %w", component.BOMRef, err)
 			}
 		}
 	}
 
+	// Add pedigree components, which dependencies may refer to.
+	if err := addCycloneDXPedigreeComponents(bom, refMap, typeMap, &spdxDoc, options); err != nil {
+		return nil, err
+	}
+
 	// Add CycloneDX dependencies to SPDX.
 	if bom.Dependencies != nil {
 		for _, deps := range *bom.Dependencies {
-			if err := AddCycloneDXDependencies(deps, refMap, &spdxDoc); err != nil {
+			if err := AddCycloneDXDependencies(deps, refMap, &spdxDoc, options); err != nil {
 				return nil, fmt.Errorf("failed to add dependencies for ref %q: %w",
 					deps.Ref, err)
 			}
 		}
 	}
 
+	// Map CycloneDX 1.6 provides to SPDX specification relationships.
+	addCycloneDXProvides(bomRefs, refMap, &spdxDoc, options)
+
+	// Map pedigree to lineage relationships.
+	addCycloneDXPedigrees(bom, refMap, &spdxDoc, options)
+
+	// Link the document to the component it is about.
+	var primary *cdx.Component
+	if bom.Metadata != nil && bom.Metadata.Component != nil && options.isSPDXElement(*bom.Metadata.Component) {
+		primary = bom.Metadata.Component
+	}
+	addDescribesRelationships(&spdxDoc, primary, bom.Components, options)
+
+	// Record the BOM level data SPDX has no place for.
+	dropBOMData(bom, bomRefs, refMap, options)
+
+	// Keep properties and annotations as SPDX annotations.
+	addCycloneDXBOMAnnotations(bom, bomRefs, refMap, &spdxDoc, options)
+
+	addPackageVerificationCodes(&spdxDoc)
+
 	log.Infof("Loaded %d components from BOM", len(refMap))
 	log.Infof("TypeMap: %+v", typeMap)
-	return &spdxDoc, nil
+
+	spdxIDs := map[string]common.ElementID{}
+	for bomRef, ref := range bomRefs {
+		if c, ok := refMap[ref]; ok && options.isSPDXElement(c) {
+			spdxIDs[bomRef] = toSPDXElementID(ref)
+		}
+	}
+	return &SPDXConversion{
+		Document:   &spdxDoc,
+		SPDXIDs:    spdxIDs,
+		LossReport: options.losses.report(),
+	}, nil
 }
 
 func SPDXToJSON(spdxDoc *spdx.Document) ([]byte, error) {
 	return json.MarshalIndent(spdxDoc, "", " ")
 }
 
+// SPDXToTagValue encodes an SPDX document in the SPDX tag-value format.
+func SPDXToTagValue(spdxDoc *spdx.Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := spdxtv.Write(withDocumentAnnotations(spdxDoc), &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// AddCycloneDXComponent adds a component and its nested components to the
+// SPDX document as packages with the primary package purpose configured for
+// their type, or as files. Nil opts use DefaultConvertOptions.
 func AddCycloneDXComponent(
 	c cdx.Component,
 	refMap map[string]cdx.Component,
 	typeMap map[string]int,
 	spdxDoc *spdx.Document,
+	opts *ConvertOptions,
 ) error {
 	if _, ok := refMap[c.BOMRef]; ok {
 		return fmt.Errorf("duplicate BOM ref: %q", c.BOMRef)
@@ -94,65 +161,77 @@ func AddCycloneDXComponent(
 
 	refMap[c.BOMRef] = c
 	typeMap[string(c.Type)] += 1
+	opts.trackComponent(c)
 
-	if IsComponentSPDXPackage(c) {
+	if opts.isSPDXFile(c) {
+		spdxDoc.Files = append(spdxDoc.Files, spdxFile(c, spdxDoc, opts))
+		addPersistentIDAnnotations(spdxDoc, c, opts)
+	} else if purpose, ok := opts.isSPDXPackage(c); ok {
 		p := &spdx.Package{
 			PackageSPDXIdentifier: toSPDXElementID(c.BOMRef),
 			PackageName:           c.Name,
 			PackageVersion:        c.Version,
 			PackageDescription:    c.Description,
+			PrimaryPackagePurpose: purpose,
 		}
-		validIdentifier := false
-		if c.PackageURL != "" {
-			p.PackageExternalReferences = append(p.PackageExternalReferences, &spdx.PackageExternalReference{
-				Category: "SECURITY",
-				Locator:  c.PackageURL,
-				RefType:  "purl",
-			})
-			validIdentifier = true
-		}
-		if c.CPE != "" {
-			p.PackageExternalReferences = append(p.PackageExternalReferences, &spdx.PackageExternalReference{
-				Category: "SECURITY",
-				Locator:  c.CPE,
-				RefType:  "cpe22Type",
-			})
-			validIdentifier = true
-		}
-		if !validIdentifier {
+		// Add purl and CPE references.
+		if !addPackageIdentifiers(p, c, opts) {
 			log.Warningf("package %q:%q:%q missing PURL and CPE", c.Name, c.Type, c.MIMEType)
 		}
 
-		// Add supplier information.
-		if c.Supplier != nil {
-			p.PackageSupplier = &common.Supplier{
-				Supplier:     c.Supplier.Name,
-				SupplierType: "NOASSERTION",
-			}
-		}
+		// Add OmniBOR and SWH persistent IDs.
+		p.PackageExternalReferences = append(p.PackageExternalReferences, spdxPersistentIDs(c, opts)...)
 
-		// Add package download location.
-		if c.ExternalReferences != nil {
-			for _, eRef := range *c.ExternalReferences {
-				if eRef.Type == cdx.ERTypeDistribution {
-					p.PackageDownloadLocation = eRef.URL
-				}
-			}
-		}
-		if p.PackageDownloadLocation == "" {
-			p.PackageDownloadLocation = "NOASSERTION"
-		}
+		// Add package checksums.
+		p.PackageChecksums = spdxChecksums(c, opts)
+
+		// Add license information.
+		p.PackageLicenseDeclared, p.PackageLicenseConcluded = spdxLicenses(c, spdxDoc, opts)
 
+		// Add supplier and originator information.
+		addPackageActors(p, c, opts)
+
+		// Add download location, home page and external references.
+		addPackageExternalReferences(p, c, opts)
+
+		// SPDX packages have no MIME type, and copyright text is not
+		// carried over.
+		opts.dropUnmapped(c,
+			unmappedField{"mime-type", c.MIMEType != ""},
+			unmappedField{"copyright", c.Copyright != ""},
+		)
 		spdxDoc.Packages = append(spdxDoc.Packages, p)
 	}
+	if opts.isSPDXElement(c) {
+		// Neither SPDX packages nor files have these.
+		opts.dropUnmapped(c,
+			unmappedField{"group", c.Group != ""},
+			unmappedField{"swid", c.SWID != nil},
+			unmappedField{"modified", c.Modified != nil},
+			unmappedField{"evidence", c.Evidence != nil},
+			unmappedField{"releaseNotes", c.ReleaseNotes != nil},
+			unmappedField{"modelCard", c.ModelCard != nil},
+			unmappedField{"data", c.Data != nil},
+			unmappedField{"cryptoProperties", c.CryptoProperties != nil},
+		)
+		addPropertyAnnotations(spdxDoc, c, opts)
+	}
 
 	// Add nested components.
 	if c.Components != nil {
 		for _, subComponent := range *c.Components {
-			err := AddCycloneDXComponent(subComponent, refMap, typeMap, spdxDoc)
+			err := AddCycloneDXComponent(subComponent, refMap, typeMap, spdxDoc, opts)
 			if err != nil {
 				return fmt.Errorf("failed to add sub-component %q: %w", subComponent.BOMRef, err)
 			}
+			if !opts.isSPDXElement(c) {
+				continue
+			}
+			if !opts.isSPDXElement(subComponent) {
+				opts.lose(c, Loss{"components", LossDropped,
+					fmt.Sprintf("CONTAINS relationship to %q, component not converted", opts.originalRef(subComponent.BOMRef))})
+				continue
+			}
 			// Add contained components with SPDX "CONTAINS" relationships.
 			spdxDoc.Relationships = append(spdxDoc.Relationships, &v2_3.Relationship{
 				RefA:         toSPDXDocElementID(c.BOMRef),
@@ -165,11 +244,16 @@ func AddCycloneDXComponent(
 	return nil
 }
 
-// AddCycloneDXDependencies maps CycloneDX dependencies to SPDX "DEPENDS_ON" relationships.
+// AddCycloneDXDependencies maps CycloneDX dependencies to SPDX dependency
+// relationships, chosen by the scope of the dependency: required components
+// are DEPENDS_ON and optional ones OPTIONAL_DEPENDENCY_OF by default.
+// Dependencies on components that were not converted, such as excluded
+// ones, are dropped.
 func AddCycloneDXDependencies(
 	dependency cdx.Dependency,
 	refMap map[string]cdx.Component,
 	spdxDoc *spdx.Document,
+	opts *ConvertOptions,
 ) error {
 	compA, exists := refMap[dependency.Ref]
 	if !exists {
@@ -187,35 +271,242 @@ func AddCycloneDXDependencies(
 			return fmt.Errorf("missing dependency reference in cdx.components: %q",
 				depRef)
 		}
+		if !opts.isSPDXElement(compA) {
+			continue
+		}
+		if !opts.isSPDXElement(compB) {
+			opts.lose(compA, Loss{"dependencies", LossDropped,
+				fmt.Sprintf("dependency on %q, component not converted", opts.originalRef(compB.BOMRef))})
+			continue
+		}
 
-		spdxDoc.Relationships = append(spdxDoc.Relationships, &v2_3.Relationship{
-			RefA:         toSPDXDocElementID(compA.BOMRef),
-			RefB:         toSPDXDocElementID(compB.BOMRef),
-			Relationship: "DEPENDS_ON",
-		})
+		relationship, _ := opts.dependencyRelationship(compB.Scope)
+		spdxDoc.Relationships = append(spdxDoc.Relationships,
+			spdxDependency(compA.BOMRef, compB.BOMRef, relationship))
 	}
 
 	return nil
 }
 
+// addCycloneDXProvides maps the provides lists of the options to SPDX
+// "SPECIFICATION_FOR" relationships from each provided specification to the
+// component providing it.
+func addCycloneDXProvides(
+	bomRefs map[string]string,
+	refMap map[string]cdx.Component,
+	spdxDoc *spdx.Document,
+	opts *ConvertOptions,
+) {
+	refs := make([]string, 0, len(opts.Provides))
+	for ref := range opts.Provides {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	normalized := func(ref string) string {
+		if id, ok := bomRefs[ref]; ok {
+			return id
+		}
+		return ref
+	}
+	for _, ref := range refs {
+		provider, ok := refMap[normalized(ref)]
+		if !ok {
+			log.Warningf("dropping provides of unknown component %q", ref)
+			continue
+		}
+		for _, specRef := range opts.Provides[ref] {
+			spec, ok := refMap[normalized(specRef)]
+			if !ok {
+				log.Warningf("dropping provides %q -> %q, unknown component", ref, specRef)
+				continue
+			}
+			if !opts.isSPDXElement(provider) || !opts.isSPDXElement(spec) {
+				log.Warningf("dropping provides %q -> %q, component not converted", ref, specRef)
+				continue
+			}
+			spdxDoc.Relationships = append(spdxDoc.Relationships, &v2_3.Relationship{
+				RefA:         toSPDXDocElementID(spec.BOMRef),
+				RefB:         toSPDXDocElementID(provider.BOMRef),
+				Relationship: common.TypeRelationshipSpecificationFor,
+			})
+		}
+	}
+}
+
 // ========== Helper methods =============
 
+// addDescribesRelationships adds "SPDXRef-DOCUMENT DESCRIBES" relationships
+// ahead of all other relationships. The primary component is described if
+// there is one, otherwise the roots of the dependency graph are. When every
+// package is in a dependency cycle there is no root, and the packages of the
+// top-level components are described.
+func addDescribesRelationships(
+	spdxDoc *spdx.Document,
+	primary *cdx.Component,
+	components *[]cdx.Component,
+	opts *ConvertOptions,
+) {
+	var described []common.ElementID
+	if primary != nil {
+		described = []common.ElementID{toSPDXElementID(primary.BOMRef)}
+	} else {
+		described = dependencyRoots(spdxDoc)
+		switch len(described) {
+		case 0:
+			described = topLevelPackages(components, opts)
+			if len(described) == 0 {
+				log.Warningf("BOM has no metadata component or package, not adding a DESCRIBES relationship")
+			} else {
+				log.Warningf("BOM has no metadata component or dependency root, describing %d top-level components",
+					len(described))
+			}
+		case 1:
+			log.Infof("BOM has no metadata component, describing dependency root %q", described[0])
+		default:
+			log.Warningf("BOM has no metadata component, describing %d dependency roots", len(described))
+		}
+	}
+
+	var relationships []*v2_3.Relationship
+	for _, id := range described {
+		relationships = append(relationships, &v2_3.Relationship{
+			RefA:         toSPDXDocElementID("DOCUMENT"),
+			RefB:         common.DocElementID{ElementRefID: id},
+			Relationship: common.TypeRelationshipDescribe,
+		})
+	}
+	spdxDoc.Relationships = append(relationships, spdxDoc.Relationships...)
+}
+
+// topLevelPackages returns the packages converted from the top-level
+// components of a BOM.
+func topLevelPackages(components *[]cdx.Component, opts *ConvertOptions) []common.ElementID {
+	if components == nil {
+		return nil
+	}
+	var ids []common.ElementID
+	for _, c := range *components {
+		if _, ok := opts.isSPDXPackage(c); ok {
+			ids = append(ids, toSPDXElementID(c.BOMRef))
+		}
+	}
+	return ids
+}
+
+// dependencyRoots returns the packages that no other element contains or
+// depends on, in any of the SPDX dependency relationships, or has as its
+// ancestor, descendant or variant. Roots with dependencies are preferred
+// over isolated packages.
+func dependencyRoots(spdxDoc *spdx.Document) []common.ElementID {
+	hasParent := map[common.ElementID]bool{}
+	hasDeps := map[common.ElementID]bool{}
+	for _, r := range spdxDoc.Relationships {
+		switch {
+		case r.Relationship == common.TypeRelationshipContains:
+			hasParent[r.RefB.ElementRefID] = true
+		case r.Relationship == common.TypeRelationshipDependsOn:
+			hasParent[r.RefB.ElementRefID] = true
+			hasDeps[r.RefA.ElementRefID] = true
+		case spdxDependencyOfRelationships[r.Relationship]:
+			hasParent[r.RefA.ElementRefID] = true
+			hasDeps[r.RefB.ElementRefID] = true
+		case r.Relationship == common.TypeRelationshipDescendantOf,
+			r.Relationship == common.TypeRelationshipAncestorOf,
+			r.Relationship == common.TypeRelationshipVariantOf:
+			hasParent[r.RefB.ElementRefID] = true
+		}
+	}
+
+	var roots, withDeps []common.ElementID
+	for _, p := range spdxDoc.Packages {
+		id := p.PackageSPDXIdentifier
+		if hasParent[id] {
+			continue
+		}
+		roots = append(roots, id)
+		if hasDeps[id] {
+			withDeps = append(withDeps, id)
+		}
+	}
+	if len(withDeps) > 0 {
+		return withDeps
+	}
+	return roots
+}
+
+// spdxDependency returns the relationship for a dependency of dependent on
+// dependency. DEPENDS_ON points from the dependent, the *_DEPENDENCY_OF
+// relationships point from the dependency.
+func spdxDependency(dependent, dependency, relationship string) *v2_3.Relationship {
+	if relationship == common.TypeRelationshipDependsOn {
+		return &v2_3.Relationship{
+			RefA:         toSPDXDocElementID(dependent),
+			RefB:         toSPDXDocElementID(dependency),
+			Relationship: relationship,
+		}
+	}
+	return &v2_3.Relationship{
+		RefA:         toSPDXDocElementID(dependency),
+		RefB:         toSPDXDocElementID(dependent),
+		Relationship: relationship,
+	}
+}
+
 func toSPDXDocElementID(bomRef string) common.DocElementID {
 	return common.DocElementID{
 		ElementRefID: toSPDXElementID(bomRef),
 	}
 }
 
+// toSPDXElementID returns the element ID for a bom-ref. tools-golang keeps
+// element IDs without the "SPDXRef-" prefix and adds it when serializing.
+// The bom-ref is sanitized, but only ConvertCycloneDXToSPDX guarantees that
+// distinct bom-refs get distinct IDs.
 func toSPDXElementID(bomRef string) common.ElementID {
-	return common.ElementID(fmt.Sprintf("SPDXRef-%s", bomRef))
+	return common.ElementID(sanitizeSPDXID(bomRef))
 }
 
-func IsComponentSPDXPackage(component cdx.Component) bool {
-	// Keeping it as switch, as we might need to add more cdx component types.
-	switch component.Type {
-	case cdx.ComponentTypeLibrary:
-		return true
-	default:
-		return false
+// spdxChecksums maps component hashes to SPDX checksums, dropping hashes
+// whose algorithm SPDX 2.3 cannot represent.
+func spdxChecksums(c cdx.Component, opts *ConvertOptions) []common.Checksum {
+	if c.Hashes == nil {
+		return nil
+	}
+	var checksums []common.Checksum
+	seen := map[common.ChecksumAlgorithm]string{}
+	for _, h := range *c.Hashes {
+		alg, ok := spdxChecksumAlgorithm(h.Algorithm)
+		if !ok {
+			opts.lose(c, Loss{"hashes", LossDropped,
+				fmt.Sprintf("%s hash, SPDX 2.3 has no equivalent algorithm", h.Algorithm)})
+			continue
+		}
+		value := strings.ToLower(h.Value)
+		if prev, ok := seen[alg]; ok {
+			if prev != value {
+				opts.lose(c, Loss{"hashes", LossDropped, fmt.Sprintf("conflicting %s hash %s", h.Algorithm, h.Value)})
+			}
+			continue
+		}
+		seen[alg] = value
+		checksums = append(checksums, common.Checksum{Algorithm: alg, Value: value})
+	}
+	return checksums
+}
+
+func spdxChecksumAlgorithm(alg cdx.HashAlgorithm) (common.ChecksumAlgorithm, bool) {
+	for spdxAlg, cdxAlg := range spdxChecksumAlgorithms {
+		if cdxAlg == alg {
+			return spdxAlg, true
+		}
 	}
+	return "", false
+}
+
+// IsComponentSPDXPackage reports whether the default options convert the
+// component to an SPDX package.
+func IsComponentSPDXPackage(component cdx.Component) bool {
+	_, ok := defaultConvertOptions.packagePurpose(component.Type)
+	return ok
 }
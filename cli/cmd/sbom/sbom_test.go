@@ -1,13 +1,17 @@
 package sbom
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddCycloneDXComponents(t *testing.T) {
@@ -25,7 +29,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			PackageURL:  "pkg:npm/test@1.0.0",
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
@@ -59,7 +63,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			Type:   cdx.ComponentTypeLibrary,
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err == nil {
 			t.Error("Expected error for duplicate BOM ref, got nil")
@@ -80,7 +84,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			Name:   "test-file",
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
@@ -112,7 +116,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			Components: emptyComponents,
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
@@ -145,7 +149,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			Components: emptyComponents,
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
@@ -166,8 +170,8 @@ func TestAddCycloneDXComponents(t *testing.T) {
 				component.Supplier.Name, pkg.PackageSupplier.Supplier)
 		}
 
-		if pkg.PackageSupplier.SupplierType != "NOASSERTION" {
-			t.Fatalf("Expected package supplier type to be NOASSERTION, got %q",
+		if pkg.PackageSupplier.SupplierType != "Organization" {
+			t.Fatalf("Expected package supplier type to be Organization, got %q",
 				pkg.PackageSupplier.SupplierType)
 		}
 	})
@@ -191,7 +195,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			Components: emptyComponents,
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
@@ -224,7 +228,7 @@ func TestAddCycloneDXComponents(t *testing.T) {
 			},
 		}
 
-		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc)
+		err := AddCycloneDXComponent(component, refMap, typeMap, spdxDoc, nil)
 		if err != nil {
 			t.Errorf("AddCycloneDXComponents() error = %v, want nil", err)
 		}
@@ -279,6 +283,32 @@ func TestAddCycloneDXDependencies(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "optional and excluded dependencies",
+			dependency: cdx.Dependency{
+				Ref:          "componentA",
+				Dependencies: &[]string{"componentB", "componentC", "componentD"},
+			},
+			componentMap: map[string]cdx.Component{
+				"componentA": {BOMRef: "componentA"},
+				"componentB": {BOMRef: "componentB", Scope: cdx.ScopeRequired},
+				"componentC": {BOMRef: "componentC", Scope: cdx.ScopeOptional},
+				"componentD": {BOMRef: "componentD", Scope: cdx.ScopeExcluded},
+			},
+			expectedErr: "",
+			expectedRels: []*v2_3.Relationship{
+				{
+					RefA:         toSPDXDocElementID("componentA"),
+					RefB:         toSPDXDocElementID("componentB"),
+					Relationship: "DEPENDS_ON",
+				},
+				{
+					RefA:         toSPDXDocElementID("componentC"),
+					RefB:         toSPDXDocElementID("componentA"),
+					Relationship: "OPTIONAL_DEPENDENCY_OF",
+				},
+			},
+		},
 		{
 			name: "missing source component",
 			dependency: cdx.Dependency{
@@ -321,7 +351,7 @@ func TestAddCycloneDXDependencies(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			doc := &v2_3.Document{}
 
-			err := AddCycloneDXDependencies(tc.dependency, tc.componentMap, doc)
+			err := AddCycloneDXDependencies(tc.dependency, tc.componentMap, doc, nil)
 
 			if tc.expectedErr != "" {
 				assert.EqualError(t, err, tc.expectedErr)
@@ -332,3 +362,123 @@ func TestAddCycloneDXDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestSPDXToTagValue(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: "2025-01-02T03:04:05Z",
+		Component: &cdx.Component{
+			BOMRef: "root",
+			Type:   cdx.ComponentTypeLibrary,
+			Name:   "network-os",
+			Components: &[]cdx.Component{{
+				BOMRef:     "lib",
+				Type:       cdx.ComponentTypeLibrary,
+				Name:       "lib",
+				Version:    "1.0.0",
+				PackageURL: "pkg:golang/example.com/lib@v1.0.0",
+			}},
+		},
+	}
+	bom.Dependencies = &[]cdx.Dependency{{Ref: "root", Dependencies: &[]string{"lib"}}}
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	b, err := SPDXToTagValue(spdxDoc)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "SPDXID: SPDXRef-lib\n")
+	assert.Contains(t, string(b), "Relationship: SPDXRef-root CONTAINS SPDXRef-lib\n")
+
+	got, err := spdxtv.Read(bytes.NewReader(b))
+	require.NoError(t, err)
+	want, err := SPDXToJSON(spdxDoc)
+	require.NoError(t, err)
+	gotJSON, err := SPDXToJSON(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(gotJSON))
+}
+
+func TestSPDXChecksums(t *testing.T) {
+	c := cdx.Component{
+		BOMRef: "image",
+		Hashes: &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"},
+			{Algorithm: cdx.HashAlgoSHA3_512, Value: "abc"},
+			{Algorithm: cdx.HashAlgoBlake2b_256, Value: "def"},
+			{Algorithm: cdx.HashAlgoBlake3, Value: "123"},
+			{Algorithm: cdx.HashAlgoSHA1, Value: "456"},
+			{Algorithm: "STREEBOG-256", Value: "789"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "0000"},
+		},
+	}
+
+	assert.Equal(t, []common.Checksum{
+		{Algorithm: common.SHA256, Value: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+		{Algorithm: common.SHA3_512, Value: "abc"},
+		{Algorithm: common.BLAKE2b_256, Value: "def"},
+		{Algorithm: common.BLAKE3, Value: "123"},
+		{Algorithm: common.SHA1, Value: "456"},
+	}, spdxChecksums(c, nil))
+	assert.Nil(t, spdxChecksums(cdx.Component{}, nil))
+}
+
+func TestDescribesRelationship(t *testing.T) {
+	describes := func(spdxDoc *spdx.Document) []string {
+		var ids []string
+		for _, r := range spdxDoc.Relationships {
+			if r.Relationship == "DESCRIBES" {
+				assert.Equal(t, toSPDXDocElementID("DOCUMENT"), r.RefA)
+				ids = append(ids, string(r.RefB.ElementRefID))
+			}
+		}
+		return ids
+	}
+	components := &[]cdx.Component{
+		{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "app"},
+		{BOMRef: "lib", Type: cdx.ComponentTypeLibrary, Name: "lib"},
+		{BOMRef: "tool", Type: cdx.ComponentTypeApplication, Name: "tool"},
+	}
+
+	t.Run("metadata component", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{BOMRef: "root", Type: cdx.ComponentTypeFirmware}}
+		bom.Components = components
+		spdxDoc, err := ConvertToGoogleSPDX(bom)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"root"}, describes(spdxDoc))
+		assert.Equal(t, "DESCRIBES", spdxDoc.Relationships[0].Relationship)
+	})
+
+	t.Run("dependency graph root", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Components = components
+		bom.Dependencies = &[]cdx.Dependency{{Ref: "app", Dependencies: &[]string{"lib"}}}
+		spdxDoc, err := ConvertToGoogleSPDX(bom)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app"}, describes(spdxDoc))
+	})
+
+	t.Run("excluded metadata component without dependencies", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{BOMRef: "root", Type: cdx.ComponentTypeFirmware}}
+		bom.Components = components
+		spdxDoc, err := ConvertToGoogleSPDX(bom, WithPackagePurposes(map[cdx.ComponentType]string{
+			cdx.ComponentTypeFirmware: "",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app", "lib", "tool"}, describes(spdxDoc))
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Components = &[]cdx.Component{{BOMRef: "a"}, {BOMRef: "b"}}
+		bom.Dependencies = &[]cdx.Dependency{
+			{Ref: "a", Dependencies: &[]string{"b"}},
+			{Ref: "b", Dependencies: &[]string{"a"}},
+		}
+		spdxDoc, err := ConvertToGoogleSPDX(bom)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, describes(spdxDoc))
+	})
+}
@@ -0,0 +1,678 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+const (
+	// SPDX3Version is the SPDX 3 specification version written by
+	// ConvertToSPDX3.
+	SPDX3Version = "3.0.1"
+	// SPDX3Context is the JSON-LD context for SPDX3Version documents.
+	SPDX3Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+	spdx3CreationInfoID = "_:creationinfo"
+	spdx3Terms          = "https://spdx.org/rdf/3.0.1/terms/"
+)
+
+// SPDX3Document is an SPDX 3 JSON-LD document. Graph holds the serialized
+// elements: SPDX3CreationInfo, SPDX3Agent, SPDX3SpdxDocument, SPDX3Sbom,
+// SPDX3Package, SPDX3File, SPDX3LicenseExpression and SPDX3Relationship.
+type SPDX3Document struct {
+	Context string `json:"@context"`
+	Graph   []any  `json:"@graph"`
+}
+
+// SPDX3CreationInfo is the creation information shared by all elements.
+type SPDX3CreationInfo struct {
+	Type         string   `json:"type"`
+	ID           string   `json:"@id"`
+	SpecVersion  string   `json:"specVersion"`
+	Created      string   `json:"created,omitempty"`
+	CreatedBy    []string `json:"createdBy"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+}
+
+// SPDX3Element holds the Core profile properties common to all elements.
+type SPDX3Element struct {
+	Type               string                    `json:"type"`
+	SPDXID             string                    `json:"spdxId"`
+	CreationInfo       string                    `json:"creationInfo"`
+	Name               string                    `json:"name,omitempty"`
+	Summary            string                    `json:"summary,omitempty"`
+	Description        string                    `json:"description,omitempty"`
+	Comment            string                    `json:"comment,omitempty"`
+	VerifiedUsing      []SPDX3Hash               `json:"verifiedUsing,omitempty"`
+	ExternalRef        []SPDX3ExternalRef        `json:"externalRef,omitempty"`
+	ExternalIdentifier []SPDX3ExternalIdentifier `json:"externalIdentifier,omitempty"`
+}
+
+// SPDX3Hash is an integrity method holding a hash value.
+type SPDX3Hash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+// SPDX3ExternalRef is a reference to a resource outside the document.
+type SPDX3ExternalRef struct {
+	Type            string   `json:"type"`
+	ExternalRefType string   `json:"externalRefType"`
+	Locator         []string `json:"locator"`
+	Comment         string   `json:"comment,omitempty"`
+}
+
+// SPDX3ExternalIdentifier is an identifier assigned outside the document,
+// such as a CPE.
+type SPDX3ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+	Comment                string `json:"comment,omitempty"`
+}
+
+// SPDX3Agent is a Person, Organization or Tool.
+type SPDX3Agent struct {
+	SPDX3Element
+}
+
+// SPDX3SpdxDocument is the SpdxDocument element wrapping the SBOM.
+type SPDX3SpdxDocument struct {
+	SPDX3Element
+	DataLicense        string   `json:"dataLicense,omitempty"`
+	ProfileConformance []string `json:"profileConformance"`
+	RootElement        []string `json:"rootElement"`
+	Element            []string `json:"element"`
+}
+
+// SPDX3Sbom is a software_Sbom element.
+type SPDX3Sbom struct {
+	SPDX3Element
+	RootElement []string `json:"rootElement,omitempty"`
+	Element     []string `json:"element"`
+}
+
+// SPDX3Package is a software_Package element.
+type SPDX3Package struct {
+	SPDX3Element
+	SuppliedBy       string   `json:"suppliedBy,omitempty"`
+	OriginatedBy     []string `json:"originatedBy,omitempty"`
+	BuiltTime        string   `json:"builtTime,omitempty"`
+	ReleaseTime      string   `json:"releaseTime,omitempty"`
+	ValidUntilTime   string   `json:"validUntilTime,omitempty"`
+	PrimaryPurpose   string   `json:"software_primaryPurpose,omitempty"`
+	CopyrightText    string   `json:"software_copyrightText,omitempty"`
+	PackageVersion   string   `json:"software_packageVersion,omitempty"`
+	DownloadLocation string   `json:"software_downloadLocation,omitempty"`
+	PackageURL       string   `json:"software_packageUrl,omitempty"`
+	HomePage         string   `json:"software_homePage,omitempty"`
+	SourceInfo       string   `json:"software_sourceInfo,omitempty"`
+}
+
+// SPDX3File is a software_File element.
+type SPDX3File struct {
+	SPDX3Element
+	CopyrightText string `json:"software_copyrightText,omitempty"`
+}
+
+// SPDX3LicenseExpression is a simplelicensing_LicenseExpression element.
+type SPDX3LicenseExpression struct {
+	SPDX3Element
+	LicenseExpression string `json:"simplelicensing_licenseExpression"`
+}
+
+// SPDX3Relationship is a Relationship, or a LifecycleScopedRelationship when
+// Scope is set.
+type SPDX3Relationship struct {
+	SPDX3Element
+	From             string   `json:"from"`
+	RelationshipType string   `json:"relationshipType"`
+	To               []string `json:"to"`
+	Scope            string   `json:"scope,omitempty"`
+}
+
+// ConvertToSPDX3 converts an SPDX 2.3 document, such as the output of
+// ConvertToGoogleSPDX, into an SPDX 3 JSON-LD document using the Core,
+// Software and SimpleLicensing profiles.
+//
+// Element IDs are built from the document namespace and the SPDX 2.3
+// identifiers. The elements SPDX 2.3 has no identifiers for, such as the SBOM,
+// agents, licenses and relationships, get generated IDs that are made unique
+// against the SPDX 2.3 ones with a numeric suffix. Relationship types are mapped following the SPDX 3 migration
+// guide, with unmapped types written as "other".
+func ConvertToSPDX3(spdxDoc *spdx.Document) (*SPDX3Document, error) {
+	if spdxDoc == nil {
+		return nil, fmt.Errorf("missing SPDX document")
+	}
+	namespace := spdxDoc.DocumentNamespace
+	if namespace == "" {
+		namespace = "urn:uuid:" + uuid.NewString()
+		log.Warningf("SPDX document has no namespace, using %q", namespace)
+	}
+	c := &spdx3Converter{
+		namespace: namespace,
+		agents:    map[string]string{},
+		licenses:  map[string]string{},
+		refs:      reservedSPDX3Refs(spdxDoc),
+	}
+
+	creationInfo := &SPDX3CreationInfo{
+		Type:        "CreationInfo",
+		ID:          spdx3CreationInfoID,
+		SpecVersion: SPDX3Version,
+	}
+	c.graph = append(c.graph, creationInfo)
+	if spdxDoc.CreationInfo != nil {
+		creationInfo.Created = spdxDoc.CreationInfo.Created
+		creationInfo.Comment = spdxDoc.CreationInfo.CreatorComment
+		for _, creator := range spdxDoc.CreationInfo.Creators {
+			id := c.agent(creator.CreatorType, creator.Creator)
+			if id == "" {
+				log.Warningf("dropping creator %q of unknown type %q", creator.Creator, creator.CreatorType)
+				continue
+			}
+			if creator.CreatorType == "Tool" {
+				creationInfo.CreatedUsing = append(creationInfo.CreatedUsing, id)
+			} else {
+				creationInfo.CreatedBy = append(creationInfo.CreatedBy, id)
+			}
+		}
+	}
+	if len(creationInfo.CreatedBy) == 0 {
+		creationInfo.CreatedBy = []string{spdx3Terms + "Core/SpdxOrganization"}
+	}
+
+	var elements []string
+	for _, p := range spdxDoc.Packages {
+		if p == nil {
+			continue
+		}
+		elements = append(elements, c.addPackage(p))
+	}
+	files := append([]*spdx.File{}, spdxDoc.Files...)
+	for _, p := range spdxDoc.Packages {
+		if p != nil {
+			files = append(files, p.Files...)
+		}
+	}
+	seenFiles := map[common.ElementID]bool{}
+	for _, f := range files {
+		if f == nil || seenFiles[f.FileSPDXIdentifier] {
+			continue
+		}
+		seenFiles[f.FileSPDXIdentifier] = true
+		elements = append(elements, c.addFile(f))
+	}
+	for _, p := range spdxDoc.Packages {
+		if p == nil {
+			continue
+		}
+		for _, f := range p.Files {
+			if f != nil {
+				c.addRelationship(c.elementID(p.PackageSPDXIdentifier), "contains",
+					[]string{c.elementID(f.FileSPDXIdentifier)}, "", "")
+			}
+		}
+	}
+
+	docID := c.elementID(spdxDoc.SPDXIdentifier)
+	var roots []string
+	for _, r := range spdxDoc.Relationships {
+		if r == nil {
+			continue
+		}
+		from, to := c.docElementID(r.RefA), c.docElementID(r.RefB)
+		relType, scope, swap, ok := spdx3RelationshipType(r.Relationship)
+		if !ok {
+			log.Warningf("mapping relationship type %q to other", r.Relationship)
+			relType = "other"
+		}
+		if swap {
+			from, to = to, from
+		}
+		if relType == "describes" && from == docID {
+			// The document itself becomes the SBOM element's root.
+			roots = append(roots, to)
+			continue
+		}
+		comment := r.RelationshipComment
+		if !ok {
+			comment = strings.TrimSpace(r.Relationship + " " + comment)
+		}
+		c.addRelationship(from, relType, []string{to}, scope, comment)
+	}
+	if p := namedRootPackage(spdxDoc); len(roots) == 0 && p != nil {
+		roots = append(roots, c.elementID(p.PackageSPDXIdentifier))
+	}
+	elements = append(elements, c.relationships...)
+	elements = append(elements, c.agentIDs...)
+	elements = append(elements, c.licenseIDs...)
+
+	sbomID := c.generatedID("SBOM")
+	c.graph = append(c.graph, &SPDX3Sbom{
+		SPDX3Element: SPDX3Element{
+			Type:         "software_Sbom",
+			SPDXID:       sbomID,
+			CreationInfo: spdx3CreationInfoID,
+			Name:         spdxDoc.DocumentName,
+		},
+		RootElement: roots,
+		Element:     elements,
+	})
+	c.graph = append(c.graph, &SPDX3SpdxDocument{
+		SPDX3Element: SPDX3Element{
+			Type:         "SpdxDocument",
+			SPDXID:       docID,
+			CreationInfo: spdx3CreationInfoID,
+			Name:         spdxDoc.DocumentName,
+			Comment:      spdxDoc.DocumentComment,
+		},
+		DataLicense:        "https://spdx.org/licenses/" + spdxDoc.DataLicense,
+		ProfileConformance: []string{"core", "software", "simpleLicensing"},
+		RootElement:        []string{sbomID},
+		Element:            append([]string{sbomID}, elements...),
+	})
+
+	return &SPDX3Document{Context: SPDX3Context, Graph: c.graph}, nil
+}
+
+// SPDX3ToJSON encodes an SPDX 3 document as indented JSON-LD.
+func SPDX3ToJSON(doc *SPDX3Document) ([]byte, error) {
+	return json.MarshalIndent(doc, "", " ")
+}
+
+// spdx3Converter accumulates the graph of an SPDX 3 document.
+type spdx3Converter struct {
+	namespace     string
+	graph         []any
+	relationships []string
+	// agents and licenses map SPDX 2.3 values to the element created for
+	// them, so each is only written once.
+	agents     map[string]string
+	agentIDs   []string
+	licenses   map[string]string
+	licenseIDs []string
+	// refs holds the SPDX 2.3 identifiers and the generated ones.
+	refs *spdxRefs
+}
+
+// reservedSPDX3Refs returns the refs of the document's identifiers, which
+// generated IDs must not take.
+func reservedSPDX3Refs(spdxDoc *spdx.Document) *spdxRefs {
+	r := &spdxRefs{taken: map[string]bool{fromSPDXElementID(spdxDoc.SPDXIdentifier): true}}
+	for _, p := range spdxDoc.Packages {
+		if p == nil {
+			continue
+		}
+		r.taken[fromSPDXElementID(p.PackageSPDXIdentifier)] = true
+		for _, f := range p.Files {
+			if f != nil {
+				r.taken[fromSPDXElementID(f.FileSPDXIdentifier)] = true
+			}
+		}
+	}
+	for _, f := range spdxDoc.Files {
+		if f != nil {
+			r.taken[fromSPDXElementID(f.FileSPDXIdentifier)] = true
+		}
+	}
+	for _, rel := range spdxDoc.Relationships {
+		if rel == nil {
+			continue
+		}
+		for _, id := range []common.DocElementID{rel.RefA, rel.RefB} {
+			if id.DocumentRefID == "" && id.SpecialID == "" {
+				r.taken[fromSPDXElementID(id.ElementRefID)] = true
+			}
+		}
+	}
+	return r
+}
+
+// generatedID returns a new element ID for an element without an SPDX 2.3
+// identifier.
+func (c *spdx3Converter) generatedID(base string) string {
+	return c.elementID(common.ElementID(c.refs.unique(base)))
+}
+
+func (c *spdx3Converter) elementID(id common.ElementID) string {
+	return c.namespace + "#" + common.RenderElementID(common.ElementID(fromSPDXElementID(id)))
+}
+
+func (c *spdx3Converter) docElementID(id common.DocElementID) string {
+	switch id.SpecialID {
+	case "NONE":
+		return spdx3Terms + "Core/NoneElement"
+	case "NOASSERTION":
+		return spdx3Terms + "Core/NoAssertionElement"
+	}
+	if id.DocumentRefID != "" {
+		return common.RenderDocElementID(id)
+	}
+	return c.elementID(id.ElementRefID)
+}
+
+// agent returns the element ID for an SPDX 2.3 actor, creating the Person,
+// Organization or Tool element on first use.
+func (c *spdx3Converter) agent(actorType, actor string) string {
+	var elementType string
+	switch actorType {
+	case "Person", "Organization", "Tool":
+		elementType = actorType
+	default:
+		return ""
+	}
+	key := actorType + ": " + actor
+	if id, ok := c.agents[key]; ok {
+		return id
+	}
+	id := c.generatedID(fmt.Sprintf("%s-%d", elementType, len(c.agentIDs)+1))
+	name, email := splitNameEmail(actor)
+	agent := &SPDX3Agent{SPDX3Element{
+		Type:         elementType,
+		SPDXID:       id,
+		CreationInfo: spdx3CreationInfoID,
+		Name:         name,
+	}}
+	if email != "" {
+		agent.ExternalIdentifier = []SPDX3ExternalIdentifier{{
+			Type:                   "ExternalIdentifier",
+			ExternalIdentifierType: "email",
+			Identifier:             email,
+		}}
+	}
+	c.agents[key] = id
+	c.agentIDs = append(c.agentIDs, id)
+	c.graph = append(c.graph, agent)
+	return id
+}
+
+// license returns the element ID for a license expression, or "" for
+// NONE and NOASSERTION.
+func (c *spdx3Converter) license(expression string) string {
+	if !isSPDXValue(expression) {
+		return ""
+	}
+	if id, ok := c.licenses[expression]; ok {
+		return id
+	}
+	id := c.generatedID(fmt.Sprintf("License-%d", len(c.licenseIDs)+1))
+	c.graph = append(c.graph, &SPDX3LicenseExpression{
+		SPDX3Element: SPDX3Element{
+			Type:         "simplelicensing_LicenseExpression",
+			SPDXID:       id,
+			CreationInfo: spdx3CreationInfoID,
+		},
+		LicenseExpression: expression,
+	})
+	c.licenses[expression] = id
+	c.licenseIDs = append(c.licenseIDs, id)
+	return id
+}
+
+func (c *spdx3Converter) addRelationship(from, relType string, to []string, scope, comment string) {
+	r := &SPDX3Relationship{
+		SPDX3Element: SPDX3Element{
+			Type:         "Relationship",
+			SPDXID:       c.generatedID(fmt.Sprintf("Relationship-%d", len(c.relationships)+1)),
+			CreationInfo: spdx3CreationInfoID,
+			Comment:      comment,
+		},
+		From:             from,
+		RelationshipType: relType,
+		To:               to,
+		Scope:            scope,
+	}
+	if scope != "" {
+		r.Type = "LifecycleScopedRelationship"
+	}
+	c.relationships = append(c.relationships, r.SPDXID)
+	c.graph = append(c.graph, r)
+}
+
+func (c *spdx3Converter) addLicenses(id, declared, concluded string) {
+	if l := c.license(declared); l != "" {
+		c.addRelationship(id, "hasDeclaredLicense", []string{l}, "", "")
+	}
+	if l := c.license(concluded); l != "" {
+		c.addRelationship(id, "hasConcludedLicense", []string{l}, "", "")
+	}
+}
+
+func (c *spdx3Converter) addPackage(p *spdx.Package) string {
+	id := c.elementID(p.PackageSPDXIdentifier)
+	pkg := &SPDX3Package{
+		SPDX3Element: SPDX3Element{
+			Type:          "software_Package",
+			SPDXID:        id,
+			CreationInfo:  spdx3CreationInfoID,
+			Name:          p.PackageName,
+			Summary:       p.PackageSummary,
+			Description:   p.PackageDescription,
+			Comment:       p.PackageComment,
+			VerifiedUsing: spdx3Hashes(p.PackageChecksums),
+		},
+		BuiltTime:      p.BuiltDate,
+		ReleaseTime:    p.ReleaseDate,
+		ValidUntilTime: p.ValidUntilDate,
+		PackageVersion: p.PackageVersion,
+		SourceInfo:     p.PackageSourceInfo,
+	}
+	if purpose := p.PrimaryPackagePurpose; purpose != "" {
+		pkg.PrimaryPurpose = spdx3Purposes[purpose]
+		if pkg.PrimaryPurpose == "" {
+			pkg.PrimaryPurpose = strings.ToLower(purpose)
+		}
+	}
+	if isSPDXValue(p.PackageCopyrightText) {
+		pkg.CopyrightText = p.PackageCopyrightText
+	}
+	if isSPDXValue(p.PackageDownloadLocation) {
+		pkg.DownloadLocation = p.PackageDownloadLocation
+	}
+	if isSPDXValue(p.PackageHomePage) {
+		pkg.HomePage = p.PackageHomePage
+	}
+	if s := p.PackageSupplier; s != nil && isSPDXValue(s.Supplier) {
+		pkg.SuppliedBy = c.agent(s.SupplierType, s.Supplier)
+		if pkg.SuppliedBy == "" {
+			pkg.SuppliedBy = c.agent("Organization", s.Supplier)
+		}
+	}
+	if o := p.PackageOriginator; o != nil && isSPDXValue(o.Originator) {
+		originator := c.agent(o.OriginatorType, o.Originator)
+		if originator == "" {
+			originator = c.agent("Organization", o.Originator)
+		}
+		pkg.OriginatedBy = []string{originator}
+	}
+
+	for _, ref := range p.PackageExternalReferences {
+		if ref == nil {
+			continue
+		}
+		if ref.RefType == "purl" && pkg.PackageURL == "" {
+			pkg.PackageURL = ref.Locator
+			continue
+		}
+		if idType, ok := spdx3IdentifierTypes[ref.RefType]; ok {
+			pkg.ExternalIdentifier = append(pkg.ExternalIdentifier, SPDX3ExternalIdentifier{
+				Type:                   "ExternalIdentifier",
+				ExternalIdentifierType: idType,
+				Identifier:             ref.Locator,
+				Comment:                ref.ExternalRefComment,
+			})
+			continue
+		}
+		refType, ok := spdx3ExternalRefTypes[ref.RefType]
+		comment := ref.ExternalRefComment
+		if !ok {
+			refType = "other"
+			comment = strings.TrimSpace(fmt.Sprintf("%s %s %s", ref.Category, ref.RefType, comment))
+		}
+		pkg.ExternalRef = append(pkg.ExternalRef, SPDX3ExternalRef{
+			Type:            "ExternalRef",
+			ExternalRefType: refType,
+			Locator:         []string{ref.Locator},
+			Comment:         comment,
+		})
+	}
+
+	c.graph = append(c.graph, pkg)
+	c.addLicenses(id, p.PackageLicenseDeclared, p.PackageLicenseConcluded)
+	return id
+}
+
+func (c *spdx3Converter) addFile(f *spdx.File) string {
+	id := c.elementID(f.FileSPDXIdentifier)
+	file := &SPDX3File{
+		SPDX3Element: SPDX3Element{
+			Type:          "software_File",
+			SPDXID:        id,
+			CreationInfo:  spdx3CreationInfoID,
+			Name:          f.FileName,
+			Comment:       f.FileComment,
+			VerifiedUsing: spdx3Hashes(f.Checksums),
+		},
+	}
+	if isSPDXValue(f.FileCopyrightText) {
+		file.CopyrightText = f.FileCopyrightText
+	}
+	c.graph = append(c.graph, file)
+	c.addLicenses(id, "", f.LicenseConcluded)
+	return id
+}
+
+func spdx3Hashes(checksums []common.Checksum) []SPDX3Hash {
+	var hashes []SPDX3Hash
+	for _, cs := range checksums {
+		alg, ok := spdx3HashAlgorithms[cs.Algorithm]
+		if !ok {
+			log.Warningf("dropping checksum with unknown algorithm %q", cs.Algorithm)
+			continue
+		}
+		hashes = append(hashes, SPDX3Hash{Type: "Hash", Algorithm: alg, HashValue: cs.Value})
+	}
+	return hashes
+}
+
+// spdx3RelationshipType returns the SPDX 3 relationship type and lifecycle
+// scope for an SPDX 2.3 relationship type, and whether the direction of the
+// relationship is reversed.
+func spdx3RelationshipType(relType string) (string, string, bool, bool) {
+	if t, ok := spdx3Relationships[relType]; ok {
+		return t.relType, "", false, true
+	}
+	if t, ok := spdx3ReversedRelationships[relType]; ok {
+		return t.relType, t.scope, true, true
+	}
+	return "", "", false, false
+}
+
+// ========== Enum mappings =============
+
+type spdx3RelationshipMapping struct {
+	relType string
+	scope   string
+}
+
+var spdx3Relationships = map[string]spdx3RelationshipMapping{
+	common.TypeRelationshipDescribe:             {relType: "describes"},
+	common.TypeRelationshipContains:             {relType: "contains"},
+	common.TypeRelationshipDependsOn:            {relType: "dependsOn"},
+	common.TypeRelationshipAncestorOf:           {relType: "ancestorOf"},
+	common.TypeRelationshipDescendantOf:         {relType: "descendantOf"},
+	common.TypeRelationshipGenerates:            {relType: "generates"},
+	common.TypeRelationshipStaticLink:           {relType: "hasStaticLink"},
+	common.TypeRelationshipDynamicLink:          {relType: "hasDynamicLink"},
+	common.TypeRelationshipHasPrerequisite:      {relType: "hasPrerequisite"},
+	common.TypeRelationshipDistributionArtifact: {relType: "hasDistributionArtifact"},
+	common.TypeRelationshipOther:                {relType: "other"},
+}
+
+// spdx3ReversedRelationships are SPDX 2.3 types whose SPDX 3 equivalent
+// points the other way.
+var spdx3ReversedRelationships = map[string]spdx3RelationshipMapping{
+	common.TypeRelationshipDescribeBy:                {relType: "describes"},
+	common.TypeRelationshipContainedBy:               {relType: "contains"},
+	common.TypeRelationshipDependencyOf:              {relType: "dependsOn"},
+	common.TypeRelationshipVariantOf:                 {relType: "hasVariant"},
+	common.TypeRelationshipGeneratedFrom:             {relType: "generates"},
+	common.TypeRelationshipPatchFor:                  {relType: "patchedBy"},
+	common.TypeRelationshipPatchApplied:              {relType: "patchedBy"},
+	common.TypeRelationshipCopyOf:                    {relType: "copiedTo"},
+	common.TypeRelationshipExpandedFromArchive:       {relType: "expandsTo"},
+	common.TypeRelationshipFileAdded:                 {relType: "hasAddedFile"},
+	common.TypeRelationshipFileDeleted:               {relType: "hasDeletedFile"},
+	common.TypeRelationshipDocumentationOf:           {relType: "hasDocumentation"},
+	common.TypeRelationshipTestOf:                    {relType: "hasTest"},
+	common.TypeRelationshipTestCaseOf:                {relType: "hasTestCase"},
+	common.TypeRelationshipExampleOf:                 {relType: "hasExample"},
+	common.TypeRelationshipDataFileOf:                {relType: "hasDataFile"},
+	common.TypeRelationshipMetafileOf:                {relType: "hasMetadata"},
+	common.TypeRelationshipPrerequisiteFor:           {relType: "hasPrerequisite"},
+	common.TypeRelationshipOptionalComponentOf:       {relType: "hasOptionalComponent"},
+	common.TypeRelationshipPackageOf:                 {relType: "packagedBy"},
+	"DEPENDENCY_MANIFEST_OF":                         {relType: "hasDependencyManifest"},
+	common.TypeRelationshipAmends:                    {relType: "amendedBy"},
+	common.TypeRelationshipOptionalDependencyOf:      {relType: "hasOptionalDependency"},
+	common.TypeRelationshipProvidedDependencyOf:      {relType: "hasProvidedDependency"},
+	common.TypeRelationshipRequirementDescriptionFor: {relType: "hasRequirement"},
+	common.TypeRelationshipSpecificationFor:          {relType: "hasSpecification"},
+	common.TypeRelationshipBuildDependencyOf:         {relType: "dependsOn", scope: "build"},
+	common.TypeRelationshipDevDependencyOf:           {relType: "dependsOn", scope: "development"},
+	common.TypeRelationshipRuntimeDependencyOf:       {relType: "dependsOn", scope: "runtime"},
+	common.TypeRelationshipTestDependencyOf:          {relType: "dependsOn", scope: "test"},
+	common.TypeRelationshipBuildToolOf:               {relType: "usesTool", scope: "build"},
+	common.TypeRelationshipDevToolOf:                 {relType: "usesTool", scope: "development"},
+	common.TypeRelationshipTestToolOf:                {relType: "usesTool", scope: "test"},
+}
+
+var spdx3HashAlgorithms = map[common.ChecksumAlgorithm]string{
+	common.ADLER32:     "adler32",
+	common.BLAKE2b_256: "blake2b256",
+	common.BLAKE2b_384: "blake2b384",
+	common.BLAKE2b_512: "blake2b512",
+	common.BLAKE3:      "blake3",
+	common.MD2:         "md2",
+	common.MD4:         "md4",
+	common.MD5:         "md5",
+	common.MD6:         "md6",
+	common.SHA1:        "sha1",
+	common.SHA224:      "sha224",
+	common.SHA256:      "sha256",
+	common.SHA384:      "sha384",
+	common.SHA512:      "sha512",
+	common.SHA3_256:    "sha3_256",
+	common.SHA3_384:    "sha3_384",
+	common.SHA3_512:    "sha3_512",
+}
+
+var spdx3Purposes = map[string]string{
+	"OPERATING-SYSTEM": "operatingSystem",
+}
+
+var spdx3IdentifierTypes = map[string]string{
+	"cpe22Type": "cpe22",
+	"cpe23Type": "cpe23",
+	"swh":       "swhid",
+	"gitoid":    "gitoid",
+}
+
+var spdx3ExternalRefTypes = map[string]string{
+	"advisory":      "securityAdvisory",
+	"fix":           "securityFix",
+	"url":           "securityOther",
+	"maven-central": "mavenCentral",
+	"npm":           "npm",
+	"nuget":         "nuget",
+	"bower":         "bower",
+}
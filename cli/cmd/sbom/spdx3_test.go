@@ -0,0 +1,216 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToSPDX3(t *testing.T) {
+	const ns = "https://example.com/spdxdocs/network-os"
+	spdxDoc := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       "CC0-1.0",
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "network-os",
+		DocumentNamespace: ns,
+		CreationInfo: &spdx.CreationInfo{
+			Created: "2025-01-02T03:04:05Z",
+			Creators: []common.Creator{
+				{Creator: "sbom-generator-1.2.3", CreatorType: "Tool"},
+				{Creator: "Example Networks (sbom@example.com)", CreatorType: "Organization"},
+			},
+		},
+		Packages: []*spdx.Package{
+			{
+				PackageSPDXIdentifier:   "root",
+				PackageName:             "network-os",
+				PackageDownloadLocation: "NOASSERTION",
+				PrimaryPackagePurpose:   "OPERATING-SYSTEM",
+				PackageSupplier:         &common.Supplier{Supplier: "Example Networks (sbom@example.com)", SupplierType: "Organization"},
+			},
+			{
+				PackageSPDXIdentifier:   "lib",
+				PackageName:             "lib",
+				PackageVersion:          "v1.0.0",
+				PackageDownloadLocation: "https://example.com/lib.tar.gz",
+				PackageLicenseDeclared:  "MIT OR Apache-2.0",
+				PackageChecksums: []common.Checksum{
+					{Algorithm: common.SHA3_256, Value: "abc"},
+				},
+				PackageExternalReferences: []*spdx.PackageExternalReference{
+					{Category: "PACKAGE-MANAGER", RefType: "purl", Locator: "pkg:golang/example.com/lib@v1.0.0"},
+					{Category: "SECURITY", RefType: "cpe23Type", Locator: "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*"},
+					{Category: "SECURITY", RefType: "advisory", Locator: "https://example.com/advisory"},
+				},
+			},
+		},
+		Relationships: []*spdx.Relationship{
+			{RefA: common.MakeDocElementID("", "root"), RefB: common.MakeDocElementID("", "DOCUMENT"), Relationship: common.TypeRelationshipDescribeBy},
+			{RefA: common.MakeDocElementID("", "lib"), RefB: common.MakeDocElementID("", "root"), Relationship: common.TypeRelationshipDevDependencyOf},
+			{RefA: common.MakeDocElementID("", "lib"), RefB: common.MakeDocElementID("", "root"), Relationship: "UNKNOWN"},
+		},
+	}
+
+	doc, err := ConvertToSPDX3(spdxDoc)
+	require.NoError(t, err)
+	assert.Equal(t, SPDX3Context, doc.Context)
+
+	byType := map[string][]any{}
+	for _, e := range doc.Graph {
+		var typ string
+		switch e := e.(type) {
+		case *SPDX3CreationInfo:
+			typ = e.Type
+		case *SPDX3Agent:
+			typ = e.Type
+		case *SPDX3Package:
+			typ = e.Type
+		case *SPDX3LicenseExpression:
+			typ = e.Type
+		case *SPDX3Relationship:
+			typ = e.Type
+		case *SPDX3Sbom:
+			typ = e.Type
+		case *SPDX3SpdxDocument:
+			typ = e.Type
+		}
+		byType[typ] = append(byType[typ], e)
+	}
+
+	org := ns + "#SPDXRef-Organization-2"
+	require.Len(t, byType["CreationInfo"], 1)
+	assert.Equal(t, &SPDX3CreationInfo{
+		Type:         "CreationInfo",
+		ID:           "_:creationinfo",
+		SpecVersion:  SPDX3Version,
+		Created:      "2025-01-02T03:04:05Z",
+		CreatedBy:    []string{org},
+		CreatedUsing: []string{ns + "#SPDXRef-Tool-1"},
+	}, byType["CreationInfo"][0])
+	require.Len(t, byType["Organization"], 1)
+	assert.Equal(t, "Example Networks", byType["Organization"][0].(*SPDX3Agent).Name)
+
+	require.Len(t, byType["software_Package"], 2)
+	root := byType["software_Package"][0].(*SPDX3Package)
+	assert.Equal(t, "operatingSystem", root.PrimaryPurpose)
+	assert.Equal(t, org, root.SuppliedBy, "supplier reuses the creator agent")
+	assert.Empty(t, root.DownloadLocation)
+
+	lib := byType["software_Package"][1].(*SPDX3Package)
+	assert.Equal(t, "pkg:golang/example.com/lib@v1.0.0", lib.PackageURL)
+	assert.Equal(t, []SPDX3Hash{{Type: "Hash", Algorithm: "sha3_256", HashValue: "abc"}}, lib.VerifiedUsing)
+	assert.Equal(t, []SPDX3ExternalIdentifier{{
+		Type:                   "ExternalIdentifier",
+		ExternalIdentifierType: "cpe23",
+		Identifier:             "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*",
+	}}, lib.ExternalIdentifier)
+	assert.Equal(t, []SPDX3ExternalRef{{
+		Type:            "ExternalRef",
+		ExternalRefType: "securityAdvisory",
+		Locator:         []string{"https://example.com/advisory"},
+	}}, lib.ExternalRef)
+
+	require.Len(t, byType["simplelicensing_LicenseExpression"], 1)
+	assert.Equal(t, "MIT OR Apache-2.0",
+		byType["simplelicensing_LicenseExpression"][0].(*SPDX3LicenseExpression).LicenseExpression)
+
+	require.Len(t, byType["Relationship"], 2)
+	declared := byType["Relationship"][0].(*SPDX3Relationship)
+	assert.Equal(t, "hasDeclaredLicense", declared.RelationshipType)
+	other := byType["Relationship"][1].(*SPDX3Relationship)
+	assert.Equal(t, "other", other.RelationshipType)
+	assert.Equal(t, "UNKNOWN", other.Comment)
+
+	require.Len(t, byType["LifecycleScopedRelationship"], 1)
+	dev := byType["LifecycleScopedRelationship"][0].(*SPDX3Relationship)
+	assert.Equal(t, ns+"#SPDXRef-root", dev.From)
+	assert.Equal(t, "dependsOn", dev.RelationshipType)
+	assert.Equal(t, []string{ns + "#SPDXRef-lib"}, dev.To)
+	assert.Equal(t, "development", dev.Scope)
+
+	require.Len(t, byType["software_Sbom"], 1)
+	assert.Equal(t, []string{ns + "#SPDXRef-root"}, byType["software_Sbom"][0].(*SPDX3Sbom).RootElement)
+	require.Len(t, byType["SpdxDocument"], 1)
+	assert.Equal(t, []string{ns + "#SPDXRef-SBOM"}, byType["SpdxDocument"][0].(*SPDX3SpdxDocument).RootElement)
+
+	b, err := SPDX3ToJSON(doc)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Len(t, decoded["@graph"], len(doc.Graph))
+}
+
+func TestConvertToSPDX3RootFallback(t *testing.T) {
+	bom, err := CycloneDXFromProto(testProtoBOM())
+	require.NoError(t, err)
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	doc, err := ConvertToSPDX3(spdxDoc)
+	require.NoError(t, err)
+	for _, e := range doc.Graph {
+		if sbom, ok := e.(*SPDX3Sbom); ok {
+			assert.Equal(t, []string{spdxDoc.DocumentNamespace + "#SPDXRef-root"}, sbom.RootElement)
+			return
+		}
+	}
+	t.Fatal("missing software_Sbom element")
+}
+
+func TestConvertToSPDX3GeneratedIDs(t *testing.T) {
+	const ns = "https://example.com/spdxdocs/app"
+	spdxDoc := &spdx.Document{
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "app",
+		DocumentNamespace: ns,
+		CreationInfo: &spdx.CreationInfo{
+			Creators: []common.Creator{{Creator: "Example", CreatorType: "Organization"}},
+		},
+		Packages: []*spdx.Package{
+			{PackageSPDXIdentifier: "SBOM", PackageName: "app", PackageLicenseDeclared: "MIT"},
+			{PackageSPDXIdentifier: "Relationship-1", PackageName: "lib"},
+			{PackageSPDXIdentifier: "License-1", PackageName: "license"},
+			{PackageSPDXIdentifier: "Organization-1", PackageName: "org"},
+		},
+		Relationships: []*spdx.Relationship{{
+			RefA:         common.MakeDocElementID("", "SBOM"),
+			RefB:         common.MakeDocElementID("", "Relationship-1"),
+			Relationship: "DEPENDS_ON",
+		}},
+	}
+
+	doc, err := ConvertToSPDX3(spdxDoc)
+	require.NoError(t, err)
+	ids := map[string]string{}
+	for _, e := range doc.Graph {
+		b, err := json.Marshal(e)
+		require.NoError(t, err)
+		var element struct {
+			Type string `json:"type"`
+			ID   string `json:"spdxId"`
+		}
+		require.NoError(t, json.Unmarshal(b, &element))
+		if element.ID == "" {
+			continue
+		}
+		assert.NotContains(t, ids, element.ID, "duplicate ID of %s", element.Type)
+		ids[element.ID] = element.Type
+	}
+	assert.Equal(t, map[string]string{
+		ns + "#SPDXRef-DOCUMENT":         "SpdxDocument",
+		ns + "#SPDXRef-SBOM":             "software_Package",
+		ns + "#SPDXRef-Relationship-1":   "software_Package",
+		ns + "#SPDXRef-License-1":        "software_Package",
+		ns + "#SPDXRef-Organization-1":   "software_Package",
+		ns + "#SPDXRef-SBOM-2":           "software_Sbom",
+		ns + "#SPDXRef-Organization-1-2": "Organization",
+		ns + "#SPDXRef-License-1-2":      "simplelicensing_LicenseExpression",
+		ns + "#SPDXRef-Relationship-1-2": "Relationship",
+		ns + "#SPDXRef-Relationship-2":   "Relationship",
+	}, ids)
+}
@@ -0,0 +1,233 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+)
+
+// defaultAnnotator is the annotator of converted annotations when the
+// options name no converting tool.
+const defaultAnnotator = "openconfig-security-services"
+
+// CycloneDX properties are kept as SPDX annotations whose comment is one of
+// these prefixes followed by the property as a JSON object, such as
+//
+//	CycloneDX property: {"name":"build-id","value":"42"}
+//
+// Component properties annotate the package or file, BOM and metadata
+// properties annotate the document. ConvertToCycloneDX restores annotations
+// in this form as properties and all other annotations as CycloneDX
+// annotations.
+const (
+	propertyAnnotationPrefix         = "CycloneDX property: "
+	bomPropertyAnnotationPrefix      = "CycloneDX BOM property: "
+	metadataPropertyAnnotationPrefix = "CycloneDX metadata property: "
+)
+
+// addAnnotation attaches an OTHER annotation to the package or file with the
+// given ID. The annotator is the converting tool and the date is the
+// document creation time. It reports false if there is no such element.
+func addAnnotation(spdxDoc *spdx.Document, id common.ElementID, comment string, opts *ConvertOptions) bool {
+	return attachAnnotation(spdxDoc, id, converterAnnotation(spdxDoc, comment, opts))
+}
+
+// addDocumentAnnotation adds an OTHER annotation about the document by the
+// converting tool.
+func addDocumentAnnotation(spdxDoc *spdx.Document, comment string, opts *ConvertOptions) {
+	addDocumentAnnotationOf(spdxDoc, converterAnnotation(spdxDoc, comment, opts))
+}
+
+func converterAnnotation(spdxDoc *spdx.Document, comment string, opts *ConvertOptions) spdx.Annotation {
+	annotator := defaultAnnotator
+	if opts != nil && opts.Tool != "" {
+		annotator = opts.Tool
+	}
+	a := spdx.Annotation{
+		Annotator:         common.Annotator{Annotator: annotator, AnnotatorType: "Tool"},
+		AnnotationType:    "OTHER",
+		AnnotationComment: comment,
+	}
+	if spdxDoc.CreationInfo != nil {
+		a.AnnotationDate = spdxDoc.CreationInfo.Created
+	}
+	return a
+}
+
+// attachAnnotation attaches an annotation to the package or file with the
+// given ID, reporting false if there is no such element.
+func attachAnnotation(spdxDoc *spdx.Document, id common.ElementID, a spdx.Annotation) bool {
+	a.AnnotationSPDXIdentifier = common.DocElementID{ElementRefID: id}
+	for _, p := range spdxDoc.Packages {
+		if p.PackageSPDXIdentifier == id {
+			p.Annotations = append(p.Annotations, a)
+			return true
+		}
+	}
+	for _, f := range spdxDoc.Files {
+		if f.FileSPDXIdentifier == id {
+			f.Annotations = append(f.Annotations, a)
+			return true
+		}
+	}
+	return false
+}
+
+// addPropertyAnnotations annotates the element converted from a component
+// with the component's properties.
+func addPropertyAnnotations(spdxDoc *spdx.Document, c cdx.Component, opts *ConvertOptions) {
+	if c.Properties == nil {
+		return
+	}
+	for _, p := range *c.Properties {
+		addAnnotation(spdxDoc, toSPDXElementID(c.BOMRef), propertyAnnotation(propertyAnnotationPrefix, p), opts)
+	}
+}
+
+// addCycloneDXBOMAnnotations maps the BOM and metadata properties to
+// document annotations and the BOM's annotations to annotations of the
+// converted elements they are about.
+//
+// A CycloneDX annotation about several subjects becomes one SPDX annotation
+// per subject. A subject that is the BOM's serial number is the document,
+// and an annotation without subjects is about the document too. The
+// annotator is the annotating person or organization, or the annotating
+// component or service as a tool.
+func addCycloneDXBOMAnnotations(
+	bom *cdx.BOM,
+	bomRefs map[string]string,
+	refMap map[string]cdx.Component,
+	spdxDoc *spdx.Document,
+	opts *ConvertOptions,
+) {
+	if bom.Properties != nil {
+		for _, p := range *bom.Properties {
+			addDocumentAnnotation(spdxDoc, propertyAnnotation(bomPropertyAnnotationPrefix, p), opts)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Properties != nil {
+		for _, p := range *bom.Metadata.Properties {
+			addDocumentAnnotation(spdxDoc, propertyAnnotation(metadataPropertyAnnotationPrefix, p), opts)
+		}
+	}
+	if bom.Annotations == nil {
+		return
+	}
+
+	for _, annotation := range *bom.Annotations {
+		a := converterAnnotation(spdxDoc, annotation.Text, opts)
+		if annotator, ok := spdxAnnotator(annotation.Annotator); ok {
+			a.Annotator = annotator
+		}
+		if annotation.Timestamp != "" {
+			a.AnnotationDate = spdxTimestamp(annotation.Timestamp)
+		}
+		if annotation.Subjects == nil || len(*annotation.Subjects) == 0 {
+			addDocumentAnnotationOf(spdxDoc, a)
+			continue
+		}
+		for _, subject := range *annotation.Subjects {
+			ref := string(subject)
+			if ref == bom.SerialNumber && ref != "" {
+				addDocumentAnnotationOf(spdxDoc, a)
+				continue
+			}
+			if id, ok := bomRefs[ref]; ok {
+				ref = id
+			}
+			if c, ok := refMap[ref]; !ok || !opts.isSPDXElement(c) {
+				opts.loseDocument(Loss{"annotations", LossDropped,
+					fmt.Sprintf("annotation of %q, not a converted component", subject)})
+				continue
+			}
+			attachAnnotation(spdxDoc, toSPDXElementID(ref), a)
+		}
+	}
+}
+
+// addDocumentAnnotationOf adds a copy of an annotation about the document.
+func addDocumentAnnotationOf(spdxDoc *spdx.Document, a spdx.Annotation) {
+	a.AnnotationSPDXIdentifier = toSPDXDocElementID("DOCUMENT")
+	spdxDoc.Annotations = append(spdxDoc.Annotations, &a)
+}
+
+// propertyAnnotation encodes a property as an annotation comment.
+func propertyAnnotation(prefix string, p cdx.Property) string {
+	// A property is two strings, which always marshal.
+	b, _ := json.Marshal(p)
+	return prefix + string(b)
+}
+
+// spdxAnnotator maps a CycloneDX annotator to an SPDX one.
+func spdxAnnotator(a *cdx.Annotator) (common.Annotator, bool) {
+	switch {
+	case a == nil:
+	case a.Individual != nil:
+		return common.Annotator{
+			Annotator:     spdxActor(a.Individual.Name, a.Individual.Email),
+			AnnotatorType: "Person",
+		}, true
+	case a.Organization != nil:
+		return common.Annotator{
+			Annotator:     spdxActor(a.Organization.Name, entityEmail(a.Organization)),
+			AnnotatorType: "Organization",
+		}, true
+	case a.Component != nil:
+		return common.Annotator{
+			Annotator:     spdxTool(a.Component.Name, a.Component.Version),
+			AnnotatorType: "Tool",
+		}, true
+	case a.Service != nil:
+		return common.Annotator{
+			Annotator:     spdxTool(a.Service.Name, a.Service.Version),
+			AnnotatorType: "Tool",
+		}, true
+	}
+	return common.Annotator{}, false
+}
+
+// propertyFromAnnotation decodes a property annotation comment with the
+// given prefix.
+func propertyFromAnnotation(prefix, comment string) (cdx.Property, bool) {
+	encoded, ok := strings.CutPrefix(comment, prefix)
+	if !ok {
+		return cdx.Property{}, false
+	}
+	var p cdx.Property
+	if err := json.Unmarshal([]byte(encoded), &p); err != nil || p.Name == "" {
+		return cdx.Property{}, false
+	}
+	return p, true
+}
+
+// withDocumentAnnotations returns a shallow copy of the document with the
+// package and file annotations added to the document annotations. The
+// tag-value format only has document level annotations, which name the
+// element they are about, whereas the JSON format nests them in the element.
+func withDocumentAnnotations(spdxDoc *spdx.Document) *spdx.Document {
+	var annotations []*spdx.Annotation
+	for _, p := range spdxDoc.Packages {
+		for i := range p.Annotations {
+			a := p.Annotations[i]
+			a.AnnotationSPDXIdentifier = common.DocElementID{ElementRefID: p.PackageSPDXIdentifier}
+			annotations = append(annotations, &a)
+		}
+	}
+	for _, f := range spdxDoc.Files {
+		for i := range f.Annotations {
+			a := f.Annotations[i]
+			a.AnnotationSPDXIdentifier = common.DocElementID{ElementRefID: f.FileSPDXIdentifier}
+			annotations = append(annotations, &a)
+		}
+	}
+	if len(annotations) == 0 {
+		return spdxDoc
+	}
+	out := *spdxDoc
+	out.Annotations = append(append([]*spdx.Annotation{}, spdxDoc.Annotations...), annotations...)
+	return &out
+}
@@ -0,0 +1,172 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSerialNumber = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+
+func testAnnotationBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = testSerialNumber
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: "2025-01-02T03:04:05Z",
+		Component: &cdx.Component{
+			BOMRef:     "app",
+			Type:       cdx.ComponentTypeApplication,
+			Name:       "app",
+			Properties: &[]cdx.Property{{Name: "build-id", Value: "42"}},
+		},
+		Properties: &[]cdx.Property{{Name: "platform", Value: "linux/amd64"}},
+	}
+	bom.Components = &[]cdx.Component{{
+		BOMRef: "lib",
+		Type:   cdx.ComponentTypeLibrary,
+		Name:   "lib",
+		Properties: &[]cdx.Property{
+			{Name: "cdx:go:module", Value: "example.com/lib"},
+			{Name: "note", Value: `contains "quotes" and: colons`},
+		},
+	}}
+	bom.Properties = &[]cdx.Property{{Name: "pipeline", Value: "nightly"}}
+	bom.Annotations = &[]cdx.Annotation{{
+		Subjects:  &[]cdx.BOMReference{"app", "lib"},
+		Annotator: &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: "Jane Doe", Email: "jane@example.com"}},
+		Timestamp: "2025-01-03T00:00:00+01:00",
+		Text:      "Reviewed for release.",
+	}, {
+		Subjects:  &[]cdx.BOMReference{testSerialNumber},
+		Annotator: &cdx.Annotator{Component: &cdx.Component{Name: "scanner", Version: "1.2.3"}},
+		Timestamp: "2025-01-04T00:00:00Z",
+		Text:      "Scanned.",
+	}}
+	return bom
+}
+
+func TestConvertAnnotations(t *testing.T) {
+	spdxDoc, err := ConvertToGoogleSPDX(testAnnotationBOM(), WithTool("converter", "1.0"))
+	require.NoError(t, err)
+
+	converter := common.Annotator{Annotator: "converter-1.0", AnnotatorType: "Tool"}
+	reviewer := common.Annotator{Annotator: "Jane Doe (jane@example.com)", AnnotatorType: "Person"}
+	packages := map[common.ElementID]*spdx.Package{}
+	for _, p := range spdxDoc.Packages {
+		packages[p.PackageSPDXIdentifier] = p
+	}
+	assert.Equal(t, []spdx.Annotation{{
+		Annotator:                converter,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: toSPDXDocElementID("lib"),
+		AnnotationComment:        `CycloneDX property: {"name":"cdx:go:module","value":"example.com/lib"}`,
+	}, {
+		Annotator:                converter,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: toSPDXDocElementID("lib"),
+		AnnotationComment:        `CycloneDX property: {"name":"note","value":"contains \"quotes\" and: colons"}`,
+	}, {
+		Annotator:                reviewer,
+		AnnotationDate:           "2025-01-02T23:00:00Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: toSPDXDocElementID("lib"),
+		AnnotationComment:        "Reviewed for release.",
+	}}, packages["lib"].Annotations)
+	require.Len(t, packages["app"].Annotations, 2)
+	assert.Equal(t, reviewer, packages["app"].Annotations[1].Annotator)
+
+	var comments []string
+	for _, a := range spdxDoc.Annotations {
+		assert.Equal(t, toSPDXDocElementID("DOCUMENT"), a.AnnotationSPDXIdentifier)
+		comments = append(comments, a.Annotator.Annotator+": "+a.AnnotationComment)
+	}
+	assert.Equal(t, []string{
+		`converter-1.0: CycloneDX BOM property: {"name":"pipeline","value":"nightly"}`,
+		`converter-1.0: CycloneDX metadata property: {"name":"platform","value":"linux/amd64"}`,
+		"scanner-1.2.3: Scanned.",
+	}, comments)
+}
+
+func TestConvertAnnotationsWithoutSubjects(t *testing.T) {
+	bom := testAnnotationBOM()
+	bom.Properties, bom.Metadata.Properties = nil, nil
+	bom.Annotations = &[]cdx.Annotation{{
+		Annotator: &cdx.Annotator{Organization: &cdx.OrganizationalEntity{Name: "Example"}},
+		Timestamp: "2025-01-05T00:00:00Z",
+		Text:      "Approved.",
+	}, {
+		Subjects:  &[]cdx.BOMReference{},
+		Annotator: &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: "Jane Doe"}},
+		Timestamp: "2025-01-06T00:00:00Z",
+		Text:      "Signed off.",
+	}}
+
+	conversion, err := ConvertCycloneDXToSPDX(bom)
+	require.NoError(t, err)
+	assert.Equal(t, []*spdx.Annotation{{
+		Annotator:                common.Annotator{Annotator: "Example", AnnotatorType: "Organization"},
+		AnnotationDate:           "2025-01-05T00:00:00Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: toSPDXDocElementID("DOCUMENT"),
+		AnnotationComment:        "Approved.",
+	}, {
+		Annotator:                common.Annotator{Annotator: "Jane Doe", AnnotatorType: "Person"},
+		AnnotationDate:           "2025-01-06T00:00:00Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: toSPDXDocElementID("DOCUMENT"),
+		AnnotationComment:        "Signed off.",
+	}}, conversion.Document.Annotations)
+	assert.Empty(t, conversion.LossReport.Document)
+}
+
+func TestAnnotationsRoundTrip(t *testing.T) {
+	spdxDoc, err := ConvertToGoogleSPDX(testAnnotationBOM())
+	require.NoError(t, err)
+
+	for _, format := range []string{"json", "tag-value"} {
+		t.Run(format, func(t *testing.T) {
+			var got *spdx.Document
+			if format == "json" {
+				b, err := SPDXToJSON(spdxDoc)
+				require.NoError(t, err)
+				got, err = spdxjson.Read(bytes.NewReader(b))
+				require.NoError(t, err)
+			} else {
+				b, err := SPDXToTagValue(spdxDoc)
+				require.NoError(t, err)
+				got, err = spdxtv.Read(bytes.NewReader(b))
+				require.NoError(t, err)
+			}
+			bom, err := ConvertToCycloneDX(got)
+			require.NoError(t, err)
+
+			want := testAnnotationBOM()
+			assert.Equal(t, want.Properties, bom.Properties)
+			assert.Equal(t, want.Metadata.Properties, bom.Metadata.Properties)
+			assert.Equal(t, want.Metadata.Component.Properties, bom.Metadata.Component.Properties)
+			require.NotNil(t, bom.Components)
+			assert.Equal(t, (*want.Components)[0].Properties, (*bom.Components)[0].Properties)
+
+			assert.Equal(t, &[]cdx.Annotation{{
+				Subjects:  &[]cdx.BOMReference{testSerialNumber},
+				Annotator: &cdx.Annotator{Component: &cdx.Component{Type: cdx.ComponentTypeApplication, Name: "scanner", Version: "1.2.3"}},
+				Timestamp: "2025-01-04T00:00:00Z",
+				Text:      "Scanned.",
+			}, {
+				Subjects:  &[]cdx.BOMReference{"app", "lib"},
+				Annotator: &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: "Jane Doe", Email: "jane@example.com"}},
+				Timestamp: "2025-01-02T23:00:00Z",
+				Text:      "Reviewed for release.",
+			}}, bom.Annotations)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package sbom
+
+import (
+	"fmt"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// spdxCreationInfo maps BOM metadata to SPDX creation info.
+//
+// The creators are the suppliers and manufacturer as organizations, the
+// authors as persons and the tools, in both the legacy and the CycloneDX 1.5
+// component and service forms, followed by the converting tool from the
+// options. Created falls back to the conversion time.
+func spdxCreationInfo(metadata *cdx.Metadata, opts *ConvertOptions) *spdx.CreationInfo {
+	info := &spdx.CreationInfo{}
+	creators := &spdxCreators{seen: map[common.Creator]bool{}}
+	if metadata != nil {
+		info.Created = spdxTimestamp(metadata.Timestamp)
+		if metadata.Component != nil {
+			creators.addEntity("Organization", metadata.Component.Supplier)
+		}
+		creators.addEntity("Organization", metadata.Supplier)
+		creators.addEntity("Organization", metadata.Manufacturer)
+		creators.addEntity("Organization", metadata.Manufacture)
+		if metadata.Authors != nil {
+			for _, author := range *metadata.Authors {
+				creators.add("Person", spdxActor(author.Name, author.Email))
+			}
+		}
+		if tools := metadata.Tools; tools != nil {
+			if tools.Tools != nil {
+				for _, tool := range *tools.Tools {
+					creators.add("Tool", spdxTool(tool.Name, tool.Version))
+				}
+			}
+			if tools.Components != nil {
+				for _, c := range *tools.Components {
+					creators.add("Tool", spdxTool(c.Name, c.Version))
+				}
+			}
+			if tools.Services != nil {
+				for _, s := range *tools.Services {
+					creators.add("Tool", spdxTool(s.Name, s.Version))
+				}
+			}
+		}
+	}
+	if opts != nil && opts.Tool != "" {
+		creators.add("Tool", opts.Tool)
+	}
+	info.Creators = creators.creators
+
+	if info.Created == "" {
+		created := time.Now()
+		if opts != nil && !opts.CreationTime.IsZero() {
+			created = opts.CreationTime
+		}
+		info.Created = created.UTC().Format(time.RFC3339)
+	}
+	return info
+}
+
+// spdxCreators collects distinct SPDX creators in order.
+type spdxCreators struct {
+	creators []common.Creator
+	seen     map[common.Creator]bool
+}
+
+func (c *spdxCreators) add(creatorType, creator string) {
+	if creator == "" {
+		return
+	}
+	key := common.Creator{Creator: creator, CreatorType: creatorType}
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.creators = append(c.creators, key)
+}
+
+func (c *spdxCreators) addEntity(creatorType string, e *cdx.OrganizationalEntity) {
+	if e == nil {
+		return
+	}
+	c.add(creatorType, spdxActor(e.Name, entityEmail(e)))
+}
+
+// spdxTimestamp normalizes a CycloneDX timestamp to the SPDX UTC format.
+// Timestamps that do not parse are kept as they are.
+func spdxTimestamp(timestamp string) string {
+	if timestamp == "" {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		log.Warningf("invalid timestamp %q: %v", timestamp, err)
+		return timestamp
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// spdxActor formats an SPDX actor as "name (email)".
+func spdxActor(name, email string) string {
+	if email == "" {
+		return name
+	}
+	if name == "" {
+		return fmt.Sprintf("(%s)", email)
+	}
+	return fmt.Sprintf("%s (%s)", name, email)
+}
+
+// spdxTool formats an SPDX tool creator as "name-version".
+func spdxTool(name, version string) string {
+	if name == "" || version == "" {
+		return name
+	}
+	return name + "-" + version
+}
+
+// entityEmail returns the first contact email of an organization.
+func entityEmail(e *cdx.OrganizationalEntity) string {
+	if e.Contact == nil {
+		return ""
+	}
+	for _, contact := range *e.Contact {
+		if contact.Email != "" {
+			return contact.Email
+		}
+	}
+	return ""
+}
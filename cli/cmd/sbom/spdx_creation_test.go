@@ -0,0 +1,89 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDXCreationInfo(t *testing.T) {
+	supplier := &cdx.OrganizationalEntity{
+		Name:    "Example Networks",
+		Contact: &[]cdx.OrganizationalContact{{Name: "PSIRT"}, {Email: "sbom@example.com"}},
+	}
+	tests := []struct {
+		desc     string
+		metadata *cdx.Metadata
+		opts     []ConvertOption
+		want     *spdx.CreationInfo
+	}{{
+		desc: "no metadata",
+		opts: []ConvertOption{WithCreationTime(time.Date(2025, 2, 3, 4, 5, 6, 7, time.FixedZone("", 3600)))},
+		want: &spdx.CreationInfo{Created: "2025-02-03T03:05:06Z"},
+	}, {
+		desc: "legacy tools",
+		metadata: &cdx.Metadata{
+			Timestamp: "2025-01-02T04:04:05+01:00",
+			Tools: &cdx.ToolsChoice{Tools: &[]cdx.Tool{
+				{Vendor: "Example", Name: "scanner", Version: "1.2.3"},
+				{Name: "sbom-tool"},
+			}},
+		},
+		opts: []ConvertOption{WithTool("converter", "v0.1.0")},
+		want: &spdx.CreationInfo{
+			Created: "2025-01-02T03:04:05Z",
+			Creators: []common.Creator{
+				{Creator: "scanner-1.2.3", CreatorType: "Tool"},
+				{Creator: "sbom-tool", CreatorType: "Tool"},
+				{Creator: "converter-v0.1.0", CreatorType: "Tool"},
+			},
+		},
+	}, {
+		desc: "CycloneDX 1.5 metadata",
+		metadata: &cdx.Metadata{
+			Timestamp: "2025-01-02T03:04:05Z",
+			Component: &cdx.Component{Name: "network-os", Supplier: supplier},
+			Supplier:  supplier,
+			Tools: &cdx.ToolsChoice{
+				Components: &[]cdx.Component{{Type: cdx.ComponentTypeApplication, Name: "scanner", Version: "1.2.3"}},
+				Services:   &[]cdx.Service{{Name: "build-service", Version: "2"}},
+			},
+			Authors: &[]cdx.OrganizationalContact{
+				{Name: "Jane Doe", Email: "jane@example.com"},
+				{Name: "John Doe"},
+			},
+			Manufacturer: &cdx.OrganizationalEntity{Name: "Example Manufacturing"},
+		},
+		want: &spdx.CreationInfo{
+			Created: "2025-01-02T03:04:05Z",
+			Creators: []common.Creator{
+				{Creator: "Example Networks (sbom@example.com)", CreatorType: "Organization"},
+				{Creator: "Example Manufacturing", CreatorType: "Organization"},
+				{Creator: "Jane Doe (jane@example.com)", CreatorType: "Person"},
+				{Creator: "John Doe", CreatorType: "Person"},
+				{Creator: "scanner-1.2.3", CreatorType: "Tool"},
+				{Creator: "build-service-2", CreatorType: "Tool"},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, spdxCreationInfo(tt.metadata, NewConvertOptions(tt.opts...)))
+		})
+	}
+}
+
+func TestSPDXCreationInfoCreatedNow(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+	spdxDoc, err := ConvertToGoogleSPDX(cdx.NewBOM())
+	require.NoError(t, err)
+
+	created, err := time.Parse(time.RFC3339, spdxDoc.CreationInfo.Created)
+	require.NoError(t, err)
+	assert.False(t, created.Before(before))
+}
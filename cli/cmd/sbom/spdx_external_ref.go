@@ -0,0 +1,133 @@
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	log "k8s.io/klog"
+)
+
+// vcsSourceInfoPrefix starts the lines of the package source info that list
+// the version control references of a component.
+const vcsSourceInfoPrefix = "Version control: "
+
+// addPackageExternalReferences maps the external references of a component
+// to SPDX package fields.
+//
+// The first distribution and website references become the download
+// location and home page, and further ones OTHER references. Version control
+// references are listed in the source info, and the first one becomes the
+// download location, in SPDX VCS syntax, if there is no distribution
+// reference. Advisories and security contacts become SECURITY advisory and
+// url references, and all other types OTHER references of their CycloneDX
+// type, such as documentation, release-notes and bom. References whose URL
+// is not a valid SPDX locator are dropped, as are the hashes of references.
+func addPackageExternalReferences(p *spdx.Package, c cdx.Component, opts *ConvertOptions) {
+	var vcs []string
+	if c.ExternalReferences != nil {
+		for _, ref := range *c.ExternalReferences {
+			if !isSPDXLocator(ref.URL) {
+				opts.lose(c, Loss{"externalReferences", LossDropped,
+					fmt.Sprintf("%s reference %q, not a valid SPDX locator", ref.Type, ref.URL)})
+				continue
+			}
+			if sliceLen(ref.Hashes) > 0 {
+				opts.lose(c, Loss{"externalReferences", LossDropped,
+					fmt.Sprintf("hashes of %s reference %s", ref.Type, ref.URL)})
+			}
+			switch ref.Type {
+			case cdx.ERTypeDistribution:
+				if p.PackageDownloadLocation == "" {
+					p.PackageDownloadLocation = ref.URL
+					continue
+				}
+			case cdx.ERTypeWebsite:
+				if p.PackageHomePage == "" {
+					p.PackageHomePage = ref.URL
+					continue
+				}
+			case cdx.ERTypeVCS:
+				vcs = append(vcs, ref.URL)
+				continue
+			}
+			category, refType := "OTHER", string(ref.Type)
+			switch ref.Type {
+			case cdx.ERTypeAdvisories:
+				category, refType = "SECURITY", "advisory"
+			case cdx.ERTypeSecurityContact:
+				category, refType = "SECURITY", "url"
+			}
+			p.PackageExternalReferences = append(p.PackageExternalReferences, &spdx.PackageExternalReference{
+				Category:           category,
+				RefType:            refType,
+				Locator:            ref.URL,
+				ExternalRefComment: ref.Comment,
+			})
+		}
+	}
+
+	if len(vcs) > 0 {
+		lines := make([]string, len(vcs))
+		for i, u := range vcs {
+			lines[i] = vcsSourceInfoPrefix + u
+		}
+		p.PackageSourceInfo = strings.Join(lines, "\n")
+		if p.PackageDownloadLocation == "" {
+			if location, ok := spdxVCSLocation(vcs[0]); ok {
+				p.PackageDownloadLocation = location
+			} else {
+				log.Warningf("component %q: VCS URL %q has no SPDX VCS form", c.BOMRef, vcs[0])
+			}
+		}
+	}
+	if p.PackageDownloadLocation == "" {
+		p.PackageDownloadLocation = "NOASSERTION"
+	}
+}
+
+// spdxVCSLocation returns a version control URL in the SPDX VCS syntax
+// "<tool>+<transport>://<host>/<path>". URLs without a tool, including
+// scp-like "git@host:path" ones, are assumed to be git.
+func spdxVCSLocation(vcsURL string) (string, bool) {
+	if m := scpLikeURLRE.FindStringSubmatch(vcsURL); m != nil {
+		return "git+ssh://" + m[1] + "/" + m[2], true
+	}
+	u, err := url.Parse(vcsURL)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	switch {
+	case strings.Contains(u.Scheme, "+"):
+		return vcsURL, true
+	case u.Scheme == "git" || u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "ssh":
+		return "git+" + vcsURL, true
+	case u.Scheme == "svn":
+		return "svn+" + vcsURL, true
+	}
+	return "", false
+}
+
+// isSPDXLocator reports whether a URL can be an SPDX external reference
+// locator or download location, which cannot be empty or contain spaces.
+func isSPDXLocator(s string) bool {
+	return s != "" && !strings.ContainsAny(s, " \t\r\n")
+}
+
+// vcsFromSourceInfo returns the version control URLs listed in a package
+// source info by addPackageExternalReferences.
+func vcsFromSourceInfo(sourceInfo string) []string {
+	var urls []string
+	for _, line := range strings.Split(sourceInfo, "\n") {
+		if u, ok := strings.CutPrefix(line, vcsSourceInfoPrefix); ok && u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// scpLikeURLRE matches scp-like git URLs such as "git@github.com:org/repo".
+var scpLikeURLRE = regexp.MustCompile(`^([A-Za-z0-9._-]+@[A-Za-z0-9.-]+):([^/][^:]*)$`)
@@ -0,0 +1,137 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPackageExternalReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []cdx.ExternalReference
+		want spdx.Package
+	}{{
+		name: "no references",
+		want: spdx.Package{PackageDownloadLocation: "NOASSERTION"},
+	}, {
+		name: "first distribution and website win",
+		refs: []cdx.ExternalReference{
+			{Type: cdx.ERTypeWebsite, URL: "https://example.com"},
+			{Type: cdx.ERTypeDistribution, URL: "https://example.com/lib-1.0.tgz"},
+			{Type: cdx.ERTypeDistribution, URL: "https://mirror.example.com/lib-1.0.tgz"},
+			{Type: cdx.ERTypeWebsite, URL: "https://lib.example.org"},
+		},
+		want: spdx.Package{
+			PackageDownloadLocation: "https://example.com/lib-1.0.tgz",
+			PackageHomePage:         "https://example.com",
+			PackageExternalReferences: []*spdx.PackageExternalReference{
+				{Category: "OTHER", RefType: "distribution", Locator: "https://mirror.example.com/lib-1.0.tgz"},
+				{Category: "OTHER", RefType: "website", Locator: "https://lib.example.org"},
+			},
+		},
+	}, {
+		name: "vcs",
+		refs: []cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/example/lib.git"},
+			{Type: cdx.ERTypeVCS, URL: "git@gitlab.example.com:mirror/lib.git"},
+		},
+		want: spdx.Package{
+			PackageDownloadLocation: "git+https://github.com/example/lib.git",
+			PackageSourceInfo: "Version control: https://github.com/example/lib.git\n" +
+				"Version control: git@gitlab.example.com:mirror/lib.git",
+		},
+	}, {
+		name: "security and other references",
+		refs: []cdx.ExternalReference{
+			{Type: cdx.ERTypeAdvisories, URL: "https://example.com/advisories", Comment: "feed"},
+			{Type: cdx.ERTypeSecurityContact, URL: "mailto:security@example.com"},
+			{Type: cdx.ERTypeDocumentation, URL: "https://docs.example.com"},
+			{Type: cdx.ERTypeReleaseNotes, URL: "https://example.com/releases/1.0"},
+			{Type: cdx.ERTypeBOM, URL: "https://example.com/lib.cdx.json"},
+			{Type: cdx.ERTypeIssueTracker, URL: "https://example.com/issues"},
+			{Type: cdx.ERTypeDocumentation, URL: "not a locator"},
+		},
+		want: spdx.Package{
+			PackageDownloadLocation: "NOASSERTION",
+			PackageExternalReferences: []*spdx.PackageExternalReference{
+				{Category: "SECURITY", RefType: "advisory", Locator: "https://example.com/advisories", ExternalRefComment: "feed"},
+				{Category: "SECURITY", RefType: "url", Locator: "mailto:security@example.com"},
+				{Category: "OTHER", RefType: "documentation", Locator: "https://docs.example.com"},
+				{Category: "OTHER", RefType: "release-notes", Locator: "https://example.com/releases/1.0"},
+				{Category: "OTHER", RefType: "bom", Locator: "https://example.com/lib.cdx.json"},
+				{Category: "OTHER", RefType: "issue-tracker", Locator: "https://example.com/issues"},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cdx.Component{BOMRef: "lib"}
+			if tc.refs != nil {
+				c.ExternalReferences = &tc.refs
+			}
+			var p spdx.Package
+			addPackageExternalReferences(&p, c, nil)
+			assert.Equal(t, tc.want, p)
+		})
+	}
+}
+
+func TestSPDXVCSLocation(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/example/lib.git", "git+https://github.com/example/lib.git"},
+		{"git://git.example.com/lib.git", "git+git://git.example.com/lib.git"},
+		{"git@github.com:example/lib.git", "git+ssh://git@github.com/example/lib.git"},
+		{"hg+https://hg.example.com/lib", "hg+https://hg.example.com/lib"},
+		{"svn://svn.example.com/lib/trunk", "svn+svn://svn.example.com/lib/trunk"},
+		{"file:///src/lib", ""},
+		{"lib", ""},
+	}
+	for _, tc := range tests {
+		got, ok := spdxVCSLocation(tc.url)
+		assert.Equal(t, tc.want, got, tc.url)
+		assert.Equal(t, tc.want != "", ok, tc.url)
+	}
+}
+
+func TestExternalReferencesRoundTrip(t *testing.T) {
+	refs := []cdx.ExternalReference{
+		{Type: cdx.ERTypeDistribution, URL: "https://example.com/lib-1.0.tgz"},
+		{Type: cdx.ERTypeDistribution, URL: "https://mirror.example.com/lib-1.0.tgz"},
+		{Type: cdx.ERTypeAdvisories, URL: "https://example.com/advisories"},
+		{Type: cdx.ERTypeSecurityContact, URL: "mailto:security@example.com"},
+		{Type: cdx.ERTypeReleaseNotes, URL: "https://example.com/releases/1.0"},
+		{Type: cdx.ERTypeWebsite, URL: "https://example.com"},
+		{Type: cdx.ERTypeVCS, URL: "https://github.com/example/lib.git"},
+	}
+	for _, tc := range []struct {
+		name string
+		refs []cdx.ExternalReference
+	}{
+		{"with distribution", refs},
+		{"vcs download location", refs[2:]},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bom := cdx.NewBOM()
+			bom.Components = &[]cdx.Component{{
+				BOMRef:             "lib",
+				Type:               cdx.ComponentTypeLibrary,
+				Name:               "lib",
+				ExternalReferences: &tc.refs,
+			}}
+			spdxDoc, err := ConvertToGoogleSPDX(bom)
+			require.NoError(t, err)
+			got, err := ConvertToCycloneDX(spdxDoc)
+			require.NoError(t, err)
+
+			require.NotNil(t, got.Metadata.Component)
+			assert.Equal(t, &tc.refs, got.Metadata.Component.ExternalReferences)
+		})
+	}
+}
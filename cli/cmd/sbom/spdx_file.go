@@ -0,0 +1,176 @@
+package sbom
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"mime"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// spdxFile maps a CycloneDX file component to an SPDX file.
+// SPDX files have no version, supplier, originator or external references.
+func spdxFile(c cdx.Component, spdxDoc *spdx.Document, opts *ConvertOptions) *spdx.File {
+	opts.dropUnmapped(c,
+		unmappedField{"version", c.Version != ""},
+		unmappedField{"supplier", c.Supplier != nil},
+		unmappedField{"manufacturer", c.Manufacturer != nil},
+		unmappedField{"author", c.Author != ""},
+		unmappedField{"authors", sliceLen(c.Authors) > 0},
+		unmappedField{"publisher", c.Publisher != ""},
+		unmappedField{"purl", c.PackageURL != ""},
+		unmappedField{"cpe", c.CPE != ""},
+		unmappedField{"externalReferences", sliceLen(c.ExternalReferences) > 0},
+	)
+	f := &spdx.File{
+		FileSPDXIdentifier: toSPDXElementID(c.BOMRef),
+		FileName:           c.Name,
+		FileTypes:          spdxFileTypes(c.MIMEType),
+		Checksums:          spdxChecksums(c, opts),
+		FileCopyrightText:  c.Copyright,
+		FileComment:        c.Description,
+	}
+	if f.FileCopyrightText == "" {
+		f.FileCopyrightText = "NOASSERTION"
+	}
+	if fileSHA1(f) == "" {
+		opts.lose(c, Loss{"hashes", LossDegraded, "no SHA-1 hash, which SPDX requires for files"})
+	}
+
+	declared, concluded := spdxLicenses(c, spdxDoc, opts)
+	f.LicenseConcluded = concluded
+	if declared != "NOASSERTION" {
+		f.LicenseInfoInFiles = []string{declared}
+	}
+	return f
+}
+
+// spdxFileTypes derives SPDX file types from a MIME type. Unknown media
+// types are OTHER.
+func spdxFileTypes(mimeType string) []string {
+	if mimeType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		log.Warningf("invalid MIME type %q: %v", mimeType, err)
+		return []string{"OTHER"}
+	}
+	if t, ok := spdxMediaFileTypes[mediaType]; ok {
+		return []string{t}
+	}
+	top, sub, _ := strings.Cut(mediaType, "/")
+	switch {
+	case top == "text":
+		return []string{"TEXT"}
+	case top == "image", top == "audio", top == "video":
+		return []string{strings.ToUpper(top)}
+	case strings.HasSuffix(sub, "+json"), strings.HasSuffix(sub, "+xml"):
+		return []string{"TEXT"}
+	case strings.HasSuffix(sub, "+zip"), strings.HasSuffix(sub, "+gzip"):
+		return []string{"ARCHIVE"}
+	}
+	return []string{"OTHER"}
+}
+
+// addPackageVerificationCodes computes the package verification code of
+// every package that contains files, and marks its files as analyzed.
+func addPackageVerificationCodes(spdxDoc *spdx.Document) {
+	files := map[common.ElementID]*spdx.File{}
+	for _, f := range spdxDoc.Files {
+		files[f.FileSPDXIdentifier] = f
+	}
+	contained := map[common.ElementID][]*spdx.File{}
+	for _, r := range spdxDoc.Relationships {
+		if r.Relationship != common.TypeRelationshipContains {
+			continue
+		}
+		if f, ok := files[r.RefB.ElementRefID]; ok {
+			contained[r.RefA.ElementRefID] = append(contained[r.RefA.ElementRefID], f)
+		}
+	}
+
+	for _, p := range spdxDoc.Packages {
+		pkgFiles := contained[p.PackageSPDXIdentifier]
+		if len(pkgFiles) == 0 {
+			continue
+		}
+		code, ok := packageVerificationCode(pkgFiles)
+		if !ok {
+			log.Warningf("package %q: not computing verification code, a contained file has no SHA1 checksum",
+				p.PackageName)
+			continue
+		}
+		p.FilesAnalyzed = true
+		p.IsFilesAnalyzedTagPresent = true
+		p.PackageVerificationCode = &common.PackageVerificationCode{Value: code}
+	}
+}
+
+// packageVerificationCode implements the SPDX 2.3 package verification code
+// algorithm: the SHA1 of the sorted and concatenated file SHA1 values.
+func packageVerificationCode(files []*spdx.File) (string, bool) {
+	var sums []string
+	for _, f := range files {
+		sum := fileSHA1(f)
+		if sum == "" {
+			return "", false
+		}
+		sums = append(sums, sum)
+	}
+	sort.Strings(sums)
+	h := sha1.Sum([]byte(strings.Join(sums, "")))
+	return hex.EncodeToString(h[:]), true
+}
+
+func fileSHA1(f *spdx.File) string {
+	for _, c := range f.Checksums {
+		if c.Algorithm == common.SHA1 {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// ========== Enum mappings =============
+
+var spdxMediaFileTypes = map[string]string{
+	"application/gzip":                              "ARCHIVE",
+	"application/java-archive":                      "ARCHIVE",
+	"application/vnd.debian.binary-package":         "ARCHIVE",
+	"application/x-7z-compressed":                   "ARCHIVE",
+	"application/x-bzip2":                           "ARCHIVE",
+	"application/x-gtar":                            "ARCHIVE",
+	"application/x-rpm":                             "ARCHIVE",
+	"application/x-tar":                             "ARCHIVE",
+	"application/x-xz":                              "ARCHIVE",
+	"application/zip":                               "ARCHIVE",
+	"application/octet-stream":                      "BINARY",
+	"application/vnd.microsoft.portable-executable": "BINARY",
+	"application/x-elf":                             "BINARY",
+	"application/x-executable":                      "BINARY",
+	"application/x-mach-binary":                     "BINARY",
+	"application/x-sharedlib":                       "BINARY",
+	"application/pdf":                               "DOCUMENTATION",
+	"text/markdown":                                 "DOCUMENTATION",
+	"application/javascript":                        "SOURCE",
+	"application/x-sh":                              "SOURCE",
+	"text/javascript":                               "SOURCE",
+	"text/x-c":                                      "SOURCE",
+	"text/x-c++":                                    "SOURCE",
+	"text/x-go":                                     "SOURCE",
+	"text/x-java":                                   "SOURCE",
+	"text/x-python":                                 "SOURCE",
+	"text/x-rust":                                   "SOURCE",
+	"text/x-shellscript":                            "SOURCE",
+	"application/json":                              "TEXT",
+	"application/xml":                               "TEXT",
+	"application/yaml":                              "TEXT",
+	"application/spdx+json":                         "SPDX",
+	"text/spdx":                                     "SPDX",
+}
@@ -0,0 +1,127 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFileComponents(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Component: &cdx.Component{
+			BOMRef: "image",
+			Type:   cdx.ComponentTypeFirmware,
+			Name:   "image",
+			Components: &[]cdx.Component{{
+				BOMRef:    "boot",
+				Type:      cdx.ComponentTypeFile,
+				Name:      "boot/vmlinuz",
+				MIMEType:  "application/octet-stream",
+				Copyright: "Copyright Example",
+				Hashes: &[]cdx.Hash{
+					{Algorithm: cdx.HashAlgoSHA1, Value: "86F7E437FAA5A7FCE15D1DDCB9EAEAEA377667B8"},
+					{Algorithm: cdx.HashAlgoSHA256, Value: "abcd"},
+				},
+				Licenses: &cdx.Licenses{{License: &cdx.License{ID: "GPL-2.0-only"}}},
+			}, {
+				BOMRef:   "config",
+				Type:     cdx.ComponentTypeFile,
+				Name:     "etc/config.yaml",
+				MIMEType: "application/yaml; charset=utf-8",
+				Hashes:   &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA1, Value: "e9d71f5ee7c92d6dc9e92ffdad17b8bd49418f98"}},
+			}},
+		},
+	}
+	bom.Components = &[]cdx.Component{{
+		BOMRef: "lib",
+		Type:   cdx.ComponentTypeLibrary,
+		Name:   "lib",
+		Components: &[]cdx.Component{{
+			BOMRef: "readme",
+			Type:   cdx.ComponentTypeFile,
+			Name:   "README",
+		}},
+	}}
+
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	require.Len(t, spdxDoc.Packages, 2)
+	assert.Equal(t, []*spdx.File{{
+		FileSPDXIdentifier: "boot",
+		FileName:           "boot/vmlinuz",
+		FileTypes:          []string{"BINARY"},
+		Checksums: []common.Checksum{
+			{Algorithm: common.SHA1, Value: "86f7e437faa5a7fce15d1ddcb9eaeaea377667b8"},
+			{Algorithm: common.SHA256, Value: "abcd"},
+		},
+		LicenseConcluded:   "NOASSERTION",
+		LicenseInfoInFiles: []string{"GPL-2.0-only"},
+		FileCopyrightText:  "Copyright Example",
+	}, {
+		FileSPDXIdentifier: "config",
+		FileName:           "etc/config.yaml",
+		FileTypes:          []string{"TEXT"},
+		Checksums:          []common.Checksum{{Algorithm: common.SHA1, Value: "e9d71f5ee7c92d6dc9e92ffdad17b8bd49418f98"}},
+		LicenseConcluded:   "NOASSERTION",
+		FileCopyrightText:  "NOASSERTION",
+	}, {
+		FileSPDXIdentifier: "readme",
+		FileName:           "README",
+		LicenseConcluded:   "NOASSERTION",
+		FileCopyrightText:  "NOASSERTION",
+	}}, spdxDoc.Files)
+	assert.Equal(t, []*v2_3.Relationship{{
+		RefA:         toSPDXDocElementID("DOCUMENT"),
+		RefB:         toSPDXDocElementID("image"),
+		Relationship: "DESCRIBES",
+	}, {
+		RefA:         toSPDXDocElementID("image"),
+		RefB:         toSPDXDocElementID("boot"),
+		Relationship: "CONTAINS",
+	}, {
+		RefA:         toSPDXDocElementID("image"),
+		RefB:         toSPDXDocElementID("config"),
+		Relationship: "CONTAINS",
+	}, {
+		RefA:         toSPDXDocElementID("lib"),
+		RefB:         toSPDXDocElementID("readme"),
+		Relationship: "CONTAINS",
+	}}, spdxDoc.Relationships)
+
+	image := spdxDoc.Packages[0]
+	assert.True(t, image.FilesAnalyzed)
+	assert.Equal(t, &common.PackageVerificationCode{
+		Value: "5463504435e4dbf2b93a3a8a00ca78e36ea40e24",
+	}, image.PackageVerificationCode)
+
+	// README has no SHA1, so lib's verification code cannot be computed.
+	lib := spdxDoc.Packages[1]
+	assert.False(t, lib.FilesAnalyzed)
+	assert.Nil(t, lib.PackageVerificationCode)
+}
+
+func TestSPDXFileTypes(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		want     []string
+	}{
+		{"", nil},
+		{"application/x-tar", []string{"ARCHIVE"}},
+		{"text/x-go; charset=utf-8", []string{"SOURCE"}},
+		{"text/plain", []string{"TEXT"}},
+		{"image/png", []string{"IMAGE"}},
+		{"application/vnd.cyclonedx+json", []string{"TEXT"}},
+		{"application/x-unknown", []string{"OTHER"}},
+		{"not a mime type", []string{"OTHER"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, spdxFileTypes(tt.mimeType), tt.mimeType)
+	}
+}
@@ -0,0 +1,137 @@
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const spdxRefPrefix = "SPDXRef-"
+
+// spdxRefs assigns the components of a BOM bom-refs that are valid and
+// unique SPDX identifiers.
+type spdxRefs struct {
+	// ids maps original bom-refs to their assigned refs.
+	ids   map[string]string
+	taken map[string]bool
+}
+
+// normalizeBOMRefs returns a copy of the BOM whose component and dependency
+// refs are valid, unique SPDX identifiers, along with the mapping from the
+// original bom-refs to the assigned ones.
+//
+// Refs are sanitized by replacing characters not allowed in an SPDX ID with
+// "-", and sanitized refs that collide get a numeric suffix in document
+// order. Components without a bom-ref get a ref generated from their group,
+// name and version. Repeated bom-refs keep mapping to the same ref so they
+// are still reported as duplicates.
+func normalizeBOMRefs(bom *cdx.BOM) (*cdx.BOM, map[string]string) {
+	r := &spdxRefs{
+		ids:   map[string]string{},
+		taken: map[string]bool{"DOCUMENT": true},
+	}
+	out := *bom
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		metadata := *bom.Metadata
+		c := r.component(*metadata.Component)
+		metadata.Component = &c
+		out.Metadata = &metadata
+	}
+	out.Components = r.components(bom.Components)
+	if bom.Dependencies != nil {
+		deps := make([]cdx.Dependency, len(*bom.Dependencies))
+		for i, dep := range *bom.Dependencies {
+			deps[i] = cdx.Dependency{Ref: r.ref(dep.Ref)}
+			if dep.Dependencies != nil {
+				refs := make([]string, len(*dep.Dependencies))
+				for j, ref := range *dep.Dependencies {
+					refs[j] = r.ref(ref)
+				}
+				deps[i].Dependencies = &refs
+			}
+		}
+		out.Dependencies = &deps
+	}
+	return &out, r.ids
+}
+
+// ref returns the ref assigned to a bom-ref, or the bom-ref itself if no
+// component has it.
+func (r *spdxRefs) ref(bomRef string) string {
+	if id, ok := r.ids[bomRef]; ok {
+		return id
+	}
+	return bomRef
+}
+
+func (r *spdxRefs) components(components *[]cdx.Component) *[]cdx.Component {
+	if components == nil {
+		return nil
+	}
+	out := make([]cdx.Component, len(*components))
+	for i, c := range *components {
+		out[i] = r.component(c)
+	}
+	return &out
+}
+
+func (r *spdxRefs) component(c cdx.Component) cdx.Component {
+	switch id, ok := r.ids[c.BOMRef]; {
+	case c.BOMRef == "":
+		c.BOMRef = r.unique(generatedBOMRef(c))
+	case ok:
+		c.BOMRef = id
+	default:
+		id = r.unique(sanitizeSPDXID(c.BOMRef))
+		r.ids[c.BOMRef] = id
+		c.BOMRef = id
+	}
+	c.Components = r.components(c.Components)
+	if c.Pedigree != nil {
+		pedigree := *c.Pedigree
+		pedigree.Ancestors = r.components(pedigree.Ancestors)
+		pedigree.Descendants = r.components(pedigree.Descendants)
+		pedigree.Variants = r.components(pedigree.Variants)
+		c.Pedigree = &pedigree
+	}
+	return c
+}
+
+// unique returns base, or base with the first free numeric suffix, and
+// marks it as taken.
+func (r *spdxRefs) unique(base string) string {
+	if base == "" {
+		base = "component"
+	}
+	id := base
+	for i := 2; r.taken[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	r.taken[id] = true
+	return id
+}
+
+// generatedBOMRef builds a ref for a component without a bom-ref.
+func generatedBOMRef(c cdx.Component) string {
+	var parts []string
+	for _, part := range []string{c.Group, c.Name, c.Version} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return sanitizeSPDXID(strings.Join(parts, "-"))
+}
+
+// sanitizeSPDXID turns a string into a valid SPDX ID without the "SPDXRef-"
+// prefix, replacing runs of characters other than letters, digits, "." and
+// "-" with "-".
+func sanitizeSPDXID(s string) string {
+	s = strings.TrimPrefix(s, spdxRefPrefix)
+	return strings.Trim(spdxIDInvalidChars.ReplaceAllString(s, "-"), "-")
+}
+
+// spdxIDInvalidChars matches characters not allowed in SPDX element and
+// LicenseRef- identifiers.
+var spdxIDInvalidChars = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
@@ -0,0 +1,112 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSPDXID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"lib", "lib"},
+		{"pkg:golang/example.com/x@v1.0.0", "pkg-golang-example.com-x-v1.0.0"},
+		{"pkg:npm/%40scope/name@1.0.0?arch=x86_64", "pkg-npm-40scope-name-1.0.0-arch-x86-64"},
+		{"SPDXRef-Package-lib", "Package-lib"},
+		{"@@", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, sanitizeSPDXID(tt.in), tt.in)
+	}
+}
+
+func TestNormalizeBOMRefs(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "DOCUMENT",
+		Name:   "root",
+		Components: &[]cdx.Component{
+			{Name: "nested", Version: "1.0"},
+			{Name: "nested", Version: "1.0"},
+		},
+	}}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "pkg:golang/x@1"},
+		{BOMRef: "pkg-golang-x-1"},
+		{BOMRef: "pkg:golang/x@1"},
+		{},
+	}
+	bom.Dependencies = &[]cdx.Dependency{
+		{Ref: "DOCUMENT", Dependencies: &[]string{"pkg:golang/x@1", "pkg-golang-x-1", "unknown"}},
+	}
+
+	got, ids := normalizeBOMRefs(bom)
+
+	assert.Equal(t, "DOCUMENT-2", got.Metadata.Component.BOMRef)
+	assert.Equal(t, "nested-1.0", (*got.Metadata.Component.Components)[0].BOMRef)
+	assert.Equal(t, "nested-1.0-2", (*got.Metadata.Component.Components)[1].BOMRef)
+	var refs []string
+	for _, c := range *got.Components {
+		refs = append(refs, c.BOMRef)
+	}
+	assert.Equal(t, []string{"pkg-golang-x-1", "pkg-golang-x-1-2", "pkg-golang-x-1", "component"}, refs)
+	assert.Equal(t, &[]cdx.Dependency{
+		{Ref: "DOCUMENT-2", Dependencies: &[]string{"pkg-golang-x-1", "pkg-golang-x-1-2", "unknown"}},
+	}, got.Dependencies)
+	assert.Equal(t, map[string]string{
+		"DOCUMENT":       "DOCUMENT-2",
+		"pkg:golang/x@1": "pkg-golang-x-1",
+		"pkg-golang-x-1": "pkg-golang-x-1-2",
+	}, ids)
+
+	// The input BOM is left untouched.
+	assert.Equal(t, "DOCUMENT", bom.Metadata.Component.BOMRef)
+	assert.Equal(t, "", (*bom.Metadata.Component.Components)[0].BOMRef)
+	assert.Equal(t, "pkg:golang/x@1", (*bom.Components)[0].BOMRef)
+}
+
+func TestConvertCycloneDXToSPDXIDs(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "pkg:generic/switch@4.30",
+		Type:   cdx.ComponentTypeDevice,
+		Name:   "switch",
+		Components: &[]cdx.Component{
+			{Type: cdx.ComponentTypeFirmware, Name: "bootloader"},
+			{BOMRef: "pkg:golang/x@1", Type: cdx.ComponentTypeLibrary, Name: "x"},
+			{BOMRef: "pkg:golang/x#1", Type: cdx.ComponentTypeLibrary, Name: "x"},
+		},
+	}}
+	bom.Dependencies = &[]cdx.Dependency{
+		{Ref: "pkg:golang/x@1", Dependencies: &[]string{"pkg:golang/x#1"}},
+	}
+
+	conversion, err := ConvertCycloneDXToSPDX(bom)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]common.ElementID{
+		"pkg:generic/switch@4.30": "pkg-generic-switch-4.30",
+		"pkg:golang/x@1":          "pkg-golang-x-1",
+		"pkg:golang/x#1":          "pkg-golang-x-1-2",
+	}, conversion.SPDXIDs)
+	var ids []common.ElementID
+	for _, p := range conversion.Document.Packages {
+		ids = append(ids, p.PackageSPDXIdentifier)
+	}
+	assert.Equal(t, []common.ElementID{"pkg-generic-switch-4.30", "bootloader", "pkg-golang-x-1", "pkg-golang-x-1-2"}, ids)
+
+	// The tag-value reader rejects IDs with characters such as ":".
+	b, err := SPDXToTagValue(conversion.Document)
+	require.NoError(t, err)
+	got, err := spdxtv.Read(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Len(t, got.Packages, 4)
+	assert.Len(t, got.Relationships, 5)
+}
@@ -0,0 +1,419 @@
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	log "k8s.io/klog"
+)
+
+// addPackageIdentifiers adds the purl and CPE of a component to a package
+// as SECURITY references, dropping invalid ones. A CPE is a cpe23Type or
+// cpe22Type reference depending on its binding, and is added in both
+// bindings if the options ask for it. It reports whether the package has a
+// valid identifier.
+func addPackageIdentifiers(p *spdx.Package, c cdx.Component, opts *ConvertOptions) bool {
+	valid := false
+	if c.PackageURL != "" {
+		if err := validatePURL(c.PackageURL); err != nil {
+			opts.lose(c, Loss{"purl", LossDropped, err.Error()})
+		} else {
+			p.PackageExternalReferences = append(p.PackageExternalReferences, &spdx.PackageExternalReference{
+				Category: "SECURITY",
+				RefType:  "purl",
+				Locator:  c.PackageURL,
+			})
+			valid = true
+		}
+	}
+	if c.CPE != "" {
+		cpe, err := parseCPE(c.CPE)
+		if err != nil {
+			opts.lose(c, Loss{"cpe", LossDropped, err.Error()})
+			return valid
+		}
+		bindings := []string{cpe.binding}
+		if opts != nil && opts.CPEBothBindings {
+			if other, err := cpe.otherBinding(); err != nil {
+				log.Warningf("component %q: CPE %q has no %s binding: %v", c.BOMRef, c.CPE, cpe.otherRefType(), err)
+			} else {
+				bindings = append(bindings, other)
+			}
+		}
+		for _, name := range bindings {
+			p.PackageExternalReferences = append(p.PackageExternalReferences, &spdx.PackageExternalReference{
+				Category: "SECURITY",
+				RefType:  cpeRefType(name),
+				Locator:  name,
+			})
+		}
+		valid = true
+	}
+	return valid
+}
+
+// persistentIDAnnotationPrefix starts the annotations that keep the OmniBOR
+// IDs and SWHIDs of file components, since SPDX 2.3 files have no external
+// references, such as "Persistent ID: gitoid:blob:sha256:<hex>".
+const persistentIDAnnotationPrefix = "Persistent ID: "
+
+// spdxPersistentIDs returns the OmniBOR IDs and then the SWHIDs of a
+// component as PERSISTENT-ID gitoid and swh references, dropping invalid
+// ones and the qualifiers of SWHIDs.
+func spdxPersistentIDs(c cdx.Component, opts *ConvertOptions) []*spdx.PackageExternalReference {
+	var refs []*spdx.PackageExternalReference
+	for _, ids := range []struct {
+		field string
+		list  *[]string
+	}{{"omniborId", c.OmniborID}, {"swhid", c.SWHID}} {
+		if ids.list == nil {
+			continue
+		}
+		for _, id := range *ids.list {
+			refType, locator, err := spdxPersistentID(id)
+			if err != nil {
+				opts.lose(c, Loss{ids.field, LossDropped, err.Error()})
+				continue
+			}
+			if locator != id {
+				opts.lose(c, Loss{ids.field, LossDegraded, fmt.Sprintf("qualifiers of SWHID %s", locator)})
+			}
+			refs = append(refs, &spdx.PackageExternalReference{
+				Category: "PERSISTENT-ID",
+				RefType:  refType,
+				Locator:  locator,
+			})
+		}
+	}
+	return refs
+}
+
+// addPersistentIDAnnotations annotates the SPDX file converted from a file
+// component with the component's OmniBOR IDs and SWHIDs.
+func addPersistentIDAnnotations(spdxDoc *spdx.Document, c cdx.Component, opts *ConvertOptions) {
+	for _, ref := range spdxPersistentIDs(c, opts) {
+		addAnnotation(spdxDoc, toSPDXElementID(c.BOMRef), persistentIDAnnotationPrefix+ref.Locator, opts)
+	}
+}
+
+// spdxPersistentID validates an OmniBOR gitoid or a SWHID and returns its
+// SPDX reference type and locator. SPDX only allows core SWHIDs, so the
+// qualifiers of a SWHID, such as ";origin=...", are removed.
+func spdxPersistentID(id string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(id, "gitoid:"):
+		if !gitoidRE.MatchString(id) {
+			return "", "", fmt.Errorf("invalid OmniBOR gitoid %q", id)
+		}
+		return "gitoid", id, nil
+	case strings.HasPrefix(id, "swh:"):
+		core, _, _ := strings.Cut(id, ";")
+		if !swhidRE.MatchString(core) {
+			return "", "", fmt.Errorf("invalid SWHID %q", id)
+		}
+		return "swh", core, nil
+	}
+	return "", "", fmt.Errorf("invalid persistent ID %q, neither a gitoid nor a SWHID", id)
+}
+
+// cycloneDXPersistentID adds an OmniBOR gitoid or SWHID to a component.
+func cycloneDXPersistentID(c *cdx.Component, id string) {
+	list := &c.SWHID
+	if strings.HasPrefix(id, "gitoid:") {
+		list = &c.OmniborID
+	}
+	var ids []string
+	if *list != nil {
+		ids = **list
+	}
+	ids = append(ids, id)
+	*list = &ids
+}
+
+// cpeName is a CPE name in the CPE 2.2 URI binding, "cpe:/a:vendor:product",
+// or the CPE 2.3 formatted string binding, "cpe:2.3:a:vendor:product:*:...".
+type cpeName struct {
+	binding string
+	// attributes are the 11 attributes of the CPE 2.3 formatted string, in
+	// its quoting, with "*" for ANY and "-" for NA.
+	attributes []string
+}
+
+// parseCPE parses and validates a CPE name in either binding.
+func parseCPE(s string) (*cpeName, error) {
+	if strings.HasPrefix(strings.ToLower(s), "cpe:2.3:") {
+		if !cpe23RE.MatchString(s) {
+			return nil, fmt.Errorf("invalid CPE 2.3 formatted string %q", s)
+		}
+		return &cpeName{binding: s, attributes: splitCPE23(s[len("cpe:2.3:"):])}, nil
+	}
+	if !cpe22RE.MatchString(s) {
+		return nil, fmt.Errorf("invalid CPE name %q, neither a 2.2 URI nor a 2.3 formatted string", s)
+	}
+	attributes, err := unbindCPE22(s[len("cpe:/"):])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CPE 2.2 URI %q: %w", s, err)
+	}
+	return &cpeName{binding: s, attributes: attributes}, nil
+}
+
+func (c *cpeName) is23() bool {
+	return strings.HasPrefix(strings.ToLower(c.binding), "cpe:2.3:")
+}
+
+// otherRefType returns the SPDX reference type of the binding the name is
+// not written in.
+func (c *cpeName) otherRefType() string {
+	if c.is23() {
+		return "cpe22Type"
+	}
+	return "cpe23Type"
+}
+
+// otherBinding returns the name in the binding it is not written in.
+func (c *cpeName) otherBinding() (string, error) {
+	if c.is23() {
+		return bindCPE22(c.attributes), nil
+	}
+	s := "cpe:2.3:" + strings.Join(c.attributes, ":")
+	if !cpe23RE.MatchString(s) {
+		return "", fmt.Errorf("invalid CPE 2.3 formatted string %q", s)
+	}
+	return s, nil
+}
+
+// cpeRefType returns the SPDX reference type of a CPE name's binding.
+func cpeRefType(name string) string {
+	if strings.HasPrefix(strings.ToLower(name), "cpe:2.3:") {
+		return "cpe23Type"
+	}
+	return "cpe22Type"
+}
+
+// sameCPE reports whether two valid CPE names are the same name, possibly in
+// different bindings.
+func sameCPE(a, b string) bool {
+	ca, err := parseCPE(a)
+	if err != nil {
+		return false
+	}
+	cb, err := parseCPE(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(strings.Join(ca.attributes, ":"), strings.Join(cb.attributes, ":"))
+}
+
+// splitCPE23 splits the attributes of a formatted string at unquoted colons.
+func splitCPE23(s string) []string {
+	var attributes []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ':':
+			attributes = append(attributes, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(attributes, s[start:])
+}
+
+// bindCPE22 binds formatted string attributes to a CPE 2.2 URI as in
+// NISTIR 7695 section 6.1.3. The URI components are part to edition and
+// language, and the extended attributes sw_edition, target_sw, target_hw
+// and other are packed into the edition.
+func bindCPE22(attributes []string) string {
+	components := make([]string, 7)
+	for i := range components {
+		components[i] = cpe22Component(attributes[i])
+	}
+	if extended := attributes[7:]; strings.Join(extended, "") != "****" {
+		packed := []string{components[5]}
+		for _, a := range extended {
+			packed = append(packed, cpe22Component(a))
+		}
+		components[5] = "~" + strings.Join(packed, "~")
+	}
+	return strings.TrimRight("cpe:/"+strings.Join(components, ":"), ":")
+}
+
+// cpe22Component binds one formatted string attribute to the URI binding.
+func cpe22Component(a string) string {
+	switch a {
+	case "*":
+		return ""
+	case "-":
+		return "-"
+	}
+	var b strings.Builder
+	for i := 0; i < len(a); i++ {
+		ch := a[i]
+		switch {
+		case ch == '\\':
+			i++
+			if ch = a[i]; ch == '-' || ch == '.' {
+				b.WriteByte(ch)
+			} else {
+				fmt.Fprintf(&b, "%%%02x", ch)
+			}
+		case ch == '?':
+			b.WriteString("%01")
+		case ch == '*':
+			b.WriteString("%02")
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// unbindCPE22 returns the formatted string attributes of the components of
+// a CPE 2.2 URI after "cpe:/", unpacking the extended attributes from the
+// edition as in NISTIR 7695 section 6.1.3.
+func unbindCPE22(s string) ([]string, error) {
+	components := strings.Split(s, ":")
+	if len(components) > 7 {
+		return nil, fmt.Errorf("%d components, the URI has at most 7", len(components))
+	}
+	attributes := make([]string, 11)
+	for i := range attributes {
+		attributes[i] = "*"
+	}
+	for i, component := range components {
+		if i == 5 && strings.HasPrefix(component, "~") {
+			packed := strings.Split(component[1:], "~")
+			if len(packed) != 5 {
+				return nil, fmt.Errorf("packed edition %q does not have 5 fields", component)
+			}
+			for j, field := range packed {
+				a, err := cpe23Attribute(field)
+				if err != nil {
+					return nil, err
+				}
+				// The packed fields are edition, sw_edition, target_sw,
+				// target_hw and other.
+				if j == 0 {
+					attributes[5] = a
+				} else {
+					attributes[6+j] = a
+				}
+			}
+			continue
+		}
+		a, err := cpe23Attribute(component)
+		if err != nil {
+			return nil, err
+		}
+		attributes[i] = a
+	}
+	return attributes, nil
+}
+
+// cpe23Attribute converts one URI component to a formatted string attribute.
+func cpe23Attribute(component string) (string, error) {
+	switch component {
+	case "":
+		return "*", nil
+	case "-":
+		return "-", nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(component); i++ {
+		ch := component[i]
+		if ch == '%' {
+			if i+2 >= len(component) {
+				return "", fmt.Errorf("truncated percent encoding in %q", component)
+			}
+			n, err := strconv.ParseUint(component[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid percent encoding in %q", component)
+			}
+			i += 2
+			switch n {
+			case 0x01:
+				b.WriteByte('?')
+				continue
+			case 0x02:
+				b.WriteByte('*')
+				continue
+			}
+			ch = byte(n)
+		}
+		if isCPEAlphanumeric(ch) {
+			b.WriteByte(ch)
+		} else {
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		}
+	}
+	return b.String(), nil
+}
+
+// isCPEAlphanumeric reports whether a character is not quoted in a
+// formatted string.
+func isCPEAlphanumeric(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' ||
+		ch == '_' || ch == '-' || ch == '.'
+}
+
+// validatePURL checks that a package URL has the "pkg:type/name" form with
+// an optional namespace, version, qualifiers and subpath, as in the purl
+// specification.
+func validatePURL(purl string) error {
+	if len(purl) < 4 || !strings.EqualFold(purl[:4], "pkg:") {
+		return fmt.Errorf("invalid purl %q: scheme is not pkg", purl)
+	}
+	rest := strings.TrimLeft(purl[4:], "/")
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, qualifiers, hasQualifiers := strings.Cut(rest, "?")
+	purlType, path, ok := strings.Cut(rest, "/")
+	if !ok || !purlTypeRE.MatchString(purlType) {
+		return fmt.Errorf("invalid purl %q: missing or invalid type", purl)
+	}
+	path = strings.TrimRight(path, "/")
+	name := path[strings.LastIndex(path, "/")+1:]
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if _, err := url.PathUnescape(segment); err != nil {
+			return fmt.Errorf("invalid purl %q: %w", purl, err)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("invalid purl %q: missing name", purl)
+	}
+	if hasQualifiers {
+		seen := map[string]bool{}
+		for _, qualifier := range strings.Split(qualifiers, "&") {
+			key, value, ok := strings.Cut(qualifier, "=")
+			key = strings.ToLower(key)
+			if !ok || value == "" || !purlQualifierKeyRE.MatchString(key) || seen[key] {
+				return fmt.Errorf("invalid purl %q: invalid qualifier %q", purl, qualifier)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
+
+var (
+	// cpe23RE is the CPE 2.3 formatted string pattern of the CPE, SPDX and
+	// CycloneDX schemas.
+	cpe23RE = regexp.MustCompile(`^cpe:2\.3:[aho\*\-](:(((\?*|\*?)([a-zA-Z0-9\-\._]|(\\[\\\*\?!"#$%&'\(\)\+,/:;<=>@\[\]\^` + "`" + `\{\|}~]))+(\?*|\*?))|[\*\-])){5}(:(([a-zA-Z]{2,3}(-([a-zA-Z]{2}|[0-9]{3}))?)|[\*\-]))(:(((\?*|\*?)([a-zA-Z0-9\-\._]|(\\[\\\*\?!"#$%&'\(\)\+,/:;<=>@\[\]\^` + "`" + `\{\|}~]))+(\?*|\*?))|[\*\-])){4}$`)
+	// cpe22RE is the CPE 2.2 URI pattern of the CPE and SPDX schemas.
+	cpe22RE = regexp.MustCompile(`^[cC][pP][eE]:/[AHOaho]?(:[A-Za-z0-9\._\-~%]*){0,6}$`)
+
+	gitoidRE = regexp.MustCompile(`^gitoid:(blob|tree|commit|tag):(sha1:[0-9a-f]{40}|sha256:[0-9a-f]{64})$`)
+	swhidRE  = regexp.MustCompile(`^swh:1:(cnt|dir|rev|rel|snp):[0-9a-f]{40}$`)
+
+	purlTypeRE         = regexp.MustCompile(`^[a-zA-Z\.\+\-][a-zA-Z0-9\.\+\-]*$`)
+	purlQualifierKeyRE = regexp.MustCompile(`^[a-z\.\-_][a-z0-9\.\-_]*$`)
+)
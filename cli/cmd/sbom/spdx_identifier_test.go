@@ -0,0 +1,226 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPE(t *testing.T) {
+	// The examples are from NISTIR 7695 section 6.1.3.
+	tests := []struct {
+		fs  string
+		uri string
+	}{
+		{"cpe:2.3:a:microsoft:internet_explorer:8.0.6001:beta:*:*:*:*:*:*", "cpe:/a:microsoft:internet_explorer:8.0.6001:beta"},
+		{"cpe:2.3:a:hp:insight_diagnostics:7.4.0.1570:-:*:*:online:win2003:x64:*", "cpe:/a:hp:insight_diagnostics:7.4.0.1570:-:~~online~win2003~x64~"},
+		{`cpe:2.3:a:foo\\bar:big\$money_manager_2010:*:*:*:*:special:ipod_touch:80gb:*`, "cpe:/a:foo%5cbar:big%24money_manager_2010:::~~special~ipod_touch~80gb~"},
+		{"cpe:2.3:o:linux:linux_kernel:5.10.0:*:*:en-us:*:*:*:*", "cpe:/o:linux:linux_kernel:5.10.0:::en-us"},
+		{`cpe:2.3:a:example:lib\?:1.0\*:*:*:*:*:*:*:*`, "cpe:/a:example:lib%3f:1.0%2a"},
+	}
+	for _, tc := range tests {
+		fs, err := parseCPE(tc.fs)
+		require.NoError(t, err, tc.fs)
+		got, err := fs.otherBinding()
+		require.NoError(t, err, tc.fs)
+		assert.Equal(t, tc.uri, got, tc.fs)
+
+		uri, err := parseCPE(tc.uri)
+		require.NoError(t, err, tc.uri)
+		got, err = uri.otherBinding()
+		require.NoError(t, err, tc.uri)
+		assert.Equal(t, tc.fs, got, tc.uri)
+
+		assert.True(t, sameCPE(tc.fs, tc.uri))
+	}
+
+	for _, invalid := range []string{
+		"",
+		"cpe:2.3:a:example:lib:1.0.0",
+		"cpe:2.3:x:example:lib:1.0.0:*:*:*:*:*:*:*",
+		"cpe:2.3:a:example:lib 2:1.0.0:*:*:*:*:*:*:*",
+		"cpe:/a:example:lib:1.0:update:edition:en:extra",
+		"cpe:/a:example:lib:1.0::~online~win2003",
+		"cpe:/a:example:lib%2",
+		"example:lib:1.0",
+	} {
+		_, err := parseCPE(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestValidatePURL(t *testing.T) {
+	for _, valid := range []string{
+		"pkg:golang/example.com/lib@v1.0.0",
+		"pkg:npm/%40angular/core@16.0.0",
+		"pkg:npm/@angular/core@16.0.0",
+		"pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie",
+		"pkg:maven/org.apache.commons/io@1.3.4#src/main",
+		"pkg:generic/openssl",
+		"pkg://pypi/django@1.11.1",
+	} {
+		assert.NoError(t, validatePURL(valid), valid)
+	}
+	for _, invalid := range []string{
+		"",
+		"golang/example.com/lib@v1.0.0",
+		"pkg:lib",
+		"pkg:1go/lib",
+		"pkg:golang/",
+		"pkg:generic/lib@1.0?arch",
+		"pkg:generic/lib@1.0?arch=x86&arch=arm",
+		"pkg:generic/%zzlib",
+	} {
+		assert.Error(t, validatePURL(invalid), invalid)
+	}
+}
+
+func TestAddPackageIdentifiers(t *testing.T) {
+	tests := []struct {
+		name      string
+		component cdx.Component
+		opts      *ConvertOptions
+		want      []*spdx.PackageExternalReference
+		wantValid bool
+	}{{
+		name: "none",
+	}, {
+		name: "cpe 2.3",
+		component: cdx.Component{
+			PackageURL: "pkg:generic/lib@1.0.0",
+			CPE:        "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*",
+		},
+		want: []*spdx.PackageExternalReference{
+			{Category: "SECURITY", RefType: "purl", Locator: "pkg:generic/lib@1.0.0"},
+			{Category: "SECURITY", RefType: "cpe23Type", Locator: "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*"},
+		},
+		wantValid: true,
+	}, {
+		name:      "cpe 2.2",
+		component: cdx.Component{CPE: "cpe:/a:example:lib:1.0.0"},
+		want: []*spdx.PackageExternalReference{
+			{Category: "SECURITY", RefType: "cpe22Type", Locator: "cpe:/a:example:lib:1.0.0"},
+		},
+		wantValid: true,
+	}, {
+		name:      "both bindings",
+		component: cdx.Component{CPE: "cpe:/a:example:lib:1.0.0"},
+		opts:      NewConvertOptions(WithCPEBothBindings()),
+		want: []*spdx.PackageExternalReference{
+			{Category: "SECURITY", RefType: "cpe22Type", Locator: "cpe:/a:example:lib:1.0.0"},
+			{Category: "SECURITY", RefType: "cpe23Type", Locator: "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*"},
+		},
+		wantValid: true,
+	}, {
+		name: "invalid",
+		component: cdx.Component{
+			PackageURL: "lib@1.0.0",
+			CPE:        "cpe:2.3:a:example:lib",
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var p spdx.Package
+			assert.Equal(t, tc.wantValid, addPackageIdentifiers(&p, tc.component, tc.opts))
+			assert.Equal(t, tc.want, p.PackageExternalReferences)
+		})
+	}
+}
+
+func TestCPEBothBindingsRoundTrip(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Components = &[]cdx.Component{{
+		BOMRef: "lib",
+		Type:   cdx.ComponentTypeLibrary,
+		Name:   "lib",
+		CPE:    "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*",
+	}}
+	spdxDoc, err := ConvertToGoogleSPDX(bom, WithCPEBothBindings())
+	require.NoError(t, err)
+	got, err := ConvertToCycloneDX(spdxDoc)
+	require.NoError(t, err)
+
+	require.NotNil(t, got.Metadata.Component)
+	assert.Equal(t, "cpe:2.3:a:example:lib:1.0.0:*:*:*:*:*:*:*", got.Metadata.Component.CPE)
+	assert.Nil(t, got.Metadata.Component.ExternalReferences)
+}
+
+func TestSPDXPersistentID(t *testing.T) {
+	tests := []struct {
+		id          string
+		wantType    string
+		wantLocator string
+	}{
+		{helloGitoid, "gitoid", helloGitoid},
+		{"gitoid:commit:sha1:261eeb9e9f8b2b4b0d119366dda99c6fd7d35c64", "gitoid", "gitoid:commit:sha1:261eeb9e9f8b2b4b0d119366dda99c6fd7d35c64"},
+		{"swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2", "swh", "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2"},
+		{
+			"swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2;origin=https://github.com/example/lib;lines=1-9",
+			"swh", "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2",
+		},
+		{"gitoid:blob:sha256:0bd6", "", ""},
+		{"gitoid:blob:md5:0bd69098bd9b9cc5934a610ab65da429", "", ""},
+		{"swh:2:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2", "", ""},
+		{"94a9ed024d3859793618152ea559a168bbcbb5e2", "", ""},
+	}
+	for _, tc := range tests {
+		refType, locator, err := spdxPersistentID(tc.id)
+		if tc.wantType == "" {
+			assert.Error(t, err, tc.id)
+			continue
+		}
+		require.NoError(t, err, tc.id)
+		assert.Equal(t, tc.wantType, refType, tc.id)
+		assert.Equal(t, tc.wantLocator, locator, tc.id)
+	}
+}
+
+func TestPersistentIDsRoundTrip(t *testing.T) {
+	swhid := "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2"
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef:    "app",
+		Type:      cdx.ComponentTypeApplication,
+		Name:      "app",
+		OmniborID: &[]string{helloGitoid},
+		SWHID:     &[]string{swhid},
+		Components: &[]cdx.Component{{
+			BOMRef:    "tool",
+			Type:      cdx.ComponentTypeFile,
+			Name:      "bin/tool",
+			OmniborID: &[]string{helloGitoid},
+		}},
+	}}
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	require.Len(t, spdxDoc.Packages, 1)
+	assert.Equal(t, []*spdx.PackageExternalReference{
+		{Category: "PERSISTENT-ID", RefType: "gitoid", Locator: helloGitoid},
+		{Category: "PERSISTENT-ID", RefType: "swh", Locator: swhid},
+	}, spdxDoc.Packages[0].PackageExternalReferences)
+	require.Len(t, spdxDoc.Files, 1)
+	require.Len(t, spdxDoc.Files[0].Annotations, 1)
+	assert.Equal(t, "Persistent ID: "+helloGitoid, spdxDoc.Files[0].Annotations[0].AnnotationComment)
+
+	b, err := SPDXToTagValue(spdxDoc)
+	require.NoError(t, err)
+	got, err := spdxtv.Read(bytes.NewReader(b))
+	require.NoError(t, err)
+	gotBOM, err := ConvertToCycloneDX(got)
+	require.NoError(t, err)
+
+	app := gotBOM.Metadata.Component
+	require.NotNil(t, app)
+	assert.Equal(t, &[]string{helloGitoid}, app.OmniborID)
+	assert.Equal(t, &[]string{swhid}, app.SWHID)
+	assert.Nil(t, app.ExternalReferences)
+	require.NotNil(t, app.Components)
+	assert.Equal(t, &[]string{helloGitoid}, (*app.Components)[0].OmniborID)
+	assert.Nil(t, gotBOM.Annotations)
+}
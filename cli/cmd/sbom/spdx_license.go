@@ -0,0 +1,163 @@
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+)
+
+const licenseRefPrefix = "LicenseRef-"
+
+// spdxLicenses maps CycloneDX licenses to the SPDX declared and concluded
+// license fields of the element converted from a component.
+//
+// Licenses acknowledged as concluded go to the concluded field, all others,
+// including expressions, to the declared field. Several licenses in one field
+// are combined with AND. Licenses that are not on the SPDX license list are
+// added to the document's other licenses under a LicenseRef- identifier, as
+// are the LicenseRef- identifiers that expressions use. Missing fields are
+// NOASSERTION.
+func spdxLicenses(c cdx.Component, spdxDoc *spdx.Document, opts *ConvertOptions) (declared, concluded string) {
+	var declaredTerms, concludedTerms []string
+	if c.Licenses != nil {
+		for _, choice := range *c.Licenses {
+			if choice.Expression != "" {
+				addExpressionLicenseRefs(choice.Expression, spdxDoc)
+				declaredTerms = append(declaredTerms, choice.Expression)
+				continue
+			}
+			if choice.License == nil {
+				continue
+			}
+			term := spdxLicenseID(c, choice.License, spdxDoc, opts)
+			if term == "" {
+				opts.lose(c, Loss{"licenses", LossDropped, "license without id or name"})
+				continue
+			}
+			if choice.License.Acknowledgement == cdx.LicenseAcknowledgementConcluded {
+				concludedTerms = append(concludedTerms, term)
+			} else {
+				declaredTerms = append(declaredTerms, term)
+			}
+		}
+	}
+	return joinLicenses(declaredTerms), joinLicenses(concludedTerms)
+}
+
+// spdxLicenseID returns the SPDX identifier for a CycloneDX license, adding
+// an other license entry for licenses outside the SPDX license list. The
+// SPDX license list has the text and URL of listed licenses, so those of the
+// component are dropped.
+func spdxLicenseID(c cdx.Component, l *cdx.License, spdxDoc *spdx.Document, opts *ConvertOptions) string {
+	if l.ID != "" && !strings.HasPrefix(l.ID, licenseRefPrefix) {
+		if l.URL != "" || l.Text != nil {
+			opts.lose(c, Loss{"licenses", LossDropped, fmt.Sprintf("text and URL of SPDX license %s", l.ID)})
+		}
+		return l.ID
+	}
+	name := l.Name
+	if name == "" {
+		name = strings.TrimPrefix(l.ID, licenseRefPrefix)
+	}
+	if name == "" {
+		return ""
+	}
+
+	base := licenseRefPrefix + spdxIDInvalidChars.ReplaceAllString(name, "-")
+	id := base
+	for i := 2; ; i++ {
+		existing := findOtherLicense(spdxDoc, id)
+		if existing == nil {
+			break
+		}
+		if existing.LicenseName == name {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	other := &spdx.OtherLicense{
+		LicenseIdentifier: id,
+		LicenseName:       name,
+		ExtractedText:     licenseText(c, l, opts),
+	}
+	if l.URL != "" {
+		other.LicenseCrossReferences = []string{l.URL}
+	}
+	spdxDoc.OtherLicenses = append(spdxDoc.OtherLicenses, other)
+	return id
+}
+
+// addExpressionLicenseRefs adds the LicenseRef- identifiers of a license
+// expression that the document does not define to its other licenses. Their
+// text is unknown. Identifiers of other documents, such as
+// DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2, are defined there.
+func addExpressionLicenseRefs(expression string, spdxDoc *spdx.Document) {
+	for _, m := range licenseRefRE.FindAllStringSubmatch(expression, -1) {
+		id := m[2]
+		if m[1] != "" || findOtherLicense(spdxDoc, id) != nil {
+			continue
+		}
+		spdxDoc.OtherLicenses = append(spdxDoc.OtherLicenses, &spdx.OtherLicense{
+			LicenseIdentifier: id,
+			LicenseName:       strings.TrimPrefix(id, licenseRefPrefix),
+			ExtractedText:     "NOASSERTION",
+		})
+	}
+}
+
+// licenseRefRE matches the LicenseRef- identifiers of a license expression,
+// with the DocumentRef- of another document they may be prefixed with.
+var licenseRefRE = regexp.MustCompile(`(DocumentRef-[A-Za-z0-9.\-]+:)?(LicenseRef-[A-Za-z0-9.\-]+)`)
+
+func findOtherLicense(spdxDoc *spdx.Document, id string) *spdx.OtherLicense {
+	for _, other := range spdxDoc.OtherLicenses {
+		if other != nil && other.LicenseIdentifier == id {
+			return other
+		}
+	}
+	return nil
+}
+
+// licenseText returns the attached license text, or NOASSERTION when the
+// license has none.
+func licenseText(c cdx.Component, l *cdx.License, opts *ConvertOptions) string {
+	if l.Text == nil || l.Text.Content == "" {
+		return "NOASSERTION"
+	}
+	text, ok := attachedText(l.Text)
+	if !ok {
+		opts.lose(c, Loss{"licenses", LossDropped, fmt.Sprintf("invalid base64 text of license %s", l.Name)})
+		return "NOASSERTION"
+	}
+	return text
+}
+
+// joinLicenses combines distinct license terms with AND, wrapping compound
+// expressions in parentheses.
+func joinLicenses(terms []string) string {
+	var parts []string
+	seen := map[string]bool{}
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		parts = append(parts, term)
+	}
+	switch len(parts) {
+	case 0:
+		return "NOASSERTION"
+	case 1:
+		return parts[0]
+	}
+	for i, part := range parts {
+		if isCompoundLicense(part) {
+			parts[i] = "(" + part + ")"
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
@@ -0,0 +1,94 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPDXLicenses(t *testing.T) {
+	tests := []struct {
+		desc          string
+		licenses      *cdx.Licenses
+		wantDeclared  string
+		wantConcluded string
+		wantOther     []*spdx.OtherLicense
+	}{{
+		desc:          "no licenses",
+		wantDeclared:  "NOASSERTION",
+		wantConcluded: "NOASSERTION",
+	}, {
+		desc:          "expression",
+		licenses:      &cdx.Licenses{{Expression: "MIT OR Apache-2.0"}},
+		wantDeclared:  "MIT OR Apache-2.0",
+		wantConcluded: "NOASSERTION",
+	}, {
+		desc: "expression with license refs",
+		licenses: &cdx.Licenses{
+			{License: &cdx.License{ID: "LicenseRef-bar"}},
+			{Expression: "MIT OR LicenseRef-foo WITH Classpath-exception-2.0"},
+			{Expression: "LicenseRef-bar AND DocumentRef-other:LicenseRef-baz OR LicenseRef-foo"},
+		},
+		wantDeclared:  "LicenseRef-bar AND (MIT OR LicenseRef-foo WITH Classpath-exception-2.0) AND (LicenseRef-bar AND DocumentRef-other:LicenseRef-baz OR LicenseRef-foo)",
+		wantConcluded: "NOASSERTION",
+		wantOther: []*spdx.OtherLicense{
+			{LicenseIdentifier: "LicenseRef-bar", LicenseName: "bar", ExtractedText: "NOASSERTION"},
+			{LicenseIdentifier: "LicenseRef-foo", LicenseName: "foo", ExtractedText: "NOASSERTION"},
+		},
+	}, {
+		desc: "acknowledged ids",
+		licenses: &cdx.Licenses{
+			{License: &cdx.License{ID: "MIT", Acknowledgement: cdx.LicenseAcknowledgementDeclared}},
+			{License: &cdx.License{ID: "Apache-2.0", Acknowledgement: cdx.LicenseAcknowledgementConcluded}},
+		},
+		wantDeclared:  "MIT",
+		wantConcluded: "Apache-2.0",
+	}, {
+		desc: "several licenses are combined",
+		licenses: &cdx.Licenses{
+			{License: &cdx.License{ID: "MIT"}},
+			{License: &cdx.License{ID: "GPL-2.0-only OR BSD-3-Clause"}},
+		},
+		wantDeclared:  "MIT AND (GPL-2.0-only OR BSD-3-Clause)",
+		wantConcluded: "NOASSERTION",
+	}, {
+		desc: "named licenses",
+		licenses: &cdx.Licenses{
+			{License: &cdx.License{
+				Name: "Example Commercial License",
+				URL:  "https://example.com/license",
+				Text: &cdx.AttachedText{Content: "TGljZW5zZSB0ZXh0", Encoding: "base64"},
+			}},
+			{License: &cdx.License{Name: "Example Commercial License"}},
+			{License: &cdx.License{Name: "Example/Commercial License", Acknowledgement: cdx.LicenseAcknowledgementConcluded}},
+			{License: &cdx.License{ID: "LicenseRef-internal"}},
+		},
+		wantDeclared:  "LicenseRef-Example-Commercial-License AND LicenseRef-internal",
+		wantConcluded: "LicenseRef-Example-Commercial-License-2",
+		wantOther: []*spdx.OtherLicense{{
+			LicenseIdentifier:      "LicenseRef-Example-Commercial-License",
+			LicenseName:            "Example Commercial License",
+			ExtractedText:          "License text",
+			LicenseCrossReferences: []string{"https://example.com/license"},
+		}, {
+			LicenseIdentifier: "LicenseRef-Example-Commercial-License-2",
+			LicenseName:       "Example/Commercial License",
+			ExtractedText:     "NOASSERTION",
+		}, {
+			LicenseIdentifier: "LicenseRef-internal",
+			LicenseName:       "internal",
+			ExtractedText:     "NOASSERTION",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			spdxDoc := &spdx.Document{}
+			declared, concluded := spdxLicenses(cdx.Component{Licenses: tt.licenses}, spdxDoc, nil)
+			assert.Equal(t, tt.wantDeclared, declared)
+			assert.Equal(t, tt.wantConcluded, concluded)
+			assert.Equal(t, tt.wantOther, spdxDoc.OtherLicenses)
+		})
+	}
+}
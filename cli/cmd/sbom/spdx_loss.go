@@ -0,0 +1,246 @@
+package sbom
+
+import (
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// LossReport lists the parts of a CycloneDX BOM that a conversion to SPDX
+// dropped or could only carry over in a degraded form.
+type LossReport struct {
+	// Document lists the losses of BOM level data.
+	Document []Loss `json:"document,omitempty"`
+	// Components lists the components with losses, in document order.
+	Components []ComponentLoss `json:"components,omitempty"`
+}
+
+// ComponentLoss lists the losses of one component.
+type ComponentLoss struct {
+	// BOMRef is the component's bom-ref, empty if it had none.
+	BOMRef string `json:"bomRef,omitempty"`
+	// SPDXID is the ID of the SPDX element the component was converted
+	// to, empty if it was dropped.
+	SPDXID string `json:"spdxId,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Losses []Loss `json:"losses"`
+}
+
+// Loss describes a field that was dropped or degraded.
+type Loss struct {
+	Field  string   `json:"field"`
+	Kind   LossKind `json:"kind"`
+	Detail string   `json:"detail,omitempty"`
+}
+
+// LossKind tells whether a field was dropped or degraded.
+type LossKind string
+
+const (
+	LossDropped  LossKind = "dropped"
+	LossDegraded LossKind = "degraded"
+)
+
+// lossCollector gathers the losses of a conversion where it drops or
+// degrades BOM data. Components are listed in the order they are converted.
+type lossCollector struct {
+	document   []Loss
+	components []*ComponentLoss
+	byRef      map[string]*ComponentLoss
+	// originalRefs maps the refs assigned by normalizeBOMRefs back to the
+	// original bom-refs.
+	originalRefs map[string]string
+}
+
+func newLossCollector(bomRefs map[string]string) *lossCollector {
+	l := &lossCollector{
+		byRef:        map[string]*ComponentLoss{},
+		originalRefs: map[string]string{},
+	}
+	for original, ref := range bomRefs {
+		l.originalRefs[ref] = original
+	}
+	return l
+}
+
+// report returns the loss report, leaving out components without losses.
+func (l *lossCollector) report() *LossReport {
+	report := &LossReport{Document: l.document}
+	for _, cl := range l.components {
+		if len(cl.Losses) > 0 {
+			report.Components = append(report.Components, *cl)
+		}
+	}
+	return report
+}
+
+// trackComponent lists a component in the loss report. A component that is
+// not converted is dropped, and a converted one whose bom-ref is not its
+// SPDX ID is degraded.
+func (o *ConvertOptions) trackComponent(c cdx.Component) {
+	if o == nil || o.losses == nil {
+		return
+	}
+	original, hasRef := o.losses.originalRefs[c.BOMRef]
+	cl := &ComponentLoss{BOMRef: original, Name: c.Name}
+	o.losses.components = append(o.losses.components, cl)
+	o.losses.byRef[c.BOMRef] = cl
+	switch {
+	case !o.isScopeConverted(c.Scope):
+		o.lose(c, Loss{"component", LossDropped, fmt.Sprintf("component scope %q is not converted", c.Scope)})
+	case !o.isSPDXElement(c):
+		o.lose(c, Loss{"component", LossDropped, fmt.Sprintf("component type %q is not converted", c.Type)})
+	default:
+		cl.SPDXID = common.RenderElementID(toSPDXElementID(c.BOMRef))
+		if !hasRef {
+			cl.Losses = append(cl.Losses, Loss{"bom-ref", LossDegraded,
+				fmt.Sprintf("component has no bom-ref, generated SPDX ID %s", cl.SPDXID)})
+		} else if original != c.BOMRef {
+			cl.Losses = append(cl.Losses, Loss{"bom-ref", LossDegraded,
+				fmt.Sprintf("bom-ref is not a valid unique SPDX ID, converted to %s", cl.SPDXID)})
+		}
+	}
+}
+
+// lose logs a loss of component data and records it in the loss report.
+func (o *ConvertOptions) lose(c cdx.Component, l Loss) {
+	if l.Detail != "" {
+		log.Warningf("component %q: %s %s: %s", c.BOMRef, l.Kind, l.Field, l.Detail)
+	} else {
+		log.Warningf("component %q: %s %s", c.BOMRef, l.Kind, l.Field)
+	}
+	if o == nil || o.losses == nil {
+		return
+	}
+	if cl, ok := o.losses.byRef[c.BOMRef]; ok {
+		cl.Losses = append(cl.Losses, l)
+	}
+}
+
+// loseDocument logs a loss of BOM level data and records it in the loss
+// report.
+func (o *ConvertOptions) loseDocument(l Loss) {
+	log.Warningf("%s %s: %s", l.Kind, l.Field, l.Detail)
+	if o == nil || o.losses == nil {
+		return
+	}
+	o.losses.document = append(o.losses.document, l)
+}
+
+// originalRef returns the bom-ref a normalized ref was assigned for.
+func (o *ConvertOptions) originalRef(ref string) string {
+	if o != nil && o.losses != nil {
+		if original, ok := o.losses.originalRefs[ref]; ok {
+			return original
+		}
+	}
+	return ref
+}
+
+// unmappedField is a component field without an SPDX equivalent, and
+// whether the component has it.
+type unmappedField struct {
+	name    string
+	present bool
+}
+
+// dropUnmapped records the fields of a component that its SPDX element has
+// no place for. Unlike other losses they are not logged, as they are lost
+// for every component that has them.
+func (o *ConvertOptions) dropUnmapped(c cdx.Component, fields ...unmappedField) {
+	if o == nil || o.losses == nil {
+		return
+	}
+	cl, ok := o.losses.byRef[c.BOMRef]
+	if !ok {
+		return
+	}
+	for _, f := range fields {
+		if f.present {
+			cl.Losses = append(cl.Losses, Loss{Field: f.name, Kind: LossDropped})
+		}
+	}
+}
+
+// dropBOMData records the BOM level data that has no SPDX equivalent.
+// Vulnerabilities written to a VEX document are degraded rather than
+// dropped, unless they affect no converted component and are left out of
+// it.
+func dropBOMData(bom *cdx.BOM, bomRefs map[string]string, refMap map[string]cdx.Component, opts *ConvertOptions) {
+	var lifecycles, licenses int
+	if bom.Metadata != nil {
+		lifecycles = sliceLen(bom.Metadata.Lifecycles)
+		if bom.Metadata.Licenses != nil {
+			licenses = len(*bom.Metadata.Licenses)
+		}
+	}
+	for _, f := range []struct {
+		name, unit string
+		count      int
+	}{
+		{"services", "services", sliceLen(bom.Services)},
+		{"externalReferences", "external references", sliceLen(bom.ExternalReferences)},
+		{"compositions", "compositions", sliceLen(bom.Compositions)},
+		{"vulnerabilities", "vulnerabilities", sliceLen(bom.Vulnerabilities)},
+		{"formulation", "formulas", sliceLen(bom.Formulation)},
+		{"declarations", "declarations", boolCount(bom.Declarations != nil)},
+		{"definitions", "definitions", boolCount(bom.Definitions != nil)},
+		{"metadata.lifecycles", "lifecycles", lifecycles},
+		{"metadata.licenses", "licenses", licenses},
+	} {
+		if f.count == 0 || f.name == "vulnerabilities" && opts.VEXFile != "" {
+			continue
+		}
+		opts.loseDocument(Loss{f.name, LossDropped, fmt.Sprintf("%d %s", f.count, f.unit)})
+	}
+	if opts.VEXFile == "" || bom.Vulnerabilities == nil {
+		return
+	}
+
+	var dropped []Loss
+	written := 0
+	for _, v := range *bom.Vulnerabilities {
+		converted := false
+		if v.Affects != nil {
+			for _, a := range *v.Affects {
+				ref := affectsRef(a.Ref, bom.SerialNumber)
+				if id, ok := bomRefs[ref]; ok {
+					ref = id
+				}
+				if c, ok := refMap[ref]; ok && opts.isSPDXElement(c) {
+					converted = true
+					break
+				}
+			}
+		}
+		if !converted {
+			dropped = append(dropped, Loss{"vulnerabilities", LossDropped,
+				fmt.Sprintf("vulnerability %q, it affects no converted component", v.ID)})
+			continue
+		}
+		written++
+	}
+	if written > 0 {
+		opts.loseDocument(Loss{"vulnerabilities", LossDegraded,
+			fmt.Sprintf("%d vulnerabilities, written to the VEX document %s", written, opts.VEXFile)})
+	}
+	for _, l := range dropped {
+		opts.loseDocument(l)
+	}
+}
+
+func sliceLen[T any](s *[]T) int {
+	if s == nil {
+		return 0
+	}
+	return len(*s)
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLossReport(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Component: &cdx.Component{
+			BOMRef:     "app",
+			Type:       cdx.ComponentTypeApplication,
+			Name:       "app",
+			Components: &[]cdx.Component{{BOMRef: "app-key", Type: cdx.ComponentTypeCryptographicAsset, Name: "app key"}},
+		},
+		Properties: &[]cdx.Property{{Name: "build", Value: "42"}},
+	}
+	bom.Components = &[]cdx.Component{{
+		BOMRef:    "pkg:golang/example.com/lib@v1.0.0",
+		Type:      cdx.ComponentTypeLibrary,
+		Name:      "lib",
+		Group:     "example.com",
+		CPE:       "cpe:2.3:a:example:lib:1.0.0",
+		Hashes:    &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "aa"}, {Algorithm: cdx.HashAlgoSHA256, Value: "BB"}},
+		Licenses:  &cdx.Licenses{{License: &cdx.License{ID: "MIT", URL: "https://opensource.org/license/mit"}}},
+		Supplier:  &cdx.OrganizationalEntity{Name: "Example", URL: &[]string{"https://example.com"}},
+		Authors:   &[]cdx.OrganizationalContact{{Name: "Jane Doe"}, {Name: "John Doe"}},
+		Publisher: "Example Publishing",
+		SWHID:     &[]string{"swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2;origin=https://example.com/lib"},
+		OmniborID: &[]string{"gitoid:blob:sha256:0bd6"},
+		ExternalReferences: &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeWebsite, URL: "https://example.com/lib docs"},
+			{Type: cdx.ERTypeDistribution, URL: "https://example.com/a.tgz"},
+			{
+				Type:   cdx.ERTypeDistribution,
+				URL:    "https://example.com/b.tgz",
+				Hashes: &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "cc"}},
+			},
+		},
+	}, {
+		Type:    cdx.ComponentTypeFile,
+		Name:    "README",
+		Version: "1",
+	}, {
+		BOMRef: "svc",
+		Type:   cdx.ComponentTypeCryptographicAsset,
+		Name:   "key",
+	}}
+	bom.Dependencies = &[]cdx.Dependency{{Ref: "app", Dependencies: &[]string{"svc"}}}
+	bom.Services = &[]cdx.Service{{Name: "api"}}
+	bom.Annotations = &[]cdx.Annotation{{
+		Subjects: &[]cdx.BOMReference{"app", "svc"},
+		Text:     "reviewed",
+	}}
+
+	conversion, err := ConvertCycloneDXToSPDX(bom, WithPackagePurposes(map[cdx.ComponentType]string{
+		cdx.ComponentTypeCryptographicAsset: "",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, &LossReport{
+		Document: []Loss{
+			{"services", LossDropped, "1 services"},
+			{"annotations", LossDropped, `annotation of "svc", not a converted component`},
+		},
+		Components: []ComponentLoss{{
+			BOMRef: "app",
+			SPDXID: "SPDXRef-app",
+			Name:   "app",
+			Losses: []Loss{
+				{"components", LossDropped, `CONTAINS relationship to "app-key", component not converted`},
+				{"dependencies", LossDropped, `dependency on "svc", component not converted`},
+			},
+		}, {
+			BOMRef: "app-key",
+			Name:   "app key",
+			Losses: []Loss{{"component", LossDropped, `component type "cryptographic-asset" is not converted`}},
+		}, {
+			BOMRef: "pkg:golang/example.com/lib@v1.0.0",
+			SPDXID: "SPDXRef-pkg-golang-example.com-lib-v1.0.0",
+			Name:   "lib",
+			Losses: []Loss{
+				{"bom-ref", LossDegraded, "bom-ref is not a valid unique SPDX ID, converted to SPDXRef-pkg-golang-example.com-lib-v1.0.0"},
+				{"cpe", LossDropped, `invalid CPE 2.3 formatted string "cpe:2.3:a:example:lib:1.0.0"`},
+				{"omniborId", LossDropped, `invalid OmniBOR gitoid "gitoid:blob:sha256:0bd6"`},
+				{"swhid", LossDegraded, "qualifiers of SWHID swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2"},
+				{"hashes", LossDropped, "conflicting SHA-256 hash BB"},
+				{"licenses", LossDropped, "text and URL of SPDX license MIT"},
+				{"supplier", LossDegraded, "address, URLs and further contacts are dropped"},
+				{"authors", LossDropped, "1 of 2 authors, SPDX has one originator"},
+				{Field: "publisher", Kind: LossDropped},
+				{"externalReferences", LossDropped, `website reference "https://example.com/lib docs", not a valid SPDX locator`},
+				{"externalReferences", LossDropped, "hashes of distribution reference https://example.com/b.tgz"},
+				{Field: "group", Kind: LossDropped},
+			},
+		}, {
+			SPDXID: "SPDXRef-README-1",
+			Name:   "README",
+			Losses: []Loss{
+				{"bom-ref", LossDegraded, "component has no bom-ref, generated SPDX ID SPDXRef-README-1"},
+				{Field: "version", Kind: LossDropped},
+				{"hashes", LossDegraded, "no SHA-1 hash, which SPDX requires for files"},
+			},
+		}, {
+			BOMRef: "svc",
+			Name:   "key",
+			Losses: []Loss{{"component", LossDropped, `component type "cryptographic-asset" is not converted`}},
+		}},
+	}, conversion.LossReport)
+}
+
+func TestLossReportLossless(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Components = &[]cdx.Component{{
+		BOMRef:     "lib",
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       "lib",
+		Version:    "1.0.0",
+		PackageURL: "pkg:generic/lib@1.0.0",
+		Licenses:   &cdx.Licenses{{Expression: "MIT OR Apache-2.0"}},
+	}}
+
+	conversion, err := ConvertCycloneDXToSPDX(bom)
+	require.NoError(t, err)
+	assert.Equal(t, &LossReport{}, conversion.LossReport)
+}
+
+func TestLossReportVEX(t *testing.T) {
+	bom := vexBOM()
+
+	conversion, err := ConvertCycloneDXToSPDX(bom)
+	require.NoError(t, err)
+	assert.Contains(t, conversion.LossReport.Document, Loss{"vulnerabilities", LossDropped, "4 vulnerabilities"})
+
+	conversion, err = ConvertCycloneDXToSPDX(bom, WithVEXFile("vex.json"))
+	require.NoError(t, err)
+	assert.Equal(t, []Loss{
+		{"vulnerabilities", LossDegraded, "3 vulnerabilities, written to the VEX document vex.json"},
+		{"vulnerabilities", LossDropped, `vulnerability "CVE-2024-0004", it affects no converted component`},
+	}, conversion.LossReport.Document)
+}
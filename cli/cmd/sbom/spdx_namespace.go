@@ -0,0 +1,64 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	log "k8s.io/klog"
+)
+
+// DefaultNamespaceBase is the default URI prefix of document namespaces.
+const DefaultNamespaceBase = "http://spdx.org/spdxdocs/"
+
+// spdxNamespace returns the document namespace for a BOM, of the form
+// "<base><document name>-<UUID>".
+//
+// The UUID is taken from the BOM serial number, or randomly generated if
+// the BOM has none. In deterministic mode it is derived from a hash of the
+// BOM instead, so converting the same BOM again yields the same namespace.
+func spdxNamespace(bom *cdx.BOM, name string, opts *ConvertOptions) string {
+	if opts == nil {
+		opts = defaultConvertOptions
+	}
+	base := opts.NamespaceBase
+	if base == "" {
+		base = DefaultNamespaceBase
+	}
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+
+	var id uuid.UUID
+	if opts.DeterministicNamespace {
+		id = bomUUID(bom)
+	} else if serialNumber := strings.TrimPrefix(bom.SerialNumber, "urn:uuid:"); serialNumber != "" {
+		var err error
+		if id, err = uuid.Parse(serialNumber); err != nil {
+			log.Warningf("invalid BOM serial number %q, generating a namespace UUID: %v", bom.SerialNumber, err)
+			id = uuid.New()
+		}
+	} else {
+		id = uuid.New()
+	}
+
+	if name == "" {
+		return base + id.String()
+	}
+	return base + url.PathEscape(name) + "-" + id.String()
+}
+
+// bomUUID derives a name based UUID from the SHA-256 of the BOM's JSON
+// encoding.
+func bomUUID(bom *cdx.BOM) uuid.UUID {
+	b, err := json.Marshal(bom)
+	if err != nil {
+		log.Warningf("failed to encode BOM for a deterministic namespace, generating a random one: %v", err)
+		return uuid.New()
+	}
+	sum := sha256.Sum256(b)
+	return uuid.NewSHA1(uuid.NameSpaceURL, sum[:])
+}
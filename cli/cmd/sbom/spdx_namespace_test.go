@@ -0,0 +1,62 @@
+package sbom
+
+import (
+	"regexp"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDXNamespace(t *testing.T) {
+	const uuidPattern = `[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`
+	bom := func(serialNumber string) *cdx.BOM {
+		bom := cdx.NewBOM()
+		bom.SerialNumber = serialNumber
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+			BOMRef: "root",
+			Type:   cdx.ComponentTypeFirmware,
+			Name:   "network os",
+		}}
+		return bom
+	}
+	namespace := func(bom *cdx.BOM, opts ...ConvertOption) string {
+		spdxDoc, err := ConvertToGoogleSPDX(bom, opts...)
+		require.NoError(t, err)
+		return spdxDoc.DocumentNamespace
+	}
+
+	t.Run("serial number", func(t *testing.T) {
+		assert.Equal(t, "http://spdx.org/spdxdocs/network%20os-3e671687-395b-41f5-a30f-a58921a69b79",
+			namespace(bom("urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79")))
+	})
+
+	t.Run("base URI", func(t *testing.T) {
+		assert.Equal(t, "https://sbom.example.com/spdx/network%20os-3e671687-395b-41f5-a30f-a58921a69b79",
+			namespace(bom("urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"),
+				WithNamespaceBase("https://sbom.example.com/spdx")))
+	})
+
+	t.Run("random UUID", func(t *testing.T) {
+		for _, serialNumber := range []string{"", "urn:uuid:not-a-uuid"} {
+			first := namespace(bom(serialNumber))
+			assert.Regexp(t, regexp.MustCompile(`^http://spdx.org/spdxdocs/network%20os-`+uuidPattern+`$`), first)
+			assert.NotEqual(t, first, namespace(bom(serialNumber)))
+		}
+	})
+
+	t.Run("no document name", func(t *testing.T) {
+		assert.Regexp(t, regexp.MustCompile(`^http://spdx.org/spdxdocs/`+uuidPattern+`$`), namespace(cdx.NewBOM()))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		first := namespace(bom(""), WithDeterministicNamespace())
+		assert.Regexp(t, regexp.MustCompile(`^http://spdx.org/spdxdocs/network%20os-`+uuidPattern+`$`), first)
+		assert.Equal(t, first, namespace(bom(""), WithDeterministicNamespace()))
+
+		changed := bom("")
+		changed.Version = 2
+		assert.NotEqual(t, first, namespace(changed, WithDeterministicNamespace()))
+	})
+}
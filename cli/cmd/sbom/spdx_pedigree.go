@@ -0,0 +1,256 @@
+package sbom
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	log "k8s.io/klog"
+)
+
+// addCycloneDXPedigreeComponents adds the ancestors, descendants and
+// variants of every component in the BOM that no other component has the
+// bom-ref of. Bom-refs are BOM wide, so this is done before dependencies,
+// which may refer to them.
+func addCycloneDXPedigreeComponents(
+	bom *cdx.BOM,
+	refMap map[string]cdx.Component,
+	typeMap map[string]int,
+	spdxDoc *spdx.Document,
+	opts *ConvertOptions,
+) error {
+	return walkComponents(bomComponents(bom), func(c cdx.Component) error {
+		if c.Pedigree == nil {
+			return nil
+		}
+		for _, related := range pedigreeLineage(c.Pedigree) {
+			if related.components == nil {
+				continue
+			}
+			for _, rc := range *related.components {
+				if _, ok := refMap[rc.BOMRef]; ok {
+					continue
+				}
+				if err := AddCycloneDXComponent(rc, refMap, typeMap, spdxDoc, opts); err != nil {
+					return fmt.Errorf("failed to add pedigree component %q of %q: %w", rc.BOMRef, c.BOMRef, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// addCycloneDXPedigrees maps the pedigree of every component in the BOM to
+// SPDX lineage relationships, see addCycloneDXPedigree.
+func addCycloneDXPedigrees(bom *cdx.BOM, refMap map[string]cdx.Component, spdxDoc *spdx.Document, opts *ConvertOptions) {
+	walkComponents(bomComponents(bom), func(c cdx.Component) error {
+		addCycloneDXPedigree(c, refMap, spdxDoc, opts)
+		return nil
+	})
+}
+
+// addCycloneDXPedigree maps the pedigree of a component to SPDX lineage
+// relationships.
+//
+// Ancestors, descendants and variants, added by
+// addCycloneDXPedigreeComponents, are related to the component with
+// DESCENDANT_OF, ANCESTOR_OF and VARIANT_OF. Patches, commits and notes are
+// annotated on the component, see addCycloneDXPatch.
+func addCycloneDXPedigree(c cdx.Component, refMap map[string]cdx.Component, spdxDoc *spdx.Document, opts *ConvertOptions) {
+	pedigree := c.Pedigree
+	if pedigree == nil {
+		return
+	}
+	if !opts.isSPDXElement(c) {
+		log.Warningf("dropping pedigree of %q, component not converted", c.BOMRef)
+		return
+	}
+
+	for _, l := range pedigreeLineage(pedigree) {
+		if l.components == nil {
+			continue
+		}
+		for _, rc := range *l.components {
+			if related, ok := refMap[rc.BOMRef]; !ok || !opts.isSPDXElement(related) {
+				log.Warningf("dropping %s relationship %q -> %q, component not converted",
+					l.relationship, c.BOMRef, rc.BOMRef)
+				continue
+			}
+			spdxDoc.Relationships = append(spdxDoc.Relationships, &v2_3.Relationship{
+				RefA:         toSPDXDocElementID(c.BOMRef),
+				RefB:         toSPDXDocElementID(rc.BOMRef),
+				Relationship: l.relationship,
+			})
+		}
+	}
+
+	if n := sliceLen(pedigree.Patches); n > 0 {
+		opts.lose(c, Loss{"pedigree.patches", LossDegraded, fmt.Sprintf("%d patches, kept as annotations", n)})
+		for i, patch := range *pedigree.Patches {
+			addCycloneDXPatch(c, i+1, patch, spdxDoc, opts)
+		}
+	}
+	if pedigree.Commits != nil {
+		for _, commit := range *pedigree.Commits {
+			addAnnotation(spdxDoc, toSPDXElementID(c.BOMRef), commitComment(commit), opts)
+		}
+	}
+	if pedigree.Notes != "" {
+		addAnnotation(spdxDoc, toSPDXElementID(c.BOMRef), "Pedigree notes: "+pedigree.Notes, opts)
+	}
+}
+
+type pedigreeRelation struct {
+	components   *[]cdx.Component
+	relationship string
+}
+
+// pedigreeLineage returns the ancestors, descendants and variants of a
+// pedigree with the SPDX relationship from the component to them.
+func pedigreeLineage(pedigree *cdx.Pedigree) []pedigreeRelation {
+	return []pedigreeRelation{
+		{pedigree.Ancestors, common.TypeRelationshipDescendantOf},
+		{pedigree.Descendants, common.TypeRelationshipAncestorOf},
+		{pedigree.Variants, common.TypeRelationshipVariantOf},
+	}
+}
+
+// bomComponents returns the metadata component and the top-level components
+// of a BOM.
+func bomComponents(bom *cdx.BOM) []cdx.Component {
+	var components []cdx.Component
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		components = append(components, *bom.Metadata.Component)
+	}
+	if bom.Components != nil {
+		components = append(components, *bom.Components...)
+	}
+	return components
+}
+
+// addCycloneDXPatch annotates a component with a patch: its type, diff and
+// the issues it resolves. A package whose download location is the diff URL
+// is the patch, and is PATCH_FOR the component.
+func addCycloneDXPatch(c cdx.Component, n int, patch cdx.Patch, spdxDoc *spdx.Document, opts *ConvertOptions) {
+	id := toSPDXElementID(c.BOMRef)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Patch %d", n)
+	if patch.Type != "" {
+		fmt.Fprintf(&b, " (%s)", patch.Type)
+	}
+	if patch.Diff != nil && patch.Diff.URL != "" {
+		fmt.Fprintf(&b, ": %s", patch.Diff.URL)
+		for _, p := range spdxDoc.Packages {
+			if p.PackageDownloadLocation == patch.Diff.URL && p.PackageSPDXIdentifier != id {
+				spdxDoc.Relationships = append(spdxDoc.Relationships, &v2_3.Relationship{
+					RefA:         common.DocElementID{ElementRefID: p.PackageSPDXIdentifier},
+					RefB:         common.DocElementID{ElementRefID: id},
+					Relationship: common.TypeRelationshipPatchFor,
+				})
+				break
+			}
+		}
+	}
+	if patch.Resolves != nil {
+		for _, issue := range *patch.Resolves {
+			fmt.Fprintf(&b, "\n%s", issueComment(issue))
+		}
+	}
+	if patch.Diff != nil && patch.Diff.Text != nil {
+		if diff, ok := attachedText(patch.Diff.Text); ok {
+			fmt.Fprintf(&b, "\n\nDiff:\n%s", diff)
+		} else {
+			opts.lose(c, Loss{"pedigree.patches", LossDropped, fmt.Sprintf("invalid diff of patch %d", n)})
+		}
+	}
+	addAnnotation(spdxDoc, id, b.String(), opts)
+}
+
+// attachedText returns the content of attached text, decoding base64.
+func attachedText(t *cdx.AttachedText) (string, bool) {
+	if t.Encoding != "base64" {
+		return t.Content, true
+	}
+	b, err := base64.StdEncoding.DecodeString(t.Content)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// issueComment describes an issue resolved by a patch, such as
+// "Resolves security issue CVE-2024-0001 (name): description".
+func issueComment(issue cdx.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Resolves %s issue %s", issue.Type, issue.ID)
+	if issue.Name != "" {
+		fmt.Fprintf(&b, " (%s)", issue.Name)
+	}
+	if issue.Description != "" {
+		fmt.Fprintf(&b, ": %s", issue.Description)
+	}
+	if issue.Source != nil {
+		fmt.Fprintf(&b, "\nSource: %s", strings.TrimSpace(issue.Source.Name+" "+issue.Source.URL))
+	}
+	if issue.References != nil && len(*issue.References) > 0 {
+		fmt.Fprintf(&b, "\nReferences: %s", strings.Join(*issue.References, " "))
+	}
+	return b.String()
+}
+
+// commitComment describes a commit in a component's pedigree.
+func commitComment(commit cdx.Commit) string {
+	var b strings.Builder
+	b.WriteString("Commit")
+	if commit.UID != "" {
+		fmt.Fprintf(&b, " %s", commit.UID)
+	}
+	if commit.URL != "" {
+		fmt.Fprintf(&b, " %s", commit.URL)
+	}
+	for _, action := range []struct {
+		role   string
+		action *cdx.IdentifiableAction
+	}{{"Author", commit.Author}, {"Committer", commit.Committer}} {
+		if action.action == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s: %s", action.role, spdxActor(action.action.Name, action.action.Email))
+		if action.action.Timestamp != "" {
+			fmt.Fprintf(&b, " at %s", action.action.Timestamp)
+		}
+	}
+	if commit.Message != "" {
+		fmt.Fprintf(&b, "\n\n%s", commit.Message)
+	}
+	return b.String()
+}
+
+// walkComponents calls fn for the components, their nested components and
+// their pedigree components, depth first and in document order.
+func walkComponents(components []cdx.Component, fn func(cdx.Component) error) error {
+	for _, c := range components {
+		if err := fn(c); err != nil {
+			return err
+		}
+		var children []cdx.Component
+		if c.Components != nil {
+			children = append(children, *c.Components...)
+		}
+		if p := c.Pedigree; p != nil {
+			for _, related := range []*[]cdx.Component{p.Ancestors, p.Descendants, p.Variants} {
+				if related != nil {
+					children = append(children, *related...)
+				}
+			}
+		}
+		if err := walkComponents(children, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
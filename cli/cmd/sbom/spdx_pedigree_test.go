@@ -0,0 +1,201 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPedigreeBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Timestamp: "2025-01-02T03:04:05Z"}
+	bom.Components = &[]cdx.Component{{
+		BOMRef:  "openssl",
+		Type:    cdx.ComponentTypeLibrary,
+		Name:    "openssl",
+		Version: "3.0.13-example1",
+		Pedigree: &cdx.Pedigree{
+			Ancestors: &[]cdx.Component{{
+				Type:       cdx.ComponentTypeLibrary,
+				Name:       "openssl",
+				Version:    "3.0.13",
+				PackageURL: "pkg:generic/openssl@3.0.13",
+			}},
+			Variants: &[]cdx.Component{{BOMRef: "openssl-fips"}},
+			Patches: &[]cdx.Patch{{
+				Type: cdx.PatchTypeBackport,
+				Diff: &cdx.Diff{
+					URL:  "https://example.com/patches/0001.patch",
+					Text: &cdx.AttachedText{Content: "LS0tIGEKKysrIGIK", Encoding: "base64"},
+				},
+				Resolves: &[]cdx.Issue{{
+					Type:        cdx.IssueTypeSecurity,
+					ID:          "CVE-2024-0727",
+					Name:        "PKCS12 NULL dereference",
+					Description: "Processing a maliciously formatted PKCS12 file may crash.",
+					Source:      &cdx.Source{Name: "NVD", URL: "https://nvd.nist.gov/"},
+					References:  &[]string{"https://www.openssl.org/news/secadv/20240125.txt"},
+				}},
+			}, {
+				Type: cdx.PatchTypeUnofficial,
+				Diff: &cdx.Diff{URL: "https://example.com/patches/0002.patch"},
+			}},
+			Commits: &[]cdx.Commit{{
+				UID:     "7b9c",
+				URL:     "https://example.com/openssl/commit/7b9c",
+				Author:  &cdx.IdentifiableAction{Name: "Jane Doe", Email: "jane@example.com", Timestamp: "2025-01-01T00:00:00Z"},
+				Message: "Backport PKCS12 fix",
+			}},
+			Notes: "Built with FIPS provider.",
+		},
+	}, {
+		BOMRef:  "openssl-fips",
+		Type:    cdx.ComponentTypeLibrary,
+		Name:    "openssl-fips",
+		Version: "3.0.13",
+	}, {
+		BOMRef:  "openssl-0001",
+		Type:    cdx.ComponentTypeLibrary,
+		Name:    "openssl-0001.patch",
+		Version: "1",
+		ExternalReferences: &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://example.com/patches/0001.patch",
+		}},
+	}}
+	return bom
+}
+
+func TestConvertPedigree(t *testing.T) {
+	spdxDoc, err := ConvertToGoogleSPDX(testPedigreeBOM(), WithTool("converter", "1.0"))
+	require.NoError(t, err)
+
+	packages := map[common.ElementID]*spdx.Package{}
+	for _, p := range spdxDoc.Packages {
+		packages[p.PackageSPDXIdentifier] = p
+	}
+	require.Len(t, packages, 4)
+	assert.Equal(t, "3.0.13", packages["openssl-3.0.13"].PackageVersion)
+
+	annotator := common.Annotator{Annotator: "converter-1.0", AnnotatorType: "Tool"}
+	assert.Equal(t, []spdx.Annotation{{
+		Annotator:                annotator,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: common.DocElementID{ElementRefID: "openssl"},
+		AnnotationComment: "Patch 1 (backport): https://example.com/patches/0001.patch\n" +
+			"Resolves security issue CVE-2024-0727 (PKCS12 NULL dereference): " +
+			"Processing a maliciously formatted PKCS12 file may crash.\n" +
+			"Source: NVD https://nvd.nist.gov/\n" +
+			"References: https://www.openssl.org/news/secadv/20240125.txt\n\n" +
+			"Diff:\n--- a\n+++ b\n",
+	}, {
+		Annotator:                annotator,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: common.DocElementID{ElementRefID: "openssl"},
+		AnnotationComment:        "Patch 2 (unofficial): https://example.com/patches/0002.patch",
+	}, {
+		Annotator:                annotator,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: common.DocElementID{ElementRefID: "openssl"},
+		AnnotationComment: "Commit 7b9c https://example.com/openssl/commit/7b9c\n" +
+			"Author: Jane Doe (jane@example.com) at 2025-01-01T00:00:00Z\n\n" +
+			"Backport PKCS12 fix",
+	}, {
+		Annotator:                annotator,
+		AnnotationDate:           "2025-01-02T03:04:05Z",
+		AnnotationType:           "OTHER",
+		AnnotationSPDXIdentifier: common.DocElementID{ElementRefID: "openssl"},
+		AnnotationComment:        "Pedigree notes: Built with FIPS provider.",
+	}}, packages["openssl"].Annotations)
+
+	var lineage []*v2_3.Relationship
+	for _, r := range spdxDoc.Relationships {
+		if r.Relationship != "DESCRIBES" {
+			lineage = append(lineage, r)
+		}
+	}
+	assert.Equal(t, []*v2_3.Relationship{{
+		RefA:         toSPDXDocElementID("openssl"),
+		RefB:         toSPDXDocElementID("openssl-3.0.13"),
+		Relationship: "DESCENDANT_OF",
+	}, {
+		RefA:         toSPDXDocElementID("openssl"),
+		RefB:         toSPDXDocElementID("openssl-fips"),
+		Relationship: "VARIANT_OF",
+	}, {
+		// Only the first patch diff is the download location of a package.
+		RefA:         toSPDXDocElementID("openssl-0001"),
+		RefB:         toSPDXDocElementID("openssl"),
+		Relationship: "PATCH_FOR",
+	}}, lineage)
+
+	conversion, err := ConvertCycloneDXToSPDX(testPedigreeBOM())
+	require.NoError(t, err)
+	require.NotEmpty(t, conversion.LossReport.Components)
+	assert.Equal(t, "openssl", conversion.LossReport.Components[0].BOMRef)
+	assert.Contains(t, conversion.LossReport.Components[0].Losses,
+		Loss{"pedigree.patches", LossDegraded, "2 patches, kept as annotations"})
+}
+
+func TestPedigreeTagValue(t *testing.T) {
+	spdxDoc, err := ConvertToGoogleSPDX(testPedigreeBOM())
+	require.NoError(t, err)
+
+	b, err := SPDXToTagValue(spdxDoc)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "SPDXREF: SPDXRef-openssl\n")
+	assert.Empty(t, spdxDoc.Annotations)
+
+	got, err := spdxtv.Read(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Len(t, got.Annotations, 4)
+	assert.Len(t, got.Packages, 4)
+}
+
+func TestPedigreeComponentDependency(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Components = &[]cdx.Component{{
+		BOMRef: "lib",
+		Type:   cdx.ComponentTypeLibrary,
+		Name:   "lib",
+		Pedigree: &cdx.Pedigree{
+			Ancestors: &[]cdx.Component{{BOMRef: "anc", Type: cdx.ComponentTypeLibrary, Name: "lib-upstream"}},
+		},
+	}, {
+		BOMRef: "app",
+		Type:   cdx.ComponentTypeApplication,
+		Name:   "app",
+	}}
+	bom.Dependencies = &[]cdx.Dependency{{Ref: "app", Dependencies: &[]string{"lib", "anc"}}}
+
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+	assert.Len(t, spdxDoc.Packages, 3)
+	assert.Equal(t, []*v2_3.Relationship{{
+		RefA:         toSPDXDocElementID("DOCUMENT"),
+		RefB:         toSPDXDocElementID("app"),
+		Relationship: "DESCRIBES",
+	}, {
+		RefA:         toSPDXDocElementID("app"),
+		RefB:         toSPDXDocElementID("lib"),
+		Relationship: "DEPENDS_ON",
+	}, {
+		RefA:         toSPDXDocElementID("app"),
+		RefB:         toSPDXDocElementID("anc"),
+		Relationship: "DEPENDS_ON",
+	}, {
+		RefA:         toSPDXDocElementID("lib"),
+		RefB:         toSPDXDocElementID("anc"),
+		Relationship: "DESCENDANT_OF",
+	}}, spdxDoc.Relationships)
+}
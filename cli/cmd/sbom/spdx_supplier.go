@@ -0,0 +1,123 @@
+package sbom
+
+import (
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+)
+
+// spdxSupplier maps the supplier of a component, or else its manufacturer,
+// to an SPDX package supplier. It also returns the CycloneDX field the
+// supplier comes from, or "" if the component has neither.
+func spdxSupplier(c cdx.Component) (*common.Supplier, string) {
+	e, field := c.Supplier, "supplier"
+	if e == nil {
+		e, field = c.Manufacturer, "manufacturer"
+	}
+	if e == nil {
+		return nil, ""
+	}
+	actor, actorType, ok := spdxEntity(e)
+	if !ok {
+		return &common.Supplier{Supplier: "NOASSERTION"}, field
+	}
+	return &common.Supplier{Supplier: actor, SupplierType: actorType}, field
+}
+
+// spdxOriginator maps the creator of a component to an SPDX package
+// originator. SPDX has a single originator, which is the first author, else
+// the publisher, else the manufacturer if it is not the supplier. It also
+// returns the CycloneDX field the originator comes from.
+func spdxOriginator(c cdx.Component) (*common.Originator, string) {
+	if c.Authors != nil {
+		for _, author := range *c.Authors {
+			if author.Name != "" || author.Email != "" {
+				return &common.Originator{
+					Originator:     spdxActor(author.Name, author.Email),
+					OriginatorType: "Person",
+				}, "authors"
+			}
+		}
+	}
+	if c.Author != "" {
+		return &common.Originator{Originator: c.Author, OriginatorType: "Person"}, "author"
+	}
+	if c.Publisher != "" {
+		return &common.Originator{Originator: c.Publisher, OriginatorType: "Organization"}, "publisher"
+	}
+	if c.Manufacturer != nil && c.Supplier != nil {
+		if actor, actorType, ok := spdxEntity(c.Manufacturer); ok {
+			return &common.Originator{Originator: actor, OriginatorType: actorType}, "manufacturer"
+		}
+	}
+	return nil, ""
+}
+
+// spdxEntity formats an organizational entity as an SPDX actor. A named
+// entity is an Organization with its first contact email. An entity without
+// a name is the Person of its first named contact.
+func spdxEntity(e *cdx.OrganizationalEntity) (actor, actorType string, ok bool) {
+	if e.Name != "" {
+		return spdxActor(e.Name, entityEmail(e)), "Organization", true
+	}
+	if e.Contact != nil {
+		for _, contact := range *e.Contact {
+			if contact.Name != "" {
+				return spdxActor(contact.Name, contact.Email), "Person", true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// entityFromSPDXActor maps an SPDX supplier or originator to an
+// organizational entity, reversing spdxEntity.
+func entityFromSPDXActor(actor, actorType string) *cdx.OrganizationalEntity {
+	if actorType != "Person" {
+		return entityFromSPDX(actor)
+	}
+	name, email := splitNameEmail(actor)
+	return &cdx.OrganizationalEntity{Contact: &[]cdx.OrganizationalContact{{Name: name, Email: email}}}
+}
+
+// addPackageActors sets the supplier and originator of a package. SPDX
+// actors have a name and an email, so the address, URLs and further
+// contacts of an entity are dropped, as are the creators that are neither
+// the supplier nor the originator.
+func addPackageActors(p *spdx.Package, c cdx.Component, opts *ConvertOptions) {
+	var supplierField, originatorField string
+	p.PackageSupplier, supplierField = spdxSupplier(c)
+	p.PackageOriginator, originatorField = spdxOriginator(c)
+	if p.PackageSupplier != nil && p.PackageSupplier.Supplier == "NOASSERTION" {
+		opts.lose(c, Loss{supplierField, LossDegraded, "entity has no name or named contact, supplier is NOASSERTION"})
+	}
+	for _, e := range []struct {
+		field  string
+		entity *cdx.OrganizationalEntity
+	}{{"supplier", c.Supplier}, {"manufacturer", c.Manufacturer}} {
+		switch {
+		case e.entity == nil:
+		case e.field != supplierField && e.field != originatorField:
+			opts.lose(c, Loss{Field: e.field, Kind: LossDropped})
+		case e.entity.Address != nil || sliceLen(e.entity.URL) > 0 || sliceLen(e.entity.Contact) > 1:
+			opts.lose(c, Loss{e.field, LossDegraded, "address, URLs and further contacts are dropped"})
+		}
+	}
+	if n := sliceLen(c.Authors); n > 1 && originatorField == "authors" {
+		opts.lose(c, Loss{"authors", LossDropped, fmt.Sprintf("%d of %d authors, SPDX has one originator", n-1, n)})
+	}
+	for _, f := range []struct {
+		field   string
+		present bool
+	}{
+		{"authors", sliceLen(c.Authors) > 0},
+		{"author", c.Author != ""},
+		{"publisher", c.Publisher != ""},
+	} {
+		if f.present && f.field != originatorField {
+			opts.lose(c, Loss{Field: f.field, Kind: LossDropped})
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDXSupplierAndOriginator(t *testing.T) {
+	example := &cdx.OrganizationalEntity{
+		Name:    "Example Inc",
+		Contact: &[]cdx.OrganizationalContact{{Name: "Sales"}, {Email: "oss@example.com"}},
+	}
+	tests := []struct {
+		name           string
+		component      cdx.Component
+		wantSupplier   *common.Supplier
+		wantOriginator *common.Originator
+	}{{
+		name: "none",
+	}, {
+		name:         "organization with email",
+		component:    cdx.Component{Supplier: example},
+		wantSupplier: &common.Supplier{Supplier: "Example Inc (oss@example.com)", SupplierType: "Organization"},
+	}, {
+		name: "person",
+		component: cdx.Component{Supplier: &cdx.OrganizationalEntity{
+			Contact: &[]cdx.OrganizationalContact{{Email: "x@example.com"}, {Name: "Jane Doe", Email: "jane@example.com"}},
+		}},
+		wantSupplier: &common.Supplier{Supplier: "Jane Doe (jane@example.com)", SupplierType: "Person"},
+	}, {
+		name:         "anonymous",
+		component:    cdx.Component{Supplier: &cdx.OrganizationalEntity{URL: &[]string{"https://example.com"}}},
+		wantSupplier: &common.Supplier{Supplier: "NOASSERTION"},
+	}, {
+		name:         "manufacturer supplies",
+		component:    cdx.Component{Manufacturer: example},
+		wantSupplier: &common.Supplier{Supplier: "Example Inc (oss@example.com)", SupplierType: "Organization"},
+	}, {
+		name: "manufacturer originates",
+		component: cdx.Component{
+			Supplier:     &cdx.OrganizationalEntity{Name: "Distro"},
+			Manufacturer: example,
+		},
+		wantSupplier:   &common.Supplier{Supplier: "Distro", SupplierType: "Organization"},
+		wantOriginator: &common.Originator{Originator: "Example Inc (oss@example.com)", OriginatorType: "Organization"},
+	}, {
+		name: "first author",
+		component: cdx.Component{
+			Authors:   &[]cdx.OrganizationalContact{{}, {Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John Doe"}},
+			Publisher: "Example Publishing",
+		},
+		wantOriginator: &common.Originator{Originator: "Jane Doe (jane@example.com)", OriginatorType: "Person"},
+	}, {
+		name:           "legacy author",
+		component:      cdx.Component{Author: "Jane Doe"},
+		wantOriginator: &common.Originator{Originator: "Jane Doe", OriginatorType: "Person"},
+	}, {
+		name:           "publisher",
+		component:      cdx.Component{Publisher: "Example Publishing", Manufacturer: example},
+		wantSupplier:   &common.Supplier{Supplier: "Example Inc (oss@example.com)", SupplierType: "Organization"},
+		wantOriginator: &common.Originator{Originator: "Example Publishing", OriginatorType: "Organization"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			supplier, _ := spdxSupplier(tc.component)
+			assert.Equal(t, tc.wantSupplier, supplier)
+			originator, _ := spdxOriginator(tc.component)
+			assert.Equal(t, tc.wantOriginator, originator)
+		})
+	}
+}
+
+func TestSupplierRoundTrip(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Components = &[]cdx.Component{{
+		BOMRef:     "lib",
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       "lib",
+		PackageURL: "pkg:generic/lib@1.0.0",
+		Supplier: &cdx.OrganizationalEntity{
+			Contact: &[]cdx.OrganizationalContact{{Name: "Jane Doe", Email: "jane@example.com"}},
+		},
+		Manufacturer: &cdx.OrganizationalEntity{
+			Name:    "Example Inc",
+			Contact: &[]cdx.OrganizationalContact{{Email: "oss@example.com"}},
+		},
+	}, {
+		BOMRef:     "tool",
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       "tool",
+		PackageURL: "pkg:generic/tool@1.0.0",
+		Supplier:   &cdx.OrganizationalEntity{Name: "Example Inc"},
+		Authors:    &[]cdx.OrganizationalContact{{Name: "John Doe"}},
+	}}
+	spdxDoc, err := ConvertToGoogleSPDX(bom)
+	require.NoError(t, err)
+
+	b, err := SPDXToTagValue(spdxDoc)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "PackageSupplier: Person: Jane Doe (jane@example.com)\n")
+	assert.Contains(t, string(b), "PackageOriginator: Organization: Example Inc (oss@example.com)\n")
+	got, err := spdxtv.Read(bytes.NewReader(b))
+	require.NoError(t, err)
+	gotBOM, err := ConvertToCycloneDX(got)
+	require.NoError(t, err)
+
+	components := map[string]cdx.Component{}
+	if gotBOM.Metadata != nil && gotBOM.Metadata.Component != nil {
+		components[gotBOM.Metadata.Component.BOMRef] = *gotBOM.Metadata.Component
+	}
+	if gotBOM.Components != nil {
+		for _, c := range *gotBOM.Components {
+			components[c.BOMRef] = c
+		}
+	}
+	for _, want := range *bom.Components {
+		c := components[want.BOMRef]
+		assert.Equal(t, want.Supplier, c.Supplier, want.BOMRef)
+		assert.Equal(t, want.Manufacturer, c.Manufacturer, want.BOMRef)
+		assert.Equal(t, want.Authors, c.Authors, want.BOMRef)
+	}
+}
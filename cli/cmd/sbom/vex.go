@@ -0,0 +1,415 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	log "k8s.io/klog"
+)
+
+// OpenVEXContext is the JSON-LD context of the OpenVEX documents written by
+// ConvertCycloneDXToOpenVEX.
+const OpenVEXContext = "https://openvex.dev/ns/v0.2.0"
+
+// OpenVEXDocument is an OpenVEX document, stating the impact of
+// vulnerabilities on products.
+type OpenVEXDocument struct {
+	Context    string             `json:"@context"`
+	ID         string             `json:"@id"`
+	Author     string             `json:"author"`
+	Timestamp  string             `json:"timestamp"`
+	Version    int                `json:"version"`
+	Tooling    string             `json:"tooling,omitempty"`
+	Statements []OpenVEXStatement `json:"statements"`
+}
+
+// OpenVEXStatement is the status of a vulnerability in a set of products.
+type OpenVEXStatement struct {
+	Vulnerability   OpenVEXVulnerability `json:"vulnerability"`
+	Timestamp       string               `json:"timestamp,omitempty"`
+	Products        []OpenVEXProduct     `json:"products"`
+	Status          string               `json:"status"`
+	StatusNotes     string               `json:"status_notes,omitempty"`
+	Justification   string               `json:"justification,omitempty"`
+	ImpactStatement string               `json:"impact_statement,omitempty"`
+	ActionStatement string               `json:"action_statement,omitempty"`
+}
+
+// OpenVEXVulnerability names a vulnerability.
+type OpenVEXVulnerability struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// OpenVEXProduct is a product a statement is about. Its ID is the IRI of
+// the SPDX element of the product, the document namespace followed by
+// "#SPDXRef-...", and its identifiers hold its purl and CPEs.
+type OpenVEXProduct struct {
+	ID          string            `json:"@id"`
+	Identifiers map[string]string `json:"identifiers,omitempty"`
+}
+
+// ConvertCycloneDXToOpenVEX returns an OpenVEX document with the
+// vulnerabilities of a BOM, for the SPDX document the BOM was converted to.
+// The products of the statements are the SPDX packages and files of the
+// affected components.
+//
+// The analysis state of a vulnerability sets the status of all its affected
+// products. A vulnerability without analysis has the status of each product
+// version listed in its affects versions, or is under investigation.
+func ConvertCycloneDXToOpenVEX(bom *cdx.BOM, conversion *SPDXConversion) (*OpenVEXDocument, error) {
+	spdxDoc := conversion.Document
+	if spdxDoc == nil || spdxDoc.CreationInfo == nil {
+		return nil, fmt.Errorf("SPDX document has no creation info")
+	}
+	doc := &OpenVEXDocument{
+		Context:    OpenVEXContext,
+		ID:         spdxDoc.DocumentNamespace + "/vex",
+		Author:     vexAuthor(spdxDoc),
+		Timestamp:  spdxDoc.CreationInfo.Created,
+		Version:    1,
+		Tooling:    vexTool(spdxDoc),
+		Statements: []OpenVEXStatement{},
+	}
+	for _, s := range vexStatements(bom, conversion) {
+		statement := OpenVEXStatement{
+			Vulnerability: OpenVEXVulnerability{
+				Name:        s.vulnerability.ID,
+				Description: s.vulnerability.Description,
+				Aliases:     vulnerabilityAliases(s.vulnerability),
+			},
+			Timestamp:       s.timestamp,
+			Status:          s.status,
+			StatusNotes:     s.notes,
+			Justification:   s.justification,
+			ImpactStatement: s.impact,
+			ActionStatement: s.action,
+		}
+		for _, p := range s.products {
+			product := OpenVEXProduct{ID: p.iri}
+			identifiers := map[string]string{}
+			if p.purl != "" {
+				identifiers["purl"] = p.purl
+			}
+			if p.cpe != "" {
+				// The OpenVEX identifier types are cpe22 and cpe23.
+				identifiers[strings.TrimSuffix(cpeRefType(p.cpe), "Type")] = p.cpe
+			}
+			if len(identifiers) > 0 {
+				product.Identifiers = identifiers
+			}
+			statement.Products = append(statement.Products, product)
+		}
+		doc.Statements = append(doc.Statements, statement)
+	}
+	return doc, nil
+}
+
+// OpenVEXToJSON encodes an OpenVEX document as indented JSON.
+func OpenVEXToJSON(doc *OpenVEXDocument) ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// vexStatement is the status of a vulnerability in a set of products, from
+// which the OpenVEX and CSAF statements are written. The status and
+// justification use the OpenVEX values.
+type vexStatement struct {
+	vulnerability cdx.Vulnerability
+	products      []vexProduct
+	timestamp     string
+	vexStatus
+}
+
+type vexStatus struct {
+	status        string
+	justification string
+	impact        string
+	action        string
+	notes         string
+	// response is the CycloneDX response the action comes from, if any.
+	response cdx.ImpactAnalysisResponse
+}
+
+// vexProduct is the SPDX element of an affected component.
+type vexProduct struct {
+	spdxID  string
+	iri     string
+	name    string
+	version string
+	purl    string
+	cpe     string
+}
+
+// vexStatements returns one statement per vulnerability of the BOM and
+// status of its affected products. Affected components that were not
+// converted are dropped, as are vulnerabilities without converted ones.
+func vexStatements(bom *cdx.BOM, conversion *SPDXConversion) []vexStatement {
+	if bom.Vulnerabilities == nil {
+		return nil
+	}
+	products := vexProducts(conversion)
+
+	var statements []vexStatement
+	for _, v := range *bom.Vulnerabilities {
+		var analysis *vexStatus
+		if v.Analysis != nil && v.Analysis.State != "" {
+			s := analysisStatus(v)
+			analysis = &s
+		}
+		first := len(statements)
+		seen := map[string]bool{}
+		if v.Affects != nil {
+			for _, a := range *v.Affects {
+				ref := affectsRef(a.Ref, bom.SerialNumber)
+				id, ok := conversion.SPDXIDs[ref]
+				if !ok {
+					log.Warningf("vulnerability %q: dropping affected %q, not a converted component", v.ID, a.Ref)
+					continue
+				}
+				p := products[id]
+				if seen[p.spdxID] {
+					continue
+				}
+				seen[p.spdxID] = true
+
+				status := versionStatus(v, a, p.version)
+				if analysis != nil {
+					status = *analysis
+				}
+				i := first
+				for ; i < len(statements); i++ {
+					if statements[i].vexStatus == status {
+						break
+					}
+				}
+				if i == len(statements) {
+					statements = append(statements, vexStatement{
+						vulnerability: v,
+						timestamp:     vulnerabilityTimestamp(v),
+						vexStatus:     status,
+					})
+				}
+				statements[i].products = append(statements[i].products, p)
+			}
+		}
+		if len(statements) == first {
+			log.Warningf("vulnerability %q: dropping vulnerability, it affects no converted component", v.ID)
+		}
+	}
+	return statements
+}
+
+// vexProducts returns the products of the converted packages and files,
+// keyed by SPDX ID.
+func vexProducts(conversion *SPDXConversion) map[common.ElementID]vexProduct {
+	spdxDoc := conversion.Document
+	products := map[common.ElementID]vexProduct{}
+	for _, id := range conversion.SPDXIDs {
+		spdxID := common.RenderElementID(id)
+		products[id] = vexProduct{spdxID: spdxID, iri: spdxDoc.DocumentNamespace + "#" + spdxID}
+	}
+	for _, pkg := range spdxDoc.Packages {
+		p, ok := products[pkg.PackageSPDXIdentifier]
+		if !ok {
+			continue
+		}
+		p.name, p.version = pkg.PackageName, pkg.PackageVersion
+		for _, ref := range pkg.PackageExternalReferences {
+			switch {
+			case ref.RefType == "purl" && p.purl == "":
+				p.purl = ref.Locator
+			case (ref.RefType == "cpe23Type" || ref.RefType == "cpe22Type") && p.cpe == "":
+				p.cpe = ref.Locator
+			}
+		}
+		products[pkg.PackageSPDXIdentifier] = p
+	}
+	for _, f := range spdxDoc.Files {
+		if p, ok := products[f.FileSPDXIdentifier]; ok {
+			p.name = f.FileName
+			products[f.FileSPDXIdentifier] = p
+		}
+	}
+	return products
+}
+
+// analysisStatus maps the analysis of a vulnerability to a VEX status.
+// OpenVEX requires an impact statement or justification for products that
+// are not affected and an action statement for affected ones.
+func analysisStatus(v cdx.Vulnerability) vexStatus {
+	a := v.Analysis
+	switch a.State {
+	case cdx.IASResolved, cdx.IASResolvedWithPedigree:
+		return vexStatus{status: "fixed", notes: a.Detail}
+	case cdx.IASExploitable:
+		s := vexStatus{status: "affected", notes: a.Detail}
+		s.action, s.response = vulnerabilityAction(v)
+		return s
+	case cdx.IASFalsePositive:
+		impact := a.Detail
+		if impact == "" {
+			impact = "The vulnerability was reported in error, it is a false positive."
+		}
+		return vexStatus{status: "not_affected", impact: impact}
+	case cdx.IASNotAffected:
+		s := vexStatus{
+			status:        "not_affected",
+			justification: openVEXJustifications[a.Justification],
+			impact:        a.Detail,
+		}
+		if s.justification == "" && s.impact == "" {
+			s.impact = "The analysis found that the product is not affected."
+		}
+		return s
+	}
+	return vexStatus{status: "under_investigation", notes: a.Detail}
+}
+
+// versionStatus returns the status of a product version from the versions
+// of an affects entry, or under investigation if its version is not listed.
+func versionStatus(v cdx.Vulnerability, a cdx.Affects, version string) vexStatus {
+	var ranges []string
+	if a.Range != nil {
+		for _, av := range *a.Range {
+			if av.Version == "" || av.Version != version {
+				if av.Range != "" {
+					ranges = append(ranges, fmt.Sprintf("%s (%s)", av.Range, av.Status))
+				}
+				continue
+			}
+			switch av.Status {
+			case cdx.VulnerabilityStatusAffected:
+				s := vexStatus{status: "affected"}
+				s.action, s.response = vulnerabilityAction(v)
+				return s
+			case cdx.VulnerabilityStatusNotAffected:
+				return vexStatus{
+					status: "not_affected",
+					impact: fmt.Sprintf("Version %s is listed as unaffected.", version),
+				}
+			}
+		}
+	}
+	s := vexStatus{status: "under_investigation"}
+	if len(ranges) > 0 {
+		s.notes = "Affected version ranges: " + strings.Join(ranges, ", ")
+	}
+	return s
+}
+
+// vulnerabilityAction returns the action statement for affected products:
+// the recommendation, the workaround or the analysis responses. It also
+// returns the first response.
+func vulnerabilityAction(v cdx.Vulnerability) (string, cdx.ImpactAnalysisResponse) {
+	var responses []string
+	var first cdx.ImpactAnalysisResponse
+	if v.Analysis != nil && v.Analysis.Response != nil {
+		for _, r := range *v.Analysis.Response {
+			if first == "" {
+				first = r
+			}
+			responses = append(responses, string(r))
+		}
+	}
+	switch {
+	case v.Recommendation != "":
+		return v.Recommendation, first
+	case v.Workaround != "":
+		return v.Workaround, first
+	case len(responses) > 0:
+		return "Response: " + strings.Join(responses, ", "), first
+	}
+	return "No remediation information is available.", first
+}
+
+// affectsRef returns the bom-ref an affects entry refers to. BOM-Links to
+// components of the same BOM, "urn:cdx:<serial>/<version>#<bom-ref>", are
+// resolved to the bom-ref.
+func affectsRef(ref, serialNumber string) string {
+	link, fragment, ok := strings.Cut(ref, "#")
+	if !ok || !strings.HasPrefix(link, "urn:cdx:") {
+		return ref
+	}
+	serial, _, _ := strings.Cut(strings.TrimPrefix(link, "urn:cdx:"), "/")
+	if serialNumber != "" && "urn:uuid:"+serial != serialNumber {
+		return ref
+	}
+	if decoded, err := url.PathUnescape(fragment); err == nil {
+		return decoded
+	}
+	return fragment
+}
+
+// vulnerabilityAliases returns the IDs of the references of a vulnerability
+// that differ from its ID.
+func vulnerabilityAliases(v cdx.Vulnerability) []string {
+	var aliases []string
+	if v.References != nil {
+		for _, ref := range *v.References {
+			if ref.ID != "" && ref.ID != v.ID {
+				aliases = append(aliases, ref.ID)
+			}
+		}
+	}
+	return aliases
+}
+
+// vulnerabilityTimestamp returns the time of the latest change to a
+// vulnerability or its analysis.
+func vulnerabilityTimestamp(v cdx.Vulnerability) string {
+	if v.Analysis != nil && v.Analysis.LastUpdated != "" {
+		return spdxTimestamp(v.Analysis.LastUpdated)
+	}
+	for _, t := range []string{v.Updated, v.Published, v.Created} {
+		if t != "" {
+			return spdxTimestamp(t)
+		}
+	}
+	return ""
+}
+
+// vexAuthor returns the first person or organization that created the SPDX
+// document, or else its first tool.
+func vexAuthor(spdxDoc *spdx.Document) string {
+	for _, c := range spdxDoc.CreationInfo.Creators {
+		if c.CreatorType == "Organization" || c.CreatorType == "Person" {
+			return c.Creator
+		}
+	}
+	if tool := vexTool(spdxDoc); tool != "" {
+		return tool
+	}
+	return defaultAnnotator
+}
+
+// vexTool returns the first tool that created the SPDX document.
+func vexTool(spdxDoc *spdx.Document) string {
+	for _, c := range spdxDoc.CreationInfo.Creators {
+		if c.CreatorType == "Tool" {
+			return c.Creator
+		}
+	}
+	return ""
+}
+
+// ========== Enum mappings =============
+
+// openVEXJustifications maps CycloneDX not affected justifications to the
+// OpenVEX and CSAF ones.
+var openVEXJustifications = map[cdx.ImpactAnalysisJustification]string{
+	cdx.IAJCodeNotPresent:               "vulnerable_code_not_present",
+	cdx.IAJCodeNotReachable:             "vulnerable_code_not_in_execute_path",
+	cdx.IAJRequiresConfiguration:        "vulnerable_code_cannot_be_controlled_by_adversary",
+	cdx.IAJRequiresDependency:           "component_not_present",
+	cdx.IAJRequiresEnvironment:          "vulnerable_code_cannot_be_controlled_by_adversary",
+	cdx.IAJProtectedByCompiler:          "inline_mitigations_already_exist",
+	cdx.IAJProtectedAtRuntime:           "inline_mitigations_already_exist",
+	cdx.IAJProtectedAtPerimeter:         "inline_mitigations_already_exist",
+	cdx.IAJProtectedByMitigatingControl: "inline_mitigations_already_exist",
+}
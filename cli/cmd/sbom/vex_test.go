@@ -0,0 +1,167 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vexBOM returns a BOM with an application, two libraries and their
+// vulnerabilities.
+func vexBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "app",
+		Type:   cdx.ComponentTypeApplication,
+		Name:   "app",
+	}}
+	bom.Components = &[]cdx.Component{{
+		BOMRef:     "lib-a",
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       "lib-a",
+		Version:    "1.0.0",
+		PackageURL: "pkg:generic/lib-a@1.0.0",
+	}, {
+		BOMRef:  "lib-b",
+		Type:    cdx.ComponentTypeLibrary,
+		Name:    "lib-b",
+		Version: "2.0.0",
+		CPE:     "cpe:2.3:a:example:lib-b:2.0.0:*:*:*:*:*:*:*",
+	}}
+	bom.Vulnerabilities = &[]cdx.Vulnerability{{
+		ID:          "CVE-2024-0001",
+		Description: "Buffer overflow in lib-a.",
+		References:  &[]cdx.VulnerabilityReference{{ID: "GHSA-aaaa-bbbb-cccc"}},
+		Analysis: &cdx.VulnerabilityAnalysis{
+			State:         cdx.IASNotAffected,
+			Justification: cdx.IAJCodeNotReachable,
+			LastUpdated:   "2024-05-01T10:00:00Z",
+		},
+		Affects: &[]cdx.Affects{
+			{Ref: "lib-a"},
+			{Ref: "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#lib-b"},
+			{Ref: "missing"},
+		},
+	}, {
+		ID:             "CVE-2024-0002",
+		Recommendation: "Upgrade lib-b to 2.0.1.",
+		Analysis: &cdx.VulnerabilityAnalysis{
+			State:    cdx.IASExploitable,
+			Response: &[]cdx.ImpactAnalysisResponse{cdx.IARUpdate},
+		},
+		Affects: &[]cdx.Affects{{Ref: "lib-b"}},
+	}, {
+		ID: "OSV-2024-3",
+		Affects: &[]cdx.Affects{{
+			Ref: "lib-a",
+			Range: &[]cdx.AffectedVersions{
+				{Version: "1.0.0", Status: cdx.VulnerabilityStatusAffected},
+			},
+		}, {
+			Ref: "lib-b",
+			Range: &[]cdx.AffectedVersions{
+				{Range: "vers:generic/<2.0.0", Status: cdx.VulnerabilityStatusAffected},
+			},
+		}},
+	}, {
+		ID:      "CVE-2024-0004",
+		Affects: &[]cdx.Affects{{Ref: "missing"}},
+	}}
+	return bom
+}
+
+func TestConvertCycloneDXToOpenVEX(t *testing.T) {
+	bom := vexBOM()
+	conversion, err := ConvertCycloneDXToSPDX(bom)
+	require.NoError(t, err)
+	spdxDoc := conversion.Document
+
+	doc, err := ConvertCycloneDXToOpenVEX(bom, conversion)
+	require.NoError(t, err)
+	assert.Equal(t, OpenVEXContext, doc.Context)
+	assert.Equal(t, spdxDoc.DocumentNamespace+"/vex", doc.ID)
+	assert.Equal(t, spdxDoc.CreationInfo.Created, doc.Timestamp)
+
+	libA := spdxDoc.DocumentNamespace + "#SPDXRef-" + string(conversion.SPDXIDs["lib-a"])
+	libB := spdxDoc.DocumentNamespace + "#SPDXRef-" + string(conversion.SPDXIDs["lib-b"])
+	productA := OpenVEXProduct{ID: libA, Identifiers: map[string]string{"purl": "pkg:generic/lib-a@1.0.0"}}
+	productB := OpenVEXProduct{ID: libB, Identifiers: map[string]string{"cpe23": "cpe:2.3:a:example:lib-b:2.0.0:*:*:*:*:*:*:*"}}
+
+	assert.Equal(t, []OpenVEXStatement{{
+		Vulnerability: OpenVEXVulnerability{
+			Name:        "CVE-2024-0001",
+			Description: "Buffer overflow in lib-a.",
+			Aliases:     []string{"GHSA-aaaa-bbbb-cccc"},
+		},
+		Timestamp:     "2024-05-01T10:00:00Z",
+		Products:      []OpenVEXProduct{productA, productB},
+		Status:        "not_affected",
+		Justification: "vulnerable_code_not_in_execute_path",
+	}, {
+		Vulnerability:   OpenVEXVulnerability{Name: "CVE-2024-0002"},
+		Products:        []OpenVEXProduct{productB},
+		Status:          "affected",
+		ActionStatement: "Upgrade lib-b to 2.0.1.",
+	}, {
+		Vulnerability:   OpenVEXVulnerability{Name: "OSV-2024-3"},
+		Products:        []OpenVEXProduct{productA},
+		Status:          "affected",
+		ActionStatement: "No remediation information is available.",
+	}, {
+		Vulnerability: OpenVEXVulnerability{Name: "OSV-2024-3"},
+		Products:      []OpenVEXProduct{productB},
+		Status:        "under_investigation",
+		StatusNotes:   "Affected version ranges: vers:generic/<2.0.0 (affected)",
+	}}, doc.Statements)
+}
+
+func TestAnalysisStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		analysis cdx.VulnerabilityAnalysis
+		want     vexStatus
+	}{{
+		name:     "resolved",
+		analysis: cdx.VulnerabilityAnalysis{State: cdx.IASResolvedWithPedigree, Detail: "Patched."},
+		want:     vexStatus{status: "fixed", notes: "Patched."},
+	}, {
+		name: "exploitable",
+		analysis: cdx.VulnerabilityAnalysis{
+			State:    cdx.IASExploitable,
+			Response: &[]cdx.ImpactAnalysisResponse{cdx.IARWorkaroundAvailable, cdx.IARUpdate},
+		},
+		want: vexStatus{status: "affected", action: "Response: workaround_available, update", response: cdx.IARWorkaroundAvailable},
+	}, {
+		name:     "false positive",
+		analysis: cdx.VulnerabilityAnalysis{State: cdx.IASFalsePositive},
+		want:     vexStatus{status: "not_affected", impact: "The vulnerability was reported in error, it is a false positive."},
+	}, {
+		name:     "not affected",
+		analysis: cdx.VulnerabilityAnalysis{State: cdx.IASNotAffected, Justification: cdx.IAJRequiresDependency},
+		want:     vexStatus{status: "not_affected", justification: "component_not_present"},
+	}, {
+		name:     "not affected without justification",
+		analysis: cdx.VulnerabilityAnalysis{State: cdx.IASNotAffected},
+		want:     vexStatus{status: "not_affected", impact: "The analysis found that the product is not affected."},
+	}, {
+		name:     "in triage",
+		analysis: cdx.VulnerabilityAnalysis{State: cdx.IASInTriage},
+		want:     vexStatus{status: "under_investigation"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, analysisStatus(cdx.Vulnerability{Analysis: &tc.analysis}))
+		})
+	}
+}
+
+func TestAffectsRef(t *testing.T) {
+	serial := "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	assert.Equal(t, "lib", affectsRef("lib", serial))
+	assert.Equal(t, "pkg:generic/lib@1.0", affectsRef("urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#pkg:generic%2Flib@1.0", serial))
+	assert.Equal(t, "urn:cdx:00000000-0000-0000-0000-000000000000/1#lib",
+		affectsRef("urn:cdx:00000000-0000-0000-0000-000000000000/1#lib", serial))
+}
@@ -3,23 +3,23 @@ module github.com/openconfig/security-services
 go 1.24.4
 
 require (
+	github.com/CycloneDX/cyclonedx-go v0.9.2
+	github.com/google/sbom-conformance v0.0.0-20250604164414-03f5a1ba924f
+	github.com/google/uuid v1.6.0
+	github.com/spdx/tools-golang v0.5.5
 	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
+	github.com/stretchr/testify v1.11.1
 	google.golang.org/protobuf v1.36.7
+	k8s.io/klog v1.0.0
 )
 
 require (
-	github.com/CycloneDX/cyclonedx-go v0.9.2 // indirect
 	github.com/anchore/go-struct-converter v0.0.0-20221118182256-c68fdcfa2092 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/glog v1.2.5 // indirect
-	github.com/google/sbom-conformance v0.0.0-20250604164414-03f5a1ba924f // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/spdx/tools-golang v0.5.5 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
-	k8s.io/klog v1.0.0 // indirect
 	sigs.k8s.io/yaml v1.4.0 // indirect
 )
@@ -0,0 +1,14764 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v5.29.3
+// source: bom-1.6.proto
+
+// CycloneDX Software Bill of Materials Standard, version 1.6.
+//
+// This is the protobuf binding of the CycloneDX 1.6 object model as
+// published by the OWASP Foundation (Apache-2.0). Field numbers and enum
+// values follow the upstream schema so that BOMs serialized by other
+// CycloneDX tooling decode with this definition.
+
+package cyclonedxpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Classification int32
+
+const (
+	Classification_CLASSIFICATION_NULL                   Classification = 0
+	Classification_CLASSIFICATION_APPLICATION            Classification = 1
+	Classification_CLASSIFICATION_FRAMEWORK              Classification = 2
+	Classification_CLASSIFICATION_LIBRARY                Classification = 3
+	Classification_CLASSIFICATION_OPERATING_SYSTEM       Classification = 4
+	Classification_CLASSIFICATION_DEVICE                 Classification = 5
+	Classification_CLASSIFICATION_FILE                   Classification = 6
+	Classification_CLASSIFICATION_CONTAINER              Classification = 7
+	Classification_CLASSIFICATION_FIRMWARE               Classification = 8
+	Classification_CLASSIFICATION_DEVICE_DRIVER          Classification = 9
+	Classification_CLASSIFICATION_PLATFORM               Classification = 10
+	Classification_CLASSIFICATION_MACHINE_LEARNING_MODEL Classification = 11
+	Classification_CLASSIFICATION_DATA                   Classification = 12
+	Classification_CLASSIFICATION_CRYPTOGRAPHIC_ASSET    Classification = 13
+)
+
+// Enum value maps for Classification.
+var (
+	Classification_name = map[int32]string{
+		0:  "CLASSIFICATION_NULL",
+		1:  "CLASSIFICATION_APPLICATION",
+		2:  "CLASSIFICATION_FRAMEWORK",
+		3:  "CLASSIFICATION_LIBRARY",
+		4:  "CLASSIFICATION_OPERATING_SYSTEM",
+		5:  "CLASSIFICATION_DEVICE",
+		6:  "CLASSIFICATION_FILE",
+		7:  "CLASSIFICATION_CONTAINER",
+		8:  "CLASSIFICATION_FIRMWARE",
+		9:  "CLASSIFICATION_DEVICE_DRIVER",
+		10: "CLASSIFICATION_PLATFORM",
+		11: "CLASSIFICATION_MACHINE_LEARNING_MODEL",
+		12: "CLASSIFICATION_DATA",
+		13: "CLASSIFICATION_CRYPTOGRAPHIC_ASSET",
+	}
+	Classification_value = map[string]int32{
+		"CLASSIFICATION_NULL":                   0,
+		"CLASSIFICATION_APPLICATION":            1,
+		"CLASSIFICATION_FRAMEWORK":              2,
+		"CLASSIFICATION_LIBRARY":                3,
+		"CLASSIFICATION_OPERATING_SYSTEM":       4,
+		"CLASSIFICATION_DEVICE":                 5,
+		"CLASSIFICATION_FILE":                   6,
+		"CLASSIFICATION_CONTAINER":              7,
+		"CLASSIFICATION_FIRMWARE":               8,
+		"CLASSIFICATION_DEVICE_DRIVER":          9,
+		"CLASSIFICATION_PLATFORM":               10,
+		"CLASSIFICATION_MACHINE_LEARNING_MODEL": 11,
+		"CLASSIFICATION_DATA":                   12,
+		"CLASSIFICATION_CRYPTOGRAPHIC_ASSET":    13,
+	}
+)
+
+func (x Classification) Enum() *Classification {
+	p := new(Classification)
+	*p = x
+	return p
+}
+
+func (x Classification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Classification) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[0].Descriptor()
+}
+
+func (Classification) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[0]
+}
+
+func (x Classification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Classification.Descriptor instead.
+func (Classification) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{0}
+}
+
+// Specifies the data flow.
+type DataFlow int32
+
+const (
+	DataFlow_DATA_FLOW_NULL           DataFlow = 0
+	DataFlow_DATA_FLOW_INBOUND        DataFlow = 1
+	DataFlow_DATA_FLOW_OUTBOUND       DataFlow = 2
+	DataFlow_DATA_FLOW_BI_DIRECTIONAL DataFlow = 3
+	DataFlow_DATA_FLOW_UNKNOWN        DataFlow = 4
+)
+
+// Enum value maps for DataFlow.
+var (
+	DataFlow_name = map[int32]string{
+		0: "DATA_FLOW_NULL",
+		1: "DATA_FLOW_INBOUND",
+		2: "DATA_FLOW_OUTBOUND",
+		3: "DATA_FLOW_BI_DIRECTIONAL",
+		4: "DATA_FLOW_UNKNOWN",
+	}
+	DataFlow_value = map[string]int32{
+		"DATA_FLOW_NULL":           0,
+		"DATA_FLOW_INBOUND":        1,
+		"DATA_FLOW_OUTBOUND":       2,
+		"DATA_FLOW_BI_DIRECTIONAL": 3,
+		"DATA_FLOW_UNKNOWN":        4,
+	}
+)
+
+func (x DataFlow) Enum() *DataFlow {
+	p := new(DataFlow)
+	*p = x
+	return p
+}
+
+func (x DataFlow) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataFlow) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[1].Descriptor()
+}
+
+func (DataFlow) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[1]
+}
+
+func (x DataFlow) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataFlow.Descriptor instead.
+func (DataFlow) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{1}
+}
+
+type ExternalReferenceType int32
+
+const (
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_OTHER                     ExternalReferenceType = 0
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_VCS                       ExternalReferenceType = 1
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ISSUE_TRACKER             ExternalReferenceType = 2
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_WEBSITE                   ExternalReferenceType = 3
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ADVISORIES                ExternalReferenceType = 4
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BOM                       ExternalReferenceType = 5
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MAILING_LIST              ExternalReferenceType = 6
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SOCIAL                    ExternalReferenceType = 7
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CHAT                      ExternalReferenceType = 8
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DOCUMENTATION             ExternalReferenceType = 9
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SUPPORT                   ExternalReferenceType = 10
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DISTRIBUTION              ExternalReferenceType = 11
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_LICENSE                   ExternalReferenceType = 12
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BUILD_META                ExternalReferenceType = 13
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_BUILD_SYSTEM              ExternalReferenceType = 14
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RELEASE_NOTES             ExternalReferenceType = 15
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SECURITY_CONTACT          ExternalReferenceType = 16
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MODEL_CARD                ExternalReferenceType = 17
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_LOG                       ExternalReferenceType = 18
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CONFIGURATION             ExternalReferenceType = 19
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_EVIDENCE                  ExternalReferenceType = 20
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_FORMULATION               ExternalReferenceType = 21
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ATTESTATION               ExternalReferenceType = 22
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_THREAT_MODEL              ExternalReferenceType = 23
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ADVERSARY_MODEL           ExternalReferenceType = 24
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RISK_ASSESSMENT           ExternalReferenceType = 25
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_VULNERABILITY_ASSERTION   ExternalReferenceType = 26
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_EXPLOITABILITY_STATEMENT  ExternalReferenceType = 27
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_PENTEST_REPORT            ExternalReferenceType = 28
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_STATIC_ANALYSIS_REPORT    ExternalReferenceType = 29
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DYNAMIC_ANALYSIS_REPORT   ExternalReferenceType = 30
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RUNTIME_ANALYSIS_REPORT   ExternalReferenceType = 31
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_COMPONENT_ANALYSIS_REPORT ExternalReferenceType = 32
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_MATURITY_REPORT           ExternalReferenceType = 33
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CERTIFICATION_REPORT      ExternalReferenceType = 34
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_QUALITY_METRICS           ExternalReferenceType = 35
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_CODIFIED_INFRASTRUCTURE   ExternalReferenceType = 36
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_POAM                      ExternalReferenceType = 37
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DISTRIBUTION_INTAKE       ExternalReferenceType = 38
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_ELECTRONIC_SIGNATURE      ExternalReferenceType = 39
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_DIGITAL_SIGNATURE         ExternalReferenceType = 40
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_RFC_9116                  ExternalReferenceType = 41
+	ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_SOURCE_DISTRIBUTION       ExternalReferenceType = 42
+)
+
+// Enum value maps for ExternalReferenceType.
+var (
+	ExternalReferenceType_name = map[int32]string{
+		0:  "EXTERNAL_REFERENCE_TYPE_OTHER",
+		1:  "EXTERNAL_REFERENCE_TYPE_VCS",
+		2:  "EXTERNAL_REFERENCE_TYPE_ISSUE_TRACKER",
+		3:  "EXTERNAL_REFERENCE_TYPE_WEBSITE",
+		4:  "EXTERNAL_REFERENCE_TYPE_ADVISORIES",
+		5:  "EXTERNAL_REFERENCE_TYPE_BOM",
+		6:  "EXTERNAL_REFERENCE_TYPE_MAILING_LIST",
+		7:  "EXTERNAL_REFERENCE_TYPE_SOCIAL",
+		8:  "EXTERNAL_REFERENCE_TYPE_CHAT",
+		9:  "EXTERNAL_REFERENCE_TYPE_DOCUMENTATION",
+		10: "EXTERNAL_REFERENCE_TYPE_SUPPORT",
+		11: "EXTERNAL_REFERENCE_TYPE_DISTRIBUTION",
+		12: "EXTERNAL_REFERENCE_TYPE_LICENSE",
+		13: "EXTERNAL_REFERENCE_TYPE_BUILD_META",
+		14: "EXTERNAL_REFERENCE_TYPE_BUILD_SYSTEM",
+		15: "EXTERNAL_REFERENCE_TYPE_RELEASE_NOTES",
+		16: "EXTERNAL_REFERENCE_TYPE_SECURITY_CONTACT",
+		17: "EXTERNAL_REFERENCE_TYPE_MODEL_CARD",
+		18: "EXTERNAL_REFERENCE_TYPE_LOG",
+		19: "EXTERNAL_REFERENCE_TYPE_CONFIGURATION",
+		20: "EXTERNAL_REFERENCE_TYPE_EVIDENCE",
+		21: "EXTERNAL_REFERENCE_TYPE_FORMULATION",
+		22: "EXTERNAL_REFERENCE_TYPE_ATTESTATION",
+		23: "EXTERNAL_REFERENCE_TYPE_THREAT_MODEL",
+		24: "EXTERNAL_REFERENCE_TYPE_ADVERSARY_MODEL",
+		25: "EXTERNAL_REFERENCE_TYPE_RISK_ASSESSMENT",
+		26: "EXTERNAL_REFERENCE_TYPE_VULNERABILITY_ASSERTION",
+		27: "EXTERNAL_REFERENCE_TYPE_EXPLOITABILITY_STATEMENT",
+		28: "EXTERNAL_REFERENCE_TYPE_PENTEST_REPORT",
+		29: "EXTERNAL_REFERENCE_TYPE_STATIC_ANALYSIS_REPORT",
+		30: "EXTERNAL_REFERENCE_TYPE_DYNAMIC_ANALYSIS_REPORT",
+		31: "EXTERNAL_REFERENCE_TYPE_RUNTIME_ANALYSIS_REPORT",
+		32: "EXTERNAL_REFERENCE_TYPE_COMPONENT_ANALYSIS_REPORT",
+		33: "EXTERNAL_REFERENCE_TYPE_MATURITY_REPORT",
+		34: "EXTERNAL_REFERENCE_TYPE_CERTIFICATION_REPORT",
+		35: "EXTERNAL_REFERENCE_TYPE_QUALITY_METRICS",
+		36: "EXTERNAL_REFERENCE_TYPE_CODIFIED_INFRASTRUCTURE",
+		37: "EXTERNAL_REFERENCE_TYPE_POAM",
+		38: "EXTERNAL_REFERENCE_TYPE_DISTRIBUTION_INTAKE",
+		39: "EXTERNAL_REFERENCE_TYPE_ELECTRONIC_SIGNATURE",
+		40: "EXTERNAL_REFERENCE_TYPE_DIGITAL_SIGNATURE",
+		41: "EXTERNAL_REFERENCE_TYPE_RFC_9116",
+		42: "EXTERNAL_REFERENCE_TYPE_SOURCE_DISTRIBUTION",
+	}
+	ExternalReferenceType_value = map[string]int32{
+		"EXTERNAL_REFERENCE_TYPE_OTHER":                     0,
+		"EXTERNAL_REFERENCE_TYPE_VCS":                       1,
+		"EXTERNAL_REFERENCE_TYPE_ISSUE_TRACKER":             2,
+		"EXTERNAL_REFERENCE_TYPE_WEBSITE":                   3,
+		"EXTERNAL_REFERENCE_TYPE_ADVISORIES":                4,
+		"EXTERNAL_REFERENCE_TYPE_BOM":                       5,
+		"EXTERNAL_REFERENCE_TYPE_MAILING_LIST":              6,
+		"EXTERNAL_REFERENCE_TYPE_SOCIAL":                    7,
+		"EXTERNAL_REFERENCE_TYPE_CHAT":                      8,
+		"EXTERNAL_REFERENCE_TYPE_DOCUMENTATION":             9,
+		"EXTERNAL_REFERENCE_TYPE_SUPPORT":                   10,
+		"EXTERNAL_REFERENCE_TYPE_DISTRIBUTION":              11,
+		"EXTERNAL_REFERENCE_TYPE_LICENSE":                   12,
+		"EXTERNAL_REFERENCE_TYPE_BUILD_META":                13,
+		"EXTERNAL_REFERENCE_TYPE_BUILD_SYSTEM":              14,
+		"EXTERNAL_REFERENCE_TYPE_RELEASE_NOTES":             15,
+		"EXTERNAL_REFERENCE_TYPE_SECURITY_CONTACT":          16,
+		"EXTERNAL_REFERENCE_TYPE_MODEL_CARD":                17,
+		"EXTERNAL_REFERENCE_TYPE_LOG":                       18,
+		"EXTERNAL_REFERENCE_TYPE_CONFIGURATION":             19,
+		"EXTERNAL_REFERENCE_TYPE_EVIDENCE":                  20,
+		"EXTERNAL_REFERENCE_TYPE_FORMULATION":               21,
+		"EXTERNAL_REFERENCE_TYPE_ATTESTATION":               22,
+		"EXTERNAL_REFERENCE_TYPE_THREAT_MODEL":              23,
+		"EXTERNAL_REFERENCE_TYPE_ADVERSARY_MODEL":           24,
+		"EXTERNAL_REFERENCE_TYPE_RISK_ASSESSMENT":           25,
+		"EXTERNAL_REFERENCE_TYPE_VULNERABILITY_ASSERTION":   26,
+		"EXTERNAL_REFERENCE_TYPE_EXPLOITABILITY_STATEMENT":  27,
+		"EXTERNAL_REFERENCE_TYPE_PENTEST_REPORT":            28,
+		"EXTERNAL_REFERENCE_TYPE_STATIC_ANALYSIS_REPORT":    29,
+		"EXTERNAL_REFERENCE_TYPE_DYNAMIC_ANALYSIS_REPORT":   30,
+		"EXTERNAL_REFERENCE_TYPE_RUNTIME_ANALYSIS_REPORT":   31,
+		"EXTERNAL_REFERENCE_TYPE_COMPONENT_ANALYSIS_REPORT": 32,
+		"EXTERNAL_REFERENCE_TYPE_MATURITY_REPORT":           33,
+		"EXTERNAL_REFERENCE_TYPE_CERTIFICATION_REPORT":      34,
+		"EXTERNAL_REFERENCE_TYPE_QUALITY_METRICS":           35,
+		"EXTERNAL_REFERENCE_TYPE_CODIFIED_INFRASTRUCTURE":   36,
+		"EXTERNAL_REFERENCE_TYPE_POAM":                      37,
+		"EXTERNAL_REFERENCE_TYPE_DISTRIBUTION_INTAKE":       38,
+		"EXTERNAL_REFERENCE_TYPE_ELECTRONIC_SIGNATURE":      39,
+		"EXTERNAL_REFERENCE_TYPE_DIGITAL_SIGNATURE":         40,
+		"EXTERNAL_REFERENCE_TYPE_RFC_9116":                  41,
+		"EXTERNAL_REFERENCE_TYPE_SOURCE_DISTRIBUTION":       42,
+	}
+)
+
+func (x ExternalReferenceType) Enum() *ExternalReferenceType {
+	p := new(ExternalReferenceType)
+	*p = x
+	return p
+}
+
+func (x ExternalReferenceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExternalReferenceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[2].Descriptor()
+}
+
+func (ExternalReferenceType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[2]
+}
+
+func (x ExternalReferenceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExternalReferenceType.Descriptor instead.
+func (ExternalReferenceType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{2}
+}
+
+type HashAlg int32
+
+const (
+	HashAlg_HASH_ALG_NULL          HashAlg = 0
+	HashAlg_HASH_ALG_MD_5          HashAlg = 1
+	HashAlg_HASH_ALG_SHA_1         HashAlg = 2
+	HashAlg_HASH_ALG_SHA_256       HashAlg = 3
+	HashAlg_HASH_ALG_SHA_384       HashAlg = 4
+	HashAlg_HASH_ALG_SHA_512       HashAlg = 5
+	HashAlg_HASH_ALG_SHA_3_256     HashAlg = 6
+	HashAlg_HASH_ALG_SHA_3_384     HashAlg = 7
+	HashAlg_HASH_ALG_SHA_3_512     HashAlg = 8
+	HashAlg_HASH_ALG_BLAKE_2_B_256 HashAlg = 9
+	HashAlg_HASH_ALG_BLAKE_2_B_384 HashAlg = 10
+	HashAlg_HASH_ALG_BLAKE_2_B_512 HashAlg = 11
+	HashAlg_HASH_ALG_BLAKE_3       HashAlg = 12
+)
+
+// Enum value maps for HashAlg.
+var (
+	HashAlg_name = map[int32]string{
+		0:  "HASH_ALG_NULL",
+		1:  "HASH_ALG_MD_5",
+		2:  "HASH_ALG_SHA_1",
+		3:  "HASH_ALG_SHA_256",
+		4:  "HASH_ALG_SHA_384",
+		5:  "HASH_ALG_SHA_512",
+		6:  "HASH_ALG_SHA_3_256",
+		7:  "HASH_ALG_SHA_3_384",
+		8:  "HASH_ALG_SHA_3_512",
+		9:  "HASH_ALG_BLAKE_2_B_256",
+		10: "HASH_ALG_BLAKE_2_B_384",
+		11: "HASH_ALG_BLAKE_2_B_512",
+		12: "HASH_ALG_BLAKE_3",
+	}
+	HashAlg_value = map[string]int32{
+		"HASH_ALG_NULL":          0,
+		"HASH_ALG_MD_5":          1,
+		"HASH_ALG_SHA_1":         2,
+		"HASH_ALG_SHA_256":       3,
+		"HASH_ALG_SHA_384":       4,
+		"HASH_ALG_SHA_512":       5,
+		"HASH_ALG_SHA_3_256":     6,
+		"HASH_ALG_SHA_3_384":     7,
+		"HASH_ALG_SHA_3_512":     8,
+		"HASH_ALG_BLAKE_2_B_256": 9,
+		"HASH_ALG_BLAKE_2_B_384": 10,
+		"HASH_ALG_BLAKE_2_B_512": 11,
+		"HASH_ALG_BLAKE_3":       12,
+	}
+)
+
+func (x HashAlg) Enum() *HashAlg {
+	p := new(HashAlg)
+	*p = x
+	return p
+}
+
+func (x HashAlg) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashAlg) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[3].Descriptor()
+}
+
+func (HashAlg) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[3]
+}
+
+func (x HashAlg) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashAlg.Descriptor instead.
+func (HashAlg) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{3}
+}
+
+type IssueClassification int32
+
+const (
+	IssueClassification_ISSUE_CLASSIFICATION_NULL        IssueClassification = 0
+	IssueClassification_ISSUE_CLASSIFICATION_DEFECT      IssueClassification = 1
+	IssueClassification_ISSUE_CLASSIFICATION_ENHANCEMENT IssueClassification = 2
+	IssueClassification_ISSUE_CLASSIFICATION_SECURITY    IssueClassification = 3
+)
+
+// Enum value maps for IssueClassification.
+var (
+	IssueClassification_name = map[int32]string{
+		0: "ISSUE_CLASSIFICATION_NULL",
+		1: "ISSUE_CLASSIFICATION_DEFECT",
+		2: "ISSUE_CLASSIFICATION_ENHANCEMENT",
+		3: "ISSUE_CLASSIFICATION_SECURITY",
+	}
+	IssueClassification_value = map[string]int32{
+		"ISSUE_CLASSIFICATION_NULL":        0,
+		"ISSUE_CLASSIFICATION_DEFECT":      1,
+		"ISSUE_CLASSIFICATION_ENHANCEMENT": 2,
+		"ISSUE_CLASSIFICATION_SECURITY":    3,
+	}
+)
+
+func (x IssueClassification) Enum() *IssueClassification {
+	p := new(IssueClassification)
+	*p = x
+	return p
+}
+
+func (x IssueClassification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IssueClassification) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[4].Descriptor()
+}
+
+func (IssueClassification) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[4]
+}
+
+func (x IssueClassification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IssueClassification.Descriptor instead.
+func (IssueClassification) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{4}
+}
+
+type LicenseAcknowledgementEnumeration int32
+
+const (
+	LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED LicenseAcknowledgementEnumeration = 0
+	LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_DECLARED    LicenseAcknowledgementEnumeration = 1
+	LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_CONCLUDED   LicenseAcknowledgementEnumeration = 2
+)
+
+// Enum value maps for LicenseAcknowledgementEnumeration.
+var (
+	LicenseAcknowledgementEnumeration_name = map[int32]string{
+		0: "LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED",
+		1: "LICENSE_ACKNOWLEDGEMENT_ENUMERATION_DECLARED",
+		2: "LICENSE_ACKNOWLEDGEMENT_ENUMERATION_CONCLUDED",
+	}
+	LicenseAcknowledgementEnumeration_value = map[string]int32{
+		"LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED": 0,
+		"LICENSE_ACKNOWLEDGEMENT_ENUMERATION_DECLARED":    1,
+		"LICENSE_ACKNOWLEDGEMENT_ENUMERATION_CONCLUDED":   2,
+	}
+)
+
+func (x LicenseAcknowledgementEnumeration) Enum() *LicenseAcknowledgementEnumeration {
+	p := new(LicenseAcknowledgementEnumeration)
+	*p = x
+	return p
+}
+
+func (x LicenseAcknowledgementEnumeration) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LicenseAcknowledgementEnumeration) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[5].Descriptor()
+}
+
+func (LicenseAcknowledgementEnumeration) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[5]
+}
+
+func (x LicenseAcknowledgementEnumeration) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LicenseAcknowledgementEnumeration.Descriptor instead.
+func (LicenseAcknowledgementEnumeration) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{5}
+}
+
+type LicensingTypeEnum int32
+
+const (
+	LicensingTypeEnum_LICENSING_TYPE_NULL             LicensingTypeEnum = 0
+	LicensingTypeEnum_LICENSING_TYPE_ACADEMIC         LicensingTypeEnum = 1
+	LicensingTypeEnum_LICENSING_TYPE_APPLIANCE        LicensingTypeEnum = 2
+	LicensingTypeEnum_LICENSING_TYPE_CLIENT_ACCESS    LicensingTypeEnum = 3
+	LicensingTypeEnum_LICENSING_TYPE_CONCURRENT_USER  LicensingTypeEnum = 4
+	LicensingTypeEnum_LICENSING_TYPE_CORE_POINTS      LicensingTypeEnum = 5
+	LicensingTypeEnum_LICENSING_TYPE_CUSTOM_METRIC    LicensingTypeEnum = 6
+	LicensingTypeEnum_LICENSING_TYPE_DEVICE           LicensingTypeEnum = 7
+	LicensingTypeEnum_LICENSING_TYPE_EVALUATION       LicensingTypeEnum = 8
+	LicensingTypeEnum_LICENSING_TYPE_NAMED_USER       LicensingTypeEnum = 9
+	LicensingTypeEnum_LICENSING_TYPE_NODE_LOCKED      LicensingTypeEnum = 10
+	LicensingTypeEnum_LICENSING_TYPE_OEM              LicensingTypeEnum = 11
+	LicensingTypeEnum_LICENSING_TYPE_PERPETUAL        LicensingTypeEnum = 12
+	LicensingTypeEnum_LICENSING_TYPE_PROCESSOR_POINTS LicensingTypeEnum = 13
+	LicensingTypeEnum_LICENSING_TYPE_SUBSCRIPTION     LicensingTypeEnum = 14
+	LicensingTypeEnum_LICENSING_TYPE_USER             LicensingTypeEnum = 15
+	LicensingTypeEnum_LICENSING_TYPE_OTHER            LicensingTypeEnum = 16
+)
+
+// Enum value maps for LicensingTypeEnum.
+var (
+	LicensingTypeEnum_name = map[int32]string{
+		0:  "LICENSING_TYPE_NULL",
+		1:  "LICENSING_TYPE_ACADEMIC",
+		2:  "LICENSING_TYPE_APPLIANCE",
+		3:  "LICENSING_TYPE_CLIENT_ACCESS",
+		4:  "LICENSING_TYPE_CONCURRENT_USER",
+		5:  "LICENSING_TYPE_CORE_POINTS",
+		6:  "LICENSING_TYPE_CUSTOM_METRIC",
+		7:  "LICENSING_TYPE_DEVICE",
+		8:  "LICENSING_TYPE_EVALUATION",
+		9:  "LICENSING_TYPE_NAMED_USER",
+		10: "LICENSING_TYPE_NODE_LOCKED",
+		11: "LICENSING_TYPE_OEM",
+		12: "LICENSING_TYPE_PERPETUAL",
+		13: "LICENSING_TYPE_PROCESSOR_POINTS",
+		14: "LICENSING_TYPE_SUBSCRIPTION",
+		15: "LICENSING_TYPE_USER",
+		16: "LICENSING_TYPE_OTHER",
+	}
+	LicensingTypeEnum_value = map[string]int32{
+		"LICENSING_TYPE_NULL":             0,
+		"LICENSING_TYPE_ACADEMIC":         1,
+		"LICENSING_TYPE_APPLIANCE":        2,
+		"LICENSING_TYPE_CLIENT_ACCESS":    3,
+		"LICENSING_TYPE_CONCURRENT_USER":  4,
+		"LICENSING_TYPE_CORE_POINTS":      5,
+		"LICENSING_TYPE_CUSTOM_METRIC":    6,
+		"LICENSING_TYPE_DEVICE":           7,
+		"LICENSING_TYPE_EVALUATION":       8,
+		"LICENSING_TYPE_NAMED_USER":       9,
+		"LICENSING_TYPE_NODE_LOCKED":      10,
+		"LICENSING_TYPE_OEM":              11,
+		"LICENSING_TYPE_PERPETUAL":        12,
+		"LICENSING_TYPE_PROCESSOR_POINTS": 13,
+		"LICENSING_TYPE_SUBSCRIPTION":     14,
+		"LICENSING_TYPE_USER":             15,
+		"LICENSING_TYPE_OTHER":            16,
+	}
+)
+
+func (x LicensingTypeEnum) Enum() *LicensingTypeEnum {
+	p := new(LicensingTypeEnum)
+	*p = x
+	return p
+}
+
+func (x LicensingTypeEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LicensingTypeEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[6].Descriptor()
+}
+
+func (LicensingTypeEnum) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[6]
+}
+
+func (x LicensingTypeEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LicensingTypeEnum.Descriptor instead.
+func (LicensingTypeEnum) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{6}
+}
+
+type LifecyclePhase int32
+
+const (
+	LifecyclePhase_LIFECYCLE_PHASE_DESIGN       LifecyclePhase = 0
+	LifecyclePhase_LIFECYCLE_PHASE_PRE_BUILD    LifecyclePhase = 1
+	LifecyclePhase_LIFECYCLE_PHASE_BUILD        LifecyclePhase = 2
+	LifecyclePhase_LIFECYCLE_PHASE_POST_BUILD   LifecyclePhase = 3
+	LifecyclePhase_LIFECYCLE_PHASE_OPERATIONS   LifecyclePhase = 4
+	LifecyclePhase_LIFECYCLE_PHASE_DISCOVERY    LifecyclePhase = 5
+	LifecyclePhase_LIFECYCLE_PHASE_DECOMMISSION LifecyclePhase = 6
+)
+
+// Enum value maps for LifecyclePhase.
+var (
+	LifecyclePhase_name = map[int32]string{
+		0: "LIFECYCLE_PHASE_DESIGN",
+		1: "LIFECYCLE_PHASE_PRE_BUILD",
+		2: "LIFECYCLE_PHASE_BUILD",
+		3: "LIFECYCLE_PHASE_POST_BUILD",
+		4: "LIFECYCLE_PHASE_OPERATIONS",
+		5: "LIFECYCLE_PHASE_DISCOVERY",
+		6: "LIFECYCLE_PHASE_DECOMMISSION",
+	}
+	LifecyclePhase_value = map[string]int32{
+		"LIFECYCLE_PHASE_DESIGN":       0,
+		"LIFECYCLE_PHASE_PRE_BUILD":    1,
+		"LIFECYCLE_PHASE_BUILD":        2,
+		"LIFECYCLE_PHASE_POST_BUILD":   3,
+		"LIFECYCLE_PHASE_OPERATIONS":   4,
+		"LIFECYCLE_PHASE_DISCOVERY":    5,
+		"LIFECYCLE_PHASE_DECOMMISSION": 6,
+	}
+)
+
+func (x LifecyclePhase) Enum() *LifecyclePhase {
+	p := new(LifecyclePhase)
+	*p = x
+	return p
+}
+
+func (x LifecyclePhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LifecyclePhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[7].Descriptor()
+}
+
+func (LifecyclePhase) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[7]
+}
+
+func (x LifecyclePhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LifecyclePhase.Descriptor instead.
+func (LifecyclePhase) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{7}
+}
+
+type PatchClassification int32
+
+const (
+	PatchClassification_PATCH_CLASSIFICATION_NULL        PatchClassification = 0
+	PatchClassification_PATCH_CLASSIFICATION_UNOFFICIAL  PatchClassification = 1
+	PatchClassification_PATCH_CLASSIFICATION_MONKEY      PatchClassification = 2
+	PatchClassification_PATCH_CLASSIFICATION_BACKPORT    PatchClassification = 3
+	PatchClassification_PATCH_CLASSIFICATION_CHERRY_PICK PatchClassification = 4
+)
+
+// Enum value maps for PatchClassification.
+var (
+	PatchClassification_name = map[int32]string{
+		0: "PATCH_CLASSIFICATION_NULL",
+		1: "PATCH_CLASSIFICATION_UNOFFICIAL",
+		2: "PATCH_CLASSIFICATION_MONKEY",
+		3: "PATCH_CLASSIFICATION_BACKPORT",
+		4: "PATCH_CLASSIFICATION_CHERRY_PICK",
+	}
+	PatchClassification_value = map[string]int32{
+		"PATCH_CLASSIFICATION_NULL":        0,
+		"PATCH_CLASSIFICATION_UNOFFICIAL":  1,
+		"PATCH_CLASSIFICATION_MONKEY":      2,
+		"PATCH_CLASSIFICATION_BACKPORT":    3,
+		"PATCH_CLASSIFICATION_CHERRY_PICK": 4,
+	}
+)
+
+func (x PatchClassification) Enum() *PatchClassification {
+	p := new(PatchClassification)
+	*p = x
+	return p
+}
+
+func (x PatchClassification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PatchClassification) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[8].Descriptor()
+}
+
+func (PatchClassification) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[8]
+}
+
+func (x PatchClassification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PatchClassification.Descriptor instead.
+func (PatchClassification) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{8}
+}
+
+type Scope int32
+
+const (
+	// Default.
+	Scope_SCOPE_UNSPECIFIED Scope = 0
+	// The component is required for runtime.
+	Scope_SCOPE_REQUIRED Scope = 1
+	// The component is optional at runtime.
+	Scope_SCOPE_OPTIONAL Scope = 2
+	// Components that are excluded provide the ability to document component usage for test and other non-runtime purposes.
+	Scope_SCOPE_EXCLUDED Scope = 3
+)
+
+// Enum value maps for Scope.
+var (
+	Scope_name = map[int32]string{
+		0: "SCOPE_UNSPECIFIED",
+		1: "SCOPE_REQUIRED",
+		2: "SCOPE_OPTIONAL",
+		3: "SCOPE_EXCLUDED",
+	}
+	Scope_value = map[string]int32{
+		"SCOPE_UNSPECIFIED": 0,
+		"SCOPE_REQUIRED":    1,
+		"SCOPE_OPTIONAL":    2,
+		"SCOPE_EXCLUDED":    3,
+	}
+)
+
+func (x Scope) Enum() *Scope {
+	p := new(Scope)
+	*p = x
+	return p
+}
+
+func (x Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[9].Descriptor()
+}
+
+func (Scope) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[9]
+}
+
+func (x Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Scope.Descriptor instead.
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{9}
+}
+
+type Aggregate int32
+
+const (
+	Aggregate_AGGREGATE_NOT_SPECIFIED                           Aggregate = 0
+	Aggregate_AGGREGATE_COMPLETE                                Aggregate = 1
+	Aggregate_AGGREGATE_INCOMPLETE                              Aggregate = 2
+	Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_ONLY             Aggregate = 3
+	Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_ONLY             Aggregate = 4
+	Aggregate_AGGREGATE_UNKNOWN                                 Aggregate = 5
+	Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY Aggregate = 6
+	Aggregate_AGGREGATE_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY  Aggregate = 7
+	Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY Aggregate = 8
+	Aggregate_AGGREGATE_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY  Aggregate = 9
+)
+
+// Enum value maps for Aggregate.
+var (
+	Aggregate_name = map[int32]string{
+		0: "AGGREGATE_NOT_SPECIFIED",
+		1: "AGGREGATE_COMPLETE",
+		2: "AGGREGATE_INCOMPLETE",
+		3: "AGGREGATE_INCOMPLETE_FIRST_PARTY_ONLY",
+		4: "AGGREGATE_INCOMPLETE_THIRD_PARTY_ONLY",
+		5: "AGGREGATE_UNKNOWN",
+		6: "AGGREGATE_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY",
+		7: "AGGREGATE_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY",
+		8: "AGGREGATE_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY",
+		9: "AGGREGATE_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY",
+	}
+	Aggregate_value = map[string]int32{
+		"AGGREGATE_NOT_SPECIFIED":                           0,
+		"AGGREGATE_COMPLETE":                                1,
+		"AGGREGATE_INCOMPLETE":                              2,
+		"AGGREGATE_INCOMPLETE_FIRST_PARTY_ONLY":             3,
+		"AGGREGATE_INCOMPLETE_THIRD_PARTY_ONLY":             4,
+		"AGGREGATE_UNKNOWN":                                 5,
+		"AGGREGATE_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY": 6,
+		"AGGREGATE_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY":  7,
+		"AGGREGATE_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY": 8,
+		"AGGREGATE_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY":  9,
+	}
+)
+
+func (x Aggregate) Enum() *Aggregate {
+	p := new(Aggregate)
+	*p = x
+	return p
+}
+
+func (x Aggregate) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Aggregate) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[10].Descriptor()
+}
+
+func (Aggregate) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[10]
+}
+
+func (x Aggregate) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Aggregate.Descriptor instead.
+func (Aggregate) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{10}
+}
+
+type EvidenceFieldType int32
+
+const (
+	EvidenceFieldType_EVIDENCE_FIELD_NULL       EvidenceFieldType = 0
+	EvidenceFieldType_EVIDENCE_FIELD_NAME       EvidenceFieldType = 1
+	EvidenceFieldType_EVIDENCE_FIELD_GROUP      EvidenceFieldType = 2
+	EvidenceFieldType_EVIDENCE_FIELD_VERSION    EvidenceFieldType = 3
+	EvidenceFieldType_EVIDENCE_FIELD_PURL       EvidenceFieldType = 4
+	EvidenceFieldType_EVIDENCE_FIELD_CPE        EvidenceFieldType = 5
+	EvidenceFieldType_EVIDENCE_FIELD_SWID       EvidenceFieldType = 6
+	EvidenceFieldType_EVIDENCE_FIELD_HASH       EvidenceFieldType = 7
+	EvidenceFieldType_EVIDENCE_FIELD_OMNIBOR_ID EvidenceFieldType = 8
+	EvidenceFieldType_EVIDENCE_FIELD_SWHID      EvidenceFieldType = 9
+)
+
+// Enum value maps for EvidenceFieldType.
+var (
+	EvidenceFieldType_name = map[int32]string{
+		0: "EVIDENCE_FIELD_NULL",
+		1: "EVIDENCE_FIELD_NAME",
+		2: "EVIDENCE_FIELD_GROUP",
+		3: "EVIDENCE_FIELD_VERSION",
+		4: "EVIDENCE_FIELD_PURL",
+		5: "EVIDENCE_FIELD_CPE",
+		6: "EVIDENCE_FIELD_SWID",
+		7: "EVIDENCE_FIELD_HASH",
+		8: "EVIDENCE_FIELD_OMNIBOR_ID",
+		9: "EVIDENCE_FIELD_SWHID",
+	}
+	EvidenceFieldType_value = map[string]int32{
+		"EVIDENCE_FIELD_NULL":       0,
+		"EVIDENCE_FIELD_NAME":       1,
+		"EVIDENCE_FIELD_GROUP":      2,
+		"EVIDENCE_FIELD_VERSION":    3,
+		"EVIDENCE_FIELD_PURL":       4,
+		"EVIDENCE_FIELD_CPE":        5,
+		"EVIDENCE_FIELD_SWID":       6,
+		"EVIDENCE_FIELD_HASH":       7,
+		"EVIDENCE_FIELD_OMNIBOR_ID": 8,
+		"EVIDENCE_FIELD_SWHID":      9,
+	}
+)
+
+func (x EvidenceFieldType) Enum() *EvidenceFieldType {
+	p := new(EvidenceFieldType)
+	*p = x
+	return p
+}
+
+func (x EvidenceFieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EvidenceFieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[11].Descriptor()
+}
+
+func (EvidenceFieldType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[11]
+}
+
+func (x EvidenceFieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EvidenceFieldType.Descriptor instead.
+func (EvidenceFieldType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{11}
+}
+
+type EvidenceTechnique int32
+
+const (
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS EvidenceTechnique = 0
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_BINARY_ANALYSIS      EvidenceTechnique = 1
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_MANIFEST_ANALYSIS    EvidenceTechnique = 2
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_AST_FINGERPRINT      EvidenceTechnique = 3
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_HASH_COMPARISON      EvidenceTechnique = 4
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_INSTRUMENTATION      EvidenceTechnique = 5
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_DYNAMIC_ANALYSIS     EvidenceTechnique = 6
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_FILENAME             EvidenceTechnique = 7
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_ATTESTATION          EvidenceTechnique = 8
+	EvidenceTechnique_EVIDENCE_TECHNIQUE_OTHER                EvidenceTechnique = 9
+)
+
+// Enum value maps for EvidenceTechnique.
+var (
+	EvidenceTechnique_name = map[int32]string{
+		0: "EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS",
+		1: "EVIDENCE_TECHNIQUE_BINARY_ANALYSIS",
+		2: "EVIDENCE_TECHNIQUE_MANIFEST_ANALYSIS",
+		3: "EVIDENCE_TECHNIQUE_AST_FINGERPRINT",
+		4: "EVIDENCE_TECHNIQUE_HASH_COMPARISON",
+		5: "EVIDENCE_TECHNIQUE_INSTRUMENTATION",
+		6: "EVIDENCE_TECHNIQUE_DYNAMIC_ANALYSIS",
+		7: "EVIDENCE_TECHNIQUE_FILENAME",
+		8: "EVIDENCE_TECHNIQUE_ATTESTATION",
+		9: "EVIDENCE_TECHNIQUE_OTHER",
+	}
+	EvidenceTechnique_value = map[string]int32{
+		"EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS": 0,
+		"EVIDENCE_TECHNIQUE_BINARY_ANALYSIS":      1,
+		"EVIDENCE_TECHNIQUE_MANIFEST_ANALYSIS":    2,
+		"EVIDENCE_TECHNIQUE_AST_FINGERPRINT":      3,
+		"EVIDENCE_TECHNIQUE_HASH_COMPARISON":      4,
+		"EVIDENCE_TECHNIQUE_INSTRUMENTATION":      5,
+		"EVIDENCE_TECHNIQUE_DYNAMIC_ANALYSIS":     6,
+		"EVIDENCE_TECHNIQUE_FILENAME":             7,
+		"EVIDENCE_TECHNIQUE_ATTESTATION":          8,
+		"EVIDENCE_TECHNIQUE_OTHER":                9,
+	}
+)
+
+func (x EvidenceTechnique) Enum() *EvidenceTechnique {
+	p := new(EvidenceTechnique)
+	*p = x
+	return p
+}
+
+func (x EvidenceTechnique) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EvidenceTechnique) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[12].Descriptor()
+}
+
+func (EvidenceTechnique) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[12]
+}
+
+func (x EvidenceTechnique) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EvidenceTechnique.Descriptor instead.
+func (EvidenceTechnique) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{12}
+}
+
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNKNOWN  Severity = 0
+	Severity_SEVERITY_CRITICAL Severity = 1
+	Severity_SEVERITY_HIGH     Severity = 2
+	Severity_SEVERITY_MEDIUM   Severity = 3
+	Severity_SEVERITY_LOW      Severity = 4
+	Severity_SEVERITY_INFO     Severity = 5
+	Severity_SEVERITY_NONE     Severity = 6
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNKNOWN",
+		1: "SEVERITY_CRITICAL",
+		2: "SEVERITY_HIGH",
+		3: "SEVERITY_MEDIUM",
+		4: "SEVERITY_LOW",
+		5: "SEVERITY_INFO",
+		6: "SEVERITY_NONE",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNKNOWN":  0,
+		"SEVERITY_CRITICAL": 1,
+		"SEVERITY_HIGH":     2,
+		"SEVERITY_MEDIUM":   3,
+		"SEVERITY_LOW":      4,
+		"SEVERITY_INFO":     5,
+		"SEVERITY_NONE":     6,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[13].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[13]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{13}
+}
+
+type ScoreMethod int32
+
+const (
+	ScoreMethod_SCORE_METHOD_NULL    ScoreMethod = 0
+	ScoreMethod_SCORE_METHOD_CVSSV2  ScoreMethod = 1
+	ScoreMethod_SCORE_METHOD_CVSSV3  ScoreMethod = 2
+	ScoreMethod_SCORE_METHOD_CVSSV31 ScoreMethod = 3
+	ScoreMethod_SCORE_METHOD_OWASP   ScoreMethod = 4
+	ScoreMethod_SCORE_METHOD_OTHER   ScoreMethod = 5
+	ScoreMethod_SCORE_METHOD_CVSSV4  ScoreMethod = 6
+	ScoreMethod_SCORE_METHOD_SSVC    ScoreMethod = 7
+)
+
+// Enum value maps for ScoreMethod.
+var (
+	ScoreMethod_name = map[int32]string{
+		0: "SCORE_METHOD_NULL",
+		1: "SCORE_METHOD_CVSSV2",
+		2: "SCORE_METHOD_CVSSV3",
+		3: "SCORE_METHOD_CVSSV31",
+		4: "SCORE_METHOD_OWASP",
+		5: "SCORE_METHOD_OTHER",
+		6: "SCORE_METHOD_CVSSV4",
+		7: "SCORE_METHOD_SSVC",
+	}
+	ScoreMethod_value = map[string]int32{
+		"SCORE_METHOD_NULL":    0,
+		"SCORE_METHOD_CVSSV2":  1,
+		"SCORE_METHOD_CVSSV3":  2,
+		"SCORE_METHOD_CVSSV31": 3,
+		"SCORE_METHOD_OWASP":   4,
+		"SCORE_METHOD_OTHER":   5,
+		"SCORE_METHOD_CVSSV4":  6,
+		"SCORE_METHOD_SSVC":    7,
+	}
+)
+
+func (x ScoreMethod) Enum() *ScoreMethod {
+	p := new(ScoreMethod)
+	*p = x
+	return p
+}
+
+func (x ScoreMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScoreMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[14].Descriptor()
+}
+
+func (ScoreMethod) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[14]
+}
+
+func (x ScoreMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScoreMethod.Descriptor instead.
+func (ScoreMethod) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{14}
+}
+
+type ImpactAnalysisJustification int32
+
+const (
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_NULL                            ImpactAnalysisJustification = 0
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_PRESENT                ImpactAnalysisJustification = 1
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_REACHABLE              ImpactAnalysisJustification = 2
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_CONFIGURATION          ImpactAnalysisJustification = 3
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_DEPENDENCY             ImpactAnalysisJustification = 4
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_ENVIRONMENT            ImpactAnalysisJustification = 5
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_COMPILER           ImpactAnalysisJustification = 6
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_RUNTIME            ImpactAnalysisJustification = 7
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_PERIMETER          ImpactAnalysisJustification = 8
+	ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_MITIGATING_CONTROL ImpactAnalysisJustification = 9
+)
+
+// Enum value maps for ImpactAnalysisJustification.
+var (
+	ImpactAnalysisJustification_name = map[int32]string{
+		0: "IMPACT_ANALYSIS_JUSTIFICATION_NULL",
+		1: "IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_PRESENT",
+		2: "IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_REACHABLE",
+		3: "IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_CONFIGURATION",
+		4: "IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_DEPENDENCY",
+		5: "IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_ENVIRONMENT",
+		6: "IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_COMPILER",
+		7: "IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_RUNTIME",
+		8: "IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_PERIMETER",
+		9: "IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_MITIGATING_CONTROL",
+	}
+	ImpactAnalysisJustification_value = map[string]int32{
+		"IMPACT_ANALYSIS_JUSTIFICATION_NULL":                            0,
+		"IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_PRESENT":                1,
+		"IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_REACHABLE":              2,
+		"IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_CONFIGURATION":          3,
+		"IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_DEPENDENCY":             4,
+		"IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_ENVIRONMENT":            5,
+		"IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_COMPILER":           6,
+		"IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_RUNTIME":            7,
+		"IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_PERIMETER":          8,
+		"IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_MITIGATING_CONTROL": 9,
+	}
+)
+
+func (x ImpactAnalysisJustification) Enum() *ImpactAnalysisJustification {
+	p := new(ImpactAnalysisJustification)
+	*p = x
+	return p
+}
+
+func (x ImpactAnalysisJustification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImpactAnalysisJustification) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[15].Descriptor()
+}
+
+func (ImpactAnalysisJustification) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[15]
+}
+
+func (x ImpactAnalysisJustification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImpactAnalysisJustification.Descriptor instead.
+func (ImpactAnalysisJustification) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{15}
+}
+
+type VulnerabilityResponse int32
+
+const (
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_NULL                 VulnerabilityResponse = 0
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_CAN_NOT_FIX          VulnerabilityResponse = 1
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_WILL_NOT_FIX         VulnerabilityResponse = 2
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_UPDATE               VulnerabilityResponse = 3
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_ROLLBACK             VulnerabilityResponse = 4
+	VulnerabilityResponse_VULNERABILITY_RESPONSE_WORKAROUND_AVAILABLE VulnerabilityResponse = 5
+)
+
+// Enum value maps for VulnerabilityResponse.
+var (
+	VulnerabilityResponse_name = map[int32]string{
+		0: "VULNERABILITY_RESPONSE_NULL",
+		1: "VULNERABILITY_RESPONSE_CAN_NOT_FIX",
+		2: "VULNERABILITY_RESPONSE_WILL_NOT_FIX",
+		3: "VULNERABILITY_RESPONSE_UPDATE",
+		4: "VULNERABILITY_RESPONSE_ROLLBACK",
+		5: "VULNERABILITY_RESPONSE_WORKAROUND_AVAILABLE",
+	}
+	VulnerabilityResponse_value = map[string]int32{
+		"VULNERABILITY_RESPONSE_NULL":                 0,
+		"VULNERABILITY_RESPONSE_CAN_NOT_FIX":          1,
+		"VULNERABILITY_RESPONSE_WILL_NOT_FIX":         2,
+		"VULNERABILITY_RESPONSE_UPDATE":               3,
+		"VULNERABILITY_RESPONSE_ROLLBACK":             4,
+		"VULNERABILITY_RESPONSE_WORKAROUND_AVAILABLE": 5,
+	}
+)
+
+func (x VulnerabilityResponse) Enum() *VulnerabilityResponse {
+	p := new(VulnerabilityResponse)
+	*p = x
+	return p
+}
+
+func (x VulnerabilityResponse) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VulnerabilityResponse) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[16].Descriptor()
+}
+
+func (VulnerabilityResponse) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[16]
+}
+
+func (x VulnerabilityResponse) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VulnerabilityResponse.Descriptor instead.
+func (VulnerabilityResponse) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{16}
+}
+
+type ImpactAnalysisState int32
+
+const (
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_NULL                   ImpactAnalysisState = 0
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_RESOLVED               ImpactAnalysisState = 1
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_RESOLVED_WITH_PEDIGREE ImpactAnalysisState = 2
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_EXPLOITABLE            ImpactAnalysisState = 3
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_IN_TRIAGE              ImpactAnalysisState = 4
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_FALSE_POSITIVE         ImpactAnalysisState = 5
+	ImpactAnalysisState_IMPACT_ANALYSIS_STATE_NOT_AFFECTED           ImpactAnalysisState = 6
+)
+
+// Enum value maps for ImpactAnalysisState.
+var (
+	ImpactAnalysisState_name = map[int32]string{
+		0: "IMPACT_ANALYSIS_STATE_NULL",
+		1: "IMPACT_ANALYSIS_STATE_RESOLVED",
+		2: "IMPACT_ANALYSIS_STATE_RESOLVED_WITH_PEDIGREE",
+		3: "IMPACT_ANALYSIS_STATE_EXPLOITABLE",
+		4: "IMPACT_ANALYSIS_STATE_IN_TRIAGE",
+		5: "IMPACT_ANALYSIS_STATE_FALSE_POSITIVE",
+		6: "IMPACT_ANALYSIS_STATE_NOT_AFFECTED",
+	}
+	ImpactAnalysisState_value = map[string]int32{
+		"IMPACT_ANALYSIS_STATE_NULL":                   0,
+		"IMPACT_ANALYSIS_STATE_RESOLVED":               1,
+		"IMPACT_ANALYSIS_STATE_RESOLVED_WITH_PEDIGREE": 2,
+		"IMPACT_ANALYSIS_STATE_EXPLOITABLE":            3,
+		"IMPACT_ANALYSIS_STATE_IN_TRIAGE":              4,
+		"IMPACT_ANALYSIS_STATE_FALSE_POSITIVE":         5,
+		"IMPACT_ANALYSIS_STATE_NOT_AFFECTED":           6,
+	}
+)
+
+func (x ImpactAnalysisState) Enum() *ImpactAnalysisState {
+	p := new(ImpactAnalysisState)
+	*p = x
+	return p
+}
+
+func (x ImpactAnalysisState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImpactAnalysisState) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[17].Descriptor()
+}
+
+func (ImpactAnalysisState) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[17]
+}
+
+func (x ImpactAnalysisState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImpactAnalysisState.Descriptor instead.
+func (ImpactAnalysisState) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{17}
+}
+
+type VulnerabilityAffectedStatus int32
+
+const (
+	// The vulnerability status of a given version or range of versions of a product.
+	VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_UNKNOWN      VulnerabilityAffectedStatus = 0
+	VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_AFFECTED     VulnerabilityAffectedStatus = 1
+	VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_NOT_AFFECTED VulnerabilityAffectedStatus = 2
+)
+
+// Enum value maps for VulnerabilityAffectedStatus.
+var (
+	VulnerabilityAffectedStatus_name = map[int32]string{
+		0: "VULNERABILITY_AFFECTED_STATUS_UNKNOWN",
+		1: "VULNERABILITY_AFFECTED_STATUS_AFFECTED",
+		2: "VULNERABILITY_AFFECTED_STATUS_NOT_AFFECTED",
+	}
+	VulnerabilityAffectedStatus_value = map[string]int32{
+		"VULNERABILITY_AFFECTED_STATUS_UNKNOWN":      0,
+		"VULNERABILITY_AFFECTED_STATUS_AFFECTED":     1,
+		"VULNERABILITY_AFFECTED_STATUS_NOT_AFFECTED": 2,
+	}
+)
+
+func (x VulnerabilityAffectedStatus) Enum() *VulnerabilityAffectedStatus {
+	p := new(VulnerabilityAffectedStatus)
+	*p = x
+	return p
+}
+
+func (x VulnerabilityAffectedStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VulnerabilityAffectedStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[18].Descriptor()
+}
+
+func (VulnerabilityAffectedStatus) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[18]
+}
+
+func (x VulnerabilityAffectedStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VulnerabilityAffectedStatus.Descriptor instead.
+func (VulnerabilityAffectedStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{18}
+}
+
+type TaskType int32
+
+const (
+	TaskType_TASK_TYPE_COPY    TaskType = 0
+	TaskType_TASK_TYPE_CLONE   TaskType = 1
+	TaskType_TASK_TYPE_LINT    TaskType = 2
+	TaskType_TASK_TYPE_SCAN    TaskType = 3
+	TaskType_TASK_TYPE_MERGE   TaskType = 4
+	TaskType_TASK_TYPE_BUILD   TaskType = 5
+	TaskType_TASK_TYPE_TEST    TaskType = 6
+	TaskType_TASK_TYPE_DELIVER TaskType = 7
+	TaskType_TASK_TYPE_DEPLOY  TaskType = 8
+	TaskType_TASK_TYPE_RELEASE TaskType = 9
+	TaskType_TASK_TYPE_CLEAN   TaskType = 10
+	TaskType_TASK_TYPE_OTHER   TaskType = 11
+)
+
+// Enum value maps for TaskType.
+var (
+	TaskType_name = map[int32]string{
+		0:  "TASK_TYPE_COPY",
+		1:  "TASK_TYPE_CLONE",
+		2:  "TASK_TYPE_LINT",
+		3:  "TASK_TYPE_SCAN",
+		4:  "TASK_TYPE_MERGE",
+		5:  "TASK_TYPE_BUILD",
+		6:  "TASK_TYPE_TEST",
+		7:  "TASK_TYPE_DELIVER",
+		8:  "TASK_TYPE_DEPLOY",
+		9:  "TASK_TYPE_RELEASE",
+		10: "TASK_TYPE_CLEAN",
+		11: "TASK_TYPE_OTHER",
+	}
+	TaskType_value = map[string]int32{
+		"TASK_TYPE_COPY":    0,
+		"TASK_TYPE_CLONE":   1,
+		"TASK_TYPE_LINT":    2,
+		"TASK_TYPE_SCAN":    3,
+		"TASK_TYPE_MERGE":   4,
+		"TASK_TYPE_BUILD":   5,
+		"TASK_TYPE_TEST":    6,
+		"TASK_TYPE_DELIVER": 7,
+		"TASK_TYPE_DEPLOY":  8,
+		"TASK_TYPE_RELEASE": 9,
+		"TASK_TYPE_CLEAN":   10,
+		"TASK_TYPE_OTHER":   11,
+	}
+)
+
+func (x TaskType) Enum() *TaskType {
+	p := new(TaskType)
+	*p = x
+	return p
+}
+
+func (x TaskType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[19].Descriptor()
+}
+
+func (TaskType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[19]
+}
+
+func (x TaskType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskType.Descriptor instead.
+func (TaskType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{19}
+}
+
+type ComponentDataType int32
+
+const (
+	// Any type of code, code snippet, or data-as-code.
+	ComponentDataType_COMPONENT_DATA_TYPE_SOURCE_CODE ComponentDataType = 0
+	// Parameters or settings that may be used by other components.
+	ComponentDataType_COMPONENT_DATA_TYPE_CONFIGURATION ComponentDataType = 1
+	// A collection of data.
+	ComponentDataType_COMPONENT_DATA_TYPE_DATASET ComponentDataType = 2
+	// Data that can be used to create new instances of what the definition defines.
+	ComponentDataType_COMPONENT_DATA_TYPE_DEFINITION ComponentDataType = 3
+	// Any other type of data that does not fit into existing definitions.
+	ComponentDataType_COMPONENT_DATA_TYPE_OTHER ComponentDataType = 4
+)
+
+// Enum value maps for ComponentDataType.
+var (
+	ComponentDataType_name = map[int32]string{
+		0: "COMPONENT_DATA_TYPE_SOURCE_CODE",
+		1: "COMPONENT_DATA_TYPE_CONFIGURATION",
+		2: "COMPONENT_DATA_TYPE_DATASET",
+		3: "COMPONENT_DATA_TYPE_DEFINITION",
+		4: "COMPONENT_DATA_TYPE_OTHER",
+	}
+	ComponentDataType_value = map[string]int32{
+		"COMPONENT_DATA_TYPE_SOURCE_CODE":   0,
+		"COMPONENT_DATA_TYPE_CONFIGURATION": 1,
+		"COMPONENT_DATA_TYPE_DATASET":       2,
+		"COMPONENT_DATA_TYPE_DEFINITION":    3,
+		"COMPONENT_DATA_TYPE_OTHER":         4,
+	}
+)
+
+func (x ComponentDataType) Enum() *ComponentDataType {
+	p := new(ComponentDataType)
+	*p = x
+	return p
+}
+
+func (x ComponentDataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ComponentDataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[20].Descriptor()
+}
+
+func (ComponentDataType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[20]
+}
+
+func (x ComponentDataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ComponentDataType.Descriptor instead.
+func (ComponentDataType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{20}
+}
+
+type ModelParameterApproachType int32
+
+const (
+	ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED          ModelParameterApproachType = 0
+	ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_UNSUPERVISED        ModelParameterApproachType = 1
+	ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_REINFORCED_LEARNING ModelParameterApproachType = 2
+	ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SEMI_SUPERVISED     ModelParameterApproachType = 3
+	ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SELF_SUPERVISED     ModelParameterApproachType = 4
+)
+
+// Enum value maps for ModelParameterApproachType.
+var (
+	ModelParameterApproachType_name = map[int32]string{
+		0: "MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED",
+		1: "MODEL_PARAMETER_APPROACH_TYPE_UNSUPERVISED",
+		2: "MODEL_PARAMETER_APPROACH_TYPE_REINFORCED_LEARNING",
+		3: "MODEL_PARAMETER_APPROACH_TYPE_SEMI_SUPERVISED",
+		4: "MODEL_PARAMETER_APPROACH_TYPE_SELF_SUPERVISED",
+	}
+	ModelParameterApproachType_value = map[string]int32{
+		"MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED":          0,
+		"MODEL_PARAMETER_APPROACH_TYPE_UNSUPERVISED":        1,
+		"MODEL_PARAMETER_APPROACH_TYPE_REINFORCED_LEARNING": 2,
+		"MODEL_PARAMETER_APPROACH_TYPE_SEMI_SUPERVISED":     3,
+		"MODEL_PARAMETER_APPROACH_TYPE_SELF_SUPERVISED":     4,
+	}
+)
+
+func (x ModelParameterApproachType) Enum() *ModelParameterApproachType {
+	p := new(ModelParameterApproachType)
+	*p = x
+	return p
+}
+
+func (x ModelParameterApproachType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelParameterApproachType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[21].Descriptor()
+}
+
+func (ModelParameterApproachType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[21]
+}
+
+func (x ModelParameterApproachType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelParameterApproachType.Descriptor instead.
+func (ModelParameterApproachType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{21}
+}
+
+type Workspace_AccessMode int32
+
+const (
+	Workspace_ACCESS_MODE_READ_ONLY       Workspace_AccessMode = 0
+	Workspace_ACCESS_MODE_READ_WRITE      Workspace_AccessMode = 1
+	Workspace_ACCESS_MODE_READ_WRITE_ONCE Workspace_AccessMode = 2
+	Workspace_ACCESS_MODE_WRITE_ONCE      Workspace_AccessMode = 3
+	Workspace_ACCESS_MODE_WRITE_ONLY      Workspace_AccessMode = 4
+)
+
+// Enum value maps for Workspace_AccessMode.
+var (
+	Workspace_AccessMode_name = map[int32]string{
+		0: "ACCESS_MODE_READ_ONLY",
+		1: "ACCESS_MODE_READ_WRITE",
+		2: "ACCESS_MODE_READ_WRITE_ONCE",
+		3: "ACCESS_MODE_WRITE_ONCE",
+		4: "ACCESS_MODE_WRITE_ONLY",
+	}
+	Workspace_AccessMode_value = map[string]int32{
+		"ACCESS_MODE_READ_ONLY":       0,
+		"ACCESS_MODE_READ_WRITE":      1,
+		"ACCESS_MODE_READ_WRITE_ONCE": 2,
+		"ACCESS_MODE_WRITE_ONCE":      3,
+		"ACCESS_MODE_WRITE_ONLY":      4,
+	}
+)
+
+func (x Workspace_AccessMode) Enum() *Workspace_AccessMode {
+	p := new(Workspace_AccessMode)
+	*p = x
+	return p
+}
+
+func (x Workspace_AccessMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Workspace_AccessMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[22].Descriptor()
+}
+
+func (Workspace_AccessMode) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[22]
+}
+
+func (x Workspace_AccessMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Workspace_AccessMode.Descriptor instead.
+func (Workspace_AccessMode) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{49, 0}
+}
+
+type Volume_VolumeMode int32
+
+const (
+	Volume_VOLUME_MODE_FILESYSTEM Volume_VolumeMode = 0
+	Volume_VOLUME_MODE_BLOCK      Volume_VolumeMode = 1
+)
+
+// Enum value maps for Volume_VolumeMode.
+var (
+	Volume_VolumeMode_name = map[int32]string{
+		0: "VOLUME_MODE_FILESYSTEM",
+		1: "VOLUME_MODE_BLOCK",
+	}
+	Volume_VolumeMode_value = map[string]int32{
+		"VOLUME_MODE_FILESYSTEM": 0,
+		"VOLUME_MODE_BLOCK":      1,
+	}
+)
+
+func (x Volume_VolumeMode) Enum() *Volume_VolumeMode {
+	p := new(Volume_VolumeMode)
+	*p = x
+	return p
+}
+
+func (x Volume_VolumeMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Volume_VolumeMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[23].Descriptor()
+}
+
+func (Volume_VolumeMode) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[23]
+}
+
+func (x Volume_VolumeMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Volume_VolumeMode.Descriptor instead.
+func (Volume_VolumeMode) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{50, 0}
+}
+
+type Trigger_TriggerType int32
+
+const (
+	Trigger_TRIGGER_TYPE_MANUAL    Trigger_TriggerType = 0
+	Trigger_TRIGGER_TYPE_API       Trigger_TriggerType = 1
+	Trigger_TRIGGER_TYPE_WEBHOOK   Trigger_TriggerType = 2
+	Trigger_TRIGGER_TYPE_SCHEDULED Trigger_TriggerType = 3
+)
+
+// Enum value maps for Trigger_TriggerType.
+var (
+	Trigger_TriggerType_name = map[int32]string{
+		0: "TRIGGER_TYPE_MANUAL",
+		1: "TRIGGER_TYPE_API",
+		2: "TRIGGER_TYPE_WEBHOOK",
+		3: "TRIGGER_TYPE_SCHEDULED",
+	}
+	Trigger_TriggerType_value = map[string]int32{
+		"TRIGGER_TYPE_MANUAL":    0,
+		"TRIGGER_TYPE_API":       1,
+		"TRIGGER_TYPE_WEBHOOK":   2,
+		"TRIGGER_TYPE_SCHEDULED": 3,
+	}
+)
+
+func (x Trigger_TriggerType) Enum() *Trigger_TriggerType {
+	p := new(Trigger_TriggerType)
+	*p = x
+	return p
+}
+
+func (x Trigger_TriggerType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Trigger_TriggerType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[24].Descriptor()
+}
+
+func (Trigger_TriggerType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[24]
+}
+
+func (x Trigger_TriggerType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Trigger_TriggerType.Descriptor instead.
+func (Trigger_TriggerType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{51, 0}
+}
+
+type OutputType_OutputTypeType int32
+
+const (
+	OutputType_OUTPUT_TYPE_ARTIFACT    OutputType_OutputTypeType = 0
+	OutputType_OUTPUT_TYPE_ATTESTATION OutputType_OutputTypeType = 1
+	OutputType_OUTPUT_TYPE_LOG         OutputType_OutputTypeType = 2
+	OutputType_OUTPUT_TYPE_EVIDENCE    OutputType_OutputTypeType = 3
+	OutputType_OUTPUT_TYPE_METRICS     OutputType_OutputTypeType = 4
+	OutputType_OUTPUT_TYPE_OTHER       OutputType_OutputTypeType = 5
+)
+
+// Enum value maps for OutputType_OutputTypeType.
+var (
+	OutputType_OutputTypeType_name = map[int32]string{
+		0: "OUTPUT_TYPE_ARTIFACT",
+		1: "OUTPUT_TYPE_ATTESTATION",
+		2: "OUTPUT_TYPE_LOG",
+		3: "OUTPUT_TYPE_EVIDENCE",
+		4: "OUTPUT_TYPE_METRICS",
+		5: "OUTPUT_TYPE_OTHER",
+	}
+	OutputType_OutputTypeType_value = map[string]int32{
+		"OUTPUT_TYPE_ARTIFACT":    0,
+		"OUTPUT_TYPE_ATTESTATION": 1,
+		"OUTPUT_TYPE_LOG":         2,
+		"OUTPUT_TYPE_EVIDENCE":    3,
+		"OUTPUT_TYPE_METRICS":     4,
+		"OUTPUT_TYPE_OTHER":       5,
+	}
+)
+
+func (x OutputType_OutputTypeType) Enum() *OutputType_OutputTypeType {
+	p := new(OutputType_OutputTypeType)
+	*p = x
+	return p
+}
+
+func (x OutputType_OutputTypeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputType_OutputTypeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[25].Descriptor()
+}
+
+func (OutputType_OutputTypeType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[25]
+}
+
+func (x OutputType_OutputTypeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputType_OutputTypeType.Descriptor instead.
+func (OutputType_OutputTypeType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{54, 0}
+}
+
+type ModelCard_ModelCardConsiderations_EnergyActivity int32
+
+const (
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_UNSPECIFIED      ModelCard_ModelCardConsiderations_EnergyActivity = 0
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_OTHER            ModelCard_ModelCardConsiderations_EnergyActivity = 1
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DESIGN           ModelCard_ModelCardConsiderations_EnergyActivity = 2
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DATA_COLLECTION  ModelCard_ModelCardConsiderations_EnergyActivity = 3
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DATA_PREPARATION ModelCard_ModelCardConsiderations_EnergyActivity = 4
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_TRAINING         ModelCard_ModelCardConsiderations_EnergyActivity = 5
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_FINE_TUNING      ModelCard_ModelCardConsiderations_EnergyActivity = 6
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_VALIDATION       ModelCard_ModelCardConsiderations_EnergyActivity = 7
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_DEPLOYMENT       ModelCard_ModelCardConsiderations_EnergyActivity = 8
+	ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_INFERENCE        ModelCard_ModelCardConsiderations_EnergyActivity = 9
+)
+
+// Enum value maps for ModelCard_ModelCardConsiderations_EnergyActivity.
+var (
+	ModelCard_ModelCardConsiderations_EnergyActivity_name = map[int32]string{
+		0: "ENERGY_ACTIVITY_UNSPECIFIED",
+		1: "ENERGY_ACTIVITY_OTHER",
+		2: "ENERGY_ACTIVITY_DESIGN",
+		3: "ENERGY_ACTIVITY_DATA_COLLECTION",
+		4: "ENERGY_ACTIVITY_DATA_PREPARATION",
+		5: "ENERGY_ACTIVITY_TRAINING",
+		6: "ENERGY_ACTIVITY_FINE_TUNING",
+		7: "ENERGY_ACTIVITY_VALIDATION",
+		8: "ENERGY_ACTIVITY_DEPLOYMENT",
+		9: "ENERGY_ACTIVITY_INFERENCE",
+	}
+	ModelCard_ModelCardConsiderations_EnergyActivity_value = map[string]int32{
+		"ENERGY_ACTIVITY_UNSPECIFIED":      0,
+		"ENERGY_ACTIVITY_OTHER":            1,
+		"ENERGY_ACTIVITY_DESIGN":           2,
+		"ENERGY_ACTIVITY_DATA_COLLECTION":  3,
+		"ENERGY_ACTIVITY_DATA_PREPARATION": 4,
+		"ENERGY_ACTIVITY_TRAINING":         5,
+		"ENERGY_ACTIVITY_FINE_TUNING":      6,
+		"ENERGY_ACTIVITY_VALIDATION":       7,
+		"ENERGY_ACTIVITY_DEPLOYMENT":       8,
+		"ENERGY_ACTIVITY_INFERENCE":        9,
+	}
+)
+
+func (x ModelCard_ModelCardConsiderations_EnergyActivity) Enum() *ModelCard_ModelCardConsiderations_EnergyActivity {
+	p := new(ModelCard_ModelCardConsiderations_EnergyActivity)
+	*p = x
+	return p
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergyActivity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelCard_ModelCardConsiderations_EnergyActivity) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[26].Descriptor()
+}
+
+func (ModelCard_ModelCardConsiderations_EnergyActivity) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[26]
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergyActivity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergyActivity.Descriptor instead.
+func (ModelCard_ModelCardConsiderations_EnergyActivity) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 0}
+}
+
+type ModelCard_ModelCardConsiderations_EnergySource int32
+
+const (
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_UNSPECIFIED ModelCard_ModelCardConsiderations_EnergySource = 0
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_COAL        ModelCard_ModelCardConsiderations_EnergySource = 1
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_OIL         ModelCard_ModelCardConsiderations_EnergySource = 2
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_NATURAL_GAS ModelCard_ModelCardConsiderations_EnergySource = 3
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_NUCLEAR     ModelCard_ModelCardConsiderations_EnergySource = 4
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_WIND        ModelCard_ModelCardConsiderations_EnergySource = 5
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_SOLAR       ModelCard_ModelCardConsiderations_EnergySource = 6
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_GEOTHERMAL  ModelCard_ModelCardConsiderations_EnergySource = 7
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_HYDROPOWER  ModelCard_ModelCardConsiderations_EnergySource = 8
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_BIOFUEL     ModelCard_ModelCardConsiderations_EnergySource = 9
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_UNKNOWN     ModelCard_ModelCardConsiderations_EnergySource = 10
+	ModelCard_ModelCardConsiderations_ENERGY_SOURCE_OTHER       ModelCard_ModelCardConsiderations_EnergySource = 11
+)
+
+// Enum value maps for ModelCard_ModelCardConsiderations_EnergySource.
+var (
+	ModelCard_ModelCardConsiderations_EnergySource_name = map[int32]string{
+		0:  "ENERGY_SOURCE_UNSPECIFIED",
+		1:  "ENERGY_SOURCE_COAL",
+		2:  "ENERGY_SOURCE_OIL",
+		3:  "ENERGY_SOURCE_NATURAL_GAS",
+		4:  "ENERGY_SOURCE_NUCLEAR",
+		5:  "ENERGY_SOURCE_WIND",
+		6:  "ENERGY_SOURCE_SOLAR",
+		7:  "ENERGY_SOURCE_GEOTHERMAL",
+		8:  "ENERGY_SOURCE_HYDROPOWER",
+		9:  "ENERGY_SOURCE_BIOFUEL",
+		10: "ENERGY_SOURCE_UNKNOWN",
+		11: "ENERGY_SOURCE_OTHER",
+	}
+	ModelCard_ModelCardConsiderations_EnergySource_value = map[string]int32{
+		"ENERGY_SOURCE_UNSPECIFIED": 0,
+		"ENERGY_SOURCE_COAL":        1,
+		"ENERGY_SOURCE_OIL":         2,
+		"ENERGY_SOURCE_NATURAL_GAS": 3,
+		"ENERGY_SOURCE_NUCLEAR":     4,
+		"ENERGY_SOURCE_WIND":        5,
+		"ENERGY_SOURCE_SOLAR":       6,
+		"ENERGY_SOURCE_GEOTHERMAL":  7,
+		"ENERGY_SOURCE_HYDROPOWER":  8,
+		"ENERGY_SOURCE_BIOFUEL":     9,
+		"ENERGY_SOURCE_UNKNOWN":     10,
+		"ENERGY_SOURCE_OTHER":       11,
+	}
+)
+
+func (x ModelCard_ModelCardConsiderations_EnergySource) Enum() *ModelCard_ModelCardConsiderations_EnergySource {
+	p := new(ModelCard_ModelCardConsiderations_EnergySource)
+	*p = x
+	return p
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergySource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelCard_ModelCardConsiderations_EnergySource) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[27].Descriptor()
+}
+
+func (ModelCard_ModelCardConsiderations_EnergySource) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[27]
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergySource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergySource.Descriptor instead.
+func (ModelCard_ModelCardConsiderations_EnergySource) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 1}
+}
+
+type ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit int32
+
+const (
+	ModelCard_ModelCardConsiderations_EnergyMeasure_ENERGY_UNIT_KWH ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit = 0
+)
+
+// Enum value maps for ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit.
+var (
+	ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit_name = map[int32]string{
+		0: "ENERGY_UNIT_KWH",
+	}
+	ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit_value = map[string]int32{
+		"ENERGY_UNIT_KWH": 0,
+	}
+)
+
+func (x ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) Enum() *ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit {
+	p := new(ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit)
+	*p = x
+	return p
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[28].Descriptor()
+}
+
+func (ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[28]
+}
+
+func (x ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit.Descriptor instead.
+func (ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 4, 0}
+}
+
+type ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit int32
+
+const (
+	ModelCard_ModelCardConsiderations_Co2Measure_CO2_UNIT_TCO2EQ ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit = 0
+)
+
+// Enum value maps for ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit.
+var (
+	ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit_name = map[int32]string{
+		0: "CO2_UNIT_TCO2EQ",
+	}
+	ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit_value = map[string]int32{
+		"CO2_UNIT_TCO2EQ": 0,
+	}
+)
+
+func (x ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) Enum() *ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit {
+	p := new(ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit)
+	*p = x
+	return p
+}
+
+func (x ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[29].Descriptor()
+}
+
+func (ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[29]
+}
+
+func (x ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit.Descriptor instead.
+func (ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 5, 0}
+}
+
+type CryptoProperties_CryptoAssetType int32
+
+const (
+	CryptoProperties_CRYPTO_ASSET_TYPE_UNSPECIFIED             CryptoProperties_CryptoAssetType = 0
+	CryptoProperties_CRYPTO_ASSET_TYPE_ALGORITHM               CryptoProperties_CryptoAssetType = 1
+	CryptoProperties_CRYPTO_ASSET_TYPE_CERTIFICATE             CryptoProperties_CryptoAssetType = 2
+	CryptoProperties_CRYPTO_ASSET_TYPE_PROTOCOL                CryptoProperties_CryptoAssetType = 3
+	CryptoProperties_CRYPTO_ASSET_TYPE_RELATED_CRYPTO_MATERIAL CryptoProperties_CryptoAssetType = 4
+)
+
+// Enum value maps for CryptoProperties_CryptoAssetType.
+var (
+	CryptoProperties_CryptoAssetType_name = map[int32]string{
+		0: "CRYPTO_ASSET_TYPE_UNSPECIFIED",
+		1: "CRYPTO_ASSET_TYPE_ALGORITHM",
+		2: "CRYPTO_ASSET_TYPE_CERTIFICATE",
+		3: "CRYPTO_ASSET_TYPE_PROTOCOL",
+		4: "CRYPTO_ASSET_TYPE_RELATED_CRYPTO_MATERIAL",
+	}
+	CryptoProperties_CryptoAssetType_value = map[string]int32{
+		"CRYPTO_ASSET_TYPE_UNSPECIFIED":             0,
+		"CRYPTO_ASSET_TYPE_ALGORITHM":               1,
+		"CRYPTO_ASSET_TYPE_CERTIFICATE":             2,
+		"CRYPTO_ASSET_TYPE_PROTOCOL":                3,
+		"CRYPTO_ASSET_TYPE_RELATED_CRYPTO_MATERIAL": 4,
+	}
+)
+
+func (x CryptoProperties_CryptoAssetType) Enum() *CryptoProperties_CryptoAssetType {
+	p := new(CryptoProperties_CryptoAssetType)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_CryptoAssetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_CryptoAssetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[30].Descriptor()
+}
+
+func (CryptoProperties_CryptoAssetType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[30]
+}
+
+func (x CryptoProperties_CryptoAssetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_CryptoAssetType.Descriptor instead.
+func (CryptoProperties_CryptoAssetType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoPrimitive int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_UNSPECIFIED   CryptoProperties_AlgorithmProperties_CryptoPrimitive = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_UNKNOWN       CryptoProperties_AlgorithmProperties_CryptoPrimitive = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_OTHER         CryptoProperties_AlgorithmProperties_CryptoPrimitive = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_DRBG          CryptoProperties_AlgorithmProperties_CryptoPrimitive = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_MAC           CryptoProperties_AlgorithmProperties_CryptoPrimitive = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_BLOCK_CIPHER  CryptoProperties_AlgorithmProperties_CryptoPrimitive = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_STREAM_CIPHER CryptoProperties_AlgorithmProperties_CryptoPrimitive = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_SIGNATURE     CryptoProperties_AlgorithmProperties_CryptoPrimitive = 7
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_HASH          CryptoProperties_AlgorithmProperties_CryptoPrimitive = 8
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_PKE           CryptoProperties_AlgorithmProperties_CryptoPrimitive = 9
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_XOF           CryptoProperties_AlgorithmProperties_CryptoPrimitive = 10
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KDF           CryptoProperties_AlgorithmProperties_CryptoPrimitive = 11
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KEY_AGREE     CryptoProperties_AlgorithmProperties_CryptoPrimitive = 12
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_KEM           CryptoProperties_AlgorithmProperties_CryptoPrimitive = 13
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_AE            CryptoProperties_AlgorithmProperties_CryptoPrimitive = 14
+	CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_COMBINER      CryptoProperties_AlgorithmProperties_CryptoPrimitive = 15
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoPrimitive.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoPrimitive_name = map[int32]string{
+		0:  "CRYPTO_PRIMITIVE_UNSPECIFIED",
+		1:  "CRYPTO_PRIMITIVE_UNKNOWN",
+		2:  "CRYPTO_PRIMITIVE_OTHER",
+		3:  "CRYPTO_PRIMITIVE_DRBG",
+		4:  "CRYPTO_PRIMITIVE_MAC",
+		5:  "CRYPTO_PRIMITIVE_BLOCK_CIPHER",
+		6:  "CRYPTO_PRIMITIVE_STREAM_CIPHER",
+		7:  "CRYPTO_PRIMITIVE_SIGNATURE",
+		8:  "CRYPTO_PRIMITIVE_HASH",
+		9:  "CRYPTO_PRIMITIVE_PKE",
+		10: "CRYPTO_PRIMITIVE_XOF",
+		11: "CRYPTO_PRIMITIVE_KDF",
+		12: "CRYPTO_PRIMITIVE_KEY_AGREE",
+		13: "CRYPTO_PRIMITIVE_KEM",
+		14: "CRYPTO_PRIMITIVE_AE",
+		15: "CRYPTO_PRIMITIVE_COMBINER",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoPrimitive_value = map[string]int32{
+		"CRYPTO_PRIMITIVE_UNSPECIFIED":   0,
+		"CRYPTO_PRIMITIVE_UNKNOWN":       1,
+		"CRYPTO_PRIMITIVE_OTHER":         2,
+		"CRYPTO_PRIMITIVE_DRBG":          3,
+		"CRYPTO_PRIMITIVE_MAC":           4,
+		"CRYPTO_PRIMITIVE_BLOCK_CIPHER":  5,
+		"CRYPTO_PRIMITIVE_STREAM_CIPHER": 6,
+		"CRYPTO_PRIMITIVE_SIGNATURE":     7,
+		"CRYPTO_PRIMITIVE_HASH":          8,
+		"CRYPTO_PRIMITIVE_PKE":           9,
+		"CRYPTO_PRIMITIVE_XOF":           10,
+		"CRYPTO_PRIMITIVE_KDF":           11,
+		"CRYPTO_PRIMITIVE_KEY_AGREE":     12,
+		"CRYPTO_PRIMITIVE_KEM":           13,
+		"CRYPTO_PRIMITIVE_AE":            14,
+		"CRYPTO_PRIMITIVE_COMBINER":      15,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoPrimitive) Enum() *CryptoProperties_AlgorithmProperties_CryptoPrimitive {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoPrimitive)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoPrimitive) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoPrimitive) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[31].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoPrimitive) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[31]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoPrimitive) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoPrimitive.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoPrimitive) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 0}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_UNSPECIFIED            CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_UNKNOWN                CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_OTHER                  CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_PLAIN_RAM     CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_ENCRYPTED_RAM CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_TEE           CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_HARDWARE               CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment = 6
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment_name = map[int32]string{
+		0: "CRYPTO_EXECUTION_ENVIRONMENT_UNSPECIFIED",
+		1: "CRYPTO_EXECUTION_ENVIRONMENT_UNKNOWN",
+		2: "CRYPTO_EXECUTION_ENVIRONMENT_OTHER",
+		3: "CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_PLAIN_RAM",
+		4: "CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_ENCRYPTED_RAM",
+		5: "CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_TEE",
+		6: "CRYPTO_EXECUTION_ENVIRONMENT_HARDWARE",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment_value = map[string]int32{
+		"CRYPTO_EXECUTION_ENVIRONMENT_UNSPECIFIED":            0,
+		"CRYPTO_EXECUTION_ENVIRONMENT_UNKNOWN":                1,
+		"CRYPTO_EXECUTION_ENVIRONMENT_OTHER":                  2,
+		"CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_PLAIN_RAM":     3,
+		"CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_ENCRYPTED_RAM": 4,
+		"CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_TEE":           5,
+		"CRYPTO_EXECUTION_ENVIRONMENT_HARDWARE":               6,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) Enum() *CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[32].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[32]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 1}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_UNSPECIFIED CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_UNKNOWN     CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_OTHER       CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_GENERIC     CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X86_32      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X86_64      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7A      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7M      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 7
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8A      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 8
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8M      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 9
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9A      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 10
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9M      CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 11
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_X390X       CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 12
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_PPC64       CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 13
+	CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_PPC64LE     CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform = 14
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform_name = map[int32]string{
+		0:  "CRYPTO_IMPLEMENTATION_PLATFORM_UNSPECIFIED",
+		1:  "CRYPTO_IMPLEMENTATION_PLATFORM_UNKNOWN",
+		2:  "CRYPTO_IMPLEMENTATION_PLATFORM_OTHER",
+		3:  "CRYPTO_IMPLEMENTATION_PLATFORM_GENERIC",
+		4:  "CRYPTO_IMPLEMENTATION_PLATFORM_X86_32",
+		5:  "CRYPTO_IMPLEMENTATION_PLATFORM_X86_64",
+		6:  "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7A",
+		7:  "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7M",
+		8:  "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8A",
+		9:  "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8M",
+		10: "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9A",
+		11: "CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9M",
+		12: "CRYPTO_IMPLEMENTATION_PLATFORM_X390X",
+		13: "CRYPTO_IMPLEMENTATION_PLATFORM_PPC64",
+		14: "CRYPTO_IMPLEMENTATION_PLATFORM_PPC64LE",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform_value = map[string]int32{
+		"CRYPTO_IMPLEMENTATION_PLATFORM_UNSPECIFIED": 0,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_UNKNOWN":     1,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_OTHER":       2,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_GENERIC":     3,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_X86_32":      4,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_X86_64":      5,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7A":      6,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7M":      7,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8A":      8,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8M":      9,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9A":      10,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9M":      11,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_X390X":       12,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_PPC64":       13,
+		"CRYPTO_IMPLEMENTATION_PLATFORM_PPC64LE":     14,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) Enum() *CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[33].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[33]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 2}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoCertificationLevel int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_UNSPECIFIED  CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_UNKNOWN      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_OTHER        CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_NONE         CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L1 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L2 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L3 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L4 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 7
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L1 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 8
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L2 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 9
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L3 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 10
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L4 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 11
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 12
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L2 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 13
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L3 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 14
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L4 CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 15
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL1      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 16
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL1_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 17
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL2      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 18
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL2_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 19
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL3      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 20
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL3_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 21
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL4      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 22
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL4_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 23
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL5      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 24
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL5_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 25
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL6      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 26
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL6_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 27
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL7      CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 28
+	CryptoProperties_AlgorithmProperties_CRYPTO_CERTIFICATION_LEVEL_CC_EAL7_PLUS CryptoProperties_AlgorithmProperties_CryptoCertificationLevel = 29
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoCertificationLevel.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoCertificationLevel_name = map[int32]string{
+		0:  "CRYPTO_CERTIFICATION_LEVEL_UNSPECIFIED",
+		1:  "CRYPTO_CERTIFICATION_LEVEL_UNKNOWN",
+		2:  "CRYPTO_CERTIFICATION_LEVEL_OTHER",
+		3:  "CRYPTO_CERTIFICATION_LEVEL_NONE",
+		4:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L1",
+		5:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L2",
+		6:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L3",
+		7:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L4",
+		8:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L1",
+		9:  "CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L2",
+		10: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L3",
+		11: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L4",
+		12: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1",
+		13: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L2",
+		14: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L3",
+		15: "CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L4",
+		16: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL1",
+		17: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL1_PLUS",
+		18: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL2",
+		19: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL2_PLUS",
+		20: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL3",
+		21: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL3_PLUS",
+		22: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL4",
+		23: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL4_PLUS",
+		24: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL5",
+		25: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL5_PLUS",
+		26: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL6",
+		27: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL6_PLUS",
+		28: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL7",
+		29: "CRYPTO_CERTIFICATION_LEVEL_CC_EAL7_PLUS",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoCertificationLevel_value = map[string]int32{
+		"CRYPTO_CERTIFICATION_LEVEL_UNSPECIFIED":  0,
+		"CRYPTO_CERTIFICATION_LEVEL_UNKNOWN":      1,
+		"CRYPTO_CERTIFICATION_LEVEL_OTHER":        2,
+		"CRYPTO_CERTIFICATION_LEVEL_NONE":         3,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L1": 4,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L2": 5,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L3": 6,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L4": 7,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L1": 8,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L2": 9,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L3": 10,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L4": 11,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1": 12,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L2": 13,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L3": 14,
+		"CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L4": 15,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL1":      16,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL1_PLUS": 17,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL2":      18,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL2_PLUS": 19,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL3":      20,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL3_PLUS": 21,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL4":      22,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL4_PLUS": 23,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL5":      24,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL5_PLUS": 25,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL6":      26,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL6_PLUS": 27,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL7":      28,
+		"CRYPTO_CERTIFICATION_LEVEL_CC_EAL7_PLUS": 29,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) Enum() *CryptoProperties_AlgorithmProperties_CryptoCertificationLevel {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoCertificationLevel)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[34].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[34]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoCertificationLevel.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoCertificationLevel) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 3}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_UNSPECIFIED CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_UNKNOWN     CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_OTHER       CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CBC         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_ECB         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CCM         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_GCM         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CFB         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 7
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_OFB         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 8
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_CTR         CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode = 9
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode_name = map[int32]string{
+		0: "CRYPTO_ALGORITHM_MODE_UNSPECIFIED",
+		1: "CRYPTO_ALGORITHM_MODE_UNKNOWN",
+		2: "CRYPTO_ALGORITHM_MODE_OTHER",
+		3: "CRYPTO_ALGORITHM_MODE_CBC",
+		4: "CRYPTO_ALGORITHM_MODE_ECB",
+		5: "CRYPTO_ALGORITHM_MODE_CCM",
+		6: "CRYPTO_ALGORITHM_MODE_GCM",
+		7: "CRYPTO_ALGORITHM_MODE_CFB",
+		8: "CRYPTO_ALGORITHM_MODE_OFB",
+		9: "CRYPTO_ALGORITHM_MODE_CTR",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode_value = map[string]int32{
+		"CRYPTO_ALGORITHM_MODE_UNSPECIFIED": 0,
+		"CRYPTO_ALGORITHM_MODE_UNKNOWN":     1,
+		"CRYPTO_ALGORITHM_MODE_OTHER":       2,
+		"CRYPTO_ALGORITHM_MODE_CBC":         3,
+		"CRYPTO_ALGORITHM_MODE_ECB":         4,
+		"CRYPTO_ALGORITHM_MODE_CCM":         5,
+		"CRYPTO_ALGORITHM_MODE_GCM":         6,
+		"CRYPTO_ALGORITHM_MODE_CFB":         7,
+		"CRYPTO_ALGORITHM_MODE_OFB":         8,
+		"CRYPTO_ALGORITHM_MODE_CTR":         9,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) Enum() *CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[35].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[35]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 4}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_UNSPECIFIED CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_UNKNOWN     CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_OTHER       CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS5       CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS7       CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_PKCS1V15    CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_OAEP        CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_RAW         CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding = 7
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding_name = map[int32]string{
+		0: "CRYPTO_ALGORITHM_PADDING_UNSPECIFIED",
+		1: "CRYPTO_ALGORITHM_PADDING_UNKNOWN",
+		2: "CRYPTO_ALGORITHM_PADDING_OTHER",
+		3: "CRYPTO_ALGORITHM_PADDING_PKCS5",
+		4: "CRYPTO_ALGORITHM_PADDING_PKCS7",
+		5: "CRYPTO_ALGORITHM_PADDING_PKCS1V15",
+		6: "CRYPTO_ALGORITHM_PADDING_OAEP",
+		7: "CRYPTO_ALGORITHM_PADDING_RAW",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding_value = map[string]int32{
+		"CRYPTO_ALGORITHM_PADDING_UNSPECIFIED": 0,
+		"CRYPTO_ALGORITHM_PADDING_UNKNOWN":     1,
+		"CRYPTO_ALGORITHM_PADDING_OTHER":       2,
+		"CRYPTO_ALGORITHM_PADDING_PKCS5":       3,
+		"CRYPTO_ALGORITHM_PADDING_PKCS7":       4,
+		"CRYPTO_ALGORITHM_PADDING_PKCS1V15":    5,
+		"CRYPTO_ALGORITHM_PADDING_OAEP":        6,
+		"CRYPTO_ALGORITHM_PADDING_RAW":         7,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) Enum() *CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[36].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[36]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 5}
+}
+
+type CryptoProperties_AlgorithmProperties_CryptoFunction int32
+
+const (
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_UNSPECIFIED CryptoProperties_AlgorithmProperties_CryptoFunction = 0
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_UNKNOWN     CryptoProperties_AlgorithmProperties_CryptoFunction = 1
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_OTHER       CryptoProperties_AlgorithmProperties_CryptoFunction = 2
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_GENERATE    CryptoProperties_AlgorithmProperties_CryptoFunction = 3
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_KEYGEN      CryptoProperties_AlgorithmProperties_CryptoFunction = 4
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_ENCRYPT     CryptoProperties_AlgorithmProperties_CryptoFunction = 5
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DECRYPT     CryptoProperties_AlgorithmProperties_CryptoFunction = 6
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DIGEST      CryptoProperties_AlgorithmProperties_CryptoFunction = 7
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_TAG         CryptoProperties_AlgorithmProperties_CryptoFunction = 8
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_KEYDERIVE   CryptoProperties_AlgorithmProperties_CryptoFunction = 9
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_SIGN        CryptoProperties_AlgorithmProperties_CryptoFunction = 10
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_VERIFY      CryptoProperties_AlgorithmProperties_CryptoFunction = 11
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_ENCAPSULATE CryptoProperties_AlgorithmProperties_CryptoFunction = 12
+	CryptoProperties_AlgorithmProperties_CRYPTO_FUNCTION_DECAPSULATE CryptoProperties_AlgorithmProperties_CryptoFunction = 13
+)
+
+// Enum value maps for CryptoProperties_AlgorithmProperties_CryptoFunction.
+var (
+	CryptoProperties_AlgorithmProperties_CryptoFunction_name = map[int32]string{
+		0:  "CRYPTO_FUNCTION_UNSPECIFIED",
+		1:  "CRYPTO_FUNCTION_UNKNOWN",
+		2:  "CRYPTO_FUNCTION_OTHER",
+		3:  "CRYPTO_FUNCTION_GENERATE",
+		4:  "CRYPTO_FUNCTION_KEYGEN",
+		5:  "CRYPTO_FUNCTION_ENCRYPT",
+		6:  "CRYPTO_FUNCTION_DECRYPT",
+		7:  "CRYPTO_FUNCTION_DIGEST",
+		8:  "CRYPTO_FUNCTION_TAG",
+		9:  "CRYPTO_FUNCTION_KEYDERIVE",
+		10: "CRYPTO_FUNCTION_SIGN",
+		11: "CRYPTO_FUNCTION_VERIFY",
+		12: "CRYPTO_FUNCTION_ENCAPSULATE",
+		13: "CRYPTO_FUNCTION_DECAPSULATE",
+	}
+	CryptoProperties_AlgorithmProperties_CryptoFunction_value = map[string]int32{
+		"CRYPTO_FUNCTION_UNSPECIFIED": 0,
+		"CRYPTO_FUNCTION_UNKNOWN":     1,
+		"CRYPTO_FUNCTION_OTHER":       2,
+		"CRYPTO_FUNCTION_GENERATE":    3,
+		"CRYPTO_FUNCTION_KEYGEN":      4,
+		"CRYPTO_FUNCTION_ENCRYPT":     5,
+		"CRYPTO_FUNCTION_DECRYPT":     6,
+		"CRYPTO_FUNCTION_DIGEST":      7,
+		"CRYPTO_FUNCTION_TAG":         8,
+		"CRYPTO_FUNCTION_KEYDERIVE":   9,
+		"CRYPTO_FUNCTION_SIGN":        10,
+		"CRYPTO_FUNCTION_VERIFY":      11,
+		"CRYPTO_FUNCTION_ENCAPSULATE": 12,
+		"CRYPTO_FUNCTION_DECAPSULATE": 13,
+	}
+)
+
+func (x CryptoProperties_AlgorithmProperties_CryptoFunction) Enum() *CryptoProperties_AlgorithmProperties_CryptoFunction {
+	p := new(CryptoProperties_AlgorithmProperties_CryptoFunction)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoFunction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoFunction) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[37].Descriptor()
+}
+
+func (CryptoProperties_AlgorithmProperties_CryptoFunction) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[37]
+}
+
+func (x CryptoProperties_AlgorithmProperties_CryptoFunction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties_CryptoFunction.Descriptor instead.
+func (CryptoProperties_AlgorithmProperties_CryptoFunction) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0, 6}
+}
+
+type CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType int32
+
+const (
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_UNSPECIFIED           CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 0
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_UNKNOWN               CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 1
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_OTHER                 CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 2
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PRIVATE_KEY           CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 3
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PUBLIC_KEY            CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 4
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SECRET_KEY            CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 5
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_KEY                   CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 6
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_CIPHERTEXT            CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 7
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SIGNATURE             CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 8
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_DIGEST                CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 9
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_INITIALIZATION_VECTOR CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 10
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_NONCE                 CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 11
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SEED                  CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 12
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SALT                  CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 13
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_SHARED_SECRET         CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 14
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_TAG                   CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 15
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_ADDITIONAL_DATA       CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 16
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_PASSWORD              CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 17
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_CREDENTIAL            CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 18
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_TOKEN                 CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType = 19
+)
+
+// Enum value maps for CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType.
+var (
+	CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType_name = map[int32]string{
+		0:  "RELATED_CRYPTO_MATERIAL_TYPE_UNSPECIFIED",
+		1:  "RELATED_CRYPTO_MATERIAL_TYPE_UNKNOWN",
+		2:  "RELATED_CRYPTO_MATERIAL_TYPE_OTHER",
+		3:  "RELATED_CRYPTO_MATERIAL_TYPE_PRIVATE_KEY",
+		4:  "RELATED_CRYPTO_MATERIAL_TYPE_PUBLIC_KEY",
+		5:  "RELATED_CRYPTO_MATERIAL_TYPE_SECRET_KEY",
+		6:  "RELATED_CRYPTO_MATERIAL_TYPE_KEY",
+		7:  "RELATED_CRYPTO_MATERIAL_TYPE_CIPHERTEXT",
+		8:  "RELATED_CRYPTO_MATERIAL_TYPE_SIGNATURE",
+		9:  "RELATED_CRYPTO_MATERIAL_TYPE_DIGEST",
+		10: "RELATED_CRYPTO_MATERIAL_TYPE_INITIALIZATION_VECTOR",
+		11: "RELATED_CRYPTO_MATERIAL_TYPE_NONCE",
+		12: "RELATED_CRYPTO_MATERIAL_TYPE_SEED",
+		13: "RELATED_CRYPTO_MATERIAL_TYPE_SALT",
+		14: "RELATED_CRYPTO_MATERIAL_TYPE_SHARED_SECRET",
+		15: "RELATED_CRYPTO_MATERIAL_TYPE_TAG",
+		16: "RELATED_CRYPTO_MATERIAL_TYPE_ADDITIONAL_DATA",
+		17: "RELATED_CRYPTO_MATERIAL_TYPE_PASSWORD",
+		18: "RELATED_CRYPTO_MATERIAL_TYPE_CREDENTIAL",
+		19: "RELATED_CRYPTO_MATERIAL_TYPE_TOKEN",
+	}
+	CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType_value = map[string]int32{
+		"RELATED_CRYPTO_MATERIAL_TYPE_UNSPECIFIED":           0,
+		"RELATED_CRYPTO_MATERIAL_TYPE_UNKNOWN":               1,
+		"RELATED_CRYPTO_MATERIAL_TYPE_OTHER":                 2,
+		"RELATED_CRYPTO_MATERIAL_TYPE_PRIVATE_KEY":           3,
+		"RELATED_CRYPTO_MATERIAL_TYPE_PUBLIC_KEY":            4,
+		"RELATED_CRYPTO_MATERIAL_TYPE_SECRET_KEY":            5,
+		"RELATED_CRYPTO_MATERIAL_TYPE_KEY":                   6,
+		"RELATED_CRYPTO_MATERIAL_TYPE_CIPHERTEXT":            7,
+		"RELATED_CRYPTO_MATERIAL_TYPE_SIGNATURE":             8,
+		"RELATED_CRYPTO_MATERIAL_TYPE_DIGEST":                9,
+		"RELATED_CRYPTO_MATERIAL_TYPE_INITIALIZATION_VECTOR": 10,
+		"RELATED_CRYPTO_MATERIAL_TYPE_NONCE":                 11,
+		"RELATED_CRYPTO_MATERIAL_TYPE_SEED":                  12,
+		"RELATED_CRYPTO_MATERIAL_TYPE_SALT":                  13,
+		"RELATED_CRYPTO_MATERIAL_TYPE_SHARED_SECRET":         14,
+		"RELATED_CRYPTO_MATERIAL_TYPE_TAG":                   15,
+		"RELATED_CRYPTO_MATERIAL_TYPE_ADDITIONAL_DATA":       16,
+		"RELATED_CRYPTO_MATERIAL_TYPE_PASSWORD":              17,
+		"RELATED_CRYPTO_MATERIAL_TYPE_CREDENTIAL":            18,
+		"RELATED_CRYPTO_MATERIAL_TYPE_TOKEN":                 19,
+	}
+)
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) Enum() *CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType {
+	p := new(CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[38].Descriptor()
+}
+
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[38]
+}
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType.Descriptor instead.
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 2, 0}
+}
+
+type CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState int32
+
+const (
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_UNSPECIFIED    CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 0
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_PRE_ACTIVATION CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 1
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_ACTIVE         CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 2
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_SUSPENDED      CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 3
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_DEACTIVATED    CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 4
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_COMPROMISED    CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 5
+	CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_DESTROYED      CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState = 6
+)
+
+// Enum value maps for CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState.
+var (
+	CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState_name = map[int32]string{
+		0: "RELATED_CRYPTO_MATERIAL_STATE_UNSPECIFIED",
+		1: "RELATED_CRYPTO_MATERIAL_STATE_PRE_ACTIVATION",
+		2: "RELATED_CRYPTO_MATERIAL_STATE_ACTIVE",
+		3: "RELATED_CRYPTO_MATERIAL_STATE_SUSPENDED",
+		4: "RELATED_CRYPTO_MATERIAL_STATE_DEACTIVATED",
+		5: "RELATED_CRYPTO_MATERIAL_STATE_COMPROMISED",
+		6: "RELATED_CRYPTO_MATERIAL_STATE_DESTROYED",
+	}
+	CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState_value = map[string]int32{
+		"RELATED_CRYPTO_MATERIAL_STATE_UNSPECIFIED":    0,
+		"RELATED_CRYPTO_MATERIAL_STATE_PRE_ACTIVATION": 1,
+		"RELATED_CRYPTO_MATERIAL_STATE_ACTIVE":         2,
+		"RELATED_CRYPTO_MATERIAL_STATE_SUSPENDED":      3,
+		"RELATED_CRYPTO_MATERIAL_STATE_DEACTIVATED":    4,
+		"RELATED_CRYPTO_MATERIAL_STATE_COMPROMISED":    5,
+		"RELATED_CRYPTO_MATERIAL_STATE_DESTROYED":      6,
+	}
+)
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) Enum() *CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState {
+	p := new(CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[39].Descriptor()
+}
+
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[39]
+}
+
+func (x CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState.Descriptor instead.
+func (CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 2, 1}
+}
+
+type CryptoProperties_ProtocolProperties_CryptoProtocolType int32
+
+const (
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_UNSPECIFIED CryptoProperties_ProtocolProperties_CryptoProtocolType = 0
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_UNKNOWN     CryptoProperties_ProtocolProperties_CryptoProtocolType = 1
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_OTHER       CryptoProperties_ProtocolProperties_CryptoProtocolType = 2
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_TLS         CryptoProperties_ProtocolProperties_CryptoProtocolType = 3
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_SSH         CryptoProperties_ProtocolProperties_CryptoProtocolType = 4
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_IPSEC       CryptoProperties_ProtocolProperties_CryptoProtocolType = 5
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_IKE         CryptoProperties_ProtocolProperties_CryptoProtocolType = 6
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_SSTP        CryptoProperties_ProtocolProperties_CryptoProtocolType = 7
+	CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_WPA         CryptoProperties_ProtocolProperties_CryptoProtocolType = 8
+)
+
+// Enum value maps for CryptoProperties_ProtocolProperties_CryptoProtocolType.
+var (
+	CryptoProperties_ProtocolProperties_CryptoProtocolType_name = map[int32]string{
+		0: "CRYPTO_PROTOCOL_TYPE_UNSPECIFIED",
+		1: "CRYPTO_PROTOCOL_TYPE_UNKNOWN",
+		2: "CRYPTO_PROTOCOL_TYPE_OTHER",
+		3: "CRYPTO_PROTOCOL_TYPE_TLS",
+		4: "CRYPTO_PROTOCOL_TYPE_SSH",
+		5: "CRYPTO_PROTOCOL_TYPE_IPSEC",
+		6: "CRYPTO_PROTOCOL_TYPE_IKE",
+		7: "CRYPTO_PROTOCOL_TYPE_SSTP",
+		8: "CRYPTO_PROTOCOL_TYPE_WPA",
+	}
+	CryptoProperties_ProtocolProperties_CryptoProtocolType_value = map[string]int32{
+		"CRYPTO_PROTOCOL_TYPE_UNSPECIFIED": 0,
+		"CRYPTO_PROTOCOL_TYPE_UNKNOWN":     1,
+		"CRYPTO_PROTOCOL_TYPE_OTHER":       2,
+		"CRYPTO_PROTOCOL_TYPE_TLS":         3,
+		"CRYPTO_PROTOCOL_TYPE_SSH":         4,
+		"CRYPTO_PROTOCOL_TYPE_IPSEC":       5,
+		"CRYPTO_PROTOCOL_TYPE_IKE":         6,
+		"CRYPTO_PROTOCOL_TYPE_SSTP":        7,
+		"CRYPTO_PROTOCOL_TYPE_WPA":         8,
+	}
+)
+
+func (x CryptoProperties_ProtocolProperties_CryptoProtocolType) Enum() *CryptoProperties_ProtocolProperties_CryptoProtocolType {
+	p := new(CryptoProperties_ProtocolProperties_CryptoProtocolType)
+	*p = x
+	return p
+}
+
+func (x CryptoProperties_ProtocolProperties_CryptoProtocolType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoProperties_ProtocolProperties_CryptoProtocolType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bom_1_6_proto_enumTypes[40].Descriptor()
+}
+
+func (CryptoProperties_ProtocolProperties_CryptoProtocolType) Type() protoreflect.EnumType {
+	return &file_bom_1_6_proto_enumTypes[40]
+}
+
+func (x CryptoProperties_ProtocolProperties_CryptoProtocolType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoProperties_ProtocolProperties_CryptoProtocolType.Descriptor instead.
+func (CryptoProperties_ProtocolProperties_CryptoProtocolType) EnumDescriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 3, 0}
+}
+
+// Specifies attributes of the text.
+type AttachedText struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the content type of the text. Defaults to 'text/plain' if not specified.
+	ContentType *string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3,oneof" json:"content_type,omitempty"`
+	// Specifies the optional encoding the text is represented in.
+	Encoding *string `protobuf:"bytes,2,opt,name=encoding,proto3,oneof" json:"encoding,omitempty"`
+	// The attached text content.
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachedText) Reset() {
+	*x = AttachedText{}
+	mi := &file_bom_1_6_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachedText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachedText) ProtoMessage() {}
+
+func (x *AttachedText) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachedText.ProtoReflect.Descriptor instead.
+func (*AttachedText) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AttachedText) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachedText) GetEncoding() string {
+	if x != nil && x.Encoding != nil {
+		return *x.Encoding
+	}
+	return ""
+}
+
+func (x *AttachedText) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Bom struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The version of the CycloneDX specification a BOM is written to (starting at version 1.3).
+	SpecVersion string `protobuf:"bytes,1,opt,name=spec_version,json=specVersion,proto3" json:"spec_version,omitempty"`
+	// The version allows component publishers/authors to make changes to existing BOMs to update various aspects of the document such as description or licenses.
+	Version *int32 `protobuf:"varint,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// Every BOM generated should have a unique serial number, even if the contents of the BOM being generated have not changed over time.
+	SerialNumber *string `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	// Provides additional information about a BOM.
+	Metadata *Metadata `protobuf:"bytes,4,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	// Provides the ability to document a list of components.
+	Components []*Component `protobuf:"bytes,5,rep,name=components,proto3" json:"components,omitempty"`
+	// Provides the ability to document a list of external services.
+	Services []*Service `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty"`
+	// Provides the ability to document external references related to the BOM or to the project the BOM describes.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,7,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
+	// Provides the ability to document dependency relationships.
+	Dependencies []*Dependency `protobuf:"bytes,8,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// Compositions describe constituent parts (including components, services, and dependency relationships) and their completeness.
+	Compositions []*Composition `protobuf:"bytes,9,rep,name=compositions,proto3" json:"compositions,omitempty"`
+	// Vulnerabilities identified in components or services.
+	Vulnerabilities []*Vulnerability `protobuf:"bytes,10,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`
+	// Comments made by people, organizations, or tools about any object with a bom-ref.
+	Annotations []*Annotation `protobuf:"bytes,11,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,12,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Describes how a component or service was manufactured or deployed.
+	Formulation []*Formula `protobuf:"bytes,13,rep,name=formulation,proto3" json:"formulation,omitempty"`
+	// The list of declarations which describe the conformance to standards.
+	Declarations *Declarations `protobuf:"bytes,14,opt,name=declarations,proto3,oneof" json:"declarations,omitempty"`
+	// A collection of reusable objects that are defined and may be used elsewhere in the BOM.
+	Definitions   *Definition `protobuf:"bytes,15,opt,name=definitions,proto3,oneof" json:"definitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bom) Reset() {
+	*x = Bom{}
+	mi := &file_bom_1_6_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bom) ProtoMessage() {}
+
+func (x *Bom) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bom.ProtoReflect.Descriptor instead.
+func (*Bom) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Bom) GetSpecVersion() string {
+	if x != nil {
+		return x.SpecVersion
+	}
+	return ""
+}
+
+func (x *Bom) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *Bom) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Bom) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Bom) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Bom) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Bom) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Bom) GetDependencies() []*Dependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Bom) GetCompositions() []*Composition {
+	if x != nil {
+		return x.Compositions
+	}
+	return nil
+}
+
+func (x *Bom) GetVulnerabilities() []*Vulnerability {
+	if x != nil {
+		return x.Vulnerabilities
+	}
+	return nil
+}
+
+func (x *Bom) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Bom) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Bom) GetFormulation() []*Formula {
+	if x != nil {
+		return x.Formulation
+	}
+	return nil
+}
+
+func (x *Bom) GetDeclarations() *Declarations {
+	if x != nil {
+		return x.Declarations
+	}
+	return nil
+}
+
+func (x *Bom) GetDefinitions() *Definition {
+	if x != nil {
+		return x.Definitions
+	}
+	return nil
+}
+
+// Specifies an individual commit.
+type Commit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A unique identifier of the commit.
+	Uid *string `protobuf:"bytes,1,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
+	// The URL to the commit.
+	Url *string `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	// The author who created the changes in the commit.
+	Author *IdentifiableAction `protobuf:"bytes,3,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	// The person who committed or pushed the commit.
+	Committer *IdentifiableAction `protobuf:"bytes,4,opt,name=committer,proto3,oneof" json:"committer,omitempty"`
+	// The text description of the contents of the commit.
+	Message       *string `protobuf:"bytes,5,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Commit) Reset() {
+	*x = Commit{}
+	mi := &file_bom_1_6_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Commit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Commit) ProtoMessage() {}
+
+func (x *Commit) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Commit.ProtoReflect.Descriptor instead.
+func (*Commit) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Commit) GetUid() string {
+	if x != nil && x.Uid != nil {
+		return *x.Uid
+	}
+	return ""
+}
+
+func (x *Commit) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *Commit) GetAuthor() *IdentifiableAction {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Commit) GetCommitter() *IdentifiableAction {
+	if x != nil {
+		return x.Committer
+	}
+	return nil
+}
+
+func (x *Commit) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type Component struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the type of component.
+	Type Classification `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.Classification" json:"type,omitempty"`
+	// The optional mime-type of the component.
+	MimeType *string `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3,oneof" json:"mime_type,omitempty"`
+	// An optional identifier which can be used to reference the component elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,3,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The organization that supplied the component.
+	Supplier *OrganizationalEntity `protobuf:"bytes,4,opt,name=supplier,proto3,oneof" json:"supplier,omitempty"`
+	// Deprecated: use authors or manufacturer instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Author *string `protobuf:"bytes,5,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	// The person(s) or organization(s) that published the component.
+	Publisher *string `protobuf:"bytes,6,opt,name=publisher,proto3,oneof" json:"publisher,omitempty"`
+	// The grouping name or identifier.
+	Group *string `protobuf:"bytes,7,opt,name=group,proto3,oneof" json:"group,omitempty"`
+	// The name of the component.
+	Name string `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	// The component version.
+	Version *string `protobuf:"bytes,9,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// Specifies a description for the component.
+	Description *string `protobuf:"bytes,10,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Specifies the scope of the component.
+	Scope *Scope `protobuf:"varint,11,opt,name=scope,proto3,enum=cyclonedx.v1_6.Scope,oneof" json:"scope,omitempty"`
+	// The hashes of the component.
+	Hashes []*Hash `protobuf:"bytes,12,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// Component license(s).
+	Licenses []*LicenseChoice `protobuf:"bytes,13,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	// A copyright notice informing users of the underlying claims to copyright ownership in a published work.
+	Copyright *string `protobuf:"bytes,14,opt,name=copyright,proto3,oneof" json:"copyright,omitempty"`
+	// Specifies a well-formed CPE name that conforms to the CPE 2.2 or 2.3 specification.
+	Cpe *string `protobuf:"bytes,15,opt,name=cpe,proto3,oneof" json:"cpe,omitempty"`
+	// Specifies the package-url (purl).
+	Purl *string `protobuf:"bytes,16,opt,name=purl,proto3,oneof" json:"purl,omitempty"`
+	// Specifies metadata and content for ISO-IEC 19770-2 Software Identification (SWID) Tags.
+	Swid *Swid `protobuf:"bytes,17,opt,name=swid,proto3,oneof" json:"swid,omitempty"`
+	// Deprecated: use the pedigree element instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Modified *bool `protobuf:"varint,18,opt,name=modified,proto3,oneof" json:"modified,omitempty"`
+	// Component pedigree is a way to document complex supply chain scenarios.
+	Pedigree *Pedigree `protobuf:"bytes,19,opt,name=pedigree,proto3,oneof" json:"pedigree,omitempty"`
+	// Provides the ability to document external references related to the component.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,20,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
+	// Specifies optional sub-components.
+	Components []*Component `protobuf:"bytes,21,rep,name=components,proto3" json:"components,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,22,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Specifies optional evidence of the component's identity, licenses and occurrences.
+	Evidence *Evidence `protobuf:"bytes,23,opt,name=evidence,proto3,oneof" json:"evidence,omitempty"`
+	// Specifies optional release notes.
+	ReleaseNotes *ReleaseNotes `protobuf:"bytes,24,opt,name=releaseNotes,proto3,oneof" json:"releaseNotes,omitempty"`
+	// A model card describes the intended uses of a machine learning model and other data useful for ML transparency.
+	ModelCard *ModelCard `protobuf:"bytes,25,opt,name=modelCard,proto3,oneof" json:"modelCard,omitempty"`
+	// Specifies the data of a component of type data.
+	Data []*ComponentData `protobuf:"bytes,26,rep,name=data,proto3" json:"data,omitempty"`
+	// Cryptographic assets have properties that uniquely define them and that make them actionable for further reasoning.
+	CryptoProperties *CryptoProperties `protobuf:"bytes,27,opt,name=cryptoProperties,proto3,oneof" json:"cryptoProperties,omitempty"`
+	// The organization that created the component.
+	Manufacturer *OrganizationalEntity `protobuf:"bytes,28,opt,name=manufacturer,proto3,oneof" json:"manufacturer,omitempty"`
+	// The person(s) who created the component.
+	Authors []*OrganizationalContact `protobuf:"bytes,29,rep,name=authors,proto3" json:"authors,omitempty"`
+	// Textual strings that aid in discovery, search, and retrieval of the associated object.
+	Tags []string `protobuf:"bytes,30,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Specifies the OmniBOR Artifact ID.
+	OmniborId []string `protobuf:"bytes,31,rep,name=omniborId,proto3" json:"omniborId,omitempty"`
+	// Specifies the Software Heritage persistent identifier (SWHID).
+	Swhid         []string `protobuf:"bytes,32,rep,name=swhid,proto3" json:"swhid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	mi := &file_bom_1_6_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Component) GetType() Classification {
+	if x != nil {
+		return x.Type
+	}
+	return Classification_CLASSIFICATION_NULL
+}
+
+func (x *Component) GetMimeType() string {
+	if x != nil && x.MimeType != nil {
+		return *x.MimeType
+	}
+	return ""
+}
+
+func (x *Component) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Component) GetSupplier() *OrganizationalEntity {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Component) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *Component) GetPublisher() string {
+	if x != nil && x.Publisher != nil {
+		return *x.Publisher
+	}
+	return ""
+}
+
+func (x *Component) GetGroup() string {
+	if x != nil && x.Group != nil {
+		return *x.Group
+	}
+	return ""
+}
+
+func (x *Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Component) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *Component) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Component) GetScope() Scope {
+	if x != nil && x.Scope != nil {
+		return *x.Scope
+	}
+	return Scope_SCOPE_UNSPECIFIED
+}
+
+func (x *Component) GetHashes() []*Hash {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *Component) GetLicenses() []*LicenseChoice {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Component) GetCopyright() string {
+	if x != nil && x.Copyright != nil {
+		return *x.Copyright
+	}
+	return ""
+}
+
+func (x *Component) GetCpe() string {
+	if x != nil && x.Cpe != nil {
+		return *x.Cpe
+	}
+	return ""
+}
+
+func (x *Component) GetPurl() string {
+	if x != nil && x.Purl != nil {
+		return *x.Purl
+	}
+	return ""
+}
+
+func (x *Component) GetSwid() *Swid {
+	if x != nil {
+		return x.Swid
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Component) GetModified() bool {
+	if x != nil && x.Modified != nil {
+		return *x.Modified
+	}
+	return false
+}
+
+func (x *Component) GetPedigree() *Pedigree {
+	if x != nil {
+		return x.Pedigree
+	}
+	return nil
+}
+
+func (x *Component) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Component) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Component) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Component) GetEvidence() *Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Component) GetReleaseNotes() *ReleaseNotes {
+	if x != nil {
+		return x.ReleaseNotes
+	}
+	return nil
+}
+
+func (x *Component) GetModelCard() *ModelCard {
+	if x != nil {
+		return x.ModelCard
+	}
+	return nil
+}
+
+func (x *Component) GetData() []*ComponentData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Component) GetCryptoProperties() *CryptoProperties {
+	if x != nil {
+		return x.CryptoProperties
+	}
+	return nil
+}
+
+func (x *Component) GetManufacturer() *OrganizationalEntity {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return nil
+}
+
+func (x *Component) GetAuthors() []*OrganizationalContact {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Component) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Component) GetOmniborId() []string {
+	if x != nil {
+		return x.OmniborId
+	}
+	return nil
+}
+
+func (x *Component) GetSwhid() []string {
+	if x != nil {
+		return x.Swhid
+	}
+	return nil
+}
+
+type DataClassification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the flow direction of the data.
+	Flow DataFlow `protobuf:"varint,1,opt,name=flow,proto3,enum=cyclonedx.v1_6.DataFlow" json:"flow,omitempty"`
+	// Data classification tags data according to its type, sensitivity, and value if altered, stolen, or destroyed.
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataClassification) Reset() {
+	*x = DataClassification{}
+	mi := &file_bom_1_6_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataClassification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataClassification) ProtoMessage() {}
+
+func (x *DataClassification) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataClassification.ProtoReflect.Descriptor instead.
+func (*DataClassification) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DataClassification) GetFlow() DataFlow {
+	if x != nil {
+		return x.Flow
+	}
+	return DataFlow_DATA_FLOW_NULL
+}
+
+func (x *DataClassification) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Dependency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// References a component or service by its bom-ref attribute.
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The bom-ref identifiers of the components or services that are dependencies of this dependency object.
+	Dependencies []*Dependency `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// The bom-ref identifiers of the components or services that define a given specification or standard.
+	Provides      []string `protobuf:"bytes,3,rep,name=provides,proto3" json:"provides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_bom_1_6_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Dependency) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *Dependency) GetDependencies() []*Dependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Dependency) GetProvides() []string {
+	if x != nil {
+		return x.Provides
+	}
+	return nil
+}
+
+type Diff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the optional text of the diff.
+	Text *AttachedText `protobuf:"bytes,1,opt,name=text,proto3,oneof" json:"text,omitempty"`
+	// Specifies the URL to the diff.
+	Url           *string `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Diff) Reset() {
+	*x = Diff{}
+	mi := &file_bom_1_6_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diff) ProtoMessage() {}
+
+func (x *Diff) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diff.ProtoReflect.Descriptor instead.
+func (*Diff) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Diff) GetText() *AttachedText {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *Diff) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+type ExternalReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the type of external reference.
+	Type ExternalReferenceType `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.ExternalReferenceType" json:"type,omitempty"`
+	// The URL to the external reference.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// An optional comment describing the external reference.
+	Comment *string `protobuf:"bytes,3,opt,name=comment,proto3,oneof" json:"comment,omitempty"`
+	// Optional integrity hashes for the external resource content.
+	Hashes        []*Hash `protobuf:"bytes,4,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalReference) Reset() {
+	*x = ExternalReference{}
+	mi := &file_bom_1_6_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalReference) ProtoMessage() {}
+
+func (x *ExternalReference) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalReference.ProtoReflect.Descriptor instead.
+func (*ExternalReference) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExternalReference) GetType() ExternalReferenceType {
+	if x != nil {
+		return x.Type
+	}
+	return ExternalReferenceType_EXTERNAL_REFERENCE_TYPE_OTHER
+}
+
+func (x *ExternalReference) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetComment() string {
+	if x != nil && x.Comment != nil {
+		return *x.Comment
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetHashes() []*Hash {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// Specifies the file hash of the component.
+type Hash struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the algorithm used to create the hash.
+	Alg HashAlg `protobuf:"varint,1,opt,name=alg,proto3,enum=cyclonedx.v1_6.HashAlg" json:"alg,omitempty"`
+	// Specifies the file hash of the component.
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hash) Reset() {
+	*x = Hash{}
+	mi := &file_bom_1_6_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hash) ProtoMessage() {}
+
+func (x *Hash) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hash.ProtoReflect.Descriptor instead.
+func (*Hash) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Hash) GetAlg() HashAlg {
+	if x != nil {
+		return x.Alg
+	}
+	return HashAlg_HASH_ALG_NULL
+}
+
+func (x *Hash) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type IdentifiableAction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The timestamp in which the action occurred.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	// The name of the individual who performed the action.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The email address of the individual who performed the action.
+	Email         *string `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentifiableAction) Reset() {
+	*x = IdentifiableAction{}
+	mi := &file_bom_1_6_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentifiableAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifiableAction) ProtoMessage() {}
+
+func (x *IdentifiableAction) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifiableAction.ProtoReflect.Descriptor instead.
+func (*IdentifiableAction) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IdentifiableAction) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *IdentifiableAction) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *IdentifiableAction) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+type Issue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the type of issue.
+	Type IssueClassification `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.IssueClassification" json:"type,omitempty"`
+	// The identifier of the issue assigned by the source of the issue.
+	Id *string `protobuf:"bytes,2,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	// The name of the issue.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A description of the issue.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The source of the issue where it is documented.
+	Source *Source `protobuf:"bytes,5,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// A collection of URL's for reference.
+	References    []string `protobuf:"bytes,6,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Issue) Reset() {
+	*x = Issue{}
+	mi := &file_bom_1_6_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Issue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Issue) ProtoMessage() {}
+
+func (x *Issue) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Issue.ProtoReflect.Descriptor instead.
+func (*Issue) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Issue) GetType() IssueClassification {
+	if x != nil {
+		return x.Type
+	}
+	return IssueClassification_ISSUE_CLASSIFICATION_NULL
+}
+
+func (x *Issue) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Issue) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Issue) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Issue) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Issue) GetReferences() []string {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+// The source of the issue or vulnerability where it is documented.
+type Source struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the source.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The url of the issue documentation as provided by the source.
+	Url           *string `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	mi := &file_bom_1_6_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Source) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Source) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+type LicenseChoice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*LicenseChoice_License
+	//	*LicenseChoice_Expression
+	Choice isLicenseChoice_Choice `protobuf_oneof:"choice"`
+	// Declared licenses and concluded licenses represent two different stages in the licensing process within software development.
+	Acknowledgement *LicenseAcknowledgementEnumeration `protobuf:"varint,3,opt,name=acknowledgement,proto3,enum=cyclonedx.v1_6.LicenseAcknowledgementEnumeration,oneof" json:"acknowledgement,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LicenseChoice) Reset() {
+	*x = LicenseChoice{}
+	mi := &file_bom_1_6_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseChoice) ProtoMessage() {}
+
+func (x *LicenseChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseChoice.ProtoReflect.Descriptor instead.
+func (*LicenseChoice) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LicenseChoice) GetChoice() isLicenseChoice_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *LicenseChoice) GetLicense() *License {
+	if x != nil {
+		if x, ok := x.Choice.(*LicenseChoice_License); ok {
+			return x.License
+		}
+	}
+	return nil
+}
+
+func (x *LicenseChoice) GetExpression() string {
+	if x != nil {
+		if x, ok := x.Choice.(*LicenseChoice_Expression); ok {
+			return x.Expression
+		}
+	}
+	return ""
+}
+
+func (x *LicenseChoice) GetAcknowledgement() LicenseAcknowledgementEnumeration {
+	if x != nil && x.Acknowledgement != nil {
+		return *x.Acknowledgement
+	}
+	return LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED
+}
+
+type isLicenseChoice_Choice interface {
+	isLicenseChoice_Choice()
+}
+
+type LicenseChoice_License struct {
+	// A license.
+	License *License `protobuf:"bytes,1,opt,name=license,proto3,oneof"`
+}
+
+type LicenseChoice_Expression struct {
+	// A valid SPDX license expression.
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3,oneof"`
+}
+
+func (*LicenseChoice_License) isLicenseChoice_Choice() {}
+
+func (*LicenseChoice_Expression) isLicenseChoice_Choice() {}
+
+type License struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to License:
+	//
+	//	*License_Id
+	//	*License_Name
+	License isLicense_License `protobuf_oneof:"license"`
+	// Specifies the optional full text of the attachment.
+	Text *AttachedText `protobuf:"bytes,3,opt,name=text,proto3,oneof" json:"text,omitempty"`
+	// The URL to the attachment file.
+	Url *string `protobuf:"bytes,4,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	// An optional identifier which can be used to reference the license elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,5,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// Licensing details describing the licensor/licensee, license type, renewal and expiration dates.
+	Licensing *Licensing `protobuf:"bytes,6,opt,name=licensing,proto3,oneof" json:"licensing,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Declared licenses and concluded licenses represent two different stages in the licensing process within software development.
+	Acknowledgement *LicenseAcknowledgementEnumeration `protobuf:"varint,8,opt,name=acknowledgement,proto3,enum=cyclonedx.v1_6.LicenseAcknowledgementEnumeration,oneof" json:"acknowledgement,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *License) Reset() {
+	*x = License{}
+	mi := &file_bom_1_6_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *License) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*License) ProtoMessage() {}
+
+func (x *License) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use License.ProtoReflect.Descriptor instead.
+func (*License) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *License) GetLicense() isLicense_License {
+	if x != nil {
+		return x.License
+	}
+	return nil
+}
+
+func (x *License) GetId() string {
+	if x != nil {
+		if x, ok := x.License.(*License_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+func (x *License) GetName() string {
+	if x != nil {
+		if x, ok := x.License.(*License_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+func (x *License) GetText() *AttachedText {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *License) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *License) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *License) GetLicensing() *Licensing {
+	if x != nil {
+		return x.Licensing
+	}
+	return nil
+}
+
+func (x *License) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *License) GetAcknowledgement() LicenseAcknowledgementEnumeration {
+	if x != nil && x.Acknowledgement != nil {
+		return *x.Acknowledgement
+	}
+	return LicenseAcknowledgementEnumeration_LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED
+}
+
+type isLicense_License interface {
+	isLicense_License()
+}
+
+type License_Id struct {
+	// A valid SPDX license ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type License_Name struct {
+	// If SPDX does not define the license used, this field may be used to provide the license name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*License_Id) isLicense_License() {}
+
+func (*License_Name) isLicense_License() {}
+
+type Licensing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// License identifiers that may be used to manage licenses and their lifecycle.
+	AltIds []string `protobuf:"bytes,1,rep,name=altIds,proto3" json:"altIds,omitempty"`
+	// The individual or organization that grants a license to another individual or organization.
+	Licensor *Licensing_OrganizationalEntityOrContact `protobuf:"bytes,2,opt,name=licensor,proto3,oneof" json:"licensor,omitempty"`
+	// The individual or organization for which a license was granted to.
+	Licensee *Licensing_OrganizationalEntityOrContact `protobuf:"bytes,3,opt,name=licensee,proto3,oneof" json:"licensee,omitempty"`
+	// The individual or organization that purchased the license.
+	Purchaser *Licensing_OrganizationalEntityOrContact `protobuf:"bytes,4,opt,name=purchaser,proto3,oneof" json:"purchaser,omitempty"`
+	// The purchase order identifier the purchaser sent to a supplier or vendor to authorize a purchase.
+	PurchaseOrder *string `protobuf:"bytes,5,opt,name=purchaseOrder,proto3,oneof" json:"purchaseOrder,omitempty"`
+	// The type of license(s) that was granted to the licensee.
+	LicenseTypes []LicensingTypeEnum `protobuf:"varint,6,rep,packed,name=licenseTypes,proto3,enum=cyclonedx.v1_6.LicensingTypeEnum" json:"licenseTypes,omitempty"`
+	// The timestamp indicating when the license was last renewed.
+	LastRenewal *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lastRenewal,proto3,oneof" json:"lastRenewal,omitempty"`
+	// The timestamp indicating when the current license expires.
+	Expiration    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expiration,proto3,oneof" json:"expiration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Licensing) Reset() {
+	*x = Licensing{}
+	mi := &file_bom_1_6_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Licensing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Licensing) ProtoMessage() {}
+
+func (x *Licensing) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Licensing.ProtoReflect.Descriptor instead.
+func (*Licensing) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Licensing) GetAltIds() []string {
+	if x != nil {
+		return x.AltIds
+	}
+	return nil
+}
+
+func (x *Licensing) GetLicensor() *Licensing_OrganizationalEntityOrContact {
+	if x != nil {
+		return x.Licensor
+	}
+	return nil
+}
+
+func (x *Licensing) GetLicensee() *Licensing_OrganizationalEntityOrContact {
+	if x != nil {
+		return x.Licensee
+	}
+	return nil
+}
+
+func (x *Licensing) GetPurchaser() *Licensing_OrganizationalEntityOrContact {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *Licensing) GetPurchaseOrder() string {
+	if x != nil && x.PurchaseOrder != nil {
+		return *x.PurchaseOrder
+	}
+	return ""
+}
+
+func (x *Licensing) GetLicenseTypes() []LicensingTypeEnum {
+	if x != nil {
+		return x.LicenseTypes
+	}
+	return nil
+}
+
+func (x *Licensing) GetLastRenewal() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRenewal
+	}
+	return nil
+}
+
+func (x *Licensing) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+type Metadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The date and time (timestamp) when the BOM was created.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	// The tool(s) used in the creation, enrichment, and validation of the BOM.
+	Tools []*Tool `protobuf:"bytes,2,rep,name=tools,proto3" json:"tools,omitempty"`
+	// The person(s) who created the BOM.
+	Authors []*OrganizationalContact `protobuf:"bytes,3,rep,name=authors,proto3" json:"authors,omitempty"`
+	// The component that the BOM describes.
+	Component *Component `protobuf:"bytes,4,opt,name=component,proto3,oneof" json:"component,omitempty"`
+	// Deprecated: use the component manufacturer instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Manufacture *OrganizationalEntity `protobuf:"bytes,5,opt,name=manufacture,proto3,oneof" json:"manufacture,omitempty"`
+	// The organization that supplied the component that the BOM describes.
+	Supplier *OrganizationalEntity `protobuf:"bytes,6,opt,name=supplier,proto3,oneof" json:"supplier,omitempty"`
+	// The license information for the BOM document.
+	Licenses []*LicenseChoice `protobuf:"bytes,7,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,8,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Lifecycles communicate the stage(s) in which data in the BOM was captured.
+	Lifecycles []*Lifecycles `protobuf:"bytes,9,rep,name=lifecycles,proto3" json:"lifecycles,omitempty"`
+	// The organization that created the BOM.
+	Manufacturer  *OrganizationalEntity `protobuf:"bytes,10,opt,name=manufacturer,proto3,oneof" json:"manufacturer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_bom_1_6_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Metadata) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Metadata) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *Metadata) GetAuthors() []*OrganizationalContact {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Metadata) GetComponent() *Component {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Metadata) GetManufacture() *OrganizationalEntity {
+	if x != nil {
+		return x.Manufacture
+	}
+	return nil
+}
+
+func (x *Metadata) GetSupplier() *OrganizationalEntity {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+func (x *Metadata) GetLicenses() []*LicenseChoice {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Metadata) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Metadata) GetLifecycles() []*Lifecycles {
+	if x != nil {
+		return x.Lifecycles
+	}
+	return nil
+}
+
+func (x *Metadata) GetManufacturer() *OrganizationalEntity {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return nil
+}
+
+type Lifecycles struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Lifecycles_Phase
+	//	*Lifecycles_Name
+	Choice isLifecycles_Choice `protobuf_oneof:"choice"`
+	// The description of the lifecycle phase.
+	Description   *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lifecycles) Reset() {
+	*x = Lifecycles{}
+	mi := &file_bom_1_6_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lifecycles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lifecycles) ProtoMessage() {}
+
+func (x *Lifecycles) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lifecycles.ProtoReflect.Descriptor instead.
+func (*Lifecycles) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Lifecycles) GetChoice() isLifecycles_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Lifecycles) GetPhase() LifecyclePhase {
+	if x != nil {
+		if x, ok := x.Choice.(*Lifecycles_Phase); ok {
+			return x.Phase
+		}
+	}
+	return LifecyclePhase_LIFECYCLE_PHASE_DESIGN
+}
+
+func (x *Lifecycles) GetName() string {
+	if x != nil {
+		if x, ok := x.Choice.(*Lifecycles_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+func (x *Lifecycles) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type isLifecycles_Choice interface {
+	isLifecycles_Choice()
+}
+
+type Lifecycles_Phase struct {
+	// A pre-defined phase in the product lifecycle.
+	Phase LifecyclePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=cyclonedx.v1_6.LifecyclePhase,oneof"`
+}
+
+type Lifecycles_Name struct {
+	// The name of the lifecycle phase.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*Lifecycles_Phase) isLifecycles_Choice() {}
+
+func (*Lifecycles_Name) isLifecycles_Choice() {}
+
+type OrganizationalContact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The name of a contact.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The email address of the contact.
+	Email *string `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	// The phone number of the contact.
+	Phone         *string `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationalContact) Reset() {
+	*x = OrganizationalContact{}
+	mi := &file_bom_1_6_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationalContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationalContact) ProtoMessage() {}
+
+func (x *OrganizationalContact) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationalContact.ProtoReflect.Descriptor instead.
+func (*OrganizationalContact) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrganizationalContact) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *OrganizationalContact) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationalContact) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *OrganizationalContact) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+type OrganizationalEntity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The name of the organization.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The URL of the organization.
+	Url []string `protobuf:"bytes,3,rep,name=url,proto3" json:"url,omitempty"`
+	// A contact person at the organization.
+	Contact []*OrganizationalContact `protobuf:"bytes,4,rep,name=contact,proto3" json:"contact,omitempty"`
+	// The physical address (location) of the organization.
+	Address       *PostalAddressType `protobuf:"bytes,5,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationalEntity) Reset() {
+	*x = OrganizationalEntity{}
+	mi := &file_bom_1_6_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationalEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationalEntity) ProtoMessage() {}
+
+func (x *OrganizationalEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationalEntity.ProtoReflect.Descriptor instead.
+func (*OrganizationalEntity) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OrganizationalEntity) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *OrganizationalEntity) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationalEntity) GetUrl() []string {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *OrganizationalEntity) GetContact() []*OrganizationalContact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *OrganizationalEntity) GetAddress() *PostalAddressType {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type PostalAddressType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the address elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The country name or the two-letter ISO 3166-1 country code.
+	Country *string `protobuf:"bytes,2,opt,name=country,proto3,oneof" json:"country,omitempty"`
+	// The region or state in the country.
+	Region *string `protobuf:"bytes,3,opt,name=region,proto3,oneof" json:"region,omitempty"`
+	// The locality or city within the country.
+	Locality *string `protobuf:"bytes,4,opt,name=locality,proto3,oneof" json:"locality,omitempty"`
+	// The post office box number.
+	PostOfficeBoxNumber *string `protobuf:"bytes,5,opt,name=post_office_box_number,json=postOfficeBoxNumber,proto3,oneof" json:"post_office_box_number,omitempty"`
+	// The postal code.
+	PostalCode *string `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3,oneof" json:"postal_code,omitempty"`
+	// The street address.
+	StreetAddress *string `protobuf:"bytes,7,opt,name=street_address,json=streetAddress,proto3,oneof" json:"street_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostalAddressType) Reset() {
+	*x = PostalAddressType{}
+	mi := &file_bom_1_6_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostalAddressType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostalAddressType) ProtoMessage() {}
+
+func (x *PostalAddressType) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostalAddressType.ProtoReflect.Descriptor instead.
+func (*PostalAddressType) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PostalAddressType) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetCountry() string {
+	if x != nil && x.Country != nil {
+		return *x.Country
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetLocality() string {
+	if x != nil && x.Locality != nil {
+		return *x.Locality
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetPostOfficeBoxNumber() string {
+	if x != nil && x.PostOfficeBoxNumber != nil {
+		return *x.PostOfficeBoxNumber
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetPostalCode() string {
+	if x != nil && x.PostalCode != nil {
+		return *x.PostalCode
+	}
+	return ""
+}
+
+func (x *PostalAddressType) GetStreetAddress() string {
+	if x != nil && x.StreetAddress != nil {
+		return *x.StreetAddress
+	}
+	return ""
+}
+
+// Specifies an individual patch.
+type Patch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the purpose for the patch.
+	Type PatchClassification `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.PatchClassification" json:"type,omitempty"`
+	// The patch file (or diff) that show changes.
+	Diff *Diff `protobuf:"bytes,2,opt,name=diff,proto3,oneof" json:"diff,omitempty"`
+	// A collection of issues the patch resolves.
+	Resolves      []*Issue `protobuf:"bytes,3,rep,name=resolves,proto3" json:"resolves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Patch) Reset() {
+	*x = Patch{}
+	mi := &file_bom_1_6_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Patch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Patch) ProtoMessage() {}
+
+func (x *Patch) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Patch.ProtoReflect.Descriptor instead.
+func (*Patch) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Patch) GetType() PatchClassification {
+	if x != nil {
+		return x.Type
+	}
+	return PatchClassification_PATCH_CLASSIFICATION_NULL
+}
+
+func (x *Patch) GetDiff() *Diff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+func (x *Patch) GetResolves() []*Issue {
+	if x != nil {
+		return x.Resolves
+	}
+	return nil
+}
+
+// Component pedigree is a way to document complex supply chain scenarios.
+type Pedigree struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Describes zero or more components in which a component is derived from.
+	Ancestors []*Component `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+	// Descendants are the exact opposite of ancestors.
+	Descendants []*Component `protobuf:"bytes,2,rep,name=descendants,proto3" json:"descendants,omitempty"`
+	// Variants describe relations where the relationship between the components are not known.
+	Variants []*Component `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	// A list of zero or more commits which provide a trail describing how the component deviates from an ancestor, descendant, or variant.
+	Commits []*Commit `protobuf:"bytes,4,rep,name=commits,proto3" json:"commits,omitempty"`
+	// A list of zero or more patches describing how the component deviates from an ancestor, descendant, or variant.
+	Patches []*Patch `protobuf:"bytes,5,rep,name=patches,proto3" json:"patches,omitempty"`
+	// Notes, observations, and other non-structured commentary describing the component's pedigree.
+	Notes         *string `protobuf:"bytes,6,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pedigree) Reset() {
+	*x = Pedigree{}
+	mi := &file_bom_1_6_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pedigree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pedigree) ProtoMessage() {}
+
+func (x *Pedigree) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pedigree.ProtoReflect.Descriptor instead.
+func (*Pedigree) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Pedigree) GetAncestors() []*Component {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+func (x *Pedigree) GetDescendants() []*Component {
+	if x != nil {
+		return x.Descendants
+	}
+	return nil
+}
+
+func (x *Pedigree) GetVariants() []*Component {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Pedigree) GetCommits() []*Commit {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+func (x *Pedigree) GetPatches() []*Patch {
+	if x != nil {
+		return x.Patches
+	}
+	return nil
+}
+
+func (x *Pedigree) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+type Service struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the service elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The organization that provides the service.
+	Provider *OrganizationalEntity `protobuf:"bytes,2,opt,name=provider,proto3,oneof" json:"provider,omitempty"`
+	// The grouping name, namespace, or identifier.
+	Group *string `protobuf:"bytes,3,opt,name=group,proto3,oneof" json:"group,omitempty"`
+	// The name of the service.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The service version.
+	Version *string `protobuf:"bytes,5,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// Specifies a description for the service.
+	Description *string `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The endpoint URIs of the service.
+	Endpoints []string `protobuf:"bytes,7,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// A boolean value indicating if the service requires authentication.
+	Authenticated *bool `protobuf:"varint,8,opt,name=authenticated,proto3,oneof" json:"authenticated,omitempty"`
+	// A boolean value indicating if use of the service crosses a trust zone or boundary.
+	XTrustBoundary *bool `protobuf:"varint,9,opt,name=x_trust_boundary,json=xTrustBoundary,proto3,oneof" json:"x_trust_boundary,omitempty"`
+	// Specifies the data classification.
+	Data []*DataClassification `protobuf:"bytes,10,rep,name=data,proto3" json:"data,omitempty"`
+	// Service license(s).
+	Licenses []*LicenseChoice `protobuf:"bytes,11,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	// Provides the ability to document external references related to the service.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,12,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
+	// Specifies optional sub-service.
+	Services []*Service `protobuf:"bytes,13,rep,name=services,proto3" json:"services,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,14,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Specifies optional release notes.
+	ReleaseNotes *ReleaseNotes `protobuf:"bytes,15,opt,name=releaseNotes,proto3,oneof" json:"releaseNotes,omitempty"`
+	// The name of the trust zone the service resides in.
+	TrustZone *string `protobuf:"bytes,16,opt,name=trustZone,proto3,oneof" json:"trustZone,omitempty"`
+	// Textual strings that aid in discovery, search, and retrieval of the associated object.
+	Tags          []string `protobuf:"bytes,17,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_bom_1_6_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Service) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Service) GetProvider() *OrganizationalEntity {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+func (x *Service) GetGroup() string {
+	if x != nil && x.Group != nil {
+		return *x.Group
+	}
+	return ""
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *Service) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Service) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Service) GetAuthenticated() bool {
+	if x != nil && x.Authenticated != nil {
+		return *x.Authenticated
+	}
+	return false
+}
+
+func (x *Service) GetXTrustBoundary() bool {
+	if x != nil && x.XTrustBoundary != nil {
+		return *x.XTrustBoundary
+	}
+	return false
+}
+
+func (x *Service) GetData() []*DataClassification {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Service) GetLicenses() []*LicenseChoice {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Service) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Service) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Service) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Service) GetReleaseNotes() *ReleaseNotes {
+	if x != nil {
+		return x.ReleaseNotes
+	}
+	return nil
+}
+
+func (x *Service) GetTrustZone() string {
+	if x != nil && x.TrustZone != nil {
+		return *x.TrustZone
+	}
+	return ""
+}
+
+func (x *Service) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Swid struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maps to the tagId of a SoftwareIdentity.
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	// Maps to the name of a SoftwareIdentity.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Maps to the version of a SoftwareIdentity.
+	Version *string `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// Maps to the tagVersion of a SoftwareIdentity.
+	TagVersion *int32 `protobuf:"varint,4,opt,name=tag_version,json=tagVersion,proto3,oneof" json:"tag_version,omitempty"`
+	// Maps to the patch of a SoftwareIdentity.
+	Patch *bool `protobuf:"varint,5,opt,name=patch,proto3,oneof" json:"patch,omitempty"`
+	// Specifies the full content of the SWID tag.
+	Text *AttachedText `protobuf:"bytes,6,opt,name=text,proto3,oneof" json:"text,omitempty"`
+	// The URL to the SWID file.
+	Url           *string `protobuf:"bytes,7,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Swid) Reset() {
+	*x = Swid{}
+	mi := &file_bom_1_6_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Swid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Swid) ProtoMessage() {}
+
+func (x *Swid) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Swid.ProtoReflect.Descriptor instead.
+func (*Swid) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Swid) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *Swid) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Swid) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *Swid) GetTagVersion() int32 {
+	if x != nil && x.TagVersion != nil {
+		return *x.TagVersion
+	}
+	return 0
+}
+
+func (x *Swid) GetPatch() bool {
+	if x != nil && x.Patch != nil {
+		return *x.Patch
+	}
+	return false
+}
+
+func (x *Swid) GetText() *AttachedText {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *Swid) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+// Specifies a tool (manual or automated).
+type Tool struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: use components or services instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Vendor *string `protobuf:"bytes,1,opt,name=vendor,proto3,oneof" json:"vendor,omitempty"`
+	// Deprecated: use components or services instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Deprecated: use components or services instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Version *string `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// Deprecated: use components or services instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	Hashes []*Hash `protobuf:"bytes,4,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// Deprecated: use components or services instead.
+	//
+	// Deprecated: Marked as deprecated in bom-1.6.proto.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,5,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
+	// A list of software and hardware components used as tools.
+	Components []*Component `protobuf:"bytes,6,rep,name=components,proto3" json:"components,omitempty"`
+	// A list of services used as tools.
+	Services      []*Service `protobuf:"bytes,7,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tool) Reset() {
+	*x = Tool{}
+	mi := &file_bom_1_6_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tool) ProtoMessage() {}
+
+func (x *Tool) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tool.ProtoReflect.Descriptor instead.
+func (*Tool) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{24}
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Tool) GetVendor() string {
+	if x != nil && x.Vendor != nil {
+		return *x.Vendor
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Tool) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Tool) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Tool) GetHashes() []*Hash {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in bom-1.6.proto.
+func (x *Tool) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Tool) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Tool) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// Specifies a property.
+type Property struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the property.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The value of the property.
+	Value         *string `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Property) Reset() {
+	*x = Property{}
+	mi := &file_bom_1_6_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Property) ProtoMessage() {}
+
+func (x *Property) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Property.ProtoReflect.Descriptor instead.
+func (*Property) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Property) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Property) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+type Composition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Indicates the aggregate completeness.
+	Aggregate Aggregate `protobuf:"varint,1,opt,name=aggregate,proto3,enum=cyclonedx.v1_6.Aggregate" json:"aggregate,omitempty"`
+	// The bom-ref identifiers of the components or services being described.
+	Assemblies []string `protobuf:"bytes,2,rep,name=assemblies,proto3" json:"assemblies,omitempty"`
+	// The bom-ref identifiers of the components or services being described.
+	Dependencies []string `protobuf:"bytes,3,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// The bom-ref identifiers of the vulnerabilities being described.
+	Vulnerabilities []string `protobuf:"bytes,4,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`
+	// An optional identifier which can be used to reference the composition elsewhere in the BOM.
+	BomRef        *string `protobuf:"bytes,5,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Composition) Reset() {
+	*x = Composition{}
+	mi := &file_bom_1_6_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Composition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Composition) ProtoMessage() {}
+
+func (x *Composition) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Composition.ProtoReflect.Descriptor instead.
+func (*Composition) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Composition) GetAggregate() Aggregate {
+	if x != nil {
+		return x.Aggregate
+	}
+	return Aggregate_AGGREGATE_NOT_SPECIFIED
+}
+
+func (x *Composition) GetAssemblies() []string {
+	if x != nil {
+		return x.Assemblies
+	}
+	return nil
+}
+
+func (x *Composition) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Composition) GetVulnerabilities() []string {
+	if x != nil {
+		return x.Vulnerabilities
+	}
+	return nil
+}
+
+func (x *Composition) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+type EvidenceCopyright struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The copyright text.
+	Text          string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceCopyright) Reset() {
+	*x = EvidenceCopyright{}
+	mi := &file_bom_1_6_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceCopyright) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceCopyright) ProtoMessage() {}
+
+func (x *EvidenceCopyright) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceCopyright.ProtoReflect.Descriptor instead.
+func (*EvidenceCopyright) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EvidenceCopyright) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type Evidence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional list of licenses obtained during analysis.
+	Licenses []*LicenseChoice `protobuf:"bytes,1,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	// Optional list of copyrights obtained during analysis.
+	Copyright []*EvidenceCopyright `protobuf:"bytes,2,rep,name=copyright,proto3" json:"copyright,omitempty"`
+	// Evidence that substantiates the identity of a component.
+	Identity []*EvidenceIdentity `protobuf:"bytes,3,rep,name=identity,proto3" json:"identity,omitempty"`
+	// Evidence of individual instances of a component spread across multiple locations.
+	Occurrences []*EvidenceOccurrences `protobuf:"bytes,4,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	// Evidence of the components use through the callstack.
+	Callstack     *Callstack `protobuf:"bytes,5,opt,name=callstack,proto3,oneof" json:"callstack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	mi := &file_bom_1_6_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Evidence) GetLicenses() []*LicenseChoice {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Evidence) GetCopyright() []*EvidenceCopyright {
+	if x != nil {
+		return x.Copyright
+	}
+	return nil
+}
+
+func (x *Evidence) GetIdentity() []*EvidenceIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *Evidence) GetOccurrences() []*EvidenceOccurrences {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+func (x *Evidence) GetCallstack() *Callstack {
+	if x != nil {
+		return x.Callstack
+	}
+	return nil
+}
+
+type EvidenceIdentity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The identity field of the component which the evidence describes.
+	Field EvidenceFieldType `protobuf:"varint,1,opt,name=field,proto3,enum=cyclonedx.v1_6.EvidenceFieldType" json:"field,omitempty"`
+	// The overall confidence of the evidence from 0 - 1, where 1 is 100% confidence.
+	Confidence *float32 `protobuf:"fixed32,2,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"`
+	// The methods used to extract and/or analyze the evidence.
+	Methods []*EvidenceMethods `protobuf:"bytes,3,rep,name=methods,proto3" json:"methods,omitempty"`
+	// The object in the BOM identified by its bom-ref.
+	Tools []string `protobuf:"bytes,4,rep,name=tools,proto3" json:"tools,omitempty"`
+	// The value of the field being described.
+	ConcludedValue *string `protobuf:"bytes,5,opt,name=concludedValue,proto3,oneof" json:"concludedValue,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EvidenceIdentity) Reset() {
+	*x = EvidenceIdentity{}
+	mi := &file_bom_1_6_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceIdentity) ProtoMessage() {}
+
+func (x *EvidenceIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceIdentity.ProtoReflect.Descriptor instead.
+func (*EvidenceIdentity) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EvidenceIdentity) GetField() EvidenceFieldType {
+	if x != nil {
+		return x.Field
+	}
+	return EvidenceFieldType_EVIDENCE_FIELD_NULL
+}
+
+func (x *EvidenceIdentity) GetConfidence() float32 {
+	if x != nil && x.Confidence != nil {
+		return *x.Confidence
+	}
+	return 0
+}
+
+func (x *EvidenceIdentity) GetMethods() []*EvidenceMethods {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *EvidenceIdentity) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *EvidenceIdentity) GetConcludedValue() string {
+	if x != nil && x.ConcludedValue != nil {
+		return *x.ConcludedValue
+	}
+	return ""
+}
+
+type EvidenceMethods struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The technique used in this method of analysis.
+	Technique EvidenceTechnique `protobuf:"varint,1,opt,name=technique,proto3,enum=cyclonedx.v1_6.EvidenceTechnique" json:"technique,omitempty"`
+	// The confidence of the evidence from 0 - 1, where 1 is 100% confidence.
+	Confidence float32 `protobuf:"fixed32,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// The value or contents of the evidence.
+	Value         *string `protobuf:"bytes,3,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceMethods) Reset() {
+	*x = EvidenceMethods{}
+	mi := &file_bom_1_6_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceMethods) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceMethods) ProtoMessage() {}
+
+func (x *EvidenceMethods) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceMethods.ProtoReflect.Descriptor instead.
+func (*EvidenceMethods) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *EvidenceMethods) GetTechnique() EvidenceTechnique {
+	if x != nil {
+		return x.Technique
+	}
+	return EvidenceTechnique_EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS
+}
+
+func (x *EvidenceMethods) GetConfidence() float32 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *EvidenceMethods) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+type EvidenceOccurrences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the occurrence elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The location or path to where the component was found.
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// The line number where the component was found.
+	Line *int32 `protobuf:"varint,3,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	// The offset where the component was found.
+	Offset *int32 `protobuf:"varint,4,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	// The symbol name that was found associated with the component.
+	Symbol *string `protobuf:"bytes,5,opt,name=symbol,proto3,oneof" json:"symbol,omitempty"`
+	// Any additional context of the detected component (e.g. a code snippet).
+	AdditionalContext *string `protobuf:"bytes,6,opt,name=additionalContext,proto3,oneof" json:"additionalContext,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EvidenceOccurrences) Reset() {
+	*x = EvidenceOccurrences{}
+	mi := &file_bom_1_6_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceOccurrences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceOccurrences) ProtoMessage() {}
+
+func (x *EvidenceOccurrences) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceOccurrences.ProtoReflect.Descriptor instead.
+func (*EvidenceOccurrences) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EvidenceOccurrences) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *EvidenceOccurrences) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *EvidenceOccurrences) GetLine() int32 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *EvidenceOccurrences) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *EvidenceOccurrences) GetSymbol() string {
+	if x != nil && x.Symbol != nil {
+		return *x.Symbol
+	}
+	return ""
+}
+
+func (x *EvidenceOccurrences) GetAdditionalContext() string {
+	if x != nil && x.AdditionalContext != nil {
+		return *x.AdditionalContext
+	}
+	return ""
+}
+
+type Callstack struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The frames seen in the callstack.
+	Frames        []*Callstack_Frames `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Callstack) Reset() {
+	*x = Callstack{}
+	mi := &file_bom_1_6_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Callstack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Callstack) ProtoMessage() {}
+
+func (x *Callstack) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Callstack.ProtoReflect.Descriptor instead.
+func (*Callstack) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Callstack) GetFrames() []*Callstack_Frames {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+type ReleaseNotes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The software versioning type the release note describes.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The title of the release.
+	Title *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// The URL to an image that may be prominently displayed with the release note.
+	FeaturedImage *string `protobuf:"bytes,3,opt,name=featuredImage,proto3,oneof" json:"featuredImage,omitempty"`
+	// The URL to an image that may be used in messaging on social media platforms.
+	SocialImage *string `protobuf:"bytes,4,opt,name=socialImage,proto3,oneof" json:"socialImage,omitempty"`
+	// A short description of the release.
+	Description *string `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The date and time (timestamp) when the release note was created.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	// Alternate names the release may be referred to as.
+	Aliases []string `protobuf:"bytes,7,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Textual strings that aid in discovery, search, and retrieval of the associated object.
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// A collection of issues that have been resolved.
+	Resolves []*Issue `protobuf:"bytes,9,rep,name=resolves,proto3" json:"resolves,omitempty"`
+	// Zero or more release notes containing the locale and content.
+	Notes []*ReleaseNotes_Note `protobuf:"bytes,10,rep,name=notes,proto3" json:"notes,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,11,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseNotes) Reset() {
+	*x = ReleaseNotes{}
+	mi := &file_bom_1_6_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseNotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseNotes) ProtoMessage() {}
+
+func (x *ReleaseNotes) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseNotes.ProtoReflect.Descriptor instead.
+func (*ReleaseNotes) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReleaseNotes) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReleaseNotes) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *ReleaseNotes) GetFeaturedImage() string {
+	if x != nil && x.FeaturedImage != nil {
+		return *x.FeaturedImage
+	}
+	return ""
+}
+
+func (x *ReleaseNotes) GetSocialImage() string {
+	if x != nil && x.SocialImage != nil {
+		return *x.SocialImage
+	}
+	return ""
+}
+
+func (x *ReleaseNotes) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ReleaseNotes) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ReleaseNotes) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *ReleaseNotes) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ReleaseNotes) GetResolves() []*Issue {
+	if x != nil {
+		return x.Resolves
+	}
+	return nil
+}
+
+func (x *ReleaseNotes) GetNotes() []*ReleaseNotes_Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *ReleaseNotes) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type Vulnerability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the vulnerability elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The identifier that uniquely identifies the vulnerability.
+	Id *string `protobuf:"bytes,2,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	// The source that published the vulnerability.
+	Source *Source `protobuf:"bytes,3,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// Zero or more pointers to vulnerabilities that are the equivalent of the vulnerability specified.
+	References []*VulnerabilityReference `protobuf:"bytes,4,rep,name=references,proto3" json:"references,omitempty"`
+	// List of vulnerability ratings.
+	Ratings []*VulnerabilityRating `protobuf:"bytes,5,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	// List of Common Weaknesses Enumerations (CWEs) codes that describes this vulnerability.
+	Cwes []int32 `protobuf:"varint,6,rep,packed,name=cwes,proto3" json:"cwes,omitempty"`
+	// A description of the vulnerability as provided by the source.
+	Description *string `protobuf:"bytes,7,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// If available, an in-depth description of the vulnerability as provided by the source organization.
+	Detail *string `protobuf:"bytes,8,opt,name=detail,proto3,oneof" json:"detail,omitempty"`
+	// Recommendations of how the vulnerability can be remediated or mitigated.
+	Recommendation *string `protobuf:"bytes,9,opt,name=recommendation,proto3,oneof" json:"recommendation,omitempty"`
+	// Published advisories of the vulnerability if provided.
+	Advisories []*Advisory `protobuf:"bytes,10,rep,name=advisories,proto3" json:"advisories,omitempty"`
+	// The date and time (timestamp) when the vulnerability record was created in the vulnerability database.
+	Created *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	// The date and time (timestamp) when the vulnerability record was first published.
+	Published *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=published,proto3,oneof" json:"published,omitempty"`
+	// The date and time (timestamp) when the vulnerability record was last updated.
+	Updated *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated,proto3,oneof" json:"updated,omitempty"`
+	// Individuals or organizations credited with the discovery of the vulnerability.
+	Credits *VulnerabilityCredits `protobuf:"bytes,14,opt,name=credits,proto3,oneof" json:"credits,omitempty"`
+	// The tool(s) used to identify, confirm, or score the vulnerability.
+	Tools []*Tool `protobuf:"bytes,15,rep,name=tools,proto3" json:"tools,omitempty"`
+	// An assessment of the impact and exploitability of the vulnerability.
+	Analysis *VulnerabilityAnalysis `protobuf:"bytes,16,opt,name=analysis,proto3,oneof" json:"analysis,omitempty"`
+	// The components or services that are affected by the vulnerability.
+	Affects []*VulnerabilityAffects `protobuf:"bytes,17,rep,name=affects,proto3" json:"affects,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,18,rep,name=properties,proto3" json:"properties,omitempty"`
+	// The date and time (timestamp) when the vulnerability record was rejected (if applicable).
+	Rejected *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=rejected,proto3,oneof" json:"rejected,omitempty"`
+	// Evidence used to reproduce the vulnerability.
+	ProofOfConcept *ProofOfConcept `protobuf:"bytes,20,opt,name=proofOfConcept,proto3,oneof" json:"proofOfConcept,omitempty"`
+	// A bypass, usually temporary, of the vulnerability that reduces its likelihood and/or impact.
+	Workaround    *string `protobuf:"bytes,21,opt,name=workaround,proto3,oneof" json:"workaround,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vulnerability) Reset() {
+	*x = Vulnerability{}
+	mi := &file_bom_1_6_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vulnerability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vulnerability) ProtoMessage() {}
+
+func (x *Vulnerability) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vulnerability.ProtoReflect.Descriptor instead.
+func (*Vulnerability) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Vulnerability) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetReferences() []*VulnerabilityReference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetRatings() []*VulnerabilityRating {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetCwes() []int32 {
+	if x != nil {
+		return x.Cwes
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetDetail() string {
+	if x != nil && x.Detail != nil {
+		return *x.Detail
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetRecommendation() string {
+	if x != nil && x.Recommendation != nil {
+		return *x.Recommendation
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetAdvisories() []*Advisory {
+	if x != nil {
+		return x.Advisories
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetPublished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Published
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetCredits() *VulnerabilityCredits {
+	if x != nil {
+		return x.Credits
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetAnalysis() *VulnerabilityAnalysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetAffects() []*VulnerabilityAffects {
+	if x != nil {
+		return x.Affects
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetRejected() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetProofOfConcept() *ProofOfConcept {
+	if x != nil {
+		return x.ProofOfConcept
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetWorkaround() string {
+	if x != nil && x.Workaround != nil {
+		return *x.Workaround
+	}
+	return ""
+}
+
+type VulnerabilityReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier that uniquely identifies the vulnerability.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The source that published the vulnerability.
+	Source        *Source `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityReference) Reset() {
+	*x = VulnerabilityReference{}
+	mi := &file_bom_1_6_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityReference) ProtoMessage() {}
+
+func (x *VulnerabilityReference) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityReference.ProtoReflect.Descriptor instead.
+func (*VulnerabilityReference) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VulnerabilityReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VulnerabilityReference) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type VulnerabilityRating struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The source that calculated the severity or risk rating of the vulnerability.
+	Source *Source `protobuf:"bytes,1,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// The numerical score of the rating.
+	Score *float64 `protobuf:"fixed64,2,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	// Textual representation of the severity that corresponds to the numerical score of the rating.
+	Severity *Severity `protobuf:"varint,3,opt,name=severity,proto3,enum=cyclonedx.v1_6.Severity,oneof" json:"severity,omitempty"`
+	// Specifies the severity or risk scoring methodology or standard used.
+	Method *ScoreMethod `protobuf:"varint,4,opt,name=method,proto3,enum=cyclonedx.v1_6.ScoreMethod,oneof" json:"method,omitempty"`
+	// Textual representation of the metric values used to score the vulnerability.
+	Vector *string `protobuf:"bytes,5,opt,name=vector,proto3,oneof" json:"vector,omitempty"`
+	// An optional reason for rating the vulnerability as it was.
+	Justification *string `protobuf:"bytes,6,opt,name=justification,proto3,oneof" json:"justification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityRating) Reset() {
+	*x = VulnerabilityRating{}
+	mi := &file_bom_1_6_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityRating) ProtoMessage() {}
+
+func (x *VulnerabilityRating) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityRating.ProtoReflect.Descriptor instead.
+func (*VulnerabilityRating) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VulnerabilityRating) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *VulnerabilityRating) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *VulnerabilityRating) GetSeverity() Severity {
+	if x != nil && x.Severity != nil {
+		return *x.Severity
+	}
+	return Severity_SEVERITY_UNKNOWN
+}
+
+func (x *VulnerabilityRating) GetMethod() ScoreMethod {
+	if x != nil && x.Method != nil {
+		return *x.Method
+	}
+	return ScoreMethod_SCORE_METHOD_NULL
+}
+
+func (x *VulnerabilityRating) GetVector() string {
+	if x != nil && x.Vector != nil {
+		return *x.Vector
+	}
+	return ""
+}
+
+func (x *VulnerabilityRating) GetJustification() string {
+	if x != nil && x.Justification != nil {
+		return *x.Justification
+	}
+	return ""
+}
+
+type Advisory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional name of the advisory.
+	Title *string `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Location where the advisory can be obtained.
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Advisory) Reset() {
+	*x = Advisory{}
+	mi := &file_bom_1_6_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Advisory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advisory) ProtoMessage() {}
+
+func (x *Advisory) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advisory.ProtoReflect.Descriptor instead.
+func (*Advisory) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Advisory) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *Advisory) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type VulnerabilityCredits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The organizations credited with vulnerability discovery.
+	Organizations []*OrganizationalEntity `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	// The individuals, not associated with organizations, that are credited with vulnerability discovery.
+	Individuals   []*OrganizationalContact `protobuf:"bytes,2,rep,name=individuals,proto3" json:"individuals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityCredits) Reset() {
+	*x = VulnerabilityCredits{}
+	mi := &file_bom_1_6_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityCredits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityCredits) ProtoMessage() {}
+
+func (x *VulnerabilityCredits) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityCredits.ProtoReflect.Descriptor instead.
+func (*VulnerabilityCredits) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VulnerabilityCredits) GetOrganizations() []*OrganizationalEntity {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+func (x *VulnerabilityCredits) GetIndividuals() []*OrganizationalContact {
+	if x != nil {
+		return x.Individuals
+	}
+	return nil
+}
+
+type VulnerabilityAnalysis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Declares the current state of an occurrence of a vulnerability, after automated or manual analysis.
+	State *ImpactAnalysisState `protobuf:"varint,1,opt,name=state,proto3,enum=cyclonedx.v1_6.ImpactAnalysisState,oneof" json:"state,omitempty"`
+	// The rationale of why the impact analysis state was asserted.
+	Justification *ImpactAnalysisJustification `protobuf:"varint,2,opt,name=justification,proto3,enum=cyclonedx.v1_6.ImpactAnalysisJustification,oneof" json:"justification,omitempty"`
+	// A response to the vulnerability by the manufacturer, supplier, or project responsible for the affected component or service.
+	Response []VulnerabilityResponse `protobuf:"varint,3,rep,packed,name=response,proto3,enum=cyclonedx.v1_6.VulnerabilityResponse" json:"response,omitempty"`
+	// Detailed description of the impact including methods used during assessment.
+	Detail *string `protobuf:"bytes,4,opt,name=detail,proto3,oneof" json:"detail,omitempty"`
+	// The date and time (timestamp) when the analysis was first issued.
+	FirstIssued *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=firstIssued,proto3,oneof" json:"firstIssued,omitempty"`
+	// The date and time (timestamp) when the analysis was last updated.
+	LastUpdated   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=lastUpdated,proto3,oneof" json:"lastUpdated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityAnalysis) Reset() {
+	*x = VulnerabilityAnalysis{}
+	mi := &file_bom_1_6_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityAnalysis) ProtoMessage() {}
+
+func (x *VulnerabilityAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityAnalysis.ProtoReflect.Descriptor instead.
+func (*VulnerabilityAnalysis) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VulnerabilityAnalysis) GetState() ImpactAnalysisState {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return ImpactAnalysisState_IMPACT_ANALYSIS_STATE_NULL
+}
+
+func (x *VulnerabilityAnalysis) GetJustification() ImpactAnalysisJustification {
+	if x != nil && x.Justification != nil {
+		return *x.Justification
+	}
+	return ImpactAnalysisJustification_IMPACT_ANALYSIS_JUSTIFICATION_NULL
+}
+
+func (x *VulnerabilityAnalysis) GetResponse() []VulnerabilityResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *VulnerabilityAnalysis) GetDetail() string {
+	if x != nil && x.Detail != nil {
+		return *x.Detail
+	}
+	return ""
+}
+
+func (x *VulnerabilityAnalysis) GetFirstIssued() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstIssued
+	}
+	return nil
+}
+
+func (x *VulnerabilityAnalysis) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type VulnerabilityAffects struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// References a component or service by the objects bom-ref.
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Zero or more individual versions or range of versions.
+	Versions      []*VulnerabilityAffectedVersions `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityAffects) Reset() {
+	*x = VulnerabilityAffects{}
+	mi := &file_bom_1_6_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityAffects) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityAffects) ProtoMessage() {}
+
+func (x *VulnerabilityAffects) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityAffects.ProtoReflect.Descriptor instead.
+func (*VulnerabilityAffects) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *VulnerabilityAffects) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *VulnerabilityAffects) GetVersions() []*VulnerabilityAffectedVersions {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type VulnerabilityAffectedVersions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*VulnerabilityAffectedVersions_Version
+	//	*VulnerabilityAffectedVersions_Range
+	Choice isVulnerabilityAffectedVersions_Choice `protobuf_oneof:"choice"`
+	// The vulnerability status for the version or range of versions.
+	Status        *VulnerabilityAffectedStatus `protobuf:"varint,3,opt,name=status,proto3,enum=cyclonedx.v1_6.VulnerabilityAffectedStatus,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnerabilityAffectedVersions) Reset() {
+	*x = VulnerabilityAffectedVersions{}
+	mi := &file_bom_1_6_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnerabilityAffectedVersions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnerabilityAffectedVersions) ProtoMessage() {}
+
+func (x *VulnerabilityAffectedVersions) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnerabilityAffectedVersions.ProtoReflect.Descriptor instead.
+func (*VulnerabilityAffectedVersions) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *VulnerabilityAffectedVersions) GetChoice() isVulnerabilityAffectedVersions_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *VulnerabilityAffectedVersions) GetVersion() string {
+	if x != nil {
+		if x, ok := x.Choice.(*VulnerabilityAffectedVersions_Version); ok {
+			return x.Version
+		}
+	}
+	return ""
+}
+
+func (x *VulnerabilityAffectedVersions) GetRange() string {
+	if x != nil {
+		if x, ok := x.Choice.(*VulnerabilityAffectedVersions_Range); ok {
+			return x.Range
+		}
+	}
+	return ""
+}
+
+func (x *VulnerabilityAffectedVersions) GetStatus() VulnerabilityAffectedStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return VulnerabilityAffectedStatus_VULNERABILITY_AFFECTED_STATUS_UNKNOWN
+}
+
+type isVulnerabilityAffectedVersions_Choice interface {
+	isVulnerabilityAffectedVersions_Choice()
+}
+
+type VulnerabilityAffectedVersions_Version struct {
+	// A single version of a component or service.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3,oneof"`
+}
+
+type VulnerabilityAffectedVersions_Range struct {
+	// A version range specified in Package URL Version Range syntax (vers).
+	Range string `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
+}
+
+func (*VulnerabilityAffectedVersions_Version) isVulnerabilityAffectedVersions_Choice() {}
+
+func (*VulnerabilityAffectedVersions_Range) isVulnerabilityAffectedVersions_Choice() {}
+
+type ProofOfConcept struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Precise steps to reproduce the vulnerability.
+	ReproductionSteps *string `protobuf:"bytes,1,opt,name=reproductionSteps,proto3,oneof" json:"reproductionSteps,omitempty"`
+	// A description of the environment in which reproduction was possible.
+	Environment *string `protobuf:"bytes,2,opt,name=environment,proto3,oneof" json:"environment,omitempty"`
+	// Supporting material that helps in reproducing or understanding how reproduction is possible.
+	SupportingMaterial []*AttachedText `protobuf:"bytes,3,rep,name=supportingMaterial,proto3" json:"supportingMaterial,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ProofOfConcept) Reset() {
+	*x = ProofOfConcept{}
+	mi := &file_bom_1_6_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofOfConcept) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofOfConcept) ProtoMessage() {}
+
+func (x *ProofOfConcept) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofOfConcept.ProtoReflect.Descriptor instead.
+func (*ProofOfConcept) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ProofOfConcept) GetReproductionSteps() string {
+	if x != nil && x.ReproductionSteps != nil {
+		return *x.ReproductionSteps
+	}
+	return ""
+}
+
+func (x *ProofOfConcept) GetEnvironment() string {
+	if x != nil && x.Environment != nil {
+		return *x.Environment
+	}
+	return ""
+}
+
+func (x *ProofOfConcept) GetSupportingMaterial() []*AttachedText {
+	if x != nil {
+		return x.SupportingMaterial
+	}
+	return nil
+}
+
+type Annotation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the annotation elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The object in the BOM identified by its bom-ref.
+	Subjects []string `protobuf:"bytes,2,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	// The organization, person, component, or service which created the textual content of the annotation.
+	Annotator *Annotation_Annotator `protobuf:"bytes,3,opt,name=annotator,proto3" json:"annotator,omitempty"`
+	// The date and time (timestamp) when the annotation was created.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The textual content of the annotation.
+	Text          string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	mi := &file_bom_1_6_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Annotation) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Annotation) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *Annotation) GetAnnotator() *Annotation_Annotator {
+	if x != nil {
+		return x.Annotator
+	}
+	return nil
+}
+
+func (x *Annotation) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Annotation) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Describes the manufacturing or deployment of components and services.
+type Formula struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the formula elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// Transient components that are used in tasks that constitute one or more of this formula's workflows.
+	Components []*Component `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	// Transient services that are used in tasks that constitute one or more of this formula's workflows.
+	Services []*Service `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	// List of workflows that can be declared to accomplish specific orchestrated goals and independently triggered.
+	Workflows []*Workflow `protobuf:"bytes,4,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,5,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Formula) Reset() {
+	*x = Formula{}
+	mi := &file_bom_1_6_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Formula) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Formula) ProtoMessage() {}
+
+func (x *Formula) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Formula.ProtoReflect.Descriptor instead.
+func (*Formula) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Formula) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Formula) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Formula) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Formula) GetWorkflows() []*Workflow {
+	if x != nil {
+		return x.Workflows
+	}
+	return nil
+}
+
+func (x *Formula) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// A specialized orchestration task.
+type Workflow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier which can be used to reference the workflow elsewhere in the BOM.
+	BomRef string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3" json:"bom_ref,omitempty"`
+	// The unique identifier for the resource instance within its deployment context.
+	Uid string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The name of the resource instance.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A description of the resource instance.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// References to component or service resources that are used to realize the resource instance.
+	ResourceReferences []*ResourceReferenceChoice `protobuf:"bytes,5,rep,name=resourceReferences,proto3" json:"resourceReferences,omitempty"`
+	// The tasks that comprise the workflow.
+	Tasks []*Task `protobuf:"bytes,6,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// The graph of dependencies between tasks within the workflow.
+	TaskDependencies []*Dependency `protobuf:"bytes,7,rep,name=taskDependencies,proto3" json:"taskDependencies,omitempty"`
+	// Indicates the types of activities performed by the set of workflow tasks.
+	TaskTypes []TaskType `protobuf:"varint,8,rep,packed,name=taskTypes,proto3,enum=cyclonedx.v1_6.TaskType" json:"taskTypes,omitempty"`
+	// The trigger that initiated the task.
+	Trigger *Trigger `protobuf:"bytes,9,opt,name=trigger,proto3,oneof" json:"trigger,omitempty"`
+	// The sequence of steps for the task.
+	Steps []*Step `protobuf:"bytes,10,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Represents resources and data brought into a task at runtime by executor or task commands.
+	Inputs []*InputType `protobuf:"bytes,11,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Represents resources and data output from a task at runtime by executor or task commands.
+	Outputs []*OutputType `protobuf:"bytes,12,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The date and time (timestamp) when the task started.
+	TimeStart *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=timeStart,proto3,oneof" json:"timeStart,omitempty"`
+	// The date and time (timestamp) when the task ended.
+	TimeEnd *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=timeEnd,proto3,oneof" json:"timeEnd,omitempty"`
+	// A set of named filesystem or data resource shareable by workflow tasks.
+	Workspaces []*Workspace `protobuf:"bytes,15,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	// A graph of the component runtime topology for the workflow's instance.
+	RuntimeTopology []*Dependency `protobuf:"bytes,16,rep,name=runtimeTopology,proto3" json:"runtimeTopology,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,17,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_bom_1_6_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Workflow) GetBomRef() string {
+	if x != nil {
+		return x.BomRef
+	}
+	return ""
+}
+
+func (x *Workflow) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Workflow) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Workflow) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Workflow) GetResourceReferences() []*ResourceReferenceChoice {
+	if x != nil {
+		return x.ResourceReferences
+	}
+	return nil
+}
+
+func (x *Workflow) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *Workflow) GetTaskDependencies() []*Dependency {
+	if x != nil {
+		return x.TaskDependencies
+	}
+	return nil
+}
+
+func (x *Workflow) GetTaskTypes() []TaskType {
+	if x != nil {
+		return x.TaskTypes
+	}
+	return nil
+}
+
+func (x *Workflow) GetTrigger() *Trigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *Workflow) GetSteps() []*Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Workflow) GetInputs() []*InputType {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Workflow) GetOutputs() []*OutputType {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Workflow) GetTimeStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *Workflow) GetTimeEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+func (x *Workflow) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+func (x *Workflow) GetRuntimeTopology() []*Dependency {
+	if x != nil {
+		return x.RuntimeTopology
+	}
+	return nil
+}
+
+func (x *Workflow) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Describes the inputs, sequence of steps and resources used to accomplish a task and its output.
+type Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier which can be used to reference the task elsewhere in the BOM.
+	BomRef string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3" json:"bom_ref,omitempty"`
+	// The unique identifier for the resource instance within its deployment context.
+	Uid string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The name of the resource instance.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A description of the resource instance.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// References to component or service resources that are used to realize the resource instance.
+	ResourceReferences []*ResourceReferenceChoice `protobuf:"bytes,5,rep,name=resourceReferences,proto3" json:"resourceReferences,omitempty"`
+	// Indicates the types of activities performed by the set of workflow tasks.
+	TaskTypes []TaskType `protobuf:"varint,6,rep,packed,name=taskTypes,proto3,enum=cyclonedx.v1_6.TaskType" json:"taskTypes,omitempty"`
+	// The trigger that initiated the task.
+	Trigger *Trigger `protobuf:"bytes,7,opt,name=trigger,proto3,oneof" json:"trigger,omitempty"`
+	// The sequence of steps for the task.
+	Steps []*Step `protobuf:"bytes,8,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Represents resources and data brought into a task at runtime by executor or task commands.
+	Inputs []*InputType `protobuf:"bytes,9,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Represents resources and data output from a task at runtime by executor or task commands.
+	Outputs []*OutputType `protobuf:"bytes,10,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The date and time (timestamp) when the task started.
+	TimeStart *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timeStart,proto3,oneof" json:"timeStart,omitempty"`
+	// The date and time (timestamp) when the task ended.
+	TimeEnd *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=timeEnd,proto3,oneof" json:"timeEnd,omitempty"`
+	// A set of named filesystem or data resource shareable by workflow tasks.
+	Workspaces []*Workspace `protobuf:"bytes,13,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	// A graph of the component runtime topology for the task's instance.
+	RuntimeTopology []*Dependency `protobuf:"bytes,14,rep,name=runtimeTopology,proto3" json:"runtimeTopology,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,15,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_bom_1_6_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Task) GetBomRef() string {
+	if x != nil {
+		return x.BomRef
+	}
+	return ""
+}
+
+func (x *Task) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Task) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetResourceReferences() []*ResourceReferenceChoice {
+	if x != nil {
+		return x.ResourceReferences
+	}
+	return nil
+}
+
+func (x *Task) GetTaskTypes() []TaskType {
+	if x != nil {
+		return x.TaskTypes
+	}
+	return nil
+}
+
+func (x *Task) GetTrigger() *Trigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *Task) GetSteps() []*Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Task) GetInputs() []*InputType {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Task) GetOutputs() []*OutputType {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Task) GetTimeStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *Task) GetTimeEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+func (x *Task) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+func (x *Task) GetRuntimeTopology() []*Dependency {
+	if x != nil {
+		return x.RuntimeTopology
+	}
+	return nil
+}
+
+func (x *Task) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Executes specific commands or tools in order to accomplish its owning task as part of a sequence.
+type Step struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A name for the step.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A description of the step.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Ordered list of commands or directives for the step.
+	Commands []*Command `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Step) Reset() {
+	*x = Step{}
+	mi := &file_bom_1_6_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Step) ProtoMessage() {}
+
+func (x *Step) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Step.ProtoReflect.Descriptor instead.
+func (*Step) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Step) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Step) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Step) GetCommands() []*Command {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *Step) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A text representation of the executed command.
+	Executed *string `protobuf:"bytes,1,opt,name=executed,proto3,oneof" json:"executed,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,2,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_bom_1_6_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Command) GetExecuted() string {
+	if x != nil && x.Executed != nil {
+		return *x.Executed
+	}
+	return ""
+}
+
+func (x *Command) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// A named filesystem or data resource shareable by workflow tasks.
+type Workspace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier which can be used to reference the workspace elsewhere in the BOM.
+	BomRef string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3" json:"bom_ref,omitempty"`
+	// The unique identifier for the resource instance within its deployment context.
+	Uid string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The name of the resource instance.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The names for the workspace as referenced by other workflow tasks.
+	Aliases []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// A description of the resource instance.
+	Description *string `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// References to component or service resources that are used to realize the resource instance.
+	ResourceReferences []*ResourceReferenceChoice `protobuf:"bytes,6,rep,name=resourceReferences,proto3" json:"resourceReferences,omitempty"`
+	// Describes the read-write access control for the workspace relative to the owning resource instance.
+	AccessMode *Workspace_AccessMode `protobuf:"varint,7,opt,name=accessMode,proto3,enum=cyclonedx.v1_6.Workspace_AccessMode,oneof" json:"accessMode,omitempty"`
+	// A path to a location on disk where the workspace will be available to the associated task's steps.
+	MountPath *string `protobuf:"bytes,8,opt,name=mountPath,proto3,oneof" json:"mountPath,omitempty"`
+	// The name of a domain-specific data type the workspace represents.
+	ManagedDataType *string `protobuf:"bytes,9,opt,name=managedDataType,proto3,oneof" json:"managedDataType,omitempty"`
+	// Identifies the reference to the request for a specific volume type and parameters.
+	VolumeRequest *string `protobuf:"bytes,10,opt,name=volumeRequest,proto3,oneof" json:"volumeRequest,omitempty"`
+	// Information about the actual volume instance allocated to the workspace.
+	Volume *Volume `protobuf:"bytes,11,opt,name=volume,proto3,oneof" json:"volume,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,12,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_bom_1_6_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Workspace) GetBomRef() string {
+	if x != nil {
+		return x.BomRef
+	}
+	return ""
+}
+
+func (x *Workspace) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Workspace) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Workspace) GetResourceReferences() []*ResourceReferenceChoice {
+	if x != nil {
+		return x.ResourceReferences
+	}
+	return nil
+}
+
+func (x *Workspace) GetAccessMode() Workspace_AccessMode {
+	if x != nil && x.AccessMode != nil {
+		return *x.AccessMode
+	}
+	return Workspace_ACCESS_MODE_READ_ONLY
+}
+
+func (x *Workspace) GetMountPath() string {
+	if x != nil && x.MountPath != nil {
+		return *x.MountPath
+	}
+	return ""
+}
+
+func (x *Workspace) GetManagedDataType() string {
+	if x != nil && x.ManagedDataType != nil {
+		return *x.ManagedDataType
+	}
+	return ""
+}
+
+func (x *Workspace) GetVolumeRequest() string {
+	if x != nil && x.VolumeRequest != nil {
+		return *x.VolumeRequest
+	}
+	return ""
+}
+
+func (x *Workspace) GetVolume() *Volume {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *Workspace) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// An identifiable, logical unit of data storage tied to a physical device.
+type Volume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The unique identifier for the volume instance within its deployment context.
+	Uid *string `protobuf:"bytes,1,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
+	// The name of the volume instance.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The mode for the volume instance.
+	Mode *Volume_VolumeMode `protobuf:"varint,3,opt,name=mode,proto3,enum=cyclonedx.v1_6.Volume_VolumeMode,oneof" json:"mode,omitempty"`
+	// The underlying path created from the actual volume.
+	Path *string `protobuf:"bytes,4,opt,name=path,proto3,oneof" json:"path,omitempty"`
+	// The allocated size of the volume accessible to the associated workspace.
+	SizeAllocated *string `protobuf:"bytes,5,opt,name=sizeAllocated,proto3,oneof" json:"sizeAllocated,omitempty"`
+	// Indicates if the volume persists beyond the life of the resource it is associated with.
+	Persistent *bool `protobuf:"varint,6,opt,name=persistent,proto3,oneof" json:"persistent,omitempty"`
+	// Indicates if the volume is remotely (i.e., network) attached.
+	Remote *bool `protobuf:"varint,7,opt,name=remote,proto3,oneof" json:"remote,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,8,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_bom_1_6_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Volume) GetUid() string {
+	if x != nil && x.Uid != nil {
+		return *x.Uid
+	}
+	return ""
+}
+
+func (x *Volume) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetMode() Volume_VolumeMode {
+	if x != nil && x.Mode != nil {
+		return *x.Mode
+	}
+	return Volume_VOLUME_MODE_FILESYSTEM
+}
+
+func (x *Volume) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *Volume) GetSizeAllocated() string {
+	if x != nil && x.SizeAllocated != nil {
+		return *x.SizeAllocated
+	}
+	return ""
+}
+
+func (x *Volume) GetPersistent() bool {
+	if x != nil && x.Persistent != nil {
+		return *x.Persistent
+	}
+	return false
+}
+
+func (x *Volume) GetRemote() bool {
+	if x != nil && x.Remote != nil {
+		return *x.Remote
+	}
+	return false
+}
+
+func (x *Volume) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Represents a resource that can conditionally activate (or fire) tasks based upon associated events and their data.
+type Trigger struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier which can be used to reference the trigger elsewhere in the BOM.
+	BomRef string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3" json:"bom_ref,omitempty"`
+	// The unique identifier for the resource instance within its deployment context.
+	Uid string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The name of the resource instance.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A description of the resource instance.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// References to component or service resources that are used to realize the resource instance.
+	ResourceReferences []*ResourceReferenceChoice `protobuf:"bytes,5,rep,name=resourceReferences,proto3" json:"resourceReferences,omitempty"`
+	// The source type of event which caused the trigger to fire.
+	Type Trigger_TriggerType `protobuf:"varint,6,opt,name=type,proto3,enum=cyclonedx.v1_6.Trigger_TriggerType" json:"type,omitempty"`
+	// The event data that caused the associated trigger to activate.
+	Event *Event `protobuf:"bytes,7,opt,name=event,proto3,oneof" json:"event,omitempty"`
+	// A list of conditions used to determine if a trigger should be activated.
+	Conditions []*Condition `protobuf:"bytes,8,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// The date and time (timestamp) when the trigger was activated.
+	TimeActivated *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timeActivated,proto3,oneof" json:"timeActivated,omitempty"`
+	// Represents resources and data brought into a task at runtime by executor or task commands.
+	Inputs []*InputType `protobuf:"bytes,10,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Represents resources and data output from a task at runtime by executor or task commands.
+	Outputs []*OutputType `protobuf:"bytes,11,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,12,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trigger) Reset() {
+	*x = Trigger{}
+	mi := &file_bom_1_6_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trigger) ProtoMessage() {}
+
+func (x *Trigger) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trigger.ProtoReflect.Descriptor instead.
+func (*Trigger) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Trigger) GetBomRef() string {
+	if x != nil {
+		return x.BomRef
+	}
+	return ""
+}
+
+func (x *Trigger) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Trigger) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Trigger) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Trigger) GetResourceReferences() []*ResourceReferenceChoice {
+	if x != nil {
+		return x.ResourceReferences
+	}
+	return nil
+}
+
+func (x *Trigger) GetType() Trigger_TriggerType {
+	if x != nil {
+		return x.Type
+	}
+	return Trigger_TRIGGER_TYPE_MANUAL
+}
+
+func (x *Trigger) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Trigger) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *Trigger) GetTimeActivated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeActivated
+	}
+	return nil
+}
+
+func (x *Trigger) GetInputs() []*InputType {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Trigger) GetOutputs() []*OutputType {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Trigger) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The unique identifier of the event.
+	Uid *string `protobuf:"bytes,1,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
+	// A description of the event.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The date and time (timestamp) when the event was received.
+	TimeReceived *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timeReceived,proto3,oneof" json:"timeReceived,omitempty"`
+	// Encoding of the raw event data.
+	Data *AttachedText `protobuf:"bytes,4,opt,name=data,proto3,oneof" json:"data,omitempty"`
+	// References the component or service that was the source of the event.
+	Source *ResourceReferenceChoice `protobuf:"bytes,5,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// References the component or service that was the target of the event.
+	Target *ResourceReferenceChoice `protobuf:"bytes,6,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_bom_1_6_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Event) GetUid() string {
+	if x != nil && x.Uid != nil {
+		return *x.Uid
+	}
+	return ""
+}
+
+func (x *Event) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Event) GetTimeReceived() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeReceived
+	}
+	return nil
+}
+
+func (x *Event) GetData() *AttachedText {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetSource() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Event) GetTarget() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Event) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Type that represents various input data types and formats.
+type InputType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A reference to the component or service that provided the input to the task.
+	Source *ResourceReferenceChoice `protobuf:"bytes,1,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// A reference to the component or service that received or stored the input if not the task itself.
+	Target *ResourceReferenceChoice `protobuf:"bytes,2,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	// A reference to an independent resource provided as an input to a task by the workflow runtime.
+	Resource *ResourceReferenceChoice `protobuf:"bytes,3,opt,name=resource,proto3,oneof" json:"resource,omitempty"`
+	// Inputs that have the form of parameters with names and values.
+	Parameters []*Parameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Inputs that have the form of environment variables.
+	EnvironmentVars []*EnvironmentVars `protobuf:"bytes,5,rep,name=environmentVars,proto3" json:"environmentVars,omitempty"`
+	// Inputs that have the form of data.
+	Data *AttachedText `protobuf:"bytes,6,opt,name=data,proto3,oneof" json:"data,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputType) Reset() {
+	*x = InputType{}
+	mi := &file_bom_1_6_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputType) ProtoMessage() {}
+
+func (x *InputType) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputType.ProtoReflect.Descriptor instead.
+func (*InputType) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *InputType) GetSource() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *InputType) GetTarget() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *InputType) GetResource() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *InputType) GetParameters() []*Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *InputType) GetEnvironmentVars() []*EnvironmentVars {
+	if x != nil {
+		return x.EnvironmentVars
+	}
+	return nil
+}
+
+func (x *InputType) GetData() *AttachedText {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *InputType) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Type that represents various output data types and formats.
+type OutputType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Describes the type of data output.
+	Type *OutputType_OutputTypeType `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.OutputType_OutputTypeType,oneof" json:"type,omitempty"`
+	// Component or service that generated or provided the output from the task.
+	Source *ResourceReferenceChoice `protobuf:"bytes,2,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	// Component or service that received the output from the task.
+	Target *ResourceReferenceChoice `protobuf:"bytes,3,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	// A reference to an independent resource generated as output by the task.
+	Resource *ResourceReferenceChoice `protobuf:"bytes,4,opt,name=resource,proto3,oneof" json:"resource,omitempty"`
+	// Outputs that have the form of data.
+	Data *AttachedText `protobuf:"bytes,5,opt,name=data,proto3,oneof" json:"data,omitempty"`
+	// Outputs that have the form of environment variables.
+	EnvironmentVars []*EnvironmentVars `protobuf:"bytes,6,rep,name=environmentVars,proto3" json:"environmentVars,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputType) Reset() {
+	*x = OutputType{}
+	mi := &file_bom_1_6_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputType) ProtoMessage() {}
+
+func (x *OutputType) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputType.ProtoReflect.Descriptor instead.
+func (*OutputType) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *OutputType) GetType() OutputType_OutputTypeType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return OutputType_OUTPUT_TYPE_ARTIFACT
+}
+
+func (x *OutputType) GetSource() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *OutputType) GetTarget() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *OutputType) GetResource() *ResourceReferenceChoice {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *OutputType) GetData() *AttachedText {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OutputType) GetEnvironmentVars() []*EnvironmentVars {
+	if x != nil {
+		return x.EnvironmentVars
+	}
+	return nil
+}
+
+func (x *OutputType) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// A reference to a locally defined resource (e.g., a bom-ref) or an externally accessible resource.
+type ResourceReferenceChoice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*ResourceReferenceChoice_Ref
+	//	*ResourceReferenceChoice_ExternalReference
+	Choice        isResourceReferenceChoice_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceReferenceChoice) Reset() {
+	*x = ResourceReferenceChoice{}
+	mi := &file_bom_1_6_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceReferenceChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceReferenceChoice) ProtoMessage() {}
+
+func (x *ResourceReferenceChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceReferenceChoice.ProtoReflect.Descriptor instead.
+func (*ResourceReferenceChoice) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ResourceReferenceChoice) GetChoice() isResourceReferenceChoice_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *ResourceReferenceChoice) GetRef() string {
+	if x != nil {
+		if x, ok := x.Choice.(*ResourceReferenceChoice_Ref); ok {
+			return x.Ref
+		}
+	}
+	return ""
+}
+
+func (x *ResourceReferenceChoice) GetExternalReference() *ExternalReference {
+	if x != nil {
+		if x, ok := x.Choice.(*ResourceReferenceChoice_ExternalReference); ok {
+			return x.ExternalReference
+		}
+	}
+	return nil
+}
+
+type isResourceReferenceChoice_Choice interface {
+	isResourceReferenceChoice_Choice()
+}
+
+type ResourceReferenceChoice_Ref struct {
+	// References an object by its bom-ref attribute.
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3,oneof"`
+}
+
+type ResourceReferenceChoice_ExternalReference struct {
+	// Reference to an externally accessible resource.
+	ExternalReference *ExternalReference `protobuf:"bytes,2,opt,name=externalReference,proto3,oneof"`
+}
+
+func (*ResourceReferenceChoice_Ref) isResourceReferenceChoice_Choice() {}
+
+func (*ResourceReferenceChoice_ExternalReference) isResourceReferenceChoice_Choice() {}
+
+// A condition that was used to determine a trigger should be activated.
+type Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Describes the set of conditions which cause the trigger to activate.
+	Description *string `protobuf:"bytes,1,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The logical expression that was evaluated that determined the trigger should be fired.
+	Expression *string `protobuf:"bytes,2,opt,name=expression,proto3,oneof" json:"expression,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	mi := &file_bom_1_6_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Condition) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Condition) GetExpression() string {
+	if x != nil && x.Expression != nil {
+		return *x.Expression
+	}
+	return ""
+}
+
+func (x *Condition) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// A representation of a functional parameter.
+type Parameter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the parameter.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The value of the parameter.
+	Value *string `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	// The data type of the parameter.
+	DataType      *string `protobuf:"bytes,3,opt,name=dataType,proto3,oneof" json:"dataType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parameter) Reset() {
+	*x = Parameter{}
+	mi := &file_bom_1_6_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameter) ProtoMessage() {}
+
+func (x *Parameter) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
+func (*Parameter) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Parameter) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Parameter) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *Parameter) GetDataType() string {
+	if x != nil && x.DataType != nil {
+		return *x.DataType
+	}
+	return ""
+}
+
+type EnvironmentVars struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*EnvironmentVars_Property
+	//	*EnvironmentVars_Value
+	Choice        isEnvironmentVars_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvironmentVars) Reset() {
+	*x = EnvironmentVars{}
+	mi := &file_bom_1_6_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentVars) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentVars) ProtoMessage() {}
+
+func (x *EnvironmentVars) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentVars.ProtoReflect.Descriptor instead.
+func (*EnvironmentVars) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *EnvironmentVars) GetChoice() isEnvironmentVars_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *EnvironmentVars) GetProperty() *Property {
+	if x != nil {
+		if x, ok := x.Choice.(*EnvironmentVars_Property); ok {
+			return x.Property
+		}
+	}
+	return nil
+}
+
+func (x *EnvironmentVars) GetValue() string {
+	if x != nil {
+		if x, ok := x.Choice.(*EnvironmentVars_Value); ok {
+			return x.Value
+		}
+	}
+	return ""
+}
+
+type isEnvironmentVars_Choice interface {
+	isEnvironmentVars_Choice()
+}
+
+type EnvironmentVars_Property struct {
+	// A name-value pair.
+	Property *Property `protobuf:"bytes,1,opt,name=property,proto3,oneof"`
+}
+
+type EnvironmentVars_Value struct {
+	// A value without a name.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3,oneof"`
+}
+
+func (*EnvironmentVars_Property) isEnvironmentVars_Choice() {}
+
+func (*EnvironmentVars_Value) isEnvironmentVars_Choice() {}
+
+type ComponentData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the dataset elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The general theme or subject matter of the data being specified.
+	Type ComponentDataType `protobuf:"varint,2,opt,name=type,proto3,enum=cyclonedx.v1_6.ComponentDataType" json:"type,omitempty"`
+	// The name of the dataset.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The contents or references to the contents of the data being described.
+	Contents *ComponentData_ComponentDataContents `protobuf:"bytes,4,opt,name=contents,proto3,oneof" json:"contents,omitempty"`
+	// Data classification tags data according to its type, sensitivity, and value if altered, stolen, or destroyed.
+	Classification *string `protobuf:"bytes,5,opt,name=classification,proto3,oneof" json:"classification,omitempty"`
+	// A description of any sensitive data in a dataset.
+	SensitiveData []string `protobuf:"bytes,6,rep,name=sensitiveData,proto3" json:"sensitiveData,omitempty"`
+	// A collection of graphics that represent various measurements.
+	Graphics *GraphicsCollection `protobuf:"bytes,7,opt,name=graphics,proto3,oneof" json:"graphics,omitempty"`
+	// A description of the dataset.
+	Description *string `protobuf:"bytes,8,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Data governance captures information regarding data ownership, stewardship, and custodianship.
+	Governance    *DataGovernance `protobuf:"bytes,9,opt,name=governance,proto3,oneof" json:"governance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentData) Reset() {
+	*x = ComponentData{}
+	mi := &file_bom_1_6_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentData) ProtoMessage() {}
+
+func (x *ComponentData) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentData.ProtoReflect.Descriptor instead.
+func (*ComponentData) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ComponentData) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *ComponentData) GetType() ComponentDataType {
+	if x != nil {
+		return x.Type
+	}
+	return ComponentDataType_COMPONENT_DATA_TYPE_SOURCE_CODE
+}
+
+func (x *ComponentData) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ComponentData) GetContents() *ComponentData_ComponentDataContents {
+	if x != nil {
+		return x.Contents
+	}
+	return nil
+}
+
+func (x *ComponentData) GetClassification() string {
+	if x != nil && x.Classification != nil {
+		return *x.Classification
+	}
+	return ""
+}
+
+func (x *ComponentData) GetSensitiveData() []string {
+	if x != nil {
+		return x.SensitiveData
+	}
+	return nil
+}
+
+func (x *ComponentData) GetGraphics() *GraphicsCollection {
+	if x != nil {
+		return x.Graphics
+	}
+	return nil
+}
+
+func (x *ComponentData) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ComponentData) GetGovernance() *DataGovernance {
+	if x != nil {
+		return x.Governance
+	}
+	return nil
+}
+
+type DataGovernance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Data custodians are responsible for the safe custody, transport, and storage of data.
+	Custodians []*DataGovernance_DataGovernanceResponsibleParty `protobuf:"bytes,1,rep,name=custodians,proto3" json:"custodians,omitempty"`
+	// Data stewards are responsible for data content, context, and associated business rules.
+	Stewards []*DataGovernance_DataGovernanceResponsibleParty `protobuf:"bytes,2,rep,name=stewards,proto3" json:"stewards,omitempty"`
+	// Data owners are concerned with risk and appropriate access to data.
+	Owners        []*DataGovernance_DataGovernanceResponsibleParty `protobuf:"bytes,3,rep,name=owners,proto3" json:"owners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataGovernance) Reset() {
+	*x = DataGovernance{}
+	mi := &file_bom_1_6_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataGovernance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataGovernance) ProtoMessage() {}
+
+func (x *DataGovernance) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataGovernance.ProtoReflect.Descriptor instead.
+func (*DataGovernance) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DataGovernance) GetCustodians() []*DataGovernance_DataGovernanceResponsibleParty {
+	if x != nil {
+		return x.Custodians
+	}
+	return nil
+}
+
+func (x *DataGovernance) GetStewards() []*DataGovernance_DataGovernanceResponsibleParty {
+	if x != nil {
+		return x.Stewards
+	}
+	return nil
+}
+
+func (x *DataGovernance) GetOwners() []*DataGovernance_DataGovernanceResponsibleParty {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+type GraphicsCollection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A description of this collection of graphics.
+	Description *string `protobuf:"bytes,1,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// A collection of graphics.
+	Collection    []*GraphicsCollection_Graphic `protobuf:"bytes,2,rep,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphicsCollection) Reset() {
+	*x = GraphicsCollection{}
+	mi := &file_bom_1_6_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphicsCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphicsCollection) ProtoMessage() {}
+
+func (x *GraphicsCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphicsCollection.ProtoReflect.Descriptor instead.
+func (*GraphicsCollection) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GraphicsCollection) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *GraphicsCollection) GetCollection() []*GraphicsCollection_Graphic {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type ModelCard struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the model card elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// Hyper-parameters for construction of the model.
+	ModelParameters *ModelCard_ModelParameters `protobuf:"bytes,2,opt,name=modelParameters,proto3,oneof" json:"modelParameters,omitempty"`
+	// A quantitative analysis of the model.
+	QuantitativeAnalysis *ModelCard_QuantitativeAnalysis `protobuf:"bytes,3,opt,name=quantitativeAnalysis,proto3,oneof" json:"quantitativeAnalysis,omitempty"`
+	// What considerations should be taken into account regarding the model's construction, training, and application?
+	Considerations *ModelCard_ModelCardConsiderations `protobuf:"bytes,4,opt,name=considerations,proto3,oneof" json:"considerations,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ModelCard) Reset() {
+	*x = ModelCard{}
+	mi := &file_bom_1_6_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard) ProtoMessage() {}
+
+func (x *ModelCard) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard.ProtoReflect.Descriptor instead.
+func (*ModelCard) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ModelCard) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *ModelCard) GetModelParameters() *ModelCard_ModelParameters {
+	if x != nil {
+		return x.ModelParameters
+	}
+	return nil
+}
+
+func (x *ModelCard) GetQuantitativeAnalysis() *ModelCard_QuantitativeAnalysis {
+	if x != nil {
+		return x.QuantitativeAnalysis
+	}
+	return nil
+}
+
+func (x *ModelCard) GetConsiderations() *ModelCard_ModelCardConsiderations {
+	if x != nil {
+		return x.Considerations
+	}
+	return nil
+}
+
+type CryptoProperties struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cryptographic assets occur in several forms: algorithms, certificates, protocols and related material.
+	AssetType CryptoProperties_CryptoAssetType `protobuf:"varint,1,opt,name=assetType,proto3,enum=cyclonedx.v1_6.CryptoProperties_CryptoAssetType" json:"assetType,omitempty"`
+	// Additional properties specific to a cryptographic algorithm.
+	AlgorithmProperties *CryptoProperties_AlgorithmProperties `protobuf:"bytes,2,opt,name=algorithmProperties,proto3,oneof" json:"algorithmProperties,omitempty"`
+	// Properties for cryptographic assets of asset type 'certificate'.
+	CertificateProperties *CryptoProperties_CertificateProperties `protobuf:"bytes,3,opt,name=certificateProperties,proto3,oneof" json:"certificateProperties,omitempty"`
+	// Properties for cryptographic assets of asset type 'related-crypto-material'.
+	RelatedCryptoMaterialProperties *CryptoProperties_RelatedCryptoMaterialProperties `protobuf:"bytes,4,opt,name=relatedCryptoMaterialProperties,proto3,oneof" json:"relatedCryptoMaterialProperties,omitempty"`
+	// Properties specific to cryptographic assets of type 'protocol'.
+	ProtocolProperties *CryptoProperties_ProtocolProperties `protobuf:"bytes,5,opt,name=protocolProperties,proto3,oneof" json:"protocolProperties,omitempty"`
+	// The object identifier (OID) of the cryptographic asset.
+	Oid           *string `protobuf:"bytes,6,opt,name=oid,proto3,oneof" json:"oid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CryptoProperties) Reset() {
+	*x = CryptoProperties{}
+	mi := &file_bom_1_6_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties) ProtoMessage() {}
+
+func (x *CryptoProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties.ProtoReflect.Descriptor instead.
+func (*CryptoProperties) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CryptoProperties) GetAssetType() CryptoProperties_CryptoAssetType {
+	if x != nil {
+		return x.AssetType
+	}
+	return CryptoProperties_CRYPTO_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *CryptoProperties) GetAlgorithmProperties() *CryptoProperties_AlgorithmProperties {
+	if x != nil {
+		return x.AlgorithmProperties
+	}
+	return nil
+}
+
+func (x *CryptoProperties) GetCertificateProperties() *CryptoProperties_CertificateProperties {
+	if x != nil {
+		return x.CertificateProperties
+	}
+	return nil
+}
+
+func (x *CryptoProperties) GetRelatedCryptoMaterialProperties() *CryptoProperties_RelatedCryptoMaterialProperties {
+	if x != nil {
+		return x.RelatedCryptoMaterialProperties
+	}
+	return nil
+}
+
+func (x *CryptoProperties) GetProtocolProperties() *CryptoProperties_ProtocolProperties {
+	if x != nil {
+		return x.ProtocolProperties
+	}
+	return nil
+}
+
+func (x *CryptoProperties) GetOid() string {
+	if x != nil && x.Oid != nil {
+		return *x.Oid
+	}
+	return ""
+}
+
+type Declarations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of assessors evaluating claims and determining conformance to requirements and confidence in that assessment.
+	Assessors []*Declarations_Assessor `protobuf:"bytes,1,rep,name=assessors,proto3" json:"assessors,omitempty"`
+	// The list of attestations asserted by an assessor that maps requirements to claims.
+	Attestations []*Declarations_Attestation `protobuf:"bytes,2,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	// The list of claims.
+	Claims []*Declarations_Claim `protobuf:"bytes,3,rep,name=claims,proto3" json:"claims,omitempty"`
+	// The list of evidence.
+	Evidence []*Declarations_Evidence `protobuf:"bytes,4,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	// The list of targets which claims are made against.
+	Targets *Declarations_Targets `protobuf:"bytes,5,opt,name=targets,proto3,oneof" json:"targets,omitempty"`
+	// A concise statement affirmed by an individual regarding all declarations, often used for third-party auditor acceptance or recipient acknowledgment.
+	Affirmation   *Declarations_Affirmation `protobuf:"bytes,6,opt,name=affirmation,proto3,oneof" json:"affirmation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations) Reset() {
+	*x = Declarations{}
+	mi := &file_bom_1_6_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations) ProtoMessage() {}
+
+func (x *Declarations) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations.ProtoReflect.Descriptor instead.
+func (*Declarations) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *Declarations) GetAssessors() []*Declarations_Assessor {
+	if x != nil {
+		return x.Assessors
+	}
+	return nil
+}
+
+func (x *Declarations) GetAttestations() []*Declarations_Attestation {
+	if x != nil {
+		return x.Attestations
+	}
+	return nil
+}
+
+func (x *Declarations) GetClaims() []*Declarations_Claim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *Declarations) GetEvidence() []*Declarations_Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Declarations) GetTargets() *Declarations_Targets {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Declarations) GetAffirmation() *Declarations_Affirmation {
+	if x != nil {
+		return x.Affirmation
+	}
+	return nil
+}
+
+type Definition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of standards which may consist of regulations, industry or organizational-specific standards, maturity models, best practices, or any other requirements.
+	Standards     []*Definition_Standard `protobuf:"bytes,1,rep,name=standards,proto3" json:"standards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Definition) Reset() {
+	*x = Definition{}
+	mi := &file_bom_1_6_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Definition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition) ProtoMessage() {}
+
+func (x *Definition) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition.ProtoReflect.Descriptor instead.
+func (*Definition) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *Definition) GetStandards() []*Definition_Standard {
+	if x != nil {
+		return x.Standards
+	}
+	return nil
+}
+
+type Licensing_OrganizationalEntityOrContact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Licensing_OrganizationalEntityOrContact_Organization
+	//	*Licensing_OrganizationalEntityOrContact_Individual
+	Choice        isLicensing_OrganizationalEntityOrContact_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Licensing_OrganizationalEntityOrContact) Reset() {
+	*x = Licensing_OrganizationalEntityOrContact{}
+	mi := &file_bom_1_6_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Licensing_OrganizationalEntityOrContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Licensing_OrganizationalEntityOrContact) ProtoMessage() {}
+
+func (x *Licensing_OrganizationalEntityOrContact) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Licensing_OrganizationalEntityOrContact.ProtoReflect.Descriptor instead.
+func (*Licensing_OrganizationalEntityOrContact) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *Licensing_OrganizationalEntityOrContact) GetChoice() isLicensing_OrganizationalEntityOrContact_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Licensing_OrganizationalEntityOrContact) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		if x, ok := x.Choice.(*Licensing_OrganizationalEntityOrContact_Organization); ok {
+			return x.Organization
+		}
+	}
+	return nil
+}
+
+func (x *Licensing_OrganizationalEntityOrContact) GetIndividual() *OrganizationalContact {
+	if x != nil {
+		if x, ok := x.Choice.(*Licensing_OrganizationalEntityOrContact_Individual); ok {
+			return x.Individual
+		}
+	}
+	return nil
+}
+
+type isLicensing_OrganizationalEntityOrContact_Choice interface {
+	isLicensing_OrganizationalEntityOrContact_Choice()
+}
+
+type Licensing_OrganizationalEntityOrContact_Organization struct {
+	Organization *OrganizationalEntity `protobuf:"bytes,1,opt,name=organization,proto3,oneof"`
+}
+
+type Licensing_OrganizationalEntityOrContact_Individual struct {
+	Individual *OrganizationalContact `protobuf:"bytes,2,opt,name=individual,proto3,oneof"`
+}
+
+func (*Licensing_OrganizationalEntityOrContact_Organization) isLicensing_OrganizationalEntityOrContact_Choice() {
+}
+
+func (*Licensing_OrganizationalEntityOrContact_Individual) isLicensing_OrganizationalEntityOrContact_Choice() {
+}
+
+type Callstack_Frames struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A package organizes modules into namespaces.
+	Package *string `protobuf:"bytes,1,opt,name=package,proto3,oneof" json:"package,omitempty"`
+	// A module or class that encloses functions/methods and other code.
+	Module string `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	// A block of code designed to perform a particular task.
+	Function *string `protobuf:"bytes,3,opt,name=function,proto3,oneof" json:"function,omitempty"`
+	// Optional arguments that are passed to the module or function.
+	Parameters []string `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// The line number the code that is called resides on.
+	Line *int32 `protobuf:"varint,5,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	// The column the code that is called resides.
+	Column *int32 `protobuf:"varint,6,opt,name=column,proto3,oneof" json:"column,omitempty"`
+	// The full path and filename of the module.
+	FullFilename  *string `protobuf:"bytes,7,opt,name=fullFilename,proto3,oneof" json:"fullFilename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Callstack_Frames) Reset() {
+	*x = Callstack_Frames{}
+	mi := &file_bom_1_6_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Callstack_Frames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Callstack_Frames) ProtoMessage() {}
+
+func (x *Callstack_Frames) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Callstack_Frames.ProtoReflect.Descriptor instead.
+func (*Callstack_Frames) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{32, 0}
+}
+
+func (x *Callstack_Frames) GetPackage() string {
+	if x != nil && x.Package != nil {
+		return *x.Package
+	}
+	return ""
+}
+
+func (x *Callstack_Frames) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *Callstack_Frames) GetFunction() string {
+	if x != nil && x.Function != nil {
+		return *x.Function
+	}
+	return ""
+}
+
+func (x *Callstack_Frames) GetParameters() []string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Callstack_Frames) GetLine() int32 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *Callstack_Frames) GetColumn() int32 {
+	if x != nil && x.Column != nil {
+		return *x.Column
+	}
+	return 0
+}
+
+func (x *Callstack_Frames) GetFullFilename() string {
+	if x != nil && x.FullFilename != nil {
+		return *x.FullFilename
+	}
+	return ""
+}
+
+type ReleaseNotes_Note struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ISO-639 (or higher) language code and optional ISO-3166 (or higher) country code.
+	Locale *string `protobuf:"bytes,1,opt,name=locale,proto3,oneof" json:"locale,omitempty"`
+	// Specifies the full content of the release note.
+	Text          *AttachedText `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseNotes_Note) Reset() {
+	*x = ReleaseNotes_Note{}
+	mi := &file_bom_1_6_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseNotes_Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseNotes_Note) ProtoMessage() {}
+
+func (x *ReleaseNotes_Note) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseNotes_Note.ProtoReflect.Descriptor instead.
+func (*ReleaseNotes_Note) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{33, 0}
+}
+
+func (x *ReleaseNotes_Note) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return ""
+}
+
+func (x *ReleaseNotes_Note) GetText() *AttachedText {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+type Annotation_Annotator struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Annotation_Annotator_Organization
+	//	*Annotation_Annotator_Individual
+	//	*Annotation_Annotator_Component
+	//	*Annotation_Annotator_Service
+	Choice        isAnnotation_Annotator_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Annotation_Annotator) Reset() {
+	*x = Annotation_Annotator{}
+	mi := &file_bom_1_6_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation_Annotator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation_Annotator) ProtoMessage() {}
+
+func (x *Annotation_Annotator) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation_Annotator.ProtoReflect.Descriptor instead.
+func (*Annotation_Annotator) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{43, 0}
+}
+
+func (x *Annotation_Annotator) GetChoice() isAnnotation_Annotator_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Annotation_Annotator) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		if x, ok := x.Choice.(*Annotation_Annotator_Organization); ok {
+			return x.Organization
+		}
+	}
+	return nil
+}
+
+func (x *Annotation_Annotator) GetIndividual() *OrganizationalContact {
+	if x != nil {
+		if x, ok := x.Choice.(*Annotation_Annotator_Individual); ok {
+			return x.Individual
+		}
+	}
+	return nil
+}
+
+func (x *Annotation_Annotator) GetComponent() *Component {
+	if x != nil {
+		if x, ok := x.Choice.(*Annotation_Annotator_Component); ok {
+			return x.Component
+		}
+	}
+	return nil
+}
+
+func (x *Annotation_Annotator) GetService() *Service {
+	if x != nil {
+		if x, ok := x.Choice.(*Annotation_Annotator_Service); ok {
+			return x.Service
+		}
+	}
+	return nil
+}
+
+type isAnnotation_Annotator_Choice interface {
+	isAnnotation_Annotator_Choice()
+}
+
+type Annotation_Annotator_Organization struct {
+	// The organization that created the annotation.
+	Organization *OrganizationalEntity `protobuf:"bytes,1,opt,name=organization,proto3,oneof"`
+}
+
+type Annotation_Annotator_Individual struct {
+	// The person that created the annotation.
+	Individual *OrganizationalContact `protobuf:"bytes,2,opt,name=individual,proto3,oneof"`
+}
+
+type Annotation_Annotator_Component struct {
+	// The tool or component that created the annotation.
+	Component *Component `protobuf:"bytes,3,opt,name=component,proto3,oneof"`
+}
+
+type Annotation_Annotator_Service struct {
+	// The service that created the annotation.
+	Service *Service `protobuf:"bytes,4,opt,name=service,proto3,oneof"`
+}
+
+func (*Annotation_Annotator_Organization) isAnnotation_Annotator_Choice() {}
+
+func (*Annotation_Annotator_Individual) isAnnotation_Annotator_Choice() {}
+
+func (*Annotation_Annotator_Component) isAnnotation_Annotator_Choice() {}
+
+func (*Annotation_Annotator_Service) isAnnotation_Annotator_Choice() {}
+
+type ComponentData_ComponentDataContents struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional way to include textual or encoded data.
+	Attachment *AttachedText `protobuf:"bytes,1,opt,name=attachment,proto3,oneof" json:"attachment,omitempty"`
+	// The URL to where the data can be retrieved.
+	Url *string `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	// Provides the ability to document name-value parameters used for configuration.
+	Properties    []*Property `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentData_ComponentDataContents) Reset() {
+	*x = ComponentData_ComponentDataContents{}
+	mi := &file_bom_1_6_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentData_ComponentDataContents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentData_ComponentDataContents) ProtoMessage() {}
+
+func (x *ComponentData_ComponentDataContents) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentData_ComponentDataContents.ProtoReflect.Descriptor instead.
+func (*ComponentData_ComponentDataContents) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{59, 0}
+}
+
+func (x *ComponentData_ComponentDataContents) GetAttachment() *AttachedText {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *ComponentData_ComponentDataContents) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *ComponentData_ComponentDataContents) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type DataGovernance_DataGovernanceResponsibleParty struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*DataGovernance_DataGovernanceResponsibleParty_Organization
+	//	*DataGovernance_DataGovernanceResponsibleParty_Contact
+	Choice        isDataGovernance_DataGovernanceResponsibleParty_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) Reset() {
+	*x = DataGovernance_DataGovernanceResponsibleParty{}
+	mi := &file_bom_1_6_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataGovernance_DataGovernanceResponsibleParty) ProtoMessage() {}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataGovernance_DataGovernanceResponsibleParty.ProtoReflect.Descriptor instead.
+func (*DataGovernance_DataGovernanceResponsibleParty) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{60, 0}
+}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) GetChoice() isDataGovernance_DataGovernanceResponsibleParty_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		if x, ok := x.Choice.(*DataGovernance_DataGovernanceResponsibleParty_Organization); ok {
+			return x.Organization
+		}
+	}
+	return nil
+}
+
+func (x *DataGovernance_DataGovernanceResponsibleParty) GetContact() *OrganizationalContact {
+	if x != nil {
+		if x, ok := x.Choice.(*DataGovernance_DataGovernanceResponsibleParty_Contact); ok {
+			return x.Contact
+		}
+	}
+	return nil
+}
+
+type isDataGovernance_DataGovernanceResponsibleParty_Choice interface {
+	isDataGovernance_DataGovernanceResponsibleParty_Choice()
+}
+
+type DataGovernance_DataGovernanceResponsibleParty_Organization struct {
+	// The organization that is responsible for specific data governance role(s).
+	Organization *OrganizationalEntity `protobuf:"bytes,1,opt,name=organization,proto3,oneof"`
+}
+
+type DataGovernance_DataGovernanceResponsibleParty_Contact struct {
+	// The individual that is responsible for specific data governance role(s).
+	Contact *OrganizationalContact `protobuf:"bytes,2,opt,name=contact,proto3,oneof"`
+}
+
+func (*DataGovernance_DataGovernanceResponsibleParty_Organization) isDataGovernance_DataGovernanceResponsibleParty_Choice() {
+}
+
+func (*DataGovernance_DataGovernanceResponsibleParty_Contact) isDataGovernance_DataGovernanceResponsibleParty_Choice() {
+}
+
+type GraphicsCollection_Graphic struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the graphic.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The graphic (vector or raster). Base64 encoding must be specified for binary images.
+	Image         *AttachedText `protobuf:"bytes,2,opt,name=image,proto3,oneof" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphicsCollection_Graphic) Reset() {
+	*x = GraphicsCollection_Graphic{}
+	mi := &file_bom_1_6_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphicsCollection_Graphic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphicsCollection_Graphic) ProtoMessage() {}
+
+func (x *GraphicsCollection_Graphic) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphicsCollection_Graphic.ProtoReflect.Descriptor instead.
+func (*GraphicsCollection_Graphic) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{61, 0}
+}
+
+func (x *GraphicsCollection_Graphic) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *GraphicsCollection_Graphic) GetImage() *AttachedText {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type ModelCard_ModelParameters struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The overall approach to learning used by the model for problem solving.
+	Approach *ModelCard_ModelParameters_Approach `protobuf:"bytes,1,opt,name=approach,proto3,oneof" json:"approach,omitempty"`
+	// Directly influences the input and/or output. Examples include classification, regression, clustering, etc.
+	Task *string `protobuf:"bytes,2,opt,name=task,proto3,oneof" json:"task,omitempty"`
+	// The model architecture family such as transformer network, convolutional neural network, etc.
+	ArchitectureFamily *string `protobuf:"bytes,3,opt,name=architectureFamily,proto3,oneof" json:"architectureFamily,omitempty"`
+	// The specific architecture of the model such as GPT-1, ResNet-50, YOLOv3, etc.
+	ModelArchitecture *string `protobuf:"bytes,4,opt,name=modelArchitecture,proto3,oneof" json:"modelArchitecture,omitempty"`
+	// The datasets used to train and evaluate the model.
+	Datasets []*ModelCard_ModelParameters_Datasets `protobuf:"bytes,5,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	// The input format(s) of the model.
+	Inputs []*ModelCard_ModelParameters_MachineLearningInputOutputParameters `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The output format(s) from the model.
+	Outputs       []*ModelCard_ModelParameters_MachineLearningInputOutputParameters `protobuf:"bytes,7,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelParameters) Reset() {
+	*x = ModelCard_ModelParameters{}
+	mi := &file_bom_1_6_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelParameters) ProtoMessage() {}
+
+func (x *ModelCard_ModelParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelParameters.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelParameters) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 0}
+}
+
+func (x *ModelCard_ModelParameters) GetApproach() *ModelCard_ModelParameters_Approach {
+	if x != nil {
+		return x.Approach
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelParameters) GetTask() string {
+	if x != nil && x.Task != nil {
+		return *x.Task
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelParameters) GetArchitectureFamily() string {
+	if x != nil && x.ArchitectureFamily != nil {
+		return *x.ArchitectureFamily
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelParameters) GetModelArchitecture() string {
+	if x != nil && x.ModelArchitecture != nil {
+		return *x.ModelArchitecture
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelParameters) GetDatasets() []*ModelCard_ModelParameters_Datasets {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelParameters) GetInputs() []*ModelCard_ModelParameters_MachineLearningInputOutputParameters {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelParameters) GetOutputs() []*ModelCard_ModelParameters_MachineLearningInputOutputParameters {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type ModelCard_QuantitativeAnalysis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The model performance metrics being reported.
+	PerformanceMetrics []*ModelCard_QuantitativeAnalysis_PerformanceMetrics `protobuf:"bytes,1,rep,name=performanceMetrics,proto3" json:"performanceMetrics,omitempty"`
+	// A collection of graphics that represent various measurements.
+	Graphics      *GraphicsCollection `protobuf:"bytes,2,opt,name=graphics,proto3,oneof" json:"graphics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_QuantitativeAnalysis) Reset() {
+	*x = ModelCard_QuantitativeAnalysis{}
+	mi := &file_bom_1_6_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_QuantitativeAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_QuantitativeAnalysis) ProtoMessage() {}
+
+func (x *ModelCard_QuantitativeAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_QuantitativeAnalysis.ProtoReflect.Descriptor instead.
+func (*ModelCard_QuantitativeAnalysis) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 1}
+}
+
+func (x *ModelCard_QuantitativeAnalysis) GetPerformanceMetrics() []*ModelCard_QuantitativeAnalysis_PerformanceMetrics {
+	if x != nil {
+		return x.PerformanceMetrics
+	}
+	return nil
+}
+
+func (x *ModelCard_QuantitativeAnalysis) GetGraphics() *GraphicsCollection {
+	if x != nil {
+		return x.Graphics
+	}
+	return nil
+}
+
+type ModelCard_ModelCardConsiderations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Who are the intended users of the model?
+	Users []string `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// What are the intended use cases of the model?
+	UseCases []string `protobuf:"bytes,2,rep,name=useCases,proto3" json:"useCases,omitempty"`
+	// What are the known technical limitations of the model?
+	TechnicalLimitations []string `protobuf:"bytes,3,rep,name=technicalLimitations,proto3" json:"technicalLimitations,omitempty"`
+	// What are the known tradeoffs in accuracy/performance of the model?
+	PerformanceTradeoffs []string `protobuf:"bytes,4,rep,name=performanceTradeoffs,proto3" json:"performanceTradeoffs,omitempty"`
+	// What are the ethical risks involved in the application of this model?
+	EthicalConsiderations []*ModelCard_ModelCardConsiderations_EthicalConsiderations `protobuf:"bytes,5,rep,name=ethicalConsiderations,proto3" json:"ethicalConsiderations,omitempty"`
+	// How does the model affect groups at risk of being systematically disadvantaged?
+	FairnessAssessments []*ModelCard_ModelCardConsiderations_FairnessAssessments `protobuf:"bytes,6,rep,name=fairnessAssessments,proto3" json:"fairnessAssessments,omitempty"`
+	// What are the various environmental impacts the corresponding machine learning model has exhibited across its lifecycle?
+	EnvironmentalConsiderations *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations `protobuf:"bytes,7,opt,name=environmentalConsiderations,proto3,oneof" json:"environmentalConsiderations,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations) Reset() {
+	*x = ModelCard_ModelCardConsiderations{}
+	mi := &file_bom_1_6_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2}
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetUseCases() []string {
+	if x != nil {
+		return x.UseCases
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetTechnicalLimitations() []string {
+	if x != nil {
+		return x.TechnicalLimitations
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetPerformanceTradeoffs() []string {
+	if x != nil {
+		return x.PerformanceTradeoffs
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetEthicalConsiderations() []*ModelCard_ModelCardConsiderations_EthicalConsiderations {
+	if x != nil {
+		return x.EthicalConsiderations
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetFairnessAssessments() []*ModelCard_ModelCardConsiderations_FairnessAssessments {
+	if x != nil {
+		return x.FairnessAssessments
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations) GetEnvironmentalConsiderations() *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations {
+	if x != nil {
+		return x.EnvironmentalConsiderations
+	}
+	return nil
+}
+
+type ModelCard_ModelParameters_Approach struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Type          *ModelParameterApproachType `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.ModelParameterApproachType,oneof" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelParameters_Approach) Reset() {
+	*x = ModelCard_ModelParameters_Approach{}
+	mi := &file_bom_1_6_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelParameters_Approach) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelParameters_Approach) ProtoMessage() {}
+
+func (x *ModelCard_ModelParameters_Approach) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelParameters_Approach.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelParameters_Approach) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 0, 0}
+}
+
+func (x *ModelCard_ModelParameters_Approach) GetType() ModelParameterApproachType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ModelParameterApproachType_MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED
+}
+
+type ModelCard_ModelParameters_Datasets struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*ModelCard_ModelParameters_Datasets_Dataset
+	//	*ModelCard_ModelParameters_Datasets_Ref
+	Choice        isModelCard_ModelParameters_Datasets_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelParameters_Datasets) Reset() {
+	*x = ModelCard_ModelParameters_Datasets{}
+	mi := &file_bom_1_6_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelParameters_Datasets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelParameters_Datasets) ProtoMessage() {}
+
+func (x *ModelCard_ModelParameters_Datasets) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelParameters_Datasets.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelParameters_Datasets) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 0, 1}
+}
+
+func (x *ModelCard_ModelParameters_Datasets) GetChoice() isModelCard_ModelParameters_Datasets_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelParameters_Datasets) GetDataset() *ComponentData {
+	if x != nil {
+		if x, ok := x.Choice.(*ModelCard_ModelParameters_Datasets_Dataset); ok {
+			return x.Dataset
+		}
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelParameters_Datasets) GetRef() string {
+	if x != nil {
+		if x, ok := x.Choice.(*ModelCard_ModelParameters_Datasets_Ref); ok {
+			return x.Ref
+		}
+	}
+	return ""
+}
+
+type isModelCard_ModelParameters_Datasets_Choice interface {
+	isModelCard_ModelParameters_Datasets_Choice()
+}
+
+type ModelCard_ModelParameters_Datasets_Dataset struct {
+	// Inline data information.
+	Dataset *ComponentData `protobuf:"bytes,1,opt,name=dataset,proto3,oneof"`
+}
+
+type ModelCard_ModelParameters_Datasets_Ref struct {
+	// References a data component by the components bom-ref attribute.
+	Ref string `protobuf:"bytes,2,opt,name=ref,proto3,oneof"`
+}
+
+func (*ModelCard_ModelParameters_Datasets_Dataset) isModelCard_ModelParameters_Datasets_Choice() {}
+
+func (*ModelCard_ModelParameters_Datasets_Ref) isModelCard_ModelParameters_Datasets_Choice() {}
+
+type ModelCard_ModelParameters_MachineLearningInputOutputParameters struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The data format for input/output to the model.
+	Format        *string `protobuf:"bytes,1,opt,name=format,proto3,oneof" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelParameters_MachineLearningInputOutputParameters) Reset() {
+	*x = ModelCard_ModelParameters_MachineLearningInputOutputParameters{}
+	mi := &file_bom_1_6_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelParameters_MachineLearningInputOutputParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelParameters_MachineLearningInputOutputParameters) ProtoMessage() {}
+
+func (x *ModelCard_ModelParameters_MachineLearningInputOutputParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelParameters_MachineLearningInputOutputParameters.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelParameters_MachineLearningInputOutputParameters) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 0, 2}
+}
+
+func (x *ModelCard_ModelParameters_MachineLearningInputOutputParameters) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+type ModelCard_QuantitativeAnalysis_PerformanceMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The type of performance metric.
+	Type *string `protobuf:"bytes,1,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	// The value of the performance metric.
+	Value *string `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	// The name of the slice this metric was computed on.
+	Slice *string `protobuf:"bytes,3,opt,name=slice,proto3,oneof" json:"slice,omitempty"`
+	// The confidence interval of the metric.
+	ConfidenceInterval *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval `protobuf:"bytes,4,opt,name=confidenceInterval,proto3,oneof" json:"confidenceInterval,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) Reset() {
+	*x = ModelCard_QuantitativeAnalysis_PerformanceMetrics{}
+	mi := &file_bom_1_6_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_QuantitativeAnalysis_PerformanceMetrics) ProtoMessage() {}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_QuantitativeAnalysis_PerformanceMetrics.ProtoReflect.Descriptor instead.
+func (*ModelCard_QuantitativeAnalysis_PerformanceMetrics) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 1, 0}
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) GetSlice() string {
+	if x != nil && x.Slice != nil {
+		return *x.Slice
+	}
+	return ""
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics) GetConfidenceInterval() *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval {
+	if x != nil {
+		return x.ConfidenceInterval
+	}
+	return nil
+}
+
+type ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The lower bound of the confidence interval.
+	LowerBound *string `protobuf:"bytes,1,opt,name=lowerBound,proto3,oneof" json:"lowerBound,omitempty"`
+	// The upper bound of the confidence interval.
+	UpperBound    *string `protobuf:"bytes,2,opt,name=upperBound,proto3,oneof" json:"upperBound,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) Reset() {
+	*x = ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval{}
+	mi := &file_bom_1_6_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) ProtoMessage() {}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval.ProtoReflect.Descriptor instead.
+func (*ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 1, 0, 0}
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) GetLowerBound() string {
+	if x != nil && x.LowerBound != nil {
+		return *x.LowerBound
+	}
+	return ""
+}
+
+func (x *ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval) GetUpperBound() string {
+	if x != nil && x.UpperBound != nil {
+		return *x.UpperBound
+	}
+	return ""
+}
+
+type ModelCard_ModelCardConsiderations_EthicalConsiderations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the risk.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Strategy used to address this risk.
+	MitigationStrategy *string `protobuf:"bytes,2,opt,name=mitigationStrategy,proto3,oneof" json:"mitigationStrategy,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_EthicalConsiderations) Reset() {
+	*x = ModelCard_ModelCardConsiderations_EthicalConsiderations{}
+	mi := &file_bom_1_6_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_EthicalConsiderations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_EthicalConsiderations) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_EthicalConsiderations) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EthicalConsiderations.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_EthicalConsiderations) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 0}
+}
+
+func (x *ModelCard_ModelCardConsiderations_EthicalConsiderations) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_EthicalConsiderations) GetMitigationStrategy() string {
+	if x != nil && x.MitigationStrategy != nil {
+		return *x.MitigationStrategy
+	}
+	return ""
+}
+
+type ModelCard_ModelCardConsiderations_FairnessAssessments struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The groups or individuals at risk of being systematically disadvantaged by the model.
+	GroupAtRisk *string `protobuf:"bytes,1,opt,name=groupAtRisk,proto3,oneof" json:"groupAtRisk,omitempty"`
+	// Expected benefits to the identified groups.
+	Benefits *string `protobuf:"bytes,2,opt,name=benefits,proto3,oneof" json:"benefits,omitempty"`
+	// Expected harms to the identified groups.
+	Harms *string `protobuf:"bytes,3,opt,name=harms,proto3,oneof" json:"harms,omitempty"`
+	// With respect to the benefits and harms outlined, please describe any mitigation strategy implemented.
+	MitigationStrategy *string `protobuf:"bytes,4,opt,name=mitigationStrategy,proto3,oneof" json:"mitigationStrategy,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) Reset() {
+	*x = ModelCard_ModelCardConsiderations_FairnessAssessments{}
+	mi := &file_bom_1_6_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_FairnessAssessments) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_FairnessAssessments.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_FairnessAssessments) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 1}
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) GetGroupAtRisk() string {
+	if x != nil && x.GroupAtRisk != nil {
+		return *x.GroupAtRisk
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) GetBenefits() string {
+	if x != nil && x.Benefits != nil {
+		return *x.Benefits
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) GetHarms() string {
+	if x != nil && x.Harms != nil {
+		return *x.Harms
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_FairnessAssessments) GetMitigationStrategy() string {
+	if x != nil && x.MitigationStrategy != nil {
+		return *x.MitigationStrategy
+	}
+	return ""
+}
+
+type ModelCard_ModelCardConsiderations_EnvironmentalConsiderations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Describes energy consumption information incurred for one or more component lifecycle activities.
+	EnergyConsumptions []*ModelCard_ModelCardConsiderations_EnergyConsumption `protobuf:"bytes,1,rep,name=energyConsumptions,proto3" json:"energyConsumptions,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,2,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) Reset() {
+	*x = ModelCard_ModelCardConsiderations_EnvironmentalConsiderations{}
+	mi := &file_bom_1_6_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnvironmentalConsiderations.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 2}
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) GetEnergyConsumptions() []*ModelCard_ModelCardConsiderations_EnergyConsumption {
+	if x != nil {
+		return x.EnergyConsumptions
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnvironmentalConsiderations) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type ModelCard_ModelCardConsiderations_EnergyConsumption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The type of activity that is part of a machine learning model development or operational lifecycle.
+	Activity ModelCard_ModelCardConsiderations_EnergyActivity `protobuf:"varint,1,opt,name=activity,proto3,enum=cyclonedx.v1_6.ModelCard_ModelCardConsiderations_EnergyActivity" json:"activity,omitempty"`
+	// The provider(s) of the energy consumed by the associated model development lifecycle activity.
+	EnergyProviders []*ModelCard_ModelCardConsiderations_EnergyProviderType `protobuf:"bytes,2,rep,name=energyProviders,proto3" json:"energyProviders,omitempty"`
+	// The total energy cost associated with the model lifecycle activity.
+	ActivityEnergyCost *ModelCard_ModelCardConsiderations_EnergyMeasure `protobuf:"bytes,3,opt,name=activityEnergyCost,proto3" json:"activityEnergyCost,omitempty"`
+	// The CO2 cost (debit) equivalent to the total energy cost.
+	Co2CostEquivalent *ModelCard_ModelCardConsiderations_Co2Measure `protobuf:"bytes,4,opt,name=co2CostEquivalent,proto3,oneof" json:"co2CostEquivalent,omitempty"`
+	// The CO2 offset (credit) for the CO2 equivalent cost.
+	Co2CostOffset *ModelCard_ModelCardConsiderations_Co2Measure `protobuf:"bytes,5,opt,name=co2CostOffset,proto3,oneof" json:"co2CostOffset,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties    []*Property `protobuf:"bytes,6,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) Reset() {
+	*x = ModelCard_ModelCardConsiderations_EnergyConsumption{}
+	mi := &file_bom_1_6_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_EnergyConsumption) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergyConsumption.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_EnergyConsumption) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 3}
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetActivity() ModelCard_ModelCardConsiderations_EnergyActivity {
+	if x != nil {
+		return x.Activity
+	}
+	return ModelCard_ModelCardConsiderations_ENERGY_ACTIVITY_UNSPECIFIED
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetEnergyProviders() []*ModelCard_ModelCardConsiderations_EnergyProviderType {
+	if x != nil {
+		return x.EnergyProviders
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetActivityEnergyCost() *ModelCard_ModelCardConsiderations_EnergyMeasure {
+	if x != nil {
+		return x.ActivityEnergyCost
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetCo2CostEquivalent() *ModelCard_ModelCardConsiderations_Co2Measure {
+	if x != nil {
+		return x.Co2CostEquivalent
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetCo2CostOffset() *ModelCard_ModelCardConsiderations_Co2Measure {
+	if x != nil {
+		return x.Co2CostOffset
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyConsumption) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type ModelCard_ModelCardConsiderations_EnergyMeasure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Quantity of energy.
+	Value float32 `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Unit of energy.
+	Unit          ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit `protobuf:"varint,2,opt,name=unit,proto3,enum=cyclonedx.v1_6.ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyMeasure) Reset() {
+	*x = ModelCard_ModelCardConsiderations_EnergyMeasure{}
+	mi := &file_bom_1_6_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyMeasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_EnergyMeasure) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyMeasure) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergyMeasure.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_EnergyMeasure) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 4}
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyMeasure) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyMeasure) GetUnit() ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return ModelCard_ModelCardConsiderations_EnergyMeasure_ENERGY_UNIT_KWH
+}
+
+type ModelCard_ModelCardConsiderations_Co2Measure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Quantity of carbon dioxide (CO2).
+	Value float32 `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Unit of carbon dioxide (CO2).
+	Unit          ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit `protobuf:"varint,2,opt,name=unit,proto3,enum=cyclonedx.v1_6.ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_Co2Measure) Reset() {
+	*x = ModelCard_ModelCardConsiderations_Co2Measure{}
+	mi := &file_bom_1_6_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_Co2Measure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_Co2Measure) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_Co2Measure) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_Co2Measure.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_Co2Measure) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 5}
+}
+
+func (x *ModelCard_ModelCardConsiderations_Co2Measure) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ModelCard_ModelCardConsiderations_Co2Measure) GetUnit() ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit {
+	if x != nil {
+		return x.Unit
+	}
+	return ModelCard_ModelCardConsiderations_Co2Measure_CO2_UNIT_TCO2EQ
+}
+
+type ModelCard_ModelCardConsiderations_EnergyProviderType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the energy provider elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// A description of the energy provider.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The organization of the energy provider.
+	Organization *OrganizationalEntity `protobuf:"bytes,3,opt,name=organization,proto3" json:"organization,omitempty"`
+	// The energy source for the energy provider.
+	EnergySource ModelCard_ModelCardConsiderations_EnergySource `protobuf:"varint,4,opt,name=energySource,proto3,enum=cyclonedx.v1_6.ModelCard_ModelCardConsiderations_EnergySource" json:"energySource,omitempty"`
+	// The energy provided by the energy source for an associated activity.
+	EnergyProvided *ModelCard_ModelCardConsiderations_EnergyMeasure `protobuf:"bytes,5,opt,name=energyProvided,proto3" json:"energyProvided,omitempty"`
+	// External references provide a way to document systems, sites, and information that may be relevant.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,6,rep,name=externalReferences,proto3" json:"externalReferences,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) Reset() {
+	*x = ModelCard_ModelCardConsiderations_EnergyProviderType{}
+	mi := &file_bom_1_6_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCard_ModelCardConsiderations_EnergyProviderType) ProtoMessage() {}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCard_ModelCardConsiderations_EnergyProviderType.ProtoReflect.Descriptor instead.
+func (*ModelCard_ModelCardConsiderations_EnergyProviderType) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{62, 2, 6}
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetEnergySource() ModelCard_ModelCardConsiderations_EnergySource {
+	if x != nil {
+		return x.EnergySource
+	}
+	return ModelCard_ModelCardConsiderations_ENERGY_SOURCE_UNSPECIFIED
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetEnergyProvided() *ModelCard_ModelCardConsiderations_EnergyMeasure {
+	if x != nil {
+		return x.EnergyProvided
+	}
+	return nil
+}
+
+func (x *ModelCard_ModelCardConsiderations_EnergyProviderType) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+type CryptoProperties_AlgorithmProperties struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cryptographic building blocks used in higher-level cryptographic systems and protocols.
+	Primitive *CryptoProperties_AlgorithmProperties_CryptoPrimitive `protobuf:"varint,1,opt,name=primitive,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoPrimitive,oneof" json:"primitive,omitempty"`
+	// An identifier for the parameter set of the cryptographic algorithm.
+	ParameterSetIdentifier *string `protobuf:"bytes,2,opt,name=parameterSetIdentifier,proto3,oneof" json:"parameterSetIdentifier,omitempty"`
+	// The specific underlying Elliptic Curve (EC) definition employed.
+	Curve *string `protobuf:"bytes,3,opt,name=curve,proto3,oneof" json:"curve,omitempty"`
+	// The target and execution environment in which the algorithm is implemented in.
+	ExecutionEnvironment *CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment `protobuf:"varint,4,opt,name=executionEnvironment,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment,oneof" json:"executionEnvironment,omitempty"`
+	// The target platform for which the algorithm is implemented.
+	ImplementationPlatform *CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform `protobuf:"varint,5,opt,name=implementationPlatform,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform,oneof" json:"implementationPlatform,omitempty"`
+	// The certification that the implementation of the cryptographic algorithm has received, if any.
+	CertificationLevel []CryptoProperties_AlgorithmProperties_CryptoCertificationLevel `protobuf:"varint,6,rep,packed,name=certificationLevel,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoCertificationLevel" json:"certificationLevel,omitempty"`
+	// The mode of operation in which the cryptographic algorithm (block cipher) is used.
+	Mode *CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode `protobuf:"varint,7,opt,name=mode,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode,oneof" json:"mode,omitempty"`
+	// The padding scheme that is used for the cryptographic algorithm.
+	Padding *CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding `protobuf:"varint,8,opt,name=padding,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding,oneof" json:"padding,omitempty"`
+	// The cryptographic functions implemented by the cryptographic algorithm.
+	CryptoFunctions []CryptoProperties_AlgorithmProperties_CryptoFunction `protobuf:"varint,9,rep,packed,name=cryptoFunctions,proto3,enum=cyclonedx.v1_6.CryptoProperties_AlgorithmProperties_CryptoFunction" json:"cryptoFunctions,omitempty"`
+	// The classical security level that a cryptographic algorithm provides (in bits).
+	ClassicalSecurityLevel *int32 `protobuf:"varint,10,opt,name=classicalSecurityLevel,proto3,oneof" json:"classicalSecurityLevel,omitempty"`
+	// The NIST security strength category as defined in https://csrc.nist.gov/projects/post-quantum-cryptography/post-quantum-cryptography-standardization/evaluation-criteria/security-(evaluation-criteria).
+	NistQuantumSecurityLevel *int32 `protobuf:"varint,11,opt,name=nistQuantumSecurityLevel,proto3,oneof" json:"nistQuantumSecurityLevel,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_AlgorithmProperties) Reset() {
+	*x = CryptoProperties_AlgorithmProperties{}
+	mi := &file_bom_1_6_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_AlgorithmProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_AlgorithmProperties) ProtoMessage() {}
+
+func (x *CryptoProperties_AlgorithmProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_AlgorithmProperties.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_AlgorithmProperties) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 0}
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetPrimitive() CryptoProperties_AlgorithmProperties_CryptoPrimitive {
+	if x != nil && x.Primitive != nil {
+		return *x.Primitive
+	}
+	return CryptoProperties_AlgorithmProperties_CRYPTO_PRIMITIVE_UNSPECIFIED
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetParameterSetIdentifier() string {
+	if x != nil && x.ParameterSetIdentifier != nil {
+		return *x.ParameterSetIdentifier
+	}
+	return ""
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetCurve() string {
+	if x != nil && x.Curve != nil {
+		return *x.Curve
+	}
+	return ""
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetExecutionEnvironment() CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment {
+	if x != nil && x.ExecutionEnvironment != nil {
+		return *x.ExecutionEnvironment
+	}
+	return CryptoProperties_AlgorithmProperties_CRYPTO_EXECUTION_ENVIRONMENT_UNSPECIFIED
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetImplementationPlatform() CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform {
+	if x != nil && x.ImplementationPlatform != nil {
+		return *x.ImplementationPlatform
+	}
+	return CryptoProperties_AlgorithmProperties_CRYPTO_IMPLEMENTATION_PLATFORM_UNSPECIFIED
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetCertificationLevel() []CryptoProperties_AlgorithmProperties_CryptoCertificationLevel {
+	if x != nil {
+		return x.CertificationLevel
+	}
+	return nil
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetMode() CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode {
+	if x != nil && x.Mode != nil {
+		return *x.Mode
+	}
+	return CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_MODE_UNSPECIFIED
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetPadding() CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding {
+	if x != nil && x.Padding != nil {
+		return *x.Padding
+	}
+	return CryptoProperties_AlgorithmProperties_CRYPTO_ALGORITHM_PADDING_UNSPECIFIED
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetCryptoFunctions() []CryptoProperties_AlgorithmProperties_CryptoFunction {
+	if x != nil {
+		return x.CryptoFunctions
+	}
+	return nil
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetClassicalSecurityLevel() int32 {
+	if x != nil && x.ClassicalSecurityLevel != nil {
+		return *x.ClassicalSecurityLevel
+	}
+	return 0
+}
+
+func (x *CryptoProperties_AlgorithmProperties) GetNistQuantumSecurityLevel() int32 {
+	if x != nil && x.NistQuantumSecurityLevel != nil {
+		return *x.NistQuantumSecurityLevel
+	}
+	return 0
+}
+
+type CryptoProperties_CertificateProperties struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The subject name for the certificate.
+	SubjectName *string `protobuf:"bytes,1,opt,name=subjectName,proto3,oneof" json:"subjectName,omitempty"`
+	// The issuer name for the certificate.
+	IssuerName *string `protobuf:"bytes,2,opt,name=issuerName,proto3,oneof" json:"issuerName,omitempty"`
+	// The date and time according to ISO-8601 standard from which the certificate is valid.
+	NotValidBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=notValidBefore,proto3,oneof" json:"notValidBefore,omitempty"`
+	// The date and time according to ISO-8601 standard from which the certificate is not valid anymore.
+	NotValidAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=notValidAfter,proto3,oneof" json:"notValidAfter,omitempty"`
+	// The bom-ref to signature algorithm used by the certificate.
+	SignatureAlgorithmRef *string `protobuf:"bytes,5,opt,name=signatureAlgorithmRef,proto3,oneof" json:"signatureAlgorithmRef,omitempty"`
+	// The bom-ref to the public key of the subject.
+	SubjectPublicKeyRef *string `protobuf:"bytes,6,opt,name=subjectPublicKeyRef,proto3,oneof" json:"subjectPublicKeyRef,omitempty"`
+	// The format of the certificate. Examples include X.509, PEM, DER, and CVC.
+	CertificateFormat *string `protobuf:"bytes,7,opt,name=certificateFormat,proto3,oneof" json:"certificateFormat,omitempty"`
+	// The file extension of the certificate. Examples include crt, pem, cer, der, and p12.
+	CertificateExtension *string `protobuf:"bytes,8,opt,name=certificateExtension,proto3,oneof" json:"certificateExtension,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_CertificateProperties) Reset() {
+	*x = CryptoProperties_CertificateProperties{}
+	mi := &file_bom_1_6_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_CertificateProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_CertificateProperties) ProtoMessage() {}
+
+func (x *CryptoProperties_CertificateProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_CertificateProperties.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_CertificateProperties) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 1}
+}
+
+func (x *CryptoProperties_CertificateProperties) GetSubjectName() string {
+	if x != nil && x.SubjectName != nil {
+		return *x.SubjectName
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CertificateProperties) GetIssuerName() string {
+	if x != nil && x.IssuerName != nil {
+		return *x.IssuerName
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CertificateProperties) GetNotValidBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotValidBefore
+	}
+	return nil
+}
+
+func (x *CryptoProperties_CertificateProperties) GetNotValidAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotValidAfter
+	}
+	return nil
+}
+
+func (x *CryptoProperties_CertificateProperties) GetSignatureAlgorithmRef() string {
+	if x != nil && x.SignatureAlgorithmRef != nil {
+		return *x.SignatureAlgorithmRef
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CertificateProperties) GetSubjectPublicKeyRef() string {
+	if x != nil && x.SubjectPublicKeyRef != nil {
+		return *x.SubjectPublicKeyRef
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CertificateProperties) GetCertificateFormat() string {
+	if x != nil && x.CertificateFormat != nil {
+		return *x.CertificateFormat
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CertificateProperties) GetCertificateExtension() string {
+	if x != nil && x.CertificateExtension != nil {
+		return *x.CertificateExtension
+	}
+	return ""
+}
+
+type CryptoProperties_RelatedCryptoMaterialProperties struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The type for the related cryptographic material.
+	Type *CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType,oneof" json:"type,omitempty"`
+	// The optional unique identifier for the related cryptographic material.
+	Id *string `protobuf:"bytes,2,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	// The key state as defined by NIST SP 800-57.
+	State *CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState `protobuf:"varint,3,opt,name=state,proto3,enum=cyclonedx.v1_6.CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState,oneof" json:"state,omitempty"`
+	// The bom-ref to the algorithm used to generate the related cryptographic material.
+	AlgorithmRef *string `protobuf:"bytes,4,opt,name=algorithmRef,proto3,oneof" json:"algorithmRef,omitempty"`
+	// The date and time (timestamp) when the related cryptographic material was created.
+	CreationDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=creationDate,proto3,oneof" json:"creationDate,omitempty"`
+	// The date and time (timestamp) when the related cryptographic material was activated.
+	ActivationDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=activationDate,proto3,oneof" json:"activationDate,omitempty"`
+	// The date and time (timestamp) when the related cryptographic material was updated.
+	UpdateDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updateDate,proto3,oneof" json:"updateDate,omitempty"`
+	// The date and time (timestamp) when the related cryptographic material expires.
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expirationDate,proto3,oneof" json:"expirationDate,omitempty"`
+	// The associated value of the cryptographic material.
+	Value *string `protobuf:"bytes,9,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	// The size of the cryptographic asset (in bits).
+	Size *int64 `protobuf:"varint,10,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// The format of the related cryptographic material (e.g. P8, PEM, DER).
+	Format *string `protobuf:"bytes,11,opt,name=format,proto3,oneof" json:"format,omitempty"`
+	// The mechanism by which the cryptographic asset is secured by.
+	SecuredBy     *CryptoProperties_SecuredBy `protobuf:"bytes,12,opt,name=securedBy,proto3,oneof" json:"securedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) Reset() {
+	*x = CryptoProperties_RelatedCryptoMaterialProperties{}
+	mi := &file_bom_1_6_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_RelatedCryptoMaterialProperties) ProtoMessage() {}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_RelatedCryptoMaterialProperties.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_RelatedCryptoMaterialProperties) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 2}
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetType() CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_TYPE_UNSPECIFIED
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetState() CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return CryptoProperties_RelatedCryptoMaterialProperties_RELATED_CRYPTO_MATERIAL_STATE_UNSPECIFIED
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetAlgorithmRef() string {
+	if x != nil && x.AlgorithmRef != nil {
+		return *x.AlgorithmRef
+	}
+	return ""
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetCreationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreationDate
+	}
+	return nil
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetActivationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivationDate
+	}
+	return nil
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetUpdateDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateDate
+	}
+	return nil
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+func (x *CryptoProperties_RelatedCryptoMaterialProperties) GetSecuredBy() *CryptoProperties_SecuredBy {
+	if x != nil {
+		return x.SecuredBy
+	}
+	return nil
+}
+
+type CryptoProperties_ProtocolProperties struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The concrete protocol type.
+	Type *CryptoProperties_ProtocolProperties_CryptoProtocolType `protobuf:"varint,1,opt,name=type,proto3,enum=cyclonedx.v1_6.CryptoProperties_ProtocolProperties_CryptoProtocolType,oneof" json:"type,omitempty"`
+	// The version of the protocol. Examples include 1.0, 1.2, and 1.99.
+	Version *string `protobuf:"bytes,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// A list of cipher suites related to the protocol.
+	CipherSuites []*CryptoProperties_CipherSuite `protobuf:"bytes,3,rep,name=cipherSuites,proto3" json:"cipherSuites,omitempty"`
+	// The IKEv2 transform types supported (types 1-4), defined in RFC 7296 section 3.3.2.
+	Ikev2TransformTypes *CryptoProperties_ProtocolProperties_Ikev2TransformTypes `protobuf:"bytes,4,opt,name=ikev2TransformTypes,proto3,oneof" json:"ikev2TransformTypes,omitempty"`
+	// A list of protocol-related cryptographic assets.
+	CryptoRefArray []string `protobuf:"bytes,5,rep,name=cryptoRefArray,proto3" json:"cryptoRefArray,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_ProtocolProperties) Reset() {
+	*x = CryptoProperties_ProtocolProperties{}
+	mi := &file_bom_1_6_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_ProtocolProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_ProtocolProperties) ProtoMessage() {}
+
+func (x *CryptoProperties_ProtocolProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_ProtocolProperties.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_ProtocolProperties) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 3}
+}
+
+func (x *CryptoProperties_ProtocolProperties) GetType() CryptoProperties_ProtocolProperties_CryptoProtocolType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return CryptoProperties_ProtocolProperties_CRYPTO_PROTOCOL_TYPE_UNSPECIFIED
+}
+
+func (x *CryptoProperties_ProtocolProperties) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *CryptoProperties_ProtocolProperties) GetCipherSuites() []*CryptoProperties_CipherSuite {
+	if x != nil {
+		return x.CipherSuites
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties) GetIkev2TransformTypes() *CryptoProperties_ProtocolProperties_Ikev2TransformTypes {
+	if x != nil {
+		return x.Ikev2TransformTypes
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties) GetCryptoRefArray() []string {
+	if x != nil {
+		return x.CryptoRefArray
+	}
+	return nil
+}
+
+type CryptoProperties_CipherSuite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A common name for the cipher suite.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// A list of algorithms related to the cipher suite.
+	Algorithms []string `protobuf:"bytes,2,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	// A list of common identifiers for the cipher suite.
+	Identifiers   []string `protobuf:"bytes,3,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_CipherSuite) Reset() {
+	*x = CryptoProperties_CipherSuite{}
+	mi := &file_bom_1_6_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_CipherSuite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_CipherSuite) ProtoMessage() {}
+
+func (x *CryptoProperties_CipherSuite) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_CipherSuite.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_CipherSuite) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 4}
+}
+
+func (x *CryptoProperties_CipherSuite) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *CryptoProperties_CipherSuite) GetAlgorithms() []string {
+	if x != nil {
+		return x.Algorithms
+	}
+	return nil
+}
+
+func (x *CryptoProperties_CipherSuite) GetIdentifiers() []string {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+type CryptoProperties_SecuredBy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Specifies the mechanism by which the cryptographic asset is secured by. Examples include HSM, TPM, SGX, Software, and None.
+	Mechanism *string `protobuf:"bytes,1,opt,name=mechanism,proto3,oneof" json:"mechanism,omitempty"`
+	// The bom-ref to the algorithm.
+	AlgorithmRef  *string `protobuf:"bytes,2,opt,name=algorithmRef,proto3,oneof" json:"algorithmRef,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_SecuredBy) Reset() {
+	*x = CryptoProperties_SecuredBy{}
+	mi := &file_bom_1_6_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_SecuredBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_SecuredBy) ProtoMessage() {}
+
+func (x *CryptoProperties_SecuredBy) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_SecuredBy.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_SecuredBy) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 5}
+}
+
+func (x *CryptoProperties_SecuredBy) GetMechanism() string {
+	if x != nil && x.Mechanism != nil {
+		return *x.Mechanism
+	}
+	return ""
+}
+
+func (x *CryptoProperties_SecuredBy) GetAlgorithmRef() string {
+	if x != nil && x.AlgorithmRef != nil {
+		return *x.AlgorithmRef
+	}
+	return ""
+}
+
+type CryptoProperties_ProtocolProperties_Ikev2TransformTypes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transform Type 1: encryption algorithms.
+	Encr []string `protobuf:"bytes,1,rep,name=encr,proto3" json:"encr,omitempty"`
+	// Transform Type 2: pseudorandom functions.
+	Prf []string `protobuf:"bytes,2,rep,name=prf,proto3" json:"prf,omitempty"`
+	// Transform Type 3: integrity algorithms.
+	Integ []string `protobuf:"bytes,3,rep,name=integ,proto3" json:"integ,omitempty"`
+	// Transform Type 4: Key Exchange Method (KE) per RFC 9370, formerly called Diffie-Hellman Group (D-H).
+	Ke []string `protobuf:"bytes,4,rep,name=ke,proto3" json:"ke,omitempty"`
+	// Specifies if an Extended Sequence Number (ESN) is used.
+	Esn *bool `protobuf:"varint,5,opt,name=esn,proto3,oneof" json:"esn,omitempty"`
+	// IKEv2 Authentication method.
+	Auth          []string `protobuf:"bytes,6,rep,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) Reset() {
+	*x = CryptoProperties_ProtocolProperties_Ikev2TransformTypes{}
+	mi := &file_bom_1_6_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoProperties_ProtocolProperties_Ikev2TransformTypes) ProtoMessage() {}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoProperties_ProtocolProperties_Ikev2TransformTypes.ProtoReflect.Descriptor instead.
+func (*CryptoProperties_ProtocolProperties_Ikev2TransformTypes) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{63, 3, 0}
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetEncr() []string {
+	if x != nil {
+		return x.Encr
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetPrf() []string {
+	if x != nil {
+		return x.Prf
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetInteg() []string {
+	if x != nil {
+		return x.Integ
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetKe() []string {
+	if x != nil {
+		return x.Ke
+	}
+	return nil
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetEsn() bool {
+	if x != nil && x.Esn != nil {
+		return *x.Esn
+	}
+	return false
+}
+
+func (x *CryptoProperties_ProtocolProperties_Ikev2TransformTypes) GetAuth() []string {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type Declarations_Assessor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The boolean indicating if the assessor is outside the organization generating claims.
+	ThirdParty *bool `protobuf:"varint,2,opt,name=thirdParty,proto3,oneof" json:"thirdParty,omitempty"`
+	// The entity issuing the assessment.
+	Organization  *OrganizationalEntity `protobuf:"bytes,3,opt,name=organization,proto3,oneof" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Assessor) Reset() {
+	*x = Declarations_Assessor{}
+	mi := &file_bom_1_6_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Assessor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Assessor) ProtoMessage() {}
+
+func (x *Declarations_Assessor) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Assessor.ProtoReflect.Descriptor instead.
+func (*Declarations_Assessor) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 0}
+}
+
+func (x *Declarations_Assessor) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Declarations_Assessor) GetThirdParty() bool {
+	if x != nil && x.ThirdParty != nil {
+		return *x.ThirdParty
+	}
+	return false
+}
+
+func (x *Declarations_Assessor) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type Declarations_Attestation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The short description explaining the main points of the attestation.
+	Summary *string `protobuf:"bytes,1,opt,name=summary,proto3,oneof" json:"summary,omitempty"`
+	// The bom-ref to the assessor asserting the attestation.
+	Assessor *string `protobuf:"bytes,2,opt,name=assessor,proto3,oneof" json:"assessor,omitempty"`
+	// The grouping of requirements to claims and the attestors declared conformance and confidence thereof.
+	Map           []*Declarations_Attestation_AttestationMap `protobuf:"bytes,3,rep,name=map,proto3" json:"map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Attestation) Reset() {
+	*x = Declarations_Attestation{}
+	mi := &file_bom_1_6_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Attestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Attestation) ProtoMessage() {}
+
+func (x *Declarations_Attestation) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Attestation.ProtoReflect.Descriptor instead.
+func (*Declarations_Attestation) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 1}
+}
+
+func (x *Declarations_Attestation) GetSummary() string {
+	if x != nil && x.Summary != nil {
+		return *x.Summary
+	}
+	return ""
+}
+
+func (x *Declarations_Attestation) GetAssessor() string {
+	if x != nil && x.Assessor != nil {
+		return *x.Assessor
+	}
+	return ""
+}
+
+func (x *Declarations_Attestation) GetMap() []*Declarations_Attestation_AttestationMap {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+type Declarations_Claim struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The bom-ref to a target representing a specific system, application, API, module, team, person, process, business unit, company, etc.
+	Target *string `protobuf:"bytes,2,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	// The specific statement or assertion about the target.
+	Predicate *string `protobuf:"bytes,3,opt,name=predicate,proto3,oneof" json:"predicate,omitempty"`
+	// The list of bom-ref to the evidence provided describing the mitigation strategies.
+	MitigationStrategies []string `protobuf:"bytes,4,rep,name=mitigationStrategies,proto3" json:"mitigationStrategies,omitempty"`
+	// The written explanation of why the evidence provided substantiates the claim.
+	Reasoning *string `protobuf:"bytes,5,opt,name=reasoning,proto3,oneof" json:"reasoning,omitempty"`
+	// The list of bom-ref to evidence that supports this claim.
+	Evidence []string `protobuf:"bytes,6,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	// The list of bom-ref to counterEvidence that supports this claim.
+	CounterEvidence []string `protobuf:"bytes,7,rep,name=counterEvidence,proto3" json:"counterEvidence,omitempty"`
+	// External references provide a way to document systems, sites, and information that may be relevant.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,8,rep,name=externalReferences,proto3" json:"externalReferences,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Declarations_Claim) Reset() {
+	*x = Declarations_Claim{}
+	mi := &file_bom_1_6_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Claim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Claim) ProtoMessage() {}
+
+func (x *Declarations_Claim) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Claim.ProtoReflect.Descriptor instead.
+func (*Declarations_Claim) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 2}
+}
+
+func (x *Declarations_Claim) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Declarations_Claim) GetTarget() string {
+	if x != nil && x.Target != nil {
+		return *x.Target
+	}
+	return ""
+}
+
+func (x *Declarations_Claim) GetPredicate() string {
+	if x != nil && x.Predicate != nil {
+		return *x.Predicate
+	}
+	return ""
+}
+
+func (x *Declarations_Claim) GetMitigationStrategies() []string {
+	if x != nil {
+		return x.MitigationStrategies
+	}
+	return nil
+}
+
+func (x *Declarations_Claim) GetReasoning() string {
+	if x != nil && x.Reasoning != nil {
+		return *x.Reasoning
+	}
+	return ""
+}
+
+func (x *Declarations_Claim) GetEvidence() []string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Declarations_Claim) GetCounterEvidence() []string {
+	if x != nil {
+		return x.CounterEvidence
+	}
+	return nil
+}
+
+func (x *Declarations_Claim) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+type Declarations_Evidence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The reference to the property name as defined in the CycloneDX Property Taxonomy.
+	PropertyName *string `protobuf:"bytes,2,opt,name=propertyName,proto3,oneof" json:"propertyName,omitempty"`
+	// The written description of what this evidence is and how it was created.
+	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The output or analysis that supports claims.
+	Data []*Declarations_Evidence_Data `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	// The date and time (timestamp) when the evidence was created.
+	Created *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	// The optional date and time (timestamp) when the evidence is no longer valid.
+	Expires *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires,proto3,oneof" json:"expires,omitempty"`
+	// The author of the evidence.
+	Author *OrganizationalContact `protobuf:"bytes,7,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	// The reviewer of the evidence.
+	Reviewer      *OrganizationalContact `protobuf:"bytes,8,opt,name=reviewer,proto3,oneof" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Evidence) Reset() {
+	*x = Declarations_Evidence{}
+	mi := &file_bom_1_6_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Evidence) ProtoMessage() {}
+
+func (x *Declarations_Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Evidence.ProtoReflect.Descriptor instead.
+func (*Declarations_Evidence) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 3}
+}
+
+func (x *Declarations_Evidence) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Declarations_Evidence) GetPropertyName() string {
+	if x != nil && x.PropertyName != nil {
+		return *x.PropertyName
+	}
+	return ""
+}
+
+func (x *Declarations_Evidence) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Declarations_Evidence) GetData() []*Declarations_Evidence_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence) GetAuthor() *OrganizationalContact {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence) GetReviewer() *OrganizationalContact {
+	if x != nil {
+		return x.Reviewer
+	}
+	return nil
+}
+
+type Declarations_Targets struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of organizations which claims are made against.
+	Organizations []*OrganizationalEntity `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	// The list of components which claims are made against.
+	Components []*Component `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	// The list of services which claims are made against.
+	Services      []*Service `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Targets) Reset() {
+	*x = Declarations_Targets{}
+	mi := &file_bom_1_6_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Targets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Targets) ProtoMessage() {}
+
+func (x *Declarations_Targets) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Targets.ProtoReflect.Descriptor instead.
+func (*Declarations_Targets) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 4}
+}
+
+func (x *Declarations_Targets) GetOrganizations() []*OrganizationalEntity {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+func (x *Declarations_Targets) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Declarations_Targets) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type Declarations_Affirmation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The brief statement affirmed by an individual regarding all declarations.
+	Statement *string `protobuf:"bytes,1,opt,name=statement,proto3,oneof" json:"statement,omitempty"`
+	// The list of signatories authorized on behalf of an organization to assert validity of this document.
+	Signatories   []*Declarations_Affirmation_Signatory `protobuf:"bytes,2,rep,name=signatories,proto3" json:"signatories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Affirmation) Reset() {
+	*x = Declarations_Affirmation{}
+	mi := &file_bom_1_6_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Affirmation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Affirmation) ProtoMessage() {}
+
+func (x *Declarations_Affirmation) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Affirmation.ProtoReflect.Descriptor instead.
+func (*Declarations_Affirmation) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 5}
+}
+
+func (x *Declarations_Affirmation) GetStatement() string {
+	if x != nil && x.Statement != nil {
+		return *x.Statement
+	}
+	return ""
+}
+
+func (x *Declarations_Affirmation) GetSignatories() []*Declarations_Affirmation_Signatory {
+	if x != nil {
+		return x.Signatories
+	}
+	return nil
+}
+
+type Declarations_Attestation_AttestationMap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The bom-ref to the requirement being attested to.
+	Requirement *string `protobuf:"bytes,1,opt,name=requirement,proto3,oneof" json:"requirement,omitempty"`
+	// The list of bom-ref to the claims being attested to.
+	Claims []string `protobuf:"bytes,2,rep,name=claims,proto3" json:"claims,omitempty"`
+	// The list of bom-ref to the counter claims being attested to.
+	CounterClaims []string `protobuf:"bytes,3,rep,name=counterClaims,proto3" json:"counterClaims,omitempty"`
+	// The conformance of the claim meeting a requirement.
+	Conformance *Declarations_Attestation_AttestationMap_Conformance `protobuf:"bytes,4,opt,name=conformance,proto3,oneof" json:"conformance,omitempty"`
+	// The confidence of the claim meeting the requirement.
+	Confidence    *Declarations_Attestation_AttestationMap_Confidence `protobuf:"bytes,5,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Attestation_AttestationMap) Reset() {
+	*x = Declarations_Attestation_AttestationMap{}
+	mi := &file_bom_1_6_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Attestation_AttestationMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Attestation_AttestationMap) ProtoMessage() {}
+
+func (x *Declarations_Attestation_AttestationMap) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Attestation_AttestationMap.ProtoReflect.Descriptor instead.
+func (*Declarations_Attestation_AttestationMap) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 1, 0}
+}
+
+func (x *Declarations_Attestation_AttestationMap) GetRequirement() string {
+	if x != nil && x.Requirement != nil {
+		return *x.Requirement
+	}
+	return ""
+}
+
+func (x *Declarations_Attestation_AttestationMap) GetClaims() []string {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *Declarations_Attestation_AttestationMap) GetCounterClaims() []string {
+	if x != nil {
+		return x.CounterClaims
+	}
+	return nil
+}
+
+func (x *Declarations_Attestation_AttestationMap) GetConformance() *Declarations_Attestation_AttestationMap_Conformance {
+	if x != nil {
+		return x.Conformance
+	}
+	return nil
+}
+
+func (x *Declarations_Attestation_AttestationMap) GetConfidence() *Declarations_Attestation_AttestationMap_Confidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return nil
+}
+
+type Declarations_Attestation_AttestationMap_Conformance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The conformance of the claim between and inclusive of 0 and 1, where 1 is 100% conformance.
+	Score *float64 `protobuf:"fixed64,1,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	// The reason for the conformance score.
+	Rationale *string `protobuf:"bytes,2,opt,name=rationale,proto3,oneof" json:"rationale,omitempty"`
+	// The list of bom-ref to the evidence provided describing the mitigation strategies.
+	MitigationStrategies []string `protobuf:"bytes,3,rep,name=mitigationStrategies,proto3" json:"mitigationStrategies,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) Reset() {
+	*x = Declarations_Attestation_AttestationMap_Conformance{}
+	mi := &file_bom_1_6_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Attestation_AttestationMap_Conformance) ProtoMessage() {}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Attestation_AttestationMap_Conformance.ProtoReflect.Descriptor instead.
+func (*Declarations_Attestation_AttestationMap_Conformance) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 1, 0, 0}
+}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) GetRationale() string {
+	if x != nil && x.Rationale != nil {
+		return *x.Rationale
+	}
+	return ""
+}
+
+func (x *Declarations_Attestation_AttestationMap_Conformance) GetMitigationStrategies() []string {
+	if x != nil {
+		return x.MitigationStrategies
+	}
+	return nil
+}
+
+type Declarations_Attestation_AttestationMap_Confidence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The confidence of the claim between and inclusive of 0 and 1, where 1 is 100% confidence.
+	Score *float64 `protobuf:"fixed64,1,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	// The rationale for the confidence score.
+	Rationale     *string `protobuf:"bytes,2,opt,name=rationale,proto3,oneof" json:"rationale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Attestation_AttestationMap_Confidence) Reset() {
+	*x = Declarations_Attestation_AttestationMap_Confidence{}
+	mi := &file_bom_1_6_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Attestation_AttestationMap_Confidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Attestation_AttestationMap_Confidence) ProtoMessage() {}
+
+func (x *Declarations_Attestation_AttestationMap_Confidence) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Attestation_AttestationMap_Confidence.ProtoReflect.Descriptor instead.
+func (*Declarations_Attestation_AttestationMap_Confidence) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 1, 0, 1}
+}
+
+func (x *Declarations_Attestation_AttestationMap_Confidence) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *Declarations_Attestation_AttestationMap_Confidence) GetRationale() string {
+	if x != nil && x.Rationale != nil {
+		return *x.Rationale
+	}
+	return ""
+}
+
+type Declarations_Evidence_Data struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the data.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The contents or references to the contents of the data being described.
+	Contents *Declarations_Evidence_Data_Contents `protobuf:"bytes,2,opt,name=contents,proto3,oneof" json:"contents,omitempty"`
+	// Data classification tags data according to its type, sensitivity, and value if altered, stolen, or destroyed.
+	Classification *DataClassification `protobuf:"bytes,3,opt,name=classification,proto3,oneof" json:"classification,omitempty"`
+	// A description of any sensitive data included.
+	SensitiveData []string `protobuf:"bytes,4,rep,name=sensitiveData,proto3" json:"sensitiveData,omitempty"`
+	// Data governance captures information regarding data ownership, stewardship, and custodianship.
+	Governance    *DataGovernance `protobuf:"bytes,5,opt,name=governance,proto3,oneof" json:"governance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Evidence_Data) Reset() {
+	*x = Declarations_Evidence_Data{}
+	mi := &file_bom_1_6_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Evidence_Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Evidence_Data) ProtoMessage() {}
+
+func (x *Declarations_Evidence_Data) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Evidence_Data.ProtoReflect.Descriptor instead.
+func (*Declarations_Evidence_Data) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 3, 0}
+}
+
+func (x *Declarations_Evidence_Data) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Declarations_Evidence_Data) GetContents() *Declarations_Evidence_Data_Contents {
+	if x != nil {
+		return x.Contents
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence_Data) GetClassification() *DataClassification {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence_Data) GetSensitiveData() []string {
+	if x != nil {
+		return x.SensitiveData
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence_Data) GetGovernance() *DataGovernance {
+	if x != nil {
+		return x.Governance
+	}
+	return nil
+}
+
+type Declarations_Evidence_Data_Contents struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional way to include textual or encoded data.
+	Attachment *AttachedText `protobuf:"bytes,1,opt,name=attachment,proto3,oneof" json:"attachment,omitempty"`
+	// The URL to where the data can be retrieved.
+	Url           *string `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Declarations_Evidence_Data_Contents) Reset() {
+	*x = Declarations_Evidence_Data_Contents{}
+	mi := &file_bom_1_6_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Evidence_Data_Contents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Evidence_Data_Contents) ProtoMessage() {}
+
+func (x *Declarations_Evidence_Data_Contents) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Evidence_Data_Contents.ProtoReflect.Descriptor instead.
+func (*Declarations_Evidence_Data_Contents) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 3, 0, 0}
+}
+
+func (x *Declarations_Evidence_Data_Contents) GetAttachment() *AttachedText {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *Declarations_Evidence_Data_Contents) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+type Declarations_Affirmation_Signatory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The signatory's name.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The signatory's role within an organization.
+	Role *string `protobuf:"bytes,2,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	// The organization of the signatory.
+	Organization *OrganizationalEntity `protobuf:"bytes,3,opt,name=organization,proto3,oneof" json:"organization,omitempty"`
+	// External references provide a way to document systems, sites, and information that may be relevant.
+	ExternalReference *ExternalReference `protobuf:"bytes,4,opt,name=externalReference,proto3,oneof" json:"externalReference,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Declarations_Affirmation_Signatory) Reset() {
+	*x = Declarations_Affirmation_Signatory{}
+	mi := &file_bom_1_6_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Declarations_Affirmation_Signatory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Declarations_Affirmation_Signatory) ProtoMessage() {}
+
+func (x *Declarations_Affirmation_Signatory) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Declarations_Affirmation_Signatory.ProtoReflect.Descriptor instead.
+func (*Declarations_Affirmation_Signatory) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{64, 5, 0}
+}
+
+func (x *Declarations_Affirmation_Signatory) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Declarations_Affirmation_Signatory) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+func (x *Declarations_Affirmation_Signatory) GetOrganization() *OrganizationalEntity {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+func (x *Declarations_Affirmation_Signatory) GetExternalReference() *ExternalReference {
+	if x != nil {
+		return x.ExternalReference
+	}
+	return nil
+}
+
+type Definition_Standard struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The name of the standard.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The version of the standard.
+	Version *string `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	// The description of the standard.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The owner of the standard, often the entity responsible for its release.
+	Owner *string `protobuf:"bytes,5,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+	// The list of requirements comprising the standard.
+	Requirements []*Definition_Standard_Requirement `protobuf:"bytes,6,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	// The list of levels associated with the standard.
+	Levels []*Definition_Standard_Level `protobuf:"bytes,7,rep,name=levels,proto3" json:"levels,omitempty"`
+	// External references provide a way to document systems, sites, and information that may be relevant.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,8,rep,name=externalReferences,proto3" json:"externalReferences,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Definition_Standard) Reset() {
+	*x = Definition_Standard{}
+	mi := &file_bom_1_6_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Definition_Standard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition_Standard) ProtoMessage() {}
+
+func (x *Definition_Standard) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition_Standard.ProtoReflect.Descriptor instead.
+func (*Definition_Standard) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{65, 0}
+}
+
+func (x *Definition_Standard) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Definition_Standard) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Definition_Standard) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *Definition_Standard) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Definition_Standard) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+func (x *Definition_Standard) GetRequirements() []*Definition_Standard_Requirement {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *Definition_Standard) GetLevels() []*Definition_Standard_Level {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *Definition_Standard) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+type Definition_Standard_Requirement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The unique identifier used in the standard to identify a specific requirement.
+	Identifier *string `protobuf:"bytes,2,opt,name=identifier,proto3,oneof" json:"identifier,omitempty"`
+	// The title of the requirement.
+	Title *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// The textual content of the requirement.
+	Text *string `protobuf:"bytes,4,opt,name=text,proto3,oneof" json:"text,omitempty"`
+	// The supplemental text that provides additional guidance or context to the requirement, but is not directly part of the requirement.
+	Descriptions []string `protobuf:"bytes,5,rep,name=descriptions,proto3" json:"descriptions,omitempty"`
+	// The Common Requirements Enumeration (CRE) identifier(s).
+	OpenCre []string `protobuf:"bytes,6,rep,name=openCre,proto3" json:"openCre,omitempty"`
+	// The optional bom-ref to a parent requirement.
+	Parent *string `protobuf:"bytes,7,opt,name=parent,proto3,oneof" json:"parent,omitempty"`
+	// Specifies optional, custom, properties.
+	Properties []*Property `protobuf:"bytes,8,rep,name=properties,proto3" json:"properties,omitempty"`
+	// External references provide a way to document systems, sites, and information that may be relevant.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,9,rep,name=externalReferences,proto3" json:"externalReferences,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Definition_Standard_Requirement) Reset() {
+	*x = Definition_Standard_Requirement{}
+	mi := &file_bom_1_6_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Definition_Standard_Requirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition_Standard_Requirement) ProtoMessage() {}
+
+func (x *Definition_Standard_Requirement) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition_Standard_Requirement.ProtoReflect.Descriptor instead.
+func (*Definition_Standard_Requirement) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{65, 0, 0}
+}
+
+func (x *Definition_Standard_Requirement) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Requirement) GetIdentifier() string {
+	if x != nil && x.Identifier != nil {
+		return *x.Identifier
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Requirement) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Requirement) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Requirement) GetDescriptions() []string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return nil
+}
+
+func (x *Definition_Standard_Requirement) GetOpenCre() []string {
+	if x != nil {
+		return x.OpenCre
+	}
+	return nil
+}
+
+func (x *Definition_Standard_Requirement) GetParent() string {
+	if x != nil && x.Parent != nil {
+		return *x.Parent
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Requirement) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Definition_Standard_Requirement) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+type Definition_Standard_Level struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An optional identifier which can be used to reference the object elsewhere in the BOM.
+	BomRef *string `protobuf:"bytes,1,opt,name=bom_ref,json=bomRef,proto3,oneof" json:"bom_ref,omitempty"`
+	// The identifier used in the standard to identify a specific level.
+	Identifier *string `protobuf:"bytes,2,opt,name=identifier,proto3,oneof" json:"identifier,omitempty"`
+	// The title of the level.
+	Title *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// The description of the level.
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The list of requirement bom-refs that comprise the level.
+	Requirements  []string `protobuf:"bytes,5,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Definition_Standard_Level) Reset() {
+	*x = Definition_Standard_Level{}
+	mi := &file_bom_1_6_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Definition_Standard_Level) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition_Standard_Level) ProtoMessage() {}
+
+func (x *Definition_Standard_Level) ProtoReflect() protoreflect.Message {
+	mi := &file_bom_1_6_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition_Standard_Level.ProtoReflect.Descriptor instead.
+func (*Definition_Standard_Level) Descriptor() ([]byte, []int) {
+	return file_bom_1_6_proto_rawDescGZIP(), []int{65, 0, 1}
+}
+
+func (x *Definition_Standard_Level) GetBomRef() string {
+	if x != nil && x.BomRef != nil {
+		return *x.BomRef
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Level) GetIdentifier() string {
+	if x != nil && x.Identifier != nil {
+		return *x.Identifier
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Level) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Level) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Definition_Standard_Level) GetRequirements() []string {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+var File_bom_1_6_proto protoreflect.FileDescriptor
+
+const file_bom_1_6_proto_rawDesc = "" +
+	"\n" +
+	"\rbom-1.6.proto\x12\x0ecyclonedx.v1_6\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8b\x01\n" +
+	"\fAttachedText\x12&\n" +
+	"\fcontent_type\x18\x01 \x01(\tH\x00R\vcontentType\x88\x01\x01\x12\x1f\n" +
+	"\bencoding\x18\x02 \x01(\tH\x01R\bencoding\x88\x01\x01\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05valueB\x0f\n" +
+	"\r_content_typeB\v\n" +
+	"\t_encoding\"\xc3\a\n" +
+	"\x03Bom\x12!\n" +
+	"\fspec_version\x18\x01 \x01(\tR\vspecVersion\x12\x1d\n" +
+	"\aversion\x18\x02 \x01(\x05H\x00R\aversion\x88\x01\x01\x12(\n" +
+	"\rserial_number\x18\x03 \x01(\tH\x01R\fserialNumber\x88\x01\x01\x129\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x18.cyclonedx.v1_6.MetadataH\x02R\bmetadata\x88\x01\x01\x129\n" +
+	"\n" +
+	"components\x18\x05 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\n" +
+	"components\x123\n" +
+	"\bservices\x18\x06 \x03(\v2\x17.cyclonedx.v1_6.ServiceR\bservices\x12R\n" +
+	"\x13external_references\x18\a \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferences\x12>\n" +
+	"\fdependencies\x18\b \x03(\v2\x1a.cyclonedx.v1_6.DependencyR\fdependencies\x12?\n" +
+	"\fcompositions\x18\t \x03(\v2\x1b.cyclonedx.v1_6.CompositionR\fcompositions\x12G\n" +
+	"\x0fvulnerabilities\x18\n" +
+	" \x03(\v2\x1d.cyclonedx.v1_6.VulnerabilityR\x0fvulnerabilities\x12<\n" +
+	"\vannotations\x18\v \x03(\v2\x1a.cyclonedx.v1_6.AnnotationR\vannotations\x128\n" +
+	"\n" +
+	"properties\x18\f \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x129\n" +
+	"\vformulation\x18\r \x03(\v2\x17.cyclonedx.v1_6.FormulaR\vformulation\x12E\n" +
+	"\fdeclarations\x18\x0e \x01(\v2\x1c.cyclonedx.v1_6.DeclarationsH\x03R\fdeclarations\x88\x01\x01\x12A\n" +
+	"\vdefinitions\x18\x0f \x01(\v2\x1a.cyclonedx.v1_6.DefinitionH\x04R\vdefinitions\x88\x01\x01B\n" +
+	"\n" +
+	"\b_versionB\x10\n" +
+	"\x0e_serial_numberB\v\n" +
+	"\t_metadataB\x0f\n" +
+	"\r_declarationsB\x0e\n" +
+	"\f_definitions\"\x92\x02\n" +
+	"\x06Commit\x12\x15\n" +
+	"\x03uid\x18\x01 \x01(\tH\x00R\x03uid\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01\x12?\n" +
+	"\x06author\x18\x03 \x01(\v2\".cyclonedx.v1_6.IdentifiableActionH\x02R\x06author\x88\x01\x01\x12E\n" +
+	"\tcommitter\x18\x04 \x01(\v2\".cyclonedx.v1_6.IdentifiableActionH\x03R\tcommitter\x88\x01\x01\x12\x1d\n" +
+	"\amessage\x18\x05 \x01(\tH\x04R\amessage\x88\x01\x01B\x06\n" +
+	"\x04_uidB\x06\n" +
+	"\x04_urlB\t\n" +
+	"\a_authorB\f\n" +
+	"\n" +
+	"_committerB\n" +
+	"\n" +
+	"\b_message\"\xe7\r\n" +
+	"\tComponent\x122\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1e.cyclonedx.v1_6.ClassificationR\x04type\x12 \n" +
+	"\tmime_type\x18\x02 \x01(\tH\x00R\bmimeType\x88\x01\x01\x12\x1c\n" +
+	"\abom_ref\x18\x03 \x01(\tH\x01R\x06bomRef\x88\x01\x01\x12E\n" +
+	"\bsupplier\x18\x04 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x02R\bsupplier\x88\x01\x01\x12\x1f\n" +
+	"\x06author\x18\x05 \x01(\tB\x02\x18\x01H\x03R\x06author\x88\x01\x01\x12!\n" +
+	"\tpublisher\x18\x06 \x01(\tH\x04R\tpublisher\x88\x01\x01\x12\x19\n" +
+	"\x05group\x18\a \x01(\tH\x05R\x05group\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\b \x01(\tR\x04name\x12\x1d\n" +
+	"\aversion\x18\t \x01(\tH\x06R\aversion\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\n" +
+	" \x01(\tH\aR\vdescription\x88\x01\x01\x120\n" +
+	"\x05scope\x18\v \x01(\x0e2\x15.cyclonedx.v1_6.ScopeH\bR\x05scope\x88\x01\x01\x12,\n" +
+	"\x06hashes\x18\f \x03(\v2\x14.cyclonedx.v1_6.HashR\x06hashes\x129\n" +
+	"\blicenses\x18\r \x03(\v2\x1d.cyclonedx.v1_6.LicenseChoiceR\blicenses\x12!\n" +
+	"\tcopyright\x18\x0e \x01(\tH\tR\tcopyright\x88\x01\x01\x12\x15\n" +
+	"\x03cpe\x18\x0f \x01(\tH\n" +
+	"R\x03cpe\x88\x01\x01\x12\x17\n" +
+	"\x04purl\x18\x10 \x01(\tH\vR\x04purl\x88\x01\x01\x12-\n" +
+	"\x04swid\x18\x11 \x01(\v2\x14.cyclonedx.v1_6.SwidH\fR\x04swid\x88\x01\x01\x12#\n" +
+	"\bmodified\x18\x12 \x01(\bB\x02\x18\x01H\rR\bmodified\x88\x01\x01\x129\n" +
+	"\bpedigree\x18\x13 \x01(\v2\x18.cyclonedx.v1_6.PedigreeH\x0eR\bpedigree\x88\x01\x01\x12R\n" +
+	"\x13external_references\x18\x14 \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferences\x129\n" +
+	"\n" +
+	"components\x18\x15 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\n" +
+	"components\x128\n" +
+	"\n" +
+	"properties\x18\x16 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x129\n" +
+	"\bevidence\x18\x17 \x01(\v2\x18.cyclonedx.v1_6.EvidenceH\x0fR\bevidence\x88\x01\x01\x12E\n" +
+	"\freleaseNotes\x18\x18 \x01(\v2\x1c.cyclonedx.v1_6.ReleaseNotesH\x10R\freleaseNotes\x88\x01\x01\x12<\n" +
+	"\tmodelCard\x18\x19 \x01(\v2\x19.cyclonedx.v1_6.ModelCardH\x11R\tmodelCard\x88\x01\x01\x121\n" +
+	"\x04data\x18\x1a \x03(\v2\x1d.cyclonedx.v1_6.ComponentDataR\x04data\x12Q\n" +
+	"\x10cryptoProperties\x18\x1b \x01(\v2 .cyclonedx.v1_6.CryptoPropertiesH\x12R\x10cryptoProperties\x88\x01\x01\x12M\n" +
+	"\fmanufacturer\x18\x1c \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x13R\fmanufacturer\x88\x01\x01\x12?\n" +
+	"\aauthors\x18\x1d \x03(\v2%.cyclonedx.v1_6.OrganizationalContactR\aauthors\x12\x12\n" +
+	"\x04tags\x18\x1e \x03(\tR\x04tags\x12\x1c\n" +
+	"\tomniborId\x18\x1f \x03(\tR\tomniborId\x12\x14\n" +
+	"\x05swhid\x18  \x03(\tR\x05swhidB\f\n" +
+	"\n" +
+	"_mime_typeB\n" +
+	"\n" +
+	"\b_bom_refB\v\n" +
+	"\t_supplierB\t\n" +
+	"\a_authorB\f\n" +
+	"\n" +
+	"_publisherB\b\n" +
+	"\x06_groupB\n" +
+	"\n" +
+	"\b_versionB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_scopeB\f\n" +
+	"\n" +
+	"_copyrightB\x06\n" +
+	"\x04_cpeB\a\n" +
+	"\x05_purlB\a\n" +
+	"\x05_swidB\v\n" +
+	"\t_modifiedB\v\n" +
+	"\t_pedigreeB\v\n" +
+	"\t_evidenceB\x0f\n" +
+	"\r_releaseNotesB\f\n" +
+	"\n" +
+	"_modelCardB\x13\n" +
+	"\x11_cryptoPropertiesB\x0f\n" +
+	"\r_manufacturer\"X\n" +
+	"\x12DataClassification\x12,\n" +
+	"\x04flow\x18\x01 \x01(\x0e2\x18.cyclonedx.v1_6.DataFlowR\x04flow\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"z\n" +
+	"\n" +
+	"Dependency\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\x12>\n" +
+	"\fdependencies\x18\x02 \x03(\v2\x1a.cyclonedx.v1_6.DependencyR\fdependencies\x12\x1a\n" +
+	"\bprovides\x18\x03 \x03(\tR\bprovides\"e\n" +
+	"\x04Diff\x125\n" +
+	"\x04text\x18\x01 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x00R\x04text\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01B\a\n" +
+	"\x05_textB\x06\n" +
+	"\x04_url\"\xb9\x01\n" +
+	"\x11ExternalReference\x129\n" +
+	"\x04type\x18\x01 \x01(\x0e2%.cyclonedx.v1_6.ExternalReferenceTypeR\x04type\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1d\n" +
+	"\acomment\x18\x03 \x01(\tH\x00R\acomment\x88\x01\x01\x12,\n" +
+	"\x06hashes\x18\x04 \x03(\v2\x14.cyclonedx.v1_6.HashR\x06hashesB\n" +
+	"\n" +
+	"\b_comment\"G\n" +
+	"\x04Hash\x12)\n" +
+	"\x03alg\x18\x01 \x01(\x0e2\x17.cyclonedx.v1_6.HashAlgR\x03alg\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xa8\x01\n" +
+	"\x12IdentifiableAction\x12=\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\ttimestamp\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x02R\x05email\x88\x01\x01B\f\n" +
+	"\n" +
+	"_timestampB\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_email\"\x95\x02\n" +
+	"\x05Issue\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.cyclonedx.v1_6.IssueClassificationR\x04type\x12\x13\n" +
+	"\x02id\x18\x02 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x123\n" +
+	"\x06source\x18\x05 \x01(\v2\x16.cyclonedx.v1_6.SourceH\x03R\x06source\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"references\x18\x06 \x03(\tR\n" +
+	"referencesB\x05\n" +
+	"\x03_idB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_source\"I\n" +
+	"\x06Source\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01B\a\n" +
+	"\x05_nameB\x06\n" +
+	"\x04_url\"\xe6\x01\n" +
+	"\rLicenseChoice\x123\n" +
+	"\alicense\x18\x01 \x01(\v2\x17.cyclonedx.v1_6.LicenseH\x00R\alicense\x12 \n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tH\x00R\n" +
+	"expression\x12`\n" +
+	"\x0facknowledgement\x18\x03 \x01(\x0e21.cyclonedx.v1_6.LicenseAcknowledgementEnumerationH\x01R\x0facknowledgement\x88\x01\x01B\b\n" +
+	"\x06choiceB\x12\n" +
+	"\x10_acknowledgement\"\xc1\x03\n" +
+	"\aLicense\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x12\x14\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x125\n" +
+	"\x04text\x18\x03 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x01R\x04text\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x04 \x01(\tH\x02R\x03url\x88\x01\x01\x12\x1c\n" +
+	"\abom_ref\x18\x05 \x01(\tH\x03R\x06bomRef\x88\x01\x01\x12<\n" +
+	"\tlicensing\x18\x06 \x01(\v2\x19.cyclonedx.v1_6.LicensingH\x04R\tlicensing\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\a \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x12`\n" +
+	"\x0facknowledgement\x18\b \x01(\x0e21.cyclonedx.v1_6.LicenseAcknowledgementEnumerationH\x05R\x0facknowledgement\x88\x01\x01B\t\n" +
+	"\alicenseB\a\n" +
+	"\x05_textB\x06\n" +
+	"\x04_urlB\n" +
+	"\n" +
+	"\b_bom_refB\f\n" +
+	"\n" +
+	"_licensingB\x12\n" +
+	"\x10_acknowledgement\"\xc3\x06\n" +
+	"\tLicensing\x12\x16\n" +
+	"\x06altIds\x18\x01 \x03(\tR\x06altIds\x12X\n" +
+	"\blicensor\x18\x02 \x01(\v27.cyclonedx.v1_6.Licensing.OrganizationalEntityOrContactH\x00R\blicensor\x88\x01\x01\x12X\n" +
+	"\blicensee\x18\x03 \x01(\v27.cyclonedx.v1_6.Licensing.OrganizationalEntityOrContactH\x01R\blicensee\x88\x01\x01\x12Z\n" +
+	"\tpurchaser\x18\x04 \x01(\v27.cyclonedx.v1_6.Licensing.OrganizationalEntityOrContactH\x02R\tpurchaser\x88\x01\x01\x12)\n" +
+	"\rpurchaseOrder\x18\x05 \x01(\tH\x03R\rpurchaseOrder\x88\x01\x01\x12E\n" +
+	"\flicenseTypes\x18\x06 \x03(\x0e2!.cyclonedx.v1_6.LicensingTypeEnumR\flicenseTypes\x12A\n" +
+	"\vlastRenewal\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x04R\vlastRenewal\x88\x01\x01\x12?\n" +
+	"\n" +
+	"expiration\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x05R\n" +
+	"expiration\x88\x01\x01\x1a\xbe\x01\n" +
+	"\x1dOrganizationalEntityOrContact\x12J\n" +
+	"\forganization\x18\x01 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x00R\forganization\x12G\n" +
+	"\n" +
+	"individual\x18\x02 \x01(\v2%.cyclonedx.v1_6.OrganizationalContactH\x00R\n" +
+	"individualB\b\n" +
+	"\x06choiceB\v\n" +
+	"\t_licensorB\v\n" +
+	"\t_licenseeB\f\n" +
+	"\n" +
+	"_purchaserB\x10\n" +
+	"\x0e_purchaseOrderB\x0e\n" +
+	"\f_lastRenewalB\r\n" +
+	"\v_expiration\"\xd6\x05\n" +
+	"\bMetadata\x12=\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\ttimestamp\x88\x01\x01\x12*\n" +
+	"\x05tools\x18\x02 \x03(\v2\x14.cyclonedx.v1_6.ToolR\x05tools\x12?\n" +
+	"\aauthors\x18\x03 \x03(\v2%.cyclonedx.v1_6.OrganizationalContactR\aauthors\x12<\n" +
+	"\tcomponent\x18\x04 \x01(\v2\x19.cyclonedx.v1_6.ComponentH\x01R\tcomponent\x88\x01\x01\x12O\n" +
+	"\vmanufacture\x18\x05 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityB\x02\x18\x01H\x02R\vmanufacture\x88\x01\x01\x12E\n" +
+	"\bsupplier\x18\x06 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x03R\bsupplier\x88\x01\x01\x129\n" +
+	"\blicenses\x18\a \x03(\v2\x1d.cyclonedx.v1_6.LicenseChoiceR\blicenses\x128\n" +
+	"\n" +
+	"properties\x18\b \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x12:\n" +
+	"\n" +
+	"lifecycles\x18\t \x03(\v2\x1a.cyclonedx.v1_6.LifecyclesR\n" +
+	"lifecycles\x12M\n" +
+	"\fmanufacturer\x18\n" +
+	" \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x04R\fmanufacturer\x88\x01\x01B\f\n" +
+	"\n" +
+	"_timestampB\f\n" +
+	"\n" +
+	"_componentB\x0e\n" +
+	"\f_manufactureB\v\n" +
+	"\t_supplierB\x0f\n" +
+	"\r_manufacturer\"\x9b\x01\n" +
+	"\n" +
+	"Lifecycles\x126\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x1e.cyclonedx.v1_6.LifecyclePhaseH\x00R\x05phase\x12\x14\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\b\n" +
+	"\x06choiceB\x0e\n" +
+	"\f_description\"\xad\x01\n" +
+	"\x15OrganizationalContact\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x19\n" +
+	"\x05phone\x18\x04 \x01(\tH\x03R\x05phone\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_emailB\b\n" +
+	"\x06_phone\"\x83\x02\n" +
+	"\x14OrganizationalEntity\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x10\n" +
+	"\x03url\x18\x03 \x03(\tR\x03url\x12?\n" +
+	"\acontact\x18\x04 \x03(\v2%.cyclonedx.v1_6.OrganizationalContactR\acontact\x12@\n" +
+	"\aaddress\x18\x05 \x01(\v2!.cyclonedx.v1_6.PostalAddressTypeH\x02R\aaddress\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_address\"\x88\x03\n" +
+	"\x11PostalAddressType\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x1d\n" +
+	"\acountry\x18\x02 \x01(\tH\x01R\acountry\x88\x01\x01\x12\x1b\n" +
+	"\x06region\x18\x03 \x01(\tH\x02R\x06region\x88\x01\x01\x12\x1f\n" +
+	"\blocality\x18\x04 \x01(\tH\x03R\blocality\x88\x01\x01\x128\n" +
+	"\x16post_office_box_number\x18\x05 \x01(\tH\x04R\x13postOfficeBoxNumber\x88\x01\x01\x12$\n" +
+	"\vpostal_code\x18\x06 \x01(\tH\x05R\n" +
+	"postalCode\x88\x01\x01\x12*\n" +
+	"\x0estreet_address\x18\a \x01(\tH\x06R\rstreetAddress\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\n" +
+	"\n" +
+	"\b_countryB\t\n" +
+	"\a_regionB\v\n" +
+	"\t_localityB\x19\n" +
+	"\x17_post_office_box_numberB\x0e\n" +
+	"\f_postal_codeB\x11\n" +
+	"\x0f_street_address\"\xab\x01\n" +
+	"\x05Patch\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.cyclonedx.v1_6.PatchClassificationR\x04type\x12-\n" +
+	"\x04diff\x18\x02 \x01(\v2\x14.cyclonedx.v1_6.DiffH\x00R\x04diff\x88\x01\x01\x121\n" +
+	"\bresolves\x18\x03 \x03(\v2\x15.cyclonedx.v1_6.IssueR\bresolvesB\a\n" +
+	"\x05_diff\"\xbf\x02\n" +
+	"\bPedigree\x127\n" +
+	"\tancestors\x18\x01 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\tancestors\x12;\n" +
+	"\vdescendants\x18\x02 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\vdescendants\x125\n" +
+	"\bvariants\x18\x03 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\bvariants\x120\n" +
+	"\acommits\x18\x04 \x03(\v2\x16.cyclonedx.v1_6.CommitR\acommits\x12/\n" +
+	"\apatches\x18\x05 \x03(\v2\x15.cyclonedx.v1_6.PatchR\apatches\x12\x19\n" +
+	"\x05notes\x18\x06 \x01(\tH\x00R\x05notes\x88\x01\x01B\b\n" +
+	"\x06_notes\"\x94\a\n" +
+	"\aService\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12E\n" +
+	"\bprovider\x18\x02 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x01R\bprovider\x88\x01\x01\x12\x19\n" +
+	"\x05group\x18\x03 \x01(\tH\x02R\x05group\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\aversion\x18\x05 \x01(\tH\x03R\aversion\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x04R\vdescription\x88\x01\x01\x12\x1c\n" +
+	"\tendpoints\x18\a \x03(\tR\tendpoints\x12)\n" +
+	"\rauthenticated\x18\b \x01(\bH\x05R\rauthenticated\x88\x01\x01\x12-\n" +
+	"\x10x_trust_boundary\x18\t \x01(\bH\x06R\x0exTrustBoundary\x88\x01\x01\x126\n" +
+	"\x04data\x18\n" +
+	" \x03(\v2\".cyclonedx.v1_6.DataClassificationR\x04data\x129\n" +
+	"\blicenses\x18\v \x03(\v2\x1d.cyclonedx.v1_6.LicenseChoiceR\blicenses\x12R\n" +
+	"\x13external_references\x18\f \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferences\x123\n" +
+	"\bservices\x18\r \x03(\v2\x17.cyclonedx.v1_6.ServiceR\bservices\x128\n" +
+	"\n" +
+	"properties\x18\x0e \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x12E\n" +
+	"\freleaseNotes\x18\x0f \x01(\v2\x1c.cyclonedx.v1_6.ReleaseNotesH\aR\freleaseNotes\x88\x01\x01\x12!\n" +
+	"\ttrustZone\x18\x10 \x01(\tH\bR\ttrustZone\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x11 \x03(\tR\x04tagsB\n" +
+	"\n" +
+	"\b_bom_refB\v\n" +
+	"\t_providerB\b\n" +
+	"\x06_groupB\n" +
+	"\n" +
+	"\b_versionB\x0e\n" +
+	"\f_descriptionB\x10\n" +
+	"\x0e_authenticatedB\x13\n" +
+	"\x11_x_trust_boundaryB\x0f\n" +
+	"\r_releaseNotesB\f\n" +
+	"\n" +
+	"_trustZone\"\x96\x02\n" +
+	"\x04Swid\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\aversion\x18\x03 \x01(\tH\x00R\aversion\x88\x01\x01\x12$\n" +
+	"\vtag_version\x18\x04 \x01(\x05H\x01R\n" +
+	"tagVersion\x88\x01\x01\x12\x19\n" +
+	"\x05patch\x18\x05 \x01(\bH\x02R\x05patch\x88\x01\x01\x125\n" +
+	"\x04text\x18\x06 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x03R\x04text\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\a \x01(\tH\x04R\x03url\x88\x01\x01B\n" +
+	"\n" +
+	"\b_versionB\x0e\n" +
+	"\f_tag_versionB\b\n" +
+	"\x06_patchB\a\n" +
+	"\x05_textB\x06\n" +
+	"\x04_url\"\x81\x03\n" +
+	"\x04Tool\x12\x1f\n" +
+	"\x06vendor\x18\x01 \x01(\tB\x02\x18\x01H\x00R\x06vendor\x88\x01\x01\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\x02\x18\x01H\x01R\x04name\x88\x01\x01\x12!\n" +
+	"\aversion\x18\x03 \x01(\tB\x02\x18\x01H\x02R\aversion\x88\x01\x01\x120\n" +
+	"\x06hashes\x18\x04 \x03(\v2\x14.cyclonedx.v1_6.HashB\x02\x18\x01R\x06hashes\x12V\n" +
+	"\x13external_references\x18\x05 \x03(\v2!.cyclonedx.v1_6.ExternalReferenceB\x02\x18\x01R\x12externalReferences\x129\n" +
+	"\n" +
+	"components\x18\x06 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\n" +
+	"components\x123\n" +
+	"\bservices\x18\a \x03(\v2\x17.cyclonedx.v1_6.ServiceR\bservicesB\t\n" +
+	"\a_vendorB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_version\"C\n" +
+	"\bProperty\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x00R\x05value\x88\x01\x01B\b\n" +
+	"\x06_value\"\xde\x01\n" +
+	"\vComposition\x127\n" +
+	"\taggregate\x18\x01 \x01(\x0e2\x19.cyclonedx.v1_6.AggregateR\taggregate\x12\x1e\n" +
+	"\n" +
+	"assemblies\x18\x02 \x03(\tR\n" +
+	"assemblies\x12\"\n" +
+	"\fdependencies\x18\x03 \x03(\tR\fdependencies\x12(\n" +
+	"\x0fvulnerabilities\x18\x04 \x03(\tR\x0fvulnerabilities\x12\x1c\n" +
+	"\abom_ref\x18\x05 \x01(\tH\x00R\x06bomRef\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_ref\"'\n" +
+	"\x11EvidenceCopyright\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\xd7\x02\n" +
+	"\bEvidence\x129\n" +
+	"\blicenses\x18\x01 \x03(\v2\x1d.cyclonedx.v1_6.LicenseChoiceR\blicenses\x12?\n" +
+	"\tcopyright\x18\x02 \x03(\v2!.cyclonedx.v1_6.EvidenceCopyrightR\tcopyright\x12<\n" +
+	"\bidentity\x18\x03 \x03(\v2 .cyclonedx.v1_6.EvidenceIdentityR\bidentity\x12E\n" +
+	"\voccurrences\x18\x04 \x03(\v2#.cyclonedx.v1_6.EvidenceOccurrencesR\voccurrences\x12<\n" +
+	"\tcallstack\x18\x05 \x01(\v2\x19.cyclonedx.v1_6.CallstackH\x00R\tcallstack\x88\x01\x01B\f\n" +
+	"\n" +
+	"_callstack\"\x90\x02\n" +
+	"\x10EvidenceIdentity\x127\n" +
+	"\x05field\x18\x01 \x01(\x0e2!.cyclonedx.v1_6.EvidenceFieldTypeR\x05field\x12#\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x02H\x00R\n" +
+	"confidence\x88\x01\x01\x129\n" +
+	"\amethods\x18\x03 \x03(\v2\x1f.cyclonedx.v1_6.EvidenceMethodsR\amethods\x12\x14\n" +
+	"\x05tools\x18\x04 \x03(\tR\x05tools\x12+\n" +
+	"\x0econcludedValue\x18\x05 \x01(\tH\x01R\x0econcludedValue\x88\x01\x01B\r\n" +
+	"\v_confidenceB\x11\n" +
+	"\x0f_concludedValue\"\x97\x01\n" +
+	"\x0fEvidenceMethods\x12?\n" +
+	"\ttechnique\x18\x01 \x01(\x0e2!.cyclonedx.v1_6.EvidenceTechniqueR\ttechnique\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x02R\n" +
+	"confidence\x12\x19\n" +
+	"\x05value\x18\x03 \x01(\tH\x00R\x05value\x88\x01\x01B\b\n" +
+	"\x06_value\"\x96\x02\n" +
+	"\x13EvidenceOccurrences\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
+	"\x04line\x18\x03 \x01(\x05H\x01R\x04line\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x04 \x01(\x05H\x02R\x06offset\x88\x01\x01\x12\x1b\n" +
+	"\x06symbol\x18\x05 \x01(\tH\x03R\x06symbol\x88\x01\x01\x121\n" +
+	"\x11additionalContext\x18\x06 \x01(\tH\x04R\x11additionalContext\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\a\n" +
+	"\x05_lineB\t\n" +
+	"\a_offsetB\t\n" +
+	"\a_symbolB\x14\n" +
+	"\x12_additionalContext\"\xe5\x02\n" +
+	"\tCallstack\x128\n" +
+	"\x06frames\x18\x01 \x03(\v2 .cyclonedx.v1_6.Callstack.FramesR\x06frames\x1a\x9d\x02\n" +
+	"\x06Frames\x12\x1d\n" +
+	"\apackage\x18\x01 \x01(\tH\x00R\apackage\x88\x01\x01\x12\x16\n" +
+	"\x06module\x18\x02 \x01(\tR\x06module\x12\x1f\n" +
+	"\bfunction\x18\x03 \x01(\tH\x01R\bfunction\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\tR\n" +
+	"parameters\x12\x17\n" +
+	"\x04line\x18\x05 \x01(\x05H\x02R\x04line\x88\x01\x01\x12\x1b\n" +
+	"\x06column\x18\x06 \x01(\x05H\x03R\x06column\x88\x01\x01\x12'\n" +
+	"\ffullFilename\x18\a \x01(\tH\x04R\ffullFilename\x88\x01\x01B\n" +
+	"\n" +
+	"\b_packageB\v\n" +
+	"\t_functionB\a\n" +
+	"\x05_lineB\t\n" +
+	"\a_columnB\x0f\n" +
+	"\r_fullFilename\"\xf5\x04\n" +
+	"\fReleaseNotes\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12)\n" +
+	"\rfeaturedImage\x18\x03 \x01(\tH\x01R\rfeaturedImage\x88\x01\x01\x12%\n" +
+	"\vsocialImage\x18\x04 \x01(\tH\x02R\vsocialImage\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x03R\vdescription\x88\x01\x01\x12=\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\ttimestamp\x88\x01\x01\x12\x18\n" +
+	"\aaliases\x18\a \x03(\tR\aaliases\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x121\n" +
+	"\bresolves\x18\t \x03(\v2\x15.cyclonedx.v1_6.IssueR\bresolves\x127\n" +
+	"\x05notes\x18\n" +
+	" \x03(\v2!.cyclonedx.v1_6.ReleaseNotes.NoteR\x05notes\x128\n" +
+	"\n" +
+	"properties\x18\v \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x1a`\n" +
+	"\x04Note\x12\x1b\n" +
+	"\x06locale\x18\x01 \x01(\tH\x00R\x06locale\x88\x01\x01\x120\n" +
+	"\x04text\x18\x02 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextR\x04textB\t\n" +
+	"\a_localeB\b\n" +
+	"\x06_titleB\x10\n" +
+	"\x0e_featuredImageB\x0e\n" +
+	"\f_socialImageB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_timestamp\"\x8e\n" +
+	"\n" +
+	"\rVulnerability\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x13\n" +
+	"\x02id\x18\x02 \x01(\tH\x01R\x02id\x88\x01\x01\x123\n" +
+	"\x06source\x18\x03 \x01(\v2\x16.cyclonedx.v1_6.SourceH\x02R\x06source\x88\x01\x01\x12F\n" +
+	"\n" +
+	"references\x18\x04 \x03(\v2&.cyclonedx.v1_6.VulnerabilityReferenceR\n" +
+	"references\x12=\n" +
+	"\aratings\x18\x05 \x03(\v2#.cyclonedx.v1_6.VulnerabilityRatingR\aratings\x12\x12\n" +
+	"\x04cwes\x18\x06 \x03(\x05R\x04cwes\x12%\n" +
+	"\vdescription\x18\a \x01(\tH\x03R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06detail\x18\b \x01(\tH\x04R\x06detail\x88\x01\x01\x12+\n" +
+	"\x0erecommendation\x18\t \x01(\tH\x05R\x0erecommendation\x88\x01\x01\x128\n" +
+	"\n" +
+	"advisories\x18\n" +
+	" \x03(\v2\x18.cyclonedx.v1_6.AdvisoryR\n" +
+	"advisories\x129\n" +
+	"\acreated\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x06R\acreated\x88\x01\x01\x12=\n" +
+	"\tpublished\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\aR\tpublished\x88\x01\x01\x129\n" +
+	"\aupdated\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\bR\aupdated\x88\x01\x01\x12C\n" +
+	"\acredits\x18\x0e \x01(\v2$.cyclonedx.v1_6.VulnerabilityCreditsH\tR\acredits\x88\x01\x01\x12*\n" +
+	"\x05tools\x18\x0f \x03(\v2\x14.cyclonedx.v1_6.ToolR\x05tools\x12F\n" +
+	"\banalysis\x18\x10 \x01(\v2%.cyclonedx.v1_6.VulnerabilityAnalysisH\n" +
+	"R\banalysis\x88\x01\x01\x12>\n" +
+	"\aaffects\x18\x11 \x03(\v2$.cyclonedx.v1_6.VulnerabilityAffectsR\aaffects\x128\n" +
+	"\n" +
+	"properties\x18\x12 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x12;\n" +
+	"\brejected\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampH\vR\brejected\x88\x01\x01\x12K\n" +
+	"\x0eproofOfConcept\x18\x14 \x01(\v2\x1e.cyclonedx.v1_6.ProofOfConceptH\fR\x0eproofOfConcept\x88\x01\x01\x12#\n" +
+	"\n" +
+	"workaround\x18\x15 \x01(\tH\rR\n" +
+	"workaround\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\x05\n" +
+	"\x03_idB\t\n" +
+	"\a_sourceB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_detailB\x11\n" +
+	"\x0f_recommendationB\n" +
+	"\n" +
+	"\b_createdB\f\n" +
+	"\n" +
+	"_publishedB\n" +
+	"\n" +
+	"\b_updatedB\n" +
+	"\n" +
+	"\b_creditsB\v\n" +
+	"\t_analysisB\v\n" +
+	"\t_rejectedB\x11\n" +
+	"\x0f_proofOfConceptB\r\n" +
+	"\v_workaround\"X\n" +
+	"\x16VulnerabilityReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x06source\x18\x02 \x01(\v2\x16.cyclonedx.v1_6.SourceR\x06source\"\xec\x02\n" +
+	"\x13VulnerabilityRating\x123\n" +
+	"\x06source\x18\x01 \x01(\v2\x16.cyclonedx.v1_6.SourceH\x00R\x06source\x88\x01\x01\x12\x19\n" +
+	"\x05score\x18\x02 \x01(\x01H\x01R\x05score\x88\x01\x01\x129\n" +
+	"\bseverity\x18\x03 \x01(\x0e2\x18.cyclonedx.v1_6.SeverityH\x02R\bseverity\x88\x01\x01\x128\n" +
+	"\x06method\x18\x04 \x01(\x0e2\x1b.cyclonedx.v1_6.ScoreMethodH\x03R\x06method\x88\x01\x01\x12\x1b\n" +
+	"\x06vector\x18\x05 \x01(\tH\x04R\x06vector\x88\x01\x01\x12)\n" +
+	"\rjustification\x18\x06 \x01(\tH\x05R\rjustification\x88\x01\x01B\t\n" +
+	"\a_sourceB\b\n" +
+	"\x06_scoreB\v\n" +
+	"\t_severityB\t\n" +
+	"\a_methodB\t\n" +
+	"\a_vectorB\x10\n" +
+	"\x0e_justification\"A\n" +
+	"\bAdvisory\x12\x19\n" +
+	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03urlB\b\n" +
+	"\x06_title\"\xab\x01\n" +
+	"\x14VulnerabilityCredits\x12J\n" +
+	"\rorganizations\x18\x01 \x03(\v2$.cyclonedx.v1_6.OrganizationalEntityR\rorganizations\x12G\n" +
+	"\vindividuals\x18\x02 \x03(\v2%.cyclonedx.v1_6.OrganizationalContactR\vindividuals\"\xdc\x03\n" +
+	"\x15VulnerabilityAnalysis\x12>\n" +
+	"\x05state\x18\x01 \x01(\x0e2#.cyclonedx.v1_6.ImpactAnalysisStateH\x00R\x05state\x88\x01\x01\x12V\n" +
+	"\rjustification\x18\x02 \x01(\x0e2+.cyclonedx.v1_6.ImpactAnalysisJustificationH\x01R\rjustification\x88\x01\x01\x12A\n" +
+	"\bresponse\x18\x03 \x03(\x0e2%.cyclonedx.v1_6.VulnerabilityResponseR\bresponse\x12\x1b\n" +
+	"\x06detail\x18\x04 \x01(\tH\x02R\x06detail\x88\x01\x01\x12A\n" +
+	"\vfirstIssued\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\vfirstIssued\x88\x01\x01\x12A\n" +
+	"\vlastUpdated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\vlastUpdated\x88\x01\x01B\b\n" +
+	"\x06_stateB\x10\n" +
+	"\x0e_justificationB\t\n" +
+	"\a_detailB\x0e\n" +
+	"\f_firstIssuedB\x0e\n" +
+	"\f_lastUpdated\"s\n" +
+	"\x14VulnerabilityAffects\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\x12I\n" +
+	"\bversions\x18\x02 \x03(\v2-.cyclonedx.v1_6.VulnerabilityAffectedVersionsR\bversions\"\xb2\x01\n" +
+	"\x1dVulnerabilityAffectedVersions\x12\x1a\n" +
+	"\aversion\x18\x01 \x01(\tH\x00R\aversion\x12\x16\n" +
+	"\x05range\x18\x02 \x01(\tH\x00R\x05range\x12H\n" +
+	"\x06status\x18\x03 \x01(\x0e2+.cyclonedx.v1_6.VulnerabilityAffectedStatusH\x01R\x06status\x88\x01\x01B\b\n" +
+	"\x06choiceB\t\n" +
+	"\a_status\"\xde\x01\n" +
+	"\x0eProofOfConcept\x121\n" +
+	"\x11reproductionSteps\x18\x01 \x01(\tH\x00R\x11reproductionSteps\x88\x01\x01\x12%\n" +
+	"\venvironment\x18\x02 \x01(\tH\x01R\venvironment\x88\x01\x01\x12L\n" +
+	"\x12supportingMaterial\x18\x03 \x03(\v2\x1c.cyclonedx.v1_6.AttachedTextR\x12supportingMaterialB\x14\n" +
+	"\x12_reproductionStepsB\x0e\n" +
+	"\f_environment\"\x81\x04\n" +
+	"\n" +
+	"Annotation\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x1a\n" +
+	"\bsubjects\x18\x02 \x03(\tR\bsubjects\x12B\n" +
+	"\tannotator\x18\x03 \x01(\v2$.cyclonedx.v1_6.Annotation.AnnotatorR\tannotator\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x1a\x9a\x02\n" +
+	"\tAnnotator\x12J\n" +
+	"\forganization\x18\x01 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x00R\forganization\x12G\n" +
+	"\n" +
+	"individual\x18\x02 \x01(\v2%.cyclonedx.v1_6.OrganizationalContactH\x00R\n" +
+	"individual\x129\n" +
+	"\tcomponent\x18\x03 \x01(\v2\x19.cyclonedx.v1_6.ComponentH\x00R\tcomponent\x123\n" +
+	"\aservice\x18\x04 \x01(\v2\x17.cyclonedx.v1_6.ServiceH\x00R\aserviceB\b\n" +
+	"\x06choiceB\n" +
+	"\n" +
+	"\b_bom_ref\"\x95\x02\n" +
+	"\aFormula\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x129\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\n" +
+	"components\x123\n" +
+	"\bservices\x18\x03 \x03(\v2\x17.cyclonedx.v1_6.ServiceR\bservices\x126\n" +
+	"\tworkflows\x18\x04 \x03(\v2\x18.cyclonedx.v1_6.WorkflowR\tworkflows\x128\n" +
+	"\n" +
+	"properties\x18\x05 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\n" +
+	"\n" +
+	"\b_bom_ref\"\xbb\a\n" +
+	"\bWorkflow\x12\x17\n" +
+	"\abom_ref\x18\x01 \x01(\tR\x06bomRef\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12W\n" +
+	"\x12resourceReferences\x18\x05 \x03(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceR\x12resourceReferences\x12*\n" +
+	"\x05tasks\x18\x06 \x03(\v2\x14.cyclonedx.v1_6.TaskR\x05tasks\x12F\n" +
+	"\x10taskDependencies\x18\a \x03(\v2\x1a.cyclonedx.v1_6.DependencyR\x10taskDependencies\x126\n" +
+	"\ttaskTypes\x18\b \x03(\x0e2\x18.cyclonedx.v1_6.TaskTypeR\ttaskTypes\x126\n" +
+	"\atrigger\x18\t \x01(\v2\x17.cyclonedx.v1_6.TriggerH\x02R\atrigger\x88\x01\x01\x12*\n" +
+	"\x05steps\x18\n" +
+	" \x03(\v2\x14.cyclonedx.v1_6.StepR\x05steps\x121\n" +
+	"\x06inputs\x18\v \x03(\v2\x19.cyclonedx.v1_6.InputTypeR\x06inputs\x124\n" +
+	"\aoutputs\x18\f \x03(\v2\x1a.cyclonedx.v1_6.OutputTypeR\aoutputs\x12=\n" +
+	"\ttimeStart\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x03R\ttimeStart\x88\x01\x01\x129\n" +
+	"\atimeEnd\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampH\x04R\atimeEnd\x88\x01\x01\x129\n" +
+	"\n" +
+	"workspaces\x18\x0f \x03(\v2\x19.cyclonedx.v1_6.WorkspaceR\n" +
+	"workspaces\x12D\n" +
+	"\x0fruntimeTopology\x18\x10 \x03(\v2\x1a.cyclonedx.v1_6.DependencyR\x0fruntimeTopology\x128\n" +
+	"\n" +
+	"properties\x18\x11 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_triggerB\f\n" +
+	"\n" +
+	"_timeStartB\n" +
+	"\n" +
+	"\b_timeEnd\"\xc3\x06\n" +
+	"\x04Task\x12\x17\n" +
+	"\abom_ref\x18\x01 \x01(\tR\x06bomRef\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12W\n" +
+	"\x12resourceReferences\x18\x05 \x03(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceR\x12resourceReferences\x126\n" +
+	"\ttaskTypes\x18\x06 \x03(\x0e2\x18.cyclonedx.v1_6.TaskTypeR\ttaskTypes\x126\n" +
+	"\atrigger\x18\a \x01(\v2\x17.cyclonedx.v1_6.TriggerH\x02R\atrigger\x88\x01\x01\x12*\n" +
+	"\x05steps\x18\b \x03(\v2\x14.cyclonedx.v1_6.StepR\x05steps\x121\n" +
+	"\x06inputs\x18\t \x03(\v2\x19.cyclonedx.v1_6.InputTypeR\x06inputs\x124\n" +
+	"\aoutputs\x18\n" +
+	" \x03(\v2\x1a.cyclonedx.v1_6.OutputTypeR\aoutputs\x12=\n" +
+	"\ttimeStart\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x03R\ttimeStart\x88\x01\x01\x129\n" +
+	"\atimeEnd\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\x04R\atimeEnd\x88\x01\x01\x129\n" +
+	"\n" +
+	"workspaces\x18\r \x03(\v2\x19.cyclonedx.v1_6.WorkspaceR\n" +
+	"workspaces\x12D\n" +
+	"\x0fruntimeTopology\x18\x0e \x03(\v2\x1a.cyclonedx.v1_6.DependencyR\x0fruntimeTopology\x128\n" +
+	"\n" +
+	"properties\x18\x0f \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_triggerB\f\n" +
+	"\n" +
+	"_timeStartB\n" +
+	"\n" +
+	"\b_timeEnd\"\xce\x01\n" +
+	"\x04Step\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x123\n" +
+	"\bcommands\x18\x03 \x03(\v2\x17.cyclonedx.v1_6.CommandR\bcommands\x128\n" +
+	"\n" +
+	"properties\x18\x04 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"q\n" +
+	"\aCommand\x12\x1f\n" +
+	"\bexecuted\x18\x01 \x01(\tH\x00R\bexecuted\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\x02 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\v\n" +
+	"\t_executed\"\xa6\x06\n" +
+	"\tWorkspace\x12\x17\n" +
+	"\abom_ref\x18\x01 \x01(\tR\x06bomRef\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x01R\vdescription\x88\x01\x01\x12W\n" +
+	"\x12resourceReferences\x18\x06 \x03(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceR\x12resourceReferences\x12I\n" +
+	"\n" +
+	"accessMode\x18\a \x01(\x0e2$.cyclonedx.v1_6.Workspace.AccessModeH\x02R\n" +
+	"accessMode\x88\x01\x01\x12!\n" +
+	"\tmountPath\x18\b \x01(\tH\x03R\tmountPath\x88\x01\x01\x12-\n" +
+	"\x0fmanagedDataType\x18\t \x01(\tH\x04R\x0fmanagedDataType\x88\x01\x01\x12)\n" +
+	"\rvolumeRequest\x18\n" +
+	" \x01(\tH\x05R\rvolumeRequest\x88\x01\x01\x123\n" +
+	"\x06volume\x18\v \x01(\v2\x16.cyclonedx.v1_6.VolumeH\x06R\x06volume\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\f \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\"\x9c\x01\n" +
+	"\n" +
+	"AccessMode\x12\x19\n" +
+	"\x15ACCESS_MODE_READ_ONLY\x10\x00\x12\x1a\n" +
+	"\x16ACCESS_MODE_READ_WRITE\x10\x01\x12\x1f\n" +
+	"\x1bACCESS_MODE_READ_WRITE_ONCE\x10\x02\x12\x1a\n" +
+	"\x16ACCESS_MODE_WRITE_ONCE\x10\x03\x12\x1a\n" +
+	"\x16ACCESS_MODE_WRITE_ONLY\x10\x04B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_accessModeB\f\n" +
+	"\n" +
+	"_mountPathB\x12\n" +
+	"\x10_managedDataTypeB\x10\n" +
+	"\x0e_volumeRequestB\t\n" +
+	"\a_volume\"\xc4\x03\n" +
+	"\x06Volume\x12\x15\n" +
+	"\x03uid\x18\x01 \x01(\tH\x00R\x03uid\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12:\n" +
+	"\x04mode\x18\x03 \x01(\x0e2!.cyclonedx.v1_6.Volume.VolumeModeH\x02R\x04mode\x88\x01\x01\x12\x17\n" +
+	"\x04path\x18\x04 \x01(\tH\x03R\x04path\x88\x01\x01\x12)\n" +
+	"\rsizeAllocated\x18\x05 \x01(\tH\x04R\rsizeAllocated\x88\x01\x01\x12#\n" +
+	"\n" +
+	"persistent\x18\x06 \x01(\bH\x05R\n" +
+	"persistent\x88\x01\x01\x12\x1b\n" +
+	"\x06remote\x18\a \x01(\bH\x06R\x06remote\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\b \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\"?\n" +
+	"\n" +
+	"VolumeMode\x12\x1a\n" +
+	"\x16VOLUME_MODE_FILESYSTEM\x10\x00\x12\x15\n" +
+	"\x11VOLUME_MODE_BLOCK\x10\x01B\x06\n" +
+	"\x04_uidB\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_modeB\a\n" +
+	"\x05_pathB\x10\n" +
+	"\x0e_sizeAllocatedB\r\n" +
+	"\v_persistentB\t\n" +
+	"\a_remote\"\x86\x06\n" +
+	"\aTrigger\x12\x17\n" +
+	"\abom_ref\x18\x01 \x01(\tR\x06bomRef\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12W\n" +
+	"\x12resourceReferences\x18\x05 \x03(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceR\x12resourceReferences\x127\n" +
+	"\x04type\x18\x06 \x01(\x0e2#.cyclonedx.v1_6.Trigger.TriggerTypeR\x04type\x120\n" +
+	"\x05event\x18\a \x01(\v2\x15.cyclonedx.v1_6.EventH\x02R\x05event\x88\x01\x01\x129\n" +
+	"\n" +
+	"conditions\x18\b \x03(\v2\x19.cyclonedx.v1_6.ConditionR\n" +
+	"conditions\x12E\n" +
+	"\rtimeActivated\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x03R\rtimeActivated\x88\x01\x01\x121\n" +
+	"\x06inputs\x18\n" +
+	" \x03(\v2\x19.cyclonedx.v1_6.InputTypeR\x06inputs\x124\n" +
+	"\aoutputs\x18\v \x03(\v2\x1a.cyclonedx.v1_6.OutputTypeR\aoutputs\x128\n" +
+	"\n" +
+	"properties\x18\f \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\"r\n" +
+	"\vTriggerType\x12\x17\n" +
+	"\x13TRIGGER_TYPE_MANUAL\x10\x00\x12\x14\n" +
+	"\x10TRIGGER_TYPE_API\x10\x01\x12\x18\n" +
+	"\x14TRIGGER_TYPE_WEBHOOK\x10\x02\x12\x1a\n" +
+	"\x16TRIGGER_TYPE_SCHEDULED\x10\x03B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_eventB\x10\n" +
+	"\x0e_timeActivated\"\xcf\x03\n" +
+	"\x05Event\x12\x15\n" +
+	"\x03uid\x18\x01 \x01(\tH\x00R\x03uid\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x12C\n" +
+	"\ftimeReceived\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\ftimeReceived\x88\x01\x01\x125\n" +
+	"\x04data\x18\x04 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x03R\x04data\x88\x01\x01\x12D\n" +
+	"\x06source\x18\x05 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x04R\x06source\x88\x01\x01\x12D\n" +
+	"\x06target\x18\x06 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x05R\x06target\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\a \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\x06\n" +
+	"\x04_uidB\x0e\n" +
+	"\f_descriptionB\x0f\n" +
+	"\r_timeReceivedB\a\n" +
+	"\x05_dataB\t\n" +
+	"\a_sourceB\t\n" +
+	"\a_target\"\x84\x04\n" +
+	"\tInputType\x12D\n" +
+	"\x06source\x18\x01 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x00R\x06source\x88\x01\x01\x12D\n" +
+	"\x06target\x18\x02 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x01R\x06target\x88\x01\x01\x12H\n" +
+	"\bresource\x18\x03 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x02R\bresource\x88\x01\x01\x129\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\v2\x19.cyclonedx.v1_6.ParameterR\n" +
+	"parameters\x12I\n" +
+	"\x0fenvironmentVars\x18\x05 \x03(\v2\x1f.cyclonedx.v1_6.EnvironmentVarsR\x0fenvironmentVars\x125\n" +
+	"\x04data\x18\x06 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x03R\x04data\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\a \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\t\n" +
+	"\a_sourceB\t\n" +
+	"\a_targetB\v\n" +
+	"\t_resourceB\a\n" +
+	"\x05_data\"\xc0\x05\n" +
+	"\n" +
+	"OutputType\x12B\n" +
+	"\x04type\x18\x01 \x01(\x0e2).cyclonedx.v1_6.OutputType.OutputTypeTypeH\x00R\x04type\x88\x01\x01\x12D\n" +
+	"\x06source\x18\x02 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x01R\x06source\x88\x01\x01\x12D\n" +
+	"\x06target\x18\x03 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x02R\x06target\x88\x01\x01\x12H\n" +
+	"\bresource\x18\x04 \x01(\v2'.cyclonedx.v1_6.ResourceReferenceChoiceH\x03R\bresource\x88\x01\x01\x125\n" +
+	"\x04data\x18\x05 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x04R\x04data\x88\x01\x01\x12I\n" +
+	"\x0fenvironmentVars\x18\x06 \x03(\v2\x1f.cyclonedx.v1_6.EnvironmentVarsR\x0fenvironmentVars\x128\n" +
+	"\n" +
+	"properties\x18\a \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\"\xa6\x01\n" +
+	"\x0eOutputTypeType\x12\x18\n" +
+	"\x14OUTPUT_TYPE_ARTIFACT\x10\x00\x12\x1b\n" +
+	"\x17OUTPUT_TYPE_ATTESTATION\x10\x01\x12\x13\n" +
+	"\x0fOUTPUT_TYPE_LOG\x10\x02\x12\x18\n" +
+	"\x14OUTPUT_TYPE_EVIDENCE\x10\x03\x12\x17\n" +
+	"\x13OUTPUT_TYPE_METRICS\x10\x04\x12\x15\n" +
+	"\x11OUTPUT_TYPE_OTHER\x10\x05B\a\n" +
+	"\x05_typeB\t\n" +
+	"\a_sourceB\t\n" +
+	"\a_targetB\v\n" +
+	"\t_resourceB\a\n" +
+	"\x05_data\"\x8a\x01\n" +
+	"\x17ResourceReferenceChoice\x12\x12\n" +
+	"\x03ref\x18\x01 \x01(\tH\x00R\x03ref\x12Q\n" +
+	"\x11externalReference\x18\x02 \x01(\v2!.cyclonedx.v1_6.ExternalReferenceH\x00R\x11externalReferenceB\b\n" +
+	"\x06choice\"\xb0\x01\n" +
+	"\tCondition\x12%\n" +
+	"\vdescription\x18\x01 \x01(\tH\x00R\vdescription\x88\x01\x01\x12#\n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tH\x01R\n" +
+	"expression\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_expression\"\x80\x01\n" +
+	"\tParameter\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x01R\x05value\x88\x01\x01\x12\x1f\n" +
+	"\bdataType\x18\x03 \x01(\tH\x02R\bdataType\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_valueB\v\n" +
+	"\t_dataType\"k\n" +
+	"\x0fEnvironmentVars\x126\n" +
+	"\bproperty\x18\x01 \x01(\v2\x18.cyclonedx.v1_6.PropertyH\x00R\bproperty\x12\x16\n" +
+	"\x05value\x18\x02 \x01(\tH\x00R\x05valueB\b\n" +
+	"\x06choice\"\xfd\x05\n" +
+	"\rComponentData\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.cyclonedx.v1_6.ComponentDataTypeR\x04type\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12T\n" +
+	"\bcontents\x18\x04 \x01(\v23.cyclonedx.v1_6.ComponentData.ComponentDataContentsH\x02R\bcontents\x88\x01\x01\x12+\n" +
+	"\x0eclassification\x18\x05 \x01(\tH\x03R\x0eclassification\x88\x01\x01\x12$\n" +
+	"\rsensitiveData\x18\x06 \x03(\tR\rsensitiveData\x12C\n" +
+	"\bgraphics\x18\a \x01(\v2\".cyclonedx.v1_6.GraphicsCollectionH\x04R\bgraphics\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\b \x01(\tH\x05R\vdescription\x88\x01\x01\x12C\n" +
+	"\n" +
+	"governance\x18\t \x01(\v2\x1e.cyclonedx.v1_6.DataGovernanceH\x06R\n" +
+	"governance\x88\x01\x01\x1a\xc2\x01\n" +
+	"\x15ComponentDataContents\x12A\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x00R\n" +
+	"attachment\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\r\n" +
+	"\v_attachmentB\x06\n" +
+	"\x04_urlB\n" +
+	"\n" +
+	"\b_bom_refB\a\n" +
+	"\x05_nameB\v\n" +
+	"\t_contentsB\x11\n" +
+	"\x0f_classificationB\v\n" +
+	"\t_graphicsB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_governance\"\xdd\x03\n" +
+	"\x0eDataGovernance\x12]\n" +
+	"\n" +
+	"custodians\x18\x01 \x03(\v2=.cyclonedx.v1_6.DataGovernance.DataGovernanceResponsiblePartyR\n" +
+	"custodians\x12Y\n" +
+	"\bstewards\x18\x02 \x03(\v2=.cyclonedx.v1_6.DataGovernance.DataGovernanceResponsiblePartyR\bstewards\x12U\n" +
+	"\x06owners\x18\x03 \x03(\v2=.cyclonedx.v1_6.DataGovernance.DataGovernanceResponsiblePartyR\x06owners\x1a\xb9\x01\n" +
+	"\x1eDataGovernanceResponsibleParty\x12J\n" +
+	"\forganization\x18\x01 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x00R\forganization\x12A\n" +
+	"\acontact\x18\x02 \x01(\v2%.cyclonedx.v1_6.OrganizationalContactH\x00R\acontactB\b\n" +
+	"\x06choice\"\x87\x02\n" +
+	"\x12GraphicsCollection\x12%\n" +
+	"\vdescription\x18\x01 \x01(\tH\x00R\vdescription\x88\x01\x01\x12J\n" +
+	"\n" +
+	"collection\x18\x02 \x03(\v2*.cyclonedx.v1_6.GraphicsCollection.GraphicR\n" +
+	"collection\x1an\n" +
+	"\aGraphic\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x127\n" +
+	"\x05image\x18\x02 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x01R\x05image\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_imageB\x0e\n" +
+	"\f_description\"\x88)\n" +
+	"\tModelCard\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12X\n" +
+	"\x0fmodelParameters\x18\x02 \x01(\v2).cyclonedx.v1_6.ModelCard.ModelParametersH\x01R\x0fmodelParameters\x88\x01\x01\x12g\n" +
+	"\x14quantitativeAnalysis\x18\x03 \x01(\v2..cyclonedx.v1_6.ModelCard.QuantitativeAnalysisH\x02R\x14quantitativeAnalysis\x88\x01\x01\x12^\n" +
+	"\x0econsiderations\x18\x04 \x01(\v21.cyclonedx.v1_6.ModelCard.ModelCardConsiderationsH\x03R\x0econsiderations\x88\x01\x01\x1a\xdb\x06\n" +
+	"\x0fModelParameters\x12S\n" +
+	"\bapproach\x18\x01 \x01(\v22.cyclonedx.v1_6.ModelCard.ModelParameters.ApproachH\x00R\bapproach\x88\x01\x01\x12\x17\n" +
+	"\x04task\x18\x02 \x01(\tH\x01R\x04task\x88\x01\x01\x123\n" +
+	"\x12architectureFamily\x18\x03 \x01(\tH\x02R\x12architectureFamily\x88\x01\x01\x121\n" +
+	"\x11modelArchitecture\x18\x04 \x01(\tH\x03R\x11modelArchitecture\x88\x01\x01\x12N\n" +
+	"\bdatasets\x18\x05 \x03(\v22.cyclonedx.v1_6.ModelCard.ModelParameters.DatasetsR\bdatasets\x12f\n" +
+	"\x06inputs\x18\x06 \x03(\v2N.cyclonedx.v1_6.ModelCard.ModelParameters.MachineLearningInputOutputParametersR\x06inputs\x12h\n" +
+	"\aoutputs\x18\a \x03(\v2N.cyclonedx.v1_6.ModelCard.ModelParameters.MachineLearningInputOutputParametersR\aoutputs\x1aX\n" +
+	"\bApproach\x12C\n" +
+	"\x04type\x18\x01 \x01(\x0e2*.cyclonedx.v1_6.ModelParameterApproachTypeH\x00R\x04type\x88\x01\x01B\a\n" +
+	"\x05_type\x1ac\n" +
+	"\bDatasets\x129\n" +
+	"\adataset\x18\x01 \x01(\v2\x1d.cyclonedx.v1_6.ComponentDataH\x00R\adataset\x12\x12\n" +
+	"\x03ref\x18\x02 \x01(\tH\x00R\x03refB\b\n" +
+	"\x06choice\x1aN\n" +
+	"$MachineLearningInputOutputParameters\x12\x1b\n" +
+	"\x06format\x18\x01 \x01(\tH\x00R\x06format\x88\x01\x01B\t\n" +
+	"\a_formatB\v\n" +
+	"\t_approachB\a\n" +
+	"\x05_taskB\x15\n" +
+	"\x13_architectureFamilyB\x14\n" +
+	"\x12_modelArchitecture\x1a\xff\x04\n" +
+	"\x14QuantitativeAnalysis\x12q\n" +
+	"\x12performanceMetrics\x18\x01 \x03(\v2A.cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetricsR\x12performanceMetrics\x12C\n" +
+	"\bgraphics\x18\x02 \x01(\v2\".cyclonedx.v1_6.GraphicsCollectionH\x00R\bgraphics\x88\x01\x01\x1a\xa1\x03\n" +
+	"\x12PerformanceMetrics\x12\x17\n" +
+	"\x04type\x18\x01 \x01(\tH\x00R\x04type\x88\x01\x01\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x01R\x05value\x88\x01\x01\x12\x19\n" +
+	"\x05slice\x18\x03 \x01(\tH\x02R\x05slice\x88\x01\x01\x12\x89\x01\n" +
+	"\x12confidenceInterval\x18\x04 \x01(\v2T.cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics.ConfidenceIntervalH\x03R\x12confidenceInterval\x88\x01\x01\x1a|\n" +
+	"\x12ConfidenceInterval\x12#\n" +
+	"\n" +
+	"lowerBound\x18\x01 \x01(\tH\x00R\n" +
+	"lowerBound\x88\x01\x01\x12#\n" +
+	"\n" +
+	"upperBound\x18\x02 \x01(\tH\x01R\n" +
+	"upperBound\x88\x01\x01B\r\n" +
+	"\v_lowerBoundB\r\n" +
+	"\v_upperBoundB\a\n" +
+	"\x05_typeB\b\n" +
+	"\x06_valueB\b\n" +
+	"\x06_sliceB\x15\n" +
+	"\x13_confidenceIntervalB\v\n" +
+	"\t_graphics\x1a\x8d\x1a\n" +
+	"\x17ModelCardConsiderations\x12\x14\n" +
+	"\x05users\x18\x01 \x03(\tR\x05users\x12\x1a\n" +
+	"\buseCases\x18\x02 \x03(\tR\buseCases\x122\n" +
+	"\x14technicalLimitations\x18\x03 \x03(\tR\x14technicalLimitations\x122\n" +
+	"\x14performanceTradeoffs\x18\x04 \x03(\tR\x14performanceTradeoffs\x12}\n" +
+	"\x15ethicalConsiderations\x18\x05 \x03(\v2G.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EthicalConsiderationsR\x15ethicalConsiderations\x12w\n" +
+	"\x13fairnessAssessments\x18\x06 \x03(\v2E.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.FairnessAssessmentsR\x13fairnessAssessments\x12\x94\x01\n" +
+	"\x1benvironmentalConsiderations\x18\a \x01(\v2M.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnvironmentalConsiderationsH\x00R\x1benvironmentalConsiderations\x88\x01\x01\x1a\x85\x01\n" +
+	"\x15EthicalConsiderations\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x123\n" +
+	"\x12mitigationStrategy\x18\x02 \x01(\tH\x01R\x12mitigationStrategy\x88\x01\x01B\a\n" +
+	"\x05_nameB\x15\n" +
+	"\x13_mitigationStrategy\x1a\xeb\x01\n" +
+	"\x13FairnessAssessments\x12%\n" +
+	"\vgroupAtRisk\x18\x01 \x01(\tH\x00R\vgroupAtRisk\x88\x01\x01\x12\x1f\n" +
+	"\bbenefits\x18\x02 \x01(\tH\x01R\bbenefits\x88\x01\x01\x12\x19\n" +
+	"\x05harms\x18\x03 \x01(\tH\x02R\x05harms\x88\x01\x01\x123\n" +
+	"\x12mitigationStrategy\x18\x04 \x01(\tH\x03R\x12mitigationStrategy\x88\x01\x01B\x0e\n" +
+	"\f_groupAtRiskB\v\n" +
+	"\t_benefitsB\b\n" +
+	"\x06_harmsB\x15\n" +
+	"\x13_mitigationStrategy\x1a\xcc\x01\n" +
+	"\x1bEnvironmentalConsiderations\x12s\n" +
+	"\x12energyConsumptions\x18\x01 \x03(\v2C.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumptionR\x12energyConsumptions\x128\n" +
+	"\n" +
+	"properties\x18\x02 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x1a\x8e\x05\n" +
+	"\x11EnergyConsumption\x12\\\n" +
+	"\bactivity\x18\x01 \x01(\x0e2@.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyActivityR\bactivity\x12n\n" +
+	"\x0fenergyProviders\x18\x02 \x03(\v2D.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderTypeR\x0fenergyProviders\x12o\n" +
+	"\x12activityEnergyCost\x18\x03 \x01(\v2?.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasureR\x12activityEnergyCost\x12o\n" +
+	"\x11co2CostEquivalent\x18\x04 \x01(\v2<.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2MeasureH\x00R\x11co2CostEquivalent\x88\x01\x01\x12g\n" +
+	"\rco2CostOffset\x18\x05 \x01(\v2<.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2MeasureH\x01R\rco2CostOffset\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\x06 \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"propertiesB\x14\n" +
+	"\x12_co2CostEquivalentB\x10\n" +
+	"\x0e_co2CostOffset\x1a\xa8\x01\n" +
+	"\rEnergyMeasure\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x02R\x05value\x12^\n" +
+	"\x04unit\x18\x02 \x01(\x0e2J.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure.EnergyUnitR\x04unit\"!\n" +
+	"\n" +
+	"EnergyUnit\x12\x13\n" +
+	"\x0fENERGY_UNIT_KWH\x10\x00\x1a\x9c\x01\n" +
+	"\n" +
+	"Co2Measure\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x02R\x05value\x12X\n" +
+	"\x04unit\x18\x02 \x01(\x0e2D.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure.Co2UnitR\x04unit\"\x1e\n" +
+	"\aCo2Unit\x12\x13\n" +
+	"\x0fCO2_UNIT_TCO2EQ\x10\x00\x1a\xdf\x03\n" +
+	"\x12EnergyProviderType\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x12H\n" +
+	"\forganization\x18\x03 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityR\forganization\x12b\n" +
+	"\fenergySource\x18\x04 \x01(\x0e2>.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergySourceR\fenergySource\x12g\n" +
+	"\x0eenergyProvided\x18\x05 \x01(\v2?.cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasureR\x0eenergyProvided\x12Q\n" +
+	"\x12externalReferences\x18\x06 \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferencesB\n" +
+	"\n" +
+	"\b_bom_refB\x0e\n" +
+	"\f_description\"\xd1\x02\n" +
+	"\x0eEnergyActivity\x12\x1f\n" +
+	"\x1bENERGY_ACTIVITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ENERGY_ACTIVITY_OTHER\x10\x01\x12\x1a\n" +
+	"\x16ENERGY_ACTIVITY_DESIGN\x10\x02\x12#\n" +
+	"\x1fENERGY_ACTIVITY_DATA_COLLECTION\x10\x03\x12$\n" +
+	" ENERGY_ACTIVITY_DATA_PREPARATION\x10\x04\x12\x1c\n" +
+	"\x18ENERGY_ACTIVITY_TRAINING\x10\x05\x12\x1f\n" +
+	"\x1bENERGY_ACTIVITY_FINE_TUNING\x10\x06\x12\x1e\n" +
+	"\x1aENERGY_ACTIVITY_VALIDATION\x10\a\x12\x1e\n" +
+	"\x1aENERGY_ACTIVITY_DEPLOYMENT\x10\b\x12\x1d\n" +
+	"\x19ENERGY_ACTIVITY_INFERENCE\x10\t\"\xd2\x02\n" +
+	"\fEnergySource\x12\x1d\n" +
+	"\x19ENERGY_SOURCE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ENERGY_SOURCE_COAL\x10\x01\x12\x15\n" +
+	"\x11ENERGY_SOURCE_OIL\x10\x02\x12\x1d\n" +
+	"\x19ENERGY_SOURCE_NATURAL_GAS\x10\x03\x12\x19\n" +
+	"\x15ENERGY_SOURCE_NUCLEAR\x10\x04\x12\x16\n" +
+	"\x12ENERGY_SOURCE_WIND\x10\x05\x12\x17\n" +
+	"\x13ENERGY_SOURCE_SOLAR\x10\x06\x12\x1c\n" +
+	"\x18ENERGY_SOURCE_GEOTHERMAL\x10\a\x12\x1c\n" +
+	"\x18ENERGY_SOURCE_HYDROPOWER\x10\b\x12\x19\n" +
+	"\x15ENERGY_SOURCE_BIOFUEL\x10\t\x12\x19\n" +
+	"\x15ENERGY_SOURCE_UNKNOWN\x10\n" +
+	"\x12\x17\n" +
+	"\x13ENERGY_SOURCE_OTHER\x10\vB\x1e\n" +
+	"\x1c_environmentalConsiderationsB\n" +
+	"\n" +
+	"\b_bom_refB\x12\n" +
+	"\x10_modelParametersB\x17\n" +
+	"\x15_quantitativeAnalysisB\x11\n" +
+	"\x0f_considerations\"\xe0N\n" +
+	"\x10CryptoProperties\x12N\n" +
+	"\tassetType\x18\x01 \x01(\x0e20.cyclonedx.v1_6.CryptoProperties.CryptoAssetTypeR\tassetType\x12k\n" +
+	"\x13algorithmProperties\x18\x02 \x01(\v24.cyclonedx.v1_6.CryptoProperties.AlgorithmPropertiesH\x00R\x13algorithmProperties\x88\x01\x01\x12q\n" +
+	"\x15certificateProperties\x18\x03 \x01(\v26.cyclonedx.v1_6.CryptoProperties.CertificatePropertiesH\x01R\x15certificateProperties\x88\x01\x01\x12\x8f\x01\n" +
+	"\x1frelatedCryptoMaterialProperties\x18\x04 \x01(\v2@.cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialPropertiesH\x02R\x1frelatedCryptoMaterialProperties\x88\x01\x01\x12h\n" +
+	"\x12protocolProperties\x18\x05 \x01(\v23.cyclonedx.v1_6.CryptoProperties.ProtocolPropertiesH\x03R\x12protocolProperties\x88\x01\x01\x12\x15\n" +
+	"\x03oid\x18\x06 \x01(\tH\x04R\x03oid\x88\x01\x01\x1a\xd0(\n" +
+	"\x13AlgorithmProperties\x12g\n" +
+	"\tprimitive\x18\x01 \x01(\x0e2D.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoPrimitiveH\x00R\tprimitive\x88\x01\x01\x12;\n" +
+	"\x16parameterSetIdentifier\x18\x02 \x01(\tH\x01R\x16parameterSetIdentifier\x88\x01\x01\x12\x19\n" +
+	"\x05curve\x18\x03 \x01(\tH\x02R\x05curve\x88\x01\x01\x12\x88\x01\n" +
+	"\x14executionEnvironment\x18\x04 \x01(\x0e2O.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoExecutionEnvironmentH\x03R\x14executionEnvironment\x88\x01\x01\x12\x8e\x01\n" +
+	"\x16implementationPlatform\x18\x05 \x01(\x0e2Q.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoImplementationPlatformH\x04R\x16implementationPlatform\x88\x01\x01\x12}\n" +
+	"\x12certificationLevel\x18\x06 \x03(\x0e2M.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoCertificationLevelR\x12certificationLevel\x12a\n" +
+	"\x04mode\x18\a \x01(\x0e2H.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmModeH\x05R\x04mode\x88\x01\x01\x12j\n" +
+	"\apadding\x18\b \x01(\x0e2K.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmPaddingH\x06R\apadding\x88\x01\x01\x12m\n" +
+	"\x0fcryptoFunctions\x18\t \x03(\x0e2C.cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoFunctionR\x0fcryptoFunctions\x12;\n" +
+	"\x16classicalSecurityLevel\x18\n" +
+	" \x01(\x05H\aR\x16classicalSecurityLevel\x88\x01\x01\x12?\n" +
+	"\x18nistQuantumSecurityLevel\x18\v \x01(\x05H\bR\x18nistQuantumSecurityLevel\x88\x01\x01\"\xe4\x03\n" +
+	"\x0fCryptoPrimitive\x12 \n" +
+	"\x1cCRYPTO_PRIMITIVE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CRYPTO_PRIMITIVE_UNKNOWN\x10\x01\x12\x1a\n" +
+	"\x16CRYPTO_PRIMITIVE_OTHER\x10\x02\x12\x19\n" +
+	"\x15CRYPTO_PRIMITIVE_DRBG\x10\x03\x12\x18\n" +
+	"\x14CRYPTO_PRIMITIVE_MAC\x10\x04\x12!\n" +
+	"\x1dCRYPTO_PRIMITIVE_BLOCK_CIPHER\x10\x05\x12\"\n" +
+	"\x1eCRYPTO_PRIMITIVE_STREAM_CIPHER\x10\x06\x12\x1e\n" +
+	"\x1aCRYPTO_PRIMITIVE_SIGNATURE\x10\a\x12\x19\n" +
+	"\x15CRYPTO_PRIMITIVE_HASH\x10\b\x12\x18\n" +
+	"\x14CRYPTO_PRIMITIVE_PKE\x10\t\x12\x18\n" +
+	"\x14CRYPTO_PRIMITIVE_XOF\x10\n" +
+	"\x12\x18\n" +
+	"\x14CRYPTO_PRIMITIVE_KDF\x10\v\x12\x1e\n" +
+	"\x1aCRYPTO_PRIMITIVE_KEY_AGREE\x10\f\x12\x18\n" +
+	"\x14CRYPTO_PRIMITIVE_KEM\x10\r\x12\x17\n" +
+	"\x13CRYPTO_PRIMITIVE_AE\x10\x0e\x12\x1d\n" +
+	"\x19CRYPTO_PRIMITIVE_COMBINER\x10\x0f\"\xe4\x02\n" +
+	"\x1aCryptoExecutionEnvironment\x12,\n" +
+	"(CRYPTO_EXECUTION_ENVIRONMENT_UNSPECIFIED\x10\x00\x12(\n" +
+	"$CRYPTO_EXECUTION_ENVIRONMENT_UNKNOWN\x10\x01\x12&\n" +
+	"\"CRYPTO_EXECUTION_ENVIRONMENT_OTHER\x10\x02\x123\n" +
+	"/CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_PLAIN_RAM\x10\x03\x127\n" +
+	"3CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_ENCRYPTED_RAM\x10\x04\x12-\n" +
+	")CRYPTO_EXECUTION_ENVIRONMENT_SOFTWARE_TEE\x10\x05\x12)\n" +
+	"%CRYPTO_EXECUTION_ENVIRONMENT_HARDWARE\x10\x06\"\xa8\x05\n" +
+	"\x1cCryptoImplementationPlatform\x12.\n" +
+	"*CRYPTO_IMPLEMENTATION_PLATFORM_UNSPECIFIED\x10\x00\x12*\n" +
+	"&CRYPTO_IMPLEMENTATION_PLATFORM_UNKNOWN\x10\x01\x12(\n" +
+	"$CRYPTO_IMPLEMENTATION_PLATFORM_OTHER\x10\x02\x12*\n" +
+	"&CRYPTO_IMPLEMENTATION_PLATFORM_GENERIC\x10\x03\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_X86_32\x10\x04\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_X86_64\x10\x05\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7A\x10\x06\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV7M\x10\a\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8A\x10\b\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV8M\x10\t\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9A\x10\n" +
+	"\x12)\n" +
+	"%CRYPTO_IMPLEMENTATION_PLATFORM_ARMV9M\x10\v\x12(\n" +
+	"$CRYPTO_IMPLEMENTATION_PLATFORM_X390X\x10\f\x12(\n" +
+	"$CRYPTO_IMPLEMENTATION_PLATFORM_PPC64\x10\r\x12*\n" +
+	"&CRYPTO_IMPLEMENTATION_PLATFORM_PPC64LE\x10\x0e\"\xa8\n" +
+	"\n" +
+	"\x18CryptoCertificationLevel\x12*\n" +
+	"&CRYPTO_CERTIFICATION_LEVEL_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_UNKNOWN\x10\x01\x12$\n" +
+	" CRYPTO_CERTIFICATION_LEVEL_OTHER\x10\x02\x12#\n" +
+	"\x1fCRYPTO_CERTIFICATION_LEVEL_NONE\x10\x03\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L1\x10\x04\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L2\x10\x05\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L3\x10\x06\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_1_L4\x10\a\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L1\x10\b\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L2\x10\t\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L3\x10\n" +
+	"\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_2_L4\x10\v\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L1\x10\f\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L2\x10\r\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L3\x10\x0e\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_FIPS140_3_L4\x10\x0f\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL1\x10\x10\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL1_PLUS\x10\x11\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL2\x10\x12\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL2_PLUS\x10\x13\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL3\x10\x14\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL3_PLUS\x10\x15\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL4\x10\x16\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL4_PLUS\x10\x17\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL5\x10\x18\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL5_PLUS\x10\x19\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL6\x10\x1a\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL6_PLUS\x10\x1b\x12&\n" +
+	"\"CRYPTO_CERTIFICATION_LEVEL_CC_EAL7\x10\x1c\x12+\n" +
+	"'CRYPTO_CERTIFICATION_LEVEL_CC_EAL7_PLUS\x10\x1d\"\xd9\x02\n" +
+	"\x13CryptoAlgorithmMode\x12%\n" +
+	"!CRYPTO_ALGORITHM_MODE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dCRYPTO_ALGORITHM_MODE_UNKNOWN\x10\x01\x12\x1f\n" +
+	"\x1bCRYPTO_ALGORITHM_MODE_OTHER\x10\x02\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_CBC\x10\x03\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_ECB\x10\x04\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_CCM\x10\x05\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_GCM\x10\x06\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_CFB\x10\a\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_OFB\x10\b\x12\x1d\n" +
+	"\x19CRYPTO_ALGORITHM_MODE_CTR\x10\t\"\xc0\x02\n" +
+	"\x16CryptoAlgorithmPadding\x12(\n" +
+	"$CRYPTO_ALGORITHM_PADDING_UNSPECIFIED\x10\x00\x12$\n" +
+	" CRYPTO_ALGORITHM_PADDING_UNKNOWN\x10\x01\x12\"\n" +
+	"\x1eCRYPTO_ALGORITHM_PADDING_OTHER\x10\x02\x12\"\n" +
+	"\x1eCRYPTO_ALGORITHM_PADDING_PKCS5\x10\x03\x12\"\n" +
+	"\x1eCRYPTO_ALGORITHM_PADDING_PKCS7\x10\x04\x12%\n" +
+	"!CRYPTO_ALGORITHM_PADDING_PKCS1V15\x10\x05\x12!\n" +
+	"\x1dCRYPTO_ALGORITHM_PADDING_OAEP\x10\x06\x12 \n" +
+	"\x1cCRYPTO_ALGORITHM_PADDING_RAW\x10\a\"\xa9\x03\n" +
+	"\x0eCryptoFunction\x12\x1f\n" +
+	"\x1bCRYPTO_FUNCTION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17CRYPTO_FUNCTION_UNKNOWN\x10\x01\x12\x19\n" +
+	"\x15CRYPTO_FUNCTION_OTHER\x10\x02\x12\x1c\n" +
+	"\x18CRYPTO_FUNCTION_GENERATE\x10\x03\x12\x1a\n" +
+	"\x16CRYPTO_FUNCTION_KEYGEN\x10\x04\x12\x1b\n" +
+	"\x17CRYPTO_FUNCTION_ENCRYPT\x10\x05\x12\x1b\n" +
+	"\x17CRYPTO_FUNCTION_DECRYPT\x10\x06\x12\x1a\n" +
+	"\x16CRYPTO_FUNCTION_DIGEST\x10\a\x12\x17\n" +
+	"\x13CRYPTO_FUNCTION_TAG\x10\b\x12\x1d\n" +
+	"\x19CRYPTO_FUNCTION_KEYDERIVE\x10\t\x12\x18\n" +
+	"\x14CRYPTO_FUNCTION_SIGN\x10\n" +
+	"\x12\x1a\n" +
+	"\x16CRYPTO_FUNCTION_VERIFY\x10\v\x12\x1f\n" +
+	"\x1bCRYPTO_FUNCTION_ENCAPSULATE\x10\f\x12\x1f\n" +
+	"\x1bCRYPTO_FUNCTION_DECAPSULATE\x10\rB\f\n" +
+	"\n" +
+	"_primitiveB\x19\n" +
+	"\x17_parameterSetIdentifierB\b\n" +
+	"\x06_curveB\x17\n" +
+	"\x15_executionEnvironmentB\x19\n" +
+	"\x17_implementationPlatformB\a\n" +
+	"\x05_modeB\n" +
+	"\n" +
+	"\b_paddingB\x19\n" +
+	"\x17_classicalSecurityLevelB\x1b\n" +
+	"\x19_nistQuantumSecurityLevel\x1a\xf6\x04\n" +
+	"\x15CertificateProperties\x12%\n" +
+	"\vsubjectName\x18\x01 \x01(\tH\x00R\vsubjectName\x88\x01\x01\x12#\n" +
+	"\n" +
+	"issuerName\x18\x02 \x01(\tH\x01R\n" +
+	"issuerName\x88\x01\x01\x12G\n" +
+	"\x0enotValidBefore\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x0enotValidBefore\x88\x01\x01\x12E\n" +
+	"\rnotValidAfter\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\rnotValidAfter\x88\x01\x01\x129\n" +
+	"\x15signatureAlgorithmRef\x18\x05 \x01(\tH\x04R\x15signatureAlgorithmRef\x88\x01\x01\x125\n" +
+	"\x13subjectPublicKeyRef\x18\x06 \x01(\tH\x05R\x13subjectPublicKeyRef\x88\x01\x01\x121\n" +
+	"\x11certificateFormat\x18\a \x01(\tH\x06R\x11certificateFormat\x88\x01\x01\x127\n" +
+	"\x14certificateExtension\x18\b \x01(\tH\aR\x14certificateExtension\x88\x01\x01B\x0e\n" +
+	"\f_subjectNameB\r\n" +
+	"\v_issuerNameB\x11\n" +
+	"\x0f_notValidBeforeB\x10\n" +
+	"\x0e_notValidAfterB\x18\n" +
+	"\x16_signatureAlgorithmRefB\x16\n" +
+	"\x14_subjectPublicKeyRefB\x14\n" +
+	"\x12_certificateFormatB\x17\n" +
+	"\x15_certificateExtension\x1a\x87\x11\n" +
+	"\x1fRelatedCryptoMaterialProperties\x12s\n" +
+	"\x04type\x18\x01 \x01(\x0e2Z.cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialTypeH\x00R\x04type\x88\x01\x01\x12\x13\n" +
+	"\x02id\x18\x02 \x01(\tH\x01R\x02id\x88\x01\x01\x12v\n" +
+	"\x05state\x18\x03 \x01(\x0e2[.cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialStateH\x02R\x05state\x88\x01\x01\x12'\n" +
+	"\falgorithmRef\x18\x04 \x01(\tH\x03R\falgorithmRef\x88\x01\x01\x12C\n" +
+	"\fcreationDate\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\fcreationDate\x88\x01\x01\x12G\n" +
+	"\x0eactivationDate\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x05R\x0eactivationDate\x88\x01\x01\x12?\n" +
+	"\n" +
+	"updateDate\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x06R\n" +
+	"updateDate\x88\x01\x01\x12G\n" +
+	"\x0eexpirationDate\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\aR\x0eexpirationDate\x88\x01\x01\x12\x19\n" +
+	"\x05value\x18\t \x01(\tH\bR\x05value\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\n" +
+	" \x01(\x03H\tR\x04size\x88\x01\x01\x12\x1b\n" +
+	"\x06format\x18\v \x01(\tH\n" +
+	"R\x06format\x88\x01\x01\x12M\n" +
+	"\tsecuredBy\x18\f \x01(\v2*.cyclonedx.v1_6.CryptoProperties.SecuredByH\vR\tsecuredBy\x88\x01\x01\"\x81\a\n" +
+	"\x19RelatedCryptoMaterialType\x12,\n" +
+	"(RELATED_CRYPTO_MATERIAL_TYPE_UNSPECIFIED\x10\x00\x12(\n" +
+	"$RELATED_CRYPTO_MATERIAL_TYPE_UNKNOWN\x10\x01\x12&\n" +
+	"\"RELATED_CRYPTO_MATERIAL_TYPE_OTHER\x10\x02\x12,\n" +
+	"(RELATED_CRYPTO_MATERIAL_TYPE_PRIVATE_KEY\x10\x03\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_TYPE_PUBLIC_KEY\x10\x04\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_TYPE_SECRET_KEY\x10\x05\x12$\n" +
+	" RELATED_CRYPTO_MATERIAL_TYPE_KEY\x10\x06\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_TYPE_CIPHERTEXT\x10\a\x12*\n" +
+	"&RELATED_CRYPTO_MATERIAL_TYPE_SIGNATURE\x10\b\x12'\n" +
+	"#RELATED_CRYPTO_MATERIAL_TYPE_DIGEST\x10\t\x126\n" +
+	"2RELATED_CRYPTO_MATERIAL_TYPE_INITIALIZATION_VECTOR\x10\n" +
+	"\x12&\n" +
+	"\"RELATED_CRYPTO_MATERIAL_TYPE_NONCE\x10\v\x12%\n" +
+	"!RELATED_CRYPTO_MATERIAL_TYPE_SEED\x10\f\x12%\n" +
+	"!RELATED_CRYPTO_MATERIAL_TYPE_SALT\x10\r\x12.\n" +
+	"*RELATED_CRYPTO_MATERIAL_TYPE_SHARED_SECRET\x10\x0e\x12$\n" +
+	" RELATED_CRYPTO_MATERIAL_TYPE_TAG\x10\x0f\x120\n" +
+	",RELATED_CRYPTO_MATERIAL_TYPE_ADDITIONAL_DATA\x10\x10\x12)\n" +
+	"%RELATED_CRYPTO_MATERIAL_TYPE_PASSWORD\x10\x11\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_TYPE_CREDENTIAL\x10\x12\x12&\n" +
+	"\"RELATED_CRYPTO_MATERIAL_TYPE_TOKEN\x10\x13\"\xdf\x02\n" +
+	"\x1aRelatedCryptoMaterialState\x12-\n" +
+	")RELATED_CRYPTO_MATERIAL_STATE_UNSPECIFIED\x10\x00\x120\n" +
+	",RELATED_CRYPTO_MATERIAL_STATE_PRE_ACTIVATION\x10\x01\x12(\n" +
+	"$RELATED_CRYPTO_MATERIAL_STATE_ACTIVE\x10\x02\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_STATE_SUSPENDED\x10\x03\x12-\n" +
+	")RELATED_CRYPTO_MATERIAL_STATE_DEACTIVATED\x10\x04\x12-\n" +
+	")RELATED_CRYPTO_MATERIAL_STATE_COMPROMISED\x10\x05\x12+\n" +
+	"'RELATED_CRYPTO_MATERIAL_STATE_DESTROYED\x10\x06B\a\n" +
+	"\x05_typeB\x05\n" +
+	"\x03_idB\b\n" +
+	"\x06_stateB\x0f\n" +
+	"\r_algorithmRefB\x0f\n" +
+	"\r_creationDateB\x11\n" +
+	"\x0f_activationDateB\r\n" +
+	"\v_updateDateB\x11\n" +
+	"\x0f_expirationDateB\b\n" +
+	"\x06_valueB\a\n" +
+	"\x05_sizeB\t\n" +
+	"\a_formatB\f\n" +
+	"\n" +
+	"_securedBy\x1a\x88\a\n" +
+	"\x12ProtocolProperties\x12_\n" +
+	"\x04type\x18\x01 \x01(\x0e2F.cyclonedx.v1_6.CryptoProperties.ProtocolProperties.CryptoProtocolTypeH\x00R\x04type\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x02 \x01(\tH\x01R\aversion\x88\x01\x01\x12P\n" +
+	"\fcipherSuites\x18\x03 \x03(\v2,.cyclonedx.v1_6.CryptoProperties.CipherSuiteR\fcipherSuites\x12~\n" +
+	"\x13ikev2TransformTypes\x18\x04 \x01(\v2G.cyclonedx.v1_6.CryptoProperties.ProtocolProperties.Ikev2TransformTypesH\x02R\x13ikev2TransformTypes\x88\x01\x01\x12&\n" +
+	"\x0ecryptoRefArray\x18\x05 \x03(\tR\x0ecryptoRefArray\x1a\x94\x01\n" +
+	"\x13Ikev2TransformTypes\x12\x12\n" +
+	"\x04encr\x18\x01 \x03(\tR\x04encr\x12\x10\n" +
+	"\x03prf\x18\x02 \x03(\tR\x03prf\x12\x14\n" +
+	"\x05integ\x18\x03 \x03(\tR\x05integ\x12\x0e\n" +
+	"\x02ke\x18\x04 \x03(\tR\x02ke\x12\x15\n" +
+	"\x03esn\x18\x05 \x01(\bH\x00R\x03esn\x88\x01\x01\x12\x12\n" +
+	"\x04auth\x18\x06 \x03(\tR\x04authB\x06\n" +
+	"\x04_esn\"\xb3\x02\n" +
+	"\x12CryptoProtocolType\x12$\n" +
+	" CRYPTO_PROTOCOL_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cCRYPTO_PROTOCOL_TYPE_UNKNOWN\x10\x01\x12\x1e\n" +
+	"\x1aCRYPTO_PROTOCOL_TYPE_OTHER\x10\x02\x12\x1c\n" +
+	"\x18CRYPTO_PROTOCOL_TYPE_TLS\x10\x03\x12\x1c\n" +
+	"\x18CRYPTO_PROTOCOL_TYPE_SSH\x10\x04\x12\x1e\n" +
+	"\x1aCRYPTO_PROTOCOL_TYPE_IPSEC\x10\x05\x12\x1c\n" +
+	"\x18CRYPTO_PROTOCOL_TYPE_IKE\x10\x06\x12\x1d\n" +
+	"\x19CRYPTO_PROTOCOL_TYPE_SSTP\x10\a\x12\x1c\n" +
+	"\x18CRYPTO_PROTOCOL_TYPE_WPA\x10\bB\a\n" +
+	"\x05_typeB\n" +
+	"\n" +
+	"\b_versionB\x16\n" +
+	"\x14_ikev2TransformTypes\x1aq\n" +
+	"\vCipherSuite\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"algorithms\x18\x02 \x03(\tR\n" +
+	"algorithms\x12 \n" +
+	"\videntifiers\x18\x03 \x03(\tR\videntifiersB\a\n" +
+	"\x05_name\x1av\n" +
+	"\tSecuredBy\x12!\n" +
+	"\tmechanism\x18\x01 \x01(\tH\x00R\tmechanism\x88\x01\x01\x12'\n" +
+	"\falgorithmRef\x18\x02 \x01(\tH\x01R\falgorithmRef\x88\x01\x01B\f\n" +
+	"\n" +
+	"_mechanismB\x0f\n" +
+	"\r_algorithmRef\"\xc7\x01\n" +
+	"\x0fCryptoAssetType\x12!\n" +
+	"\x1dCRYPTO_ASSET_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCRYPTO_ASSET_TYPE_ALGORITHM\x10\x01\x12!\n" +
+	"\x1dCRYPTO_ASSET_TYPE_CERTIFICATE\x10\x02\x12\x1e\n" +
+	"\x1aCRYPTO_ASSET_TYPE_PROTOCOL\x10\x03\x12-\n" +
+	")CRYPTO_ASSET_TYPE_RELATED_CRYPTO_MATERIAL\x10\x04B\x16\n" +
+	"\x14_algorithmPropertiesB\x18\n" +
+	"\x16_certificatePropertiesB\"\n" +
+	" _relatedCryptoMaterialPropertiesB\x15\n" +
+	"\x13_protocolPropertiesB\x06\n" +
+	"\x04_oid\"\xd6\x1b\n" +
+	"\fDeclarations\x12C\n" +
+	"\tassessors\x18\x01 \x03(\v2%.cyclonedx.v1_6.Declarations.AssessorR\tassessors\x12L\n" +
+	"\fattestations\x18\x02 \x03(\v2(.cyclonedx.v1_6.Declarations.AttestationR\fattestations\x12:\n" +
+	"\x06claims\x18\x03 \x03(\v2\".cyclonedx.v1_6.Declarations.ClaimR\x06claims\x12A\n" +
+	"\bevidence\x18\x04 \x03(\v2%.cyclonedx.v1_6.Declarations.EvidenceR\bevidence\x12C\n" +
+	"\atargets\x18\x05 \x01(\v2$.cyclonedx.v1_6.Declarations.TargetsH\x00R\atargets\x88\x01\x01\x12O\n" +
+	"\vaffirmation\x18\x06 \x01(\v2(.cyclonedx.v1_6.Declarations.AffirmationH\x01R\vaffirmation\x88\x01\x01\x1a\xc8\x01\n" +
+	"\bAssessor\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12#\n" +
+	"\n" +
+	"thirdParty\x18\x02 \x01(\bH\x01R\n" +
+	"thirdParty\x88\x01\x01\x12M\n" +
+	"\forganization\x18\x03 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x02R\forganization\x88\x01\x01B\n" +
+	"\n" +
+	"\b_bom_refB\r\n" +
+	"\v_thirdPartyB\x0f\n" +
+	"\r_organization\x1a\xab\x06\n" +
+	"\vAttestation\x12\x1d\n" +
+	"\asummary\x18\x01 \x01(\tH\x00R\asummary\x88\x01\x01\x12\x1f\n" +
+	"\bassessor\x18\x02 \x01(\tH\x01R\bassessor\x88\x01\x01\x12I\n" +
+	"\x03map\x18\x03 \x03(\v27.cyclonedx.v1_6.Declarations.Attestation.AttestationMapR\x03map\x1a\xf7\x04\n" +
+	"\x0eAttestationMap\x12%\n" +
+	"\vrequirement\x18\x01 \x01(\tH\x00R\vrequirement\x88\x01\x01\x12\x16\n" +
+	"\x06claims\x18\x02 \x03(\tR\x06claims\x12$\n" +
+	"\rcounterClaims\x18\x03 \x03(\tR\rcounterClaims\x12j\n" +
+	"\vconformance\x18\x04 \x01(\v2C.cyclonedx.v1_6.Declarations.Attestation.AttestationMap.ConformanceH\x01R\vconformance\x88\x01\x01\x12g\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\v2B.cyclonedx.v1_6.Declarations.Attestation.AttestationMap.ConfidenceH\x02R\n" +
+	"confidence\x88\x01\x01\x1a\x97\x01\n" +
+	"\vConformance\x12\x19\n" +
+	"\x05score\x18\x01 \x01(\x01H\x00R\x05score\x88\x01\x01\x12!\n" +
+	"\trationale\x18\x02 \x01(\tH\x01R\trationale\x88\x01\x01\x122\n" +
+	"\x14mitigationStrategies\x18\x03 \x03(\tR\x14mitigationStrategiesB\b\n" +
+	"\x06_scoreB\f\n" +
+	"\n" +
+	"_rationale\x1ab\n" +
+	"\n" +
+	"Confidence\x12\x19\n" +
+	"\x05score\x18\x01 \x01(\x01H\x00R\x05score\x88\x01\x01\x12!\n" +
+	"\trationale\x18\x02 \x01(\tH\x01R\trationale\x88\x01\x01B\b\n" +
+	"\x06_scoreB\f\n" +
+	"\n" +
+	"_rationaleB\x0e\n" +
+	"\f_requirementB\x0e\n" +
+	"\f_conformanceB\r\n" +
+	"\v_confidenceB\n" +
+	"\n" +
+	"\b_summaryB\v\n" +
+	"\t_assessor\x1a\x88\x03\n" +
+	"\x05Claim\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x1b\n" +
+	"\x06target\x18\x02 \x01(\tH\x01R\x06target\x88\x01\x01\x12!\n" +
+	"\tpredicate\x18\x03 \x01(\tH\x02R\tpredicate\x88\x01\x01\x122\n" +
+	"\x14mitigationStrategies\x18\x04 \x03(\tR\x14mitigationStrategies\x12!\n" +
+	"\treasoning\x18\x05 \x01(\tH\x03R\treasoning\x88\x01\x01\x12\x1a\n" +
+	"\bevidence\x18\x06 \x03(\tR\bevidence\x12(\n" +
+	"\x0fcounterEvidence\x18\a \x03(\tR\x0fcounterEvidence\x12Q\n" +
+	"\x12externalReferences\x18\b \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferencesB\n" +
+	"\n" +
+	"\b_bom_refB\t\n" +
+	"\a_targetB\f\n" +
+	"\n" +
+	"_predicateB\f\n" +
+	"\n" +
+	"_reasoning\x1a\x80\b\n" +
+	"\bEvidence\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12'\n" +
+	"\fpropertyName\x18\x02 \x01(\tH\x01R\fpropertyName\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x02R\vdescription\x88\x01\x01\x12>\n" +
+	"\x04data\x18\x04 \x03(\v2*.cyclonedx.v1_6.Declarations.Evidence.DataR\x04data\x129\n" +
+	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\acreated\x88\x01\x01\x129\n" +
+	"\aexpires\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\aexpires\x88\x01\x01\x12B\n" +
+	"\x06author\x18\a \x01(\v2%.cyclonedx.v1_6.OrganizationalContactH\x05R\x06author\x88\x01\x01\x12F\n" +
+	"\breviewer\x18\b \x01(\v2%.cyclonedx.v1_6.OrganizationalContactH\x06R\breviewer\x88\x01\x01\x1a\xe6\x03\n" +
+	"\x04Data\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12T\n" +
+	"\bcontents\x18\x02 \x01(\v23.cyclonedx.v1_6.Declarations.Evidence.Data.ContentsH\x01R\bcontents\x88\x01\x01\x12O\n" +
+	"\x0eclassification\x18\x03 \x01(\v2\".cyclonedx.v1_6.DataClassificationH\x02R\x0eclassification\x88\x01\x01\x12$\n" +
+	"\rsensitiveData\x18\x04 \x03(\tR\rsensitiveData\x12C\n" +
+	"\n" +
+	"governance\x18\x05 \x01(\v2\x1e.cyclonedx.v1_6.DataGovernanceH\x03R\n" +
+	"governance\x88\x01\x01\x1a{\n" +
+	"\bContents\x12A\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1c.cyclonedx.v1_6.AttachedTextH\x00R\n" +
+	"attachment\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01B\r\n" +
+	"\v_attachmentB\x06\n" +
+	"\x04_urlB\a\n" +
+	"\x05_nameB\v\n" +
+	"\t_contentsB\x11\n" +
+	"\x0f_classificationB\r\n" +
+	"\v_governanceB\n" +
+	"\n" +
+	"\b_bom_refB\x0f\n" +
+	"\r_propertyNameB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_createdB\n" +
+	"\n" +
+	"\b_expiresB\t\n" +
+	"\a_authorB\v\n" +
+	"\t_reviewer\x1a\xc5\x01\n" +
+	"\aTargets\x12J\n" +
+	"\rorganizations\x18\x01 \x03(\v2$.cyclonedx.v1_6.OrganizationalEntityR\rorganizations\x129\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2\x19.cyclonedx.v1_6.ComponentR\n" +
+	"components\x123\n" +
+	"\bservices\x18\x03 \x03(\v2\x17.cyclonedx.v1_6.ServiceR\bservices\x1a\xb2\x03\n" +
+	"\vAffirmation\x12!\n" +
+	"\tstatement\x18\x01 \x01(\tH\x00R\tstatement\x88\x01\x01\x12T\n" +
+	"\vsignatories\x18\x02 \x03(\v22.cyclonedx.v1_6.Declarations.Affirmation.SignatoryR\vsignatories\x1a\x9b\x02\n" +
+	"\tSignatory\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x17\n" +
+	"\x04role\x18\x02 \x01(\tH\x01R\x04role\x88\x01\x01\x12M\n" +
+	"\forganization\x18\x03 \x01(\v2$.cyclonedx.v1_6.OrganizationalEntityH\x02R\forganization\x88\x01\x01\x12T\n" +
+	"\x11externalReference\x18\x04 \x01(\v2!.cyclonedx.v1_6.ExternalReferenceH\x03R\x11externalReference\x88\x01\x01B\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_roleB\x0f\n" +
+	"\r_organizationB\x14\n" +
+	"\x12_externalReferenceB\f\n" +
+	"\n" +
+	"_statementB\n" +
+	"\n" +
+	"\b_targetsB\x0e\n" +
+	"\f_affirmation\"\xaa\t\n" +
+	"\n" +
+	"Definition\x12A\n" +
+	"\tstandards\x18\x01 \x03(\v2#.cyclonedx.v1_6.Definition.StandardR\tstandards\x1a\xd8\b\n" +
+	"\bStandard\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x03 \x01(\tH\x02R\aversion\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x03R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05owner\x18\x05 \x01(\tH\x04R\x05owner\x88\x01\x01\x12S\n" +
+	"\frequirements\x18\x06 \x03(\v2/.cyclonedx.v1_6.Definition.Standard.RequirementR\frequirements\x12A\n" +
+	"\x06levels\x18\a \x03(\v2).cyclonedx.v1_6.Definition.Standard.LevelR\x06levels\x12Q\n" +
+	"\x12externalReferences\x18\b \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferences\x1a\xa5\x03\n" +
+	"\vRequirement\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12#\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\tH\x01R\n" +
+	"identifier\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x02R\x05title\x88\x01\x01\x12\x17\n" +
+	"\x04text\x18\x04 \x01(\tH\x03R\x04text\x88\x01\x01\x12\"\n" +
+	"\fdescriptions\x18\x05 \x03(\tR\fdescriptions\x12\x18\n" +
+	"\aopenCre\x18\x06 \x03(\tR\aopenCre\x12\x1b\n" +
+	"\x06parent\x18\a \x01(\tH\x04R\x06parent\x88\x01\x01\x128\n" +
+	"\n" +
+	"properties\x18\b \x03(\v2\x18.cyclonedx.v1_6.PropertyR\n" +
+	"properties\x12Q\n" +
+	"\x12externalReferences\x18\t \x03(\v2!.cyclonedx.v1_6.ExternalReferenceR\x12externalReferencesB\n" +
+	"\n" +
+	"\b_bom_refB\r\n" +
+	"\v_identifierB\b\n" +
+	"\x06_titleB\a\n" +
+	"\x05_textB\t\n" +
+	"\a_parent\x1a\xe5\x01\n" +
+	"\x05Level\x12\x1c\n" +
+	"\abom_ref\x18\x01 \x01(\tH\x00R\x06bomRef\x88\x01\x01\x12#\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\tH\x01R\n" +
+	"identifier\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x02R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x03R\vdescription\x88\x01\x01\x12\"\n" +
+	"\frequirements\x18\x05 \x03(\tR\frequirementsB\n" +
+	"\n" +
+	"\b_bom_refB\r\n" +
+	"\v_identifierB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_bom_refB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_versionB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_owner*\xc2\x03\n" +
+	"\x0eClassification\x12\x17\n" +
+	"\x13CLASSIFICATION_NULL\x10\x00\x12\x1e\n" +
+	"\x1aCLASSIFICATION_APPLICATION\x10\x01\x12\x1c\n" +
+	"\x18CLASSIFICATION_FRAMEWORK\x10\x02\x12\x1a\n" +
+	"\x16CLASSIFICATION_LIBRARY\x10\x03\x12#\n" +
+	"\x1fCLASSIFICATION_OPERATING_SYSTEM\x10\x04\x12\x19\n" +
+	"\x15CLASSIFICATION_DEVICE\x10\x05\x12\x17\n" +
+	"\x13CLASSIFICATION_FILE\x10\x06\x12\x1c\n" +
+	"\x18CLASSIFICATION_CONTAINER\x10\a\x12\x1b\n" +
+	"\x17CLASSIFICATION_FIRMWARE\x10\b\x12 \n" +
+	"\x1cCLASSIFICATION_DEVICE_DRIVER\x10\t\x12\x1b\n" +
+	"\x17CLASSIFICATION_PLATFORM\x10\n" +
+	"\x12)\n" +
+	"%CLASSIFICATION_MACHINE_LEARNING_MODEL\x10\v\x12\x17\n" +
+	"\x13CLASSIFICATION_DATA\x10\f\x12&\n" +
+	"\"CLASSIFICATION_CRYPTOGRAPHIC_ASSET\x10\r*\x82\x01\n" +
+	"\bDataFlow\x12\x12\n" +
+	"\x0eDATA_FLOW_NULL\x10\x00\x12\x15\n" +
+	"\x11DATA_FLOW_INBOUND\x10\x01\x12\x16\n" +
+	"\x12DATA_FLOW_OUTBOUND\x10\x02\x12\x1c\n" +
+	"\x18DATA_FLOW_BI_DIRECTIONAL\x10\x03\x12\x15\n" +
+	"\x11DATA_FLOW_UNKNOWN\x10\x04*\xd6\x0e\n" +
+	"\x15ExternalReferenceType\x12!\n" +
+	"\x1dEXTERNAL_REFERENCE_TYPE_OTHER\x10\x00\x12\x1f\n" +
+	"\x1bEXTERNAL_REFERENCE_TYPE_VCS\x10\x01\x12)\n" +
+	"%EXTERNAL_REFERENCE_TYPE_ISSUE_TRACKER\x10\x02\x12#\n" +
+	"\x1fEXTERNAL_REFERENCE_TYPE_WEBSITE\x10\x03\x12&\n" +
+	"\"EXTERNAL_REFERENCE_TYPE_ADVISORIES\x10\x04\x12\x1f\n" +
+	"\x1bEXTERNAL_REFERENCE_TYPE_BOM\x10\x05\x12(\n" +
+	"$EXTERNAL_REFERENCE_TYPE_MAILING_LIST\x10\x06\x12\"\n" +
+	"\x1eEXTERNAL_REFERENCE_TYPE_SOCIAL\x10\a\x12 \n" +
+	"\x1cEXTERNAL_REFERENCE_TYPE_CHAT\x10\b\x12)\n" +
+	"%EXTERNAL_REFERENCE_TYPE_DOCUMENTATION\x10\t\x12#\n" +
+	"\x1fEXTERNAL_REFERENCE_TYPE_SUPPORT\x10\n" +
+	"\x12(\n" +
+	"$EXTERNAL_REFERENCE_TYPE_DISTRIBUTION\x10\v\x12#\n" +
+	"\x1fEXTERNAL_REFERENCE_TYPE_LICENSE\x10\f\x12&\n" +
+	"\"EXTERNAL_REFERENCE_TYPE_BUILD_META\x10\r\x12(\n" +
+	"$EXTERNAL_REFERENCE_TYPE_BUILD_SYSTEM\x10\x0e\x12)\n" +
+	"%EXTERNAL_REFERENCE_TYPE_RELEASE_NOTES\x10\x0f\x12,\n" +
+	"(EXTERNAL_REFERENCE_TYPE_SECURITY_CONTACT\x10\x10\x12&\n" +
+	"\"EXTERNAL_REFERENCE_TYPE_MODEL_CARD\x10\x11\x12\x1f\n" +
+	"\x1bEXTERNAL_REFERENCE_TYPE_LOG\x10\x12\x12)\n" +
+	"%EXTERNAL_REFERENCE_TYPE_CONFIGURATION\x10\x13\x12$\n" +
+	" EXTERNAL_REFERENCE_TYPE_EVIDENCE\x10\x14\x12'\n" +
+	"#EXTERNAL_REFERENCE_TYPE_FORMULATION\x10\x15\x12'\n" +
+	"#EXTERNAL_REFERENCE_TYPE_ATTESTATION\x10\x16\x12(\n" +
+	"$EXTERNAL_REFERENCE_TYPE_THREAT_MODEL\x10\x17\x12+\n" +
+	"'EXTERNAL_REFERENCE_TYPE_ADVERSARY_MODEL\x10\x18\x12+\n" +
+	"'EXTERNAL_REFERENCE_TYPE_RISK_ASSESSMENT\x10\x19\x123\n" +
+	"/EXTERNAL_REFERENCE_TYPE_VULNERABILITY_ASSERTION\x10\x1a\x124\n" +
+	"0EXTERNAL_REFERENCE_TYPE_EXPLOITABILITY_STATEMENT\x10\x1b\x12*\n" +
+	"&EXTERNAL_REFERENCE_TYPE_PENTEST_REPORT\x10\x1c\x122\n" +
+	".EXTERNAL_REFERENCE_TYPE_STATIC_ANALYSIS_REPORT\x10\x1d\x123\n" +
+	"/EXTERNAL_REFERENCE_TYPE_DYNAMIC_ANALYSIS_REPORT\x10\x1e\x123\n" +
+	"/EXTERNAL_REFERENCE_TYPE_RUNTIME_ANALYSIS_REPORT\x10\x1f\x125\n" +
+	"1EXTERNAL_REFERENCE_TYPE_COMPONENT_ANALYSIS_REPORT\x10 \x12+\n" +
+	"'EXTERNAL_REFERENCE_TYPE_MATURITY_REPORT\x10!\x120\n" +
+	",EXTERNAL_REFERENCE_TYPE_CERTIFICATION_REPORT\x10\"\x12+\n" +
+	"'EXTERNAL_REFERENCE_TYPE_QUALITY_METRICS\x10#\x123\n" +
+	"/EXTERNAL_REFERENCE_TYPE_CODIFIED_INFRASTRUCTURE\x10$\x12 \n" +
+	"\x1cEXTERNAL_REFERENCE_TYPE_POAM\x10%\x12/\n" +
+	"+EXTERNAL_REFERENCE_TYPE_DISTRIBUTION_INTAKE\x10&\x120\n" +
+	",EXTERNAL_REFERENCE_TYPE_ELECTRONIC_SIGNATURE\x10'\x12-\n" +
+	")EXTERNAL_REFERENCE_TYPE_DIGITAL_SIGNATURE\x10(\x12$\n" +
+	" EXTERNAL_REFERENCE_TYPE_RFC_9116\x10)\x12/\n" +
+	"+EXTERNAL_REFERENCE_TYPE_SOURCE_DISTRIBUTION\x10**\xb7\x02\n" +
+	"\aHashAlg\x12\x11\n" +
+	"\rHASH_ALG_NULL\x10\x00\x12\x11\n" +
+	"\rHASH_ALG_MD_5\x10\x01\x12\x12\n" +
+	"\x0eHASH_ALG_SHA_1\x10\x02\x12\x14\n" +
+	"\x10HASH_ALG_SHA_256\x10\x03\x12\x14\n" +
+	"\x10HASH_ALG_SHA_384\x10\x04\x12\x14\n" +
+	"\x10HASH_ALG_SHA_512\x10\x05\x12\x16\n" +
+	"\x12HASH_ALG_SHA_3_256\x10\x06\x12\x16\n" +
+	"\x12HASH_ALG_SHA_3_384\x10\a\x12\x16\n" +
+	"\x12HASH_ALG_SHA_3_512\x10\b\x12\x1a\n" +
+	"\x16HASH_ALG_BLAKE_2_B_256\x10\t\x12\x1a\n" +
+	"\x16HASH_ALG_BLAKE_2_B_384\x10\n" +
+	"\x12\x1a\n" +
+	"\x16HASH_ALG_BLAKE_2_B_512\x10\v\x12\x14\n" +
+	"\x10HASH_ALG_BLAKE_3\x10\f*\x9e\x01\n" +
+	"\x13IssueClassification\x12\x1d\n" +
+	"\x19ISSUE_CLASSIFICATION_NULL\x10\x00\x12\x1f\n" +
+	"\x1bISSUE_CLASSIFICATION_DEFECT\x10\x01\x12$\n" +
+	" ISSUE_CLASSIFICATION_ENHANCEMENT\x10\x02\x12!\n" +
+	"\x1dISSUE_CLASSIFICATION_SECURITY\x10\x03*\xbd\x01\n" +
+	"!LicenseAcknowledgementEnumeration\x123\n" +
+	"/LICENSE_ACKNOWLEDGEMENT_ENUMERATION_UNSPECIFIED\x10\x00\x120\n" +
+	",LICENSE_ACKNOWLEDGEMENT_ENUMERATION_DECLARED\x10\x01\x121\n" +
+	"-LICENSE_ACKNOWLEDGEMENT_ENUMERATION_CONCLUDED\x10\x02*\x97\x04\n" +
+	"\x11LicensingTypeEnum\x12\x17\n" +
+	"\x13LICENSING_TYPE_NULL\x10\x00\x12\x1b\n" +
+	"\x17LICENSING_TYPE_ACADEMIC\x10\x01\x12\x1c\n" +
+	"\x18LICENSING_TYPE_APPLIANCE\x10\x02\x12 \n" +
+	"\x1cLICENSING_TYPE_CLIENT_ACCESS\x10\x03\x12\"\n" +
+	"\x1eLICENSING_TYPE_CONCURRENT_USER\x10\x04\x12\x1e\n" +
+	"\x1aLICENSING_TYPE_CORE_POINTS\x10\x05\x12 \n" +
+	"\x1cLICENSING_TYPE_CUSTOM_METRIC\x10\x06\x12\x19\n" +
+	"\x15LICENSING_TYPE_DEVICE\x10\a\x12\x1d\n" +
+	"\x19LICENSING_TYPE_EVALUATION\x10\b\x12\x1d\n" +
+	"\x19LICENSING_TYPE_NAMED_USER\x10\t\x12\x1e\n" +
+	"\x1aLICENSING_TYPE_NODE_LOCKED\x10\n" +
+	"\x12\x16\n" +
+	"\x12LICENSING_TYPE_OEM\x10\v\x12\x1c\n" +
+	"\x18LICENSING_TYPE_PERPETUAL\x10\f\x12#\n" +
+	"\x1fLICENSING_TYPE_PROCESSOR_POINTS\x10\r\x12\x1f\n" +
+	"\x1bLICENSING_TYPE_SUBSCRIPTION\x10\x0e\x12\x17\n" +
+	"\x13LICENSING_TYPE_USER\x10\x0f\x12\x18\n" +
+	"\x14LICENSING_TYPE_OTHER\x10\x10*\xe7\x01\n" +
+	"\x0eLifecyclePhase\x12\x1a\n" +
+	"\x16LIFECYCLE_PHASE_DESIGN\x10\x00\x12\x1d\n" +
+	"\x19LIFECYCLE_PHASE_PRE_BUILD\x10\x01\x12\x19\n" +
+	"\x15LIFECYCLE_PHASE_BUILD\x10\x02\x12\x1e\n" +
+	"\x1aLIFECYCLE_PHASE_POST_BUILD\x10\x03\x12\x1e\n" +
+	"\x1aLIFECYCLE_PHASE_OPERATIONS\x10\x04\x12\x1d\n" +
+	"\x19LIFECYCLE_PHASE_DISCOVERY\x10\x05\x12 \n" +
+	"\x1cLIFECYCLE_PHASE_DECOMMISSION\x10\x06*\xc3\x01\n" +
+	"\x13PatchClassification\x12\x1d\n" +
+	"\x19PATCH_CLASSIFICATION_NULL\x10\x00\x12#\n" +
+	"\x1fPATCH_CLASSIFICATION_UNOFFICIAL\x10\x01\x12\x1f\n" +
+	"\x1bPATCH_CLASSIFICATION_MONKEY\x10\x02\x12!\n" +
+	"\x1dPATCH_CLASSIFICATION_BACKPORT\x10\x03\x12$\n" +
+	" PATCH_CLASSIFICATION_CHERRY_PICK\x10\x04*Z\n" +
+	"\x05Scope\x12\x15\n" +
+	"\x11SCOPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSCOPE_REQUIRED\x10\x01\x12\x12\n" +
+	"\x0eSCOPE_OPTIONAL\x10\x02\x12\x12\n" +
+	"\x0eSCOPE_EXCLUDED\x10\x03*\xa1\x03\n" +
+	"\tAggregate\x12\x1b\n" +
+	"\x17AGGREGATE_NOT_SPECIFIED\x10\x00\x12\x16\n" +
+	"\x12AGGREGATE_COMPLETE\x10\x01\x12\x18\n" +
+	"\x14AGGREGATE_INCOMPLETE\x10\x02\x12)\n" +
+	"%AGGREGATE_INCOMPLETE_FIRST_PARTY_ONLY\x10\x03\x12)\n" +
+	"%AGGREGATE_INCOMPLETE_THIRD_PARTY_ONLY\x10\x04\x12\x15\n" +
+	"\x11AGGREGATE_UNKNOWN\x10\x05\x125\n" +
+	"1AGGREGATE_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY\x10\x06\x124\n" +
+	"0AGGREGATE_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY\x10\a\x125\n" +
+	"1AGGREGATE_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY\x10\b\x124\n" +
+	"0AGGREGATE_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY\x10\t*\x97\x02\n" +
+	"\x11EvidenceFieldType\x12\x17\n" +
+	"\x13EVIDENCE_FIELD_NULL\x10\x00\x12\x17\n" +
+	"\x13EVIDENCE_FIELD_NAME\x10\x01\x12\x18\n" +
+	"\x14EVIDENCE_FIELD_GROUP\x10\x02\x12\x1a\n" +
+	"\x16EVIDENCE_FIELD_VERSION\x10\x03\x12\x17\n" +
+	"\x13EVIDENCE_FIELD_PURL\x10\x04\x12\x16\n" +
+	"\x12EVIDENCE_FIELD_CPE\x10\x05\x12\x17\n" +
+	"\x13EVIDENCE_FIELD_SWID\x10\x06\x12\x17\n" +
+	"\x13EVIDENCE_FIELD_HASH\x10\a\x12\x1d\n" +
+	"\x19EVIDENCE_FIELD_OMNIBOR_ID\x10\b\x12\x18\n" +
+	"\x14EVIDENCE_FIELD_SWHID\x10\t*\x96\x03\n" +
+	"\x11EvidenceTechnique\x12+\n" +
+	"'EVIDENCE_TECHNIQUE_SOURCE_CODE_ANALYSIS\x10\x00\x12&\n" +
+	"\"EVIDENCE_TECHNIQUE_BINARY_ANALYSIS\x10\x01\x12(\n" +
+	"$EVIDENCE_TECHNIQUE_MANIFEST_ANALYSIS\x10\x02\x12&\n" +
+	"\"EVIDENCE_TECHNIQUE_AST_FINGERPRINT\x10\x03\x12&\n" +
+	"\"EVIDENCE_TECHNIQUE_HASH_COMPARISON\x10\x04\x12&\n" +
+	"\"EVIDENCE_TECHNIQUE_INSTRUMENTATION\x10\x05\x12'\n" +
+	"#EVIDENCE_TECHNIQUE_DYNAMIC_ANALYSIS\x10\x06\x12\x1f\n" +
+	"\x1bEVIDENCE_TECHNIQUE_FILENAME\x10\a\x12\"\n" +
+	"\x1eEVIDENCE_TECHNIQUE_ATTESTATION\x10\b\x12\x1c\n" +
+	"\x18EVIDENCE_TECHNIQUE_OTHER\x10\t*\x97\x01\n" +
+	"\bSeverity\x12\x14\n" +
+	"\x10SEVERITY_UNKNOWN\x10\x00\x12\x15\n" +
+	"\x11SEVERITY_CRITICAL\x10\x01\x12\x11\n" +
+	"\rSEVERITY_HIGH\x10\x02\x12\x13\n" +
+	"\x0fSEVERITY_MEDIUM\x10\x03\x12\x10\n" +
+	"\fSEVERITY_LOW\x10\x04\x12\x11\n" +
+	"\rSEVERITY_INFO\x10\x05\x12\x11\n" +
+	"\rSEVERITY_NONE\x10\x06*\xd0\x01\n" +
+	"\vScoreMethod\x12\x15\n" +
+	"\x11SCORE_METHOD_NULL\x10\x00\x12\x17\n" +
+	"\x13SCORE_METHOD_CVSSV2\x10\x01\x12\x17\n" +
+	"\x13SCORE_METHOD_CVSSV3\x10\x02\x12\x18\n" +
+	"\x14SCORE_METHOD_CVSSV31\x10\x03\x12\x16\n" +
+	"\x12SCORE_METHOD_OWASP\x10\x04\x12\x16\n" +
+	"\x12SCORE_METHOD_OTHER\x10\x05\x12\x17\n" +
+	"\x13SCORE_METHOD_CVSSV4\x10\x06\x12\x15\n" +
+	"\x11SCORE_METHOD_SSVC\x10\a*\xc6\x04\n" +
+	"\x1bImpactAnalysisJustification\x12&\n" +
+	"\"IMPACT_ANALYSIS_JUSTIFICATION_NULL\x10\x00\x122\n" +
+	".IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_PRESENT\x10\x01\x124\n" +
+	"0IMPACT_ANALYSIS_JUSTIFICATION_CODE_NOT_REACHABLE\x10\x02\x128\n" +
+	"4IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_CONFIGURATION\x10\x03\x125\n" +
+	"1IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_DEPENDENCY\x10\x04\x126\n" +
+	"2IMPACT_ANALYSIS_JUSTIFICATION_REQUIRES_ENVIRONMENT\x10\x05\x127\n" +
+	"3IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_COMPILER\x10\x06\x126\n" +
+	"2IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_RUNTIME\x10\a\x128\n" +
+	"4IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_AT_PERIMETER\x10\b\x12A\n" +
+	"=IMPACT_ANALYSIS_JUSTIFICATION_PROTECTED_BY_MITIGATING_CONTROL\x10\t*\x82\x02\n" +
+	"\x15VulnerabilityResponse\x12\x1f\n" +
+	"\x1bVULNERABILITY_RESPONSE_NULL\x10\x00\x12&\n" +
+	"\"VULNERABILITY_RESPONSE_CAN_NOT_FIX\x10\x01\x12'\n" +
+	"#VULNERABILITY_RESPONSE_WILL_NOT_FIX\x10\x02\x12!\n" +
+	"\x1dVULNERABILITY_RESPONSE_UPDATE\x10\x03\x12#\n" +
+	"\x1fVULNERABILITY_RESPONSE_ROLLBACK\x10\x04\x12/\n" +
+	"+VULNERABILITY_RESPONSE_WORKAROUND_AVAILABLE\x10\x05*\xa9\x02\n" +
+	"\x13ImpactAnalysisState\x12\x1e\n" +
+	"\x1aIMPACT_ANALYSIS_STATE_NULL\x10\x00\x12\"\n" +
+	"\x1eIMPACT_ANALYSIS_STATE_RESOLVED\x10\x01\x120\n" +
+	",IMPACT_ANALYSIS_STATE_RESOLVED_WITH_PEDIGREE\x10\x02\x12%\n" +
+	"!IMPACT_ANALYSIS_STATE_EXPLOITABLE\x10\x03\x12#\n" +
+	"\x1fIMPACT_ANALYSIS_STATE_IN_TRIAGE\x10\x04\x12(\n" +
+	"$IMPACT_ANALYSIS_STATE_FALSE_POSITIVE\x10\x05\x12&\n" +
+	"\"IMPACT_ANALYSIS_STATE_NOT_AFFECTED\x10\x06*\xa4\x01\n" +
+	"\x1bVulnerabilityAffectedStatus\x12)\n" +
+	"%VULNERABILITY_AFFECTED_STATUS_UNKNOWN\x10\x00\x12*\n" +
+	"&VULNERABILITY_AFFECTED_STATUS_AFFECTED\x10\x01\x12.\n" +
+	"*VULNERABILITY_AFFECTED_STATUS_NOT_AFFECTED\x10\x02*\x87\x02\n" +
+	"\bTaskType\x12\x12\n" +
+	"\x0eTASK_TYPE_COPY\x10\x00\x12\x13\n" +
+	"\x0fTASK_TYPE_CLONE\x10\x01\x12\x12\n" +
+	"\x0eTASK_TYPE_LINT\x10\x02\x12\x12\n" +
+	"\x0eTASK_TYPE_SCAN\x10\x03\x12\x13\n" +
+	"\x0fTASK_TYPE_MERGE\x10\x04\x12\x13\n" +
+	"\x0fTASK_TYPE_BUILD\x10\x05\x12\x12\n" +
+	"\x0eTASK_TYPE_TEST\x10\x06\x12\x15\n" +
+	"\x11TASK_TYPE_DELIVER\x10\a\x12\x14\n" +
+	"\x10TASK_TYPE_DEPLOY\x10\b\x12\x15\n" +
+	"\x11TASK_TYPE_RELEASE\x10\t\x12\x13\n" +
+	"\x0fTASK_TYPE_CLEAN\x10\n" +
+	"\x12\x13\n" +
+	"\x0fTASK_TYPE_OTHER\x10\v*\xc3\x01\n" +
+	"\x11ComponentDataType\x12#\n" +
+	"\x1fCOMPONENT_DATA_TYPE_SOURCE_CODE\x10\x00\x12%\n" +
+	"!COMPONENT_DATA_TYPE_CONFIGURATION\x10\x01\x12\x1f\n" +
+	"\x1bCOMPONENT_DATA_TYPE_DATASET\x10\x02\x12\"\n" +
+	"\x1eCOMPONENT_DATA_TYPE_DEFINITION\x10\x03\x12\x1d\n" +
+	"\x19COMPONENT_DATA_TYPE_OTHER\x10\x04*\x97\x02\n" +
+	"\x1aModelParameterApproachType\x12,\n" +
+	"(MODEL_PARAMETER_APPROACH_TYPE_SUPERVISED\x10\x00\x12.\n" +
+	"*MODEL_PARAMETER_APPROACH_TYPE_UNSUPERVISED\x10\x01\x125\n" +
+	"1MODEL_PARAMETER_APPROACH_TYPE_REINFORCED_LEARNING\x10\x02\x121\n" +
+	"-MODEL_PARAMETER_APPROACH_TYPE_SEMI_SUPERVISED\x10\x03\x121\n" +
+	"-MODEL_PARAMETER_APPROACH_TYPE_SELF_SUPERVISED\x10\x04BJZHgithub.com/openconfig/security-services/proto/cyclonedx/v1_6;cyclonedxpbb\x06proto3"
+
+var (
+	file_bom_1_6_proto_rawDescOnce sync.Once
+	file_bom_1_6_proto_rawDescData []byte
+)
+
+func file_bom_1_6_proto_rawDescGZIP() []byte {
+	file_bom_1_6_proto_rawDescOnce.Do(func() {
+		file_bom_1_6_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bom_1_6_proto_rawDesc), len(file_bom_1_6_proto_rawDesc)))
+	})
+	return file_bom_1_6_proto_rawDescData
+}
+
+var file_bom_1_6_proto_enumTypes = make([]protoimpl.EnumInfo, 41)
+var file_bom_1_6_proto_msgTypes = make([]protoimpl.MessageInfo, 110)
+var file_bom_1_6_proto_goTypes = []any{
+	(Classification)(0),                    // 0: cyclonedx.v1_6.Classification
+	(DataFlow)(0),                          // 1: cyclonedx.v1_6.DataFlow
+	(ExternalReferenceType)(0),             // 2: cyclonedx.v1_6.ExternalReferenceType
+	(HashAlg)(0),                           // 3: cyclonedx.v1_6.HashAlg
+	(IssueClassification)(0),               // 4: cyclonedx.v1_6.IssueClassification
+	(LicenseAcknowledgementEnumeration)(0), // 5: cyclonedx.v1_6.LicenseAcknowledgementEnumeration
+	(LicensingTypeEnum)(0),                 // 6: cyclonedx.v1_6.LicensingTypeEnum
+	(LifecyclePhase)(0),                    // 7: cyclonedx.v1_6.LifecyclePhase
+	(PatchClassification)(0),               // 8: cyclonedx.v1_6.PatchClassification
+	(Scope)(0),                             // 9: cyclonedx.v1_6.Scope
+	(Aggregate)(0),                         // 10: cyclonedx.v1_6.Aggregate
+	(EvidenceFieldType)(0),                 // 11: cyclonedx.v1_6.EvidenceFieldType
+	(EvidenceTechnique)(0),                 // 12: cyclonedx.v1_6.EvidenceTechnique
+	(Severity)(0),                          // 13: cyclonedx.v1_6.Severity
+	(ScoreMethod)(0),                       // 14: cyclonedx.v1_6.ScoreMethod
+	(ImpactAnalysisJustification)(0),       // 15: cyclonedx.v1_6.ImpactAnalysisJustification
+	(VulnerabilityResponse)(0),             // 16: cyclonedx.v1_6.VulnerabilityResponse
+	(ImpactAnalysisState)(0),               // 17: cyclonedx.v1_6.ImpactAnalysisState
+	(VulnerabilityAffectedStatus)(0),       // 18: cyclonedx.v1_6.VulnerabilityAffectedStatus
+	(TaskType)(0),                          // 19: cyclonedx.v1_6.TaskType
+	(ComponentDataType)(0),                 // 20: cyclonedx.v1_6.ComponentDataType
+	(ModelParameterApproachType)(0),        // 21: cyclonedx.v1_6.ModelParameterApproachType
+	(Workspace_AccessMode)(0),              // 22: cyclonedx.v1_6.Workspace.AccessMode
+	(Volume_VolumeMode)(0),                 // 23: cyclonedx.v1_6.Volume.VolumeMode
+	(Trigger_TriggerType)(0),               // 24: cyclonedx.v1_6.Trigger.TriggerType
+	(OutputType_OutputTypeType)(0),         // 25: cyclonedx.v1_6.OutputType.OutputTypeType
+	(ModelCard_ModelCardConsiderations_EnergyActivity)(0),                            // 26: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyActivity
+	(ModelCard_ModelCardConsiderations_EnergySource)(0),                              // 27: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergySource
+	(ModelCard_ModelCardConsiderations_EnergyMeasure_EnergyUnit)(0),                  // 28: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure.EnergyUnit
+	(ModelCard_ModelCardConsiderations_Co2Measure_Co2Unit)(0),                        // 29: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure.Co2Unit
+	(CryptoProperties_CryptoAssetType)(0),                                            // 30: cyclonedx.v1_6.CryptoProperties.CryptoAssetType
+	(CryptoProperties_AlgorithmProperties_CryptoPrimitive)(0),                        // 31: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoPrimitive
+	(CryptoProperties_AlgorithmProperties_CryptoExecutionEnvironment)(0),             // 32: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoExecutionEnvironment
+	(CryptoProperties_AlgorithmProperties_CryptoImplementationPlatform)(0),           // 33: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoImplementationPlatform
+	(CryptoProperties_AlgorithmProperties_CryptoCertificationLevel)(0),               // 34: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoCertificationLevel
+	(CryptoProperties_AlgorithmProperties_CryptoAlgorithmMode)(0),                    // 35: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmMode
+	(CryptoProperties_AlgorithmProperties_CryptoAlgorithmPadding)(0),                 // 36: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmPadding
+	(CryptoProperties_AlgorithmProperties_CryptoFunction)(0),                         // 37: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoFunction
+	(CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialType)(0),  // 38: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialType
+	(CryptoProperties_RelatedCryptoMaterialProperties_RelatedCryptoMaterialState)(0), // 39: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialState
+	(CryptoProperties_ProtocolProperties_CryptoProtocolType)(0),                      // 40: cyclonedx.v1_6.CryptoProperties.ProtocolProperties.CryptoProtocolType
+	(*AttachedText)(nil),                  // 41: cyclonedx.v1_6.AttachedText
+	(*Bom)(nil),                           // 42: cyclonedx.v1_6.Bom
+	(*Commit)(nil),                        // 43: cyclonedx.v1_6.Commit
+	(*Component)(nil),                     // 44: cyclonedx.v1_6.Component
+	(*DataClassification)(nil),            // 45: cyclonedx.v1_6.DataClassification
+	(*Dependency)(nil),                    // 46: cyclonedx.v1_6.Dependency
+	(*Diff)(nil),                          // 47: cyclonedx.v1_6.Diff
+	(*ExternalReference)(nil),             // 48: cyclonedx.v1_6.ExternalReference
+	(*Hash)(nil),                          // 49: cyclonedx.v1_6.Hash
+	(*IdentifiableAction)(nil),            // 50: cyclonedx.v1_6.IdentifiableAction
+	(*Issue)(nil),                         // 51: cyclonedx.v1_6.Issue
+	(*Source)(nil),                        // 52: cyclonedx.v1_6.Source
+	(*LicenseChoice)(nil),                 // 53: cyclonedx.v1_6.LicenseChoice
+	(*License)(nil),                       // 54: cyclonedx.v1_6.License
+	(*Licensing)(nil),                     // 55: cyclonedx.v1_6.Licensing
+	(*Metadata)(nil),                      // 56: cyclonedx.v1_6.Metadata
+	(*Lifecycles)(nil),                    // 57: cyclonedx.v1_6.Lifecycles
+	(*OrganizationalContact)(nil),         // 58: cyclonedx.v1_6.OrganizationalContact
+	(*OrganizationalEntity)(nil),          // 59: cyclonedx.v1_6.OrganizationalEntity
+	(*PostalAddressType)(nil),             // 60: cyclonedx.v1_6.PostalAddressType
+	(*Patch)(nil),                         // 61: cyclonedx.v1_6.Patch
+	(*Pedigree)(nil),                      // 62: cyclonedx.v1_6.Pedigree
+	(*Service)(nil),                       // 63: cyclonedx.v1_6.Service
+	(*Swid)(nil),                          // 64: cyclonedx.v1_6.Swid
+	(*Tool)(nil),                          // 65: cyclonedx.v1_6.Tool
+	(*Property)(nil),                      // 66: cyclonedx.v1_6.Property
+	(*Composition)(nil),                   // 67: cyclonedx.v1_6.Composition
+	(*EvidenceCopyright)(nil),             // 68: cyclonedx.v1_6.EvidenceCopyright
+	(*Evidence)(nil),                      // 69: cyclonedx.v1_6.Evidence
+	(*EvidenceIdentity)(nil),              // 70: cyclonedx.v1_6.EvidenceIdentity
+	(*EvidenceMethods)(nil),               // 71: cyclonedx.v1_6.EvidenceMethods
+	(*EvidenceOccurrences)(nil),           // 72: cyclonedx.v1_6.EvidenceOccurrences
+	(*Callstack)(nil),                     // 73: cyclonedx.v1_6.Callstack
+	(*ReleaseNotes)(nil),                  // 74: cyclonedx.v1_6.ReleaseNotes
+	(*Vulnerability)(nil),                 // 75: cyclonedx.v1_6.Vulnerability
+	(*VulnerabilityReference)(nil),        // 76: cyclonedx.v1_6.VulnerabilityReference
+	(*VulnerabilityRating)(nil),           // 77: cyclonedx.v1_6.VulnerabilityRating
+	(*Advisory)(nil),                      // 78: cyclonedx.v1_6.Advisory
+	(*VulnerabilityCredits)(nil),          // 79: cyclonedx.v1_6.VulnerabilityCredits
+	(*VulnerabilityAnalysis)(nil),         // 80: cyclonedx.v1_6.VulnerabilityAnalysis
+	(*VulnerabilityAffects)(nil),          // 81: cyclonedx.v1_6.VulnerabilityAffects
+	(*VulnerabilityAffectedVersions)(nil), // 82: cyclonedx.v1_6.VulnerabilityAffectedVersions
+	(*ProofOfConcept)(nil),                // 83: cyclonedx.v1_6.ProofOfConcept
+	(*Annotation)(nil),                    // 84: cyclonedx.v1_6.Annotation
+	(*Formula)(nil),                       // 85: cyclonedx.v1_6.Formula
+	(*Workflow)(nil),                      // 86: cyclonedx.v1_6.Workflow
+	(*Task)(nil),                          // 87: cyclonedx.v1_6.Task
+	(*Step)(nil),                          // 88: cyclonedx.v1_6.Step
+	(*Command)(nil),                       // 89: cyclonedx.v1_6.Command
+	(*Workspace)(nil),                     // 90: cyclonedx.v1_6.Workspace
+	(*Volume)(nil),                        // 91: cyclonedx.v1_6.Volume
+	(*Trigger)(nil),                       // 92: cyclonedx.v1_6.Trigger
+	(*Event)(nil),                         // 93: cyclonedx.v1_6.Event
+	(*InputType)(nil),                     // 94: cyclonedx.v1_6.InputType
+	(*OutputType)(nil),                    // 95: cyclonedx.v1_6.OutputType
+	(*ResourceReferenceChoice)(nil),       // 96: cyclonedx.v1_6.ResourceReferenceChoice
+	(*Condition)(nil),                     // 97: cyclonedx.v1_6.Condition
+	(*Parameter)(nil),                     // 98: cyclonedx.v1_6.Parameter
+	(*EnvironmentVars)(nil),               // 99: cyclonedx.v1_6.EnvironmentVars
+	(*ComponentData)(nil),                 // 100: cyclonedx.v1_6.ComponentData
+	(*DataGovernance)(nil),                // 101: cyclonedx.v1_6.DataGovernance
+	(*GraphicsCollection)(nil),            // 102: cyclonedx.v1_6.GraphicsCollection
+	(*ModelCard)(nil),                     // 103: cyclonedx.v1_6.ModelCard
+	(*CryptoProperties)(nil),              // 104: cyclonedx.v1_6.CryptoProperties
+	(*Declarations)(nil),                  // 105: cyclonedx.v1_6.Declarations
+	(*Definition)(nil),                    // 106: cyclonedx.v1_6.Definition
+	(*Licensing_OrganizationalEntityOrContact)(nil),                              // 107: cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact
+	(*Callstack_Frames)(nil),                                                     // 108: cyclonedx.v1_6.Callstack.Frames
+	(*ReleaseNotes_Note)(nil),                                                    // 109: cyclonedx.v1_6.ReleaseNotes.Note
+	(*Annotation_Annotator)(nil),                                                 // 110: cyclonedx.v1_6.Annotation.Annotator
+	(*ComponentData_ComponentDataContents)(nil),                                  // 111: cyclonedx.v1_6.ComponentData.ComponentDataContents
+	(*DataGovernance_DataGovernanceResponsibleParty)(nil),                        // 112: cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty
+	(*GraphicsCollection_Graphic)(nil),                                           // 113: cyclonedx.v1_6.GraphicsCollection.Graphic
+	(*ModelCard_ModelParameters)(nil),                                            // 114: cyclonedx.v1_6.ModelCard.ModelParameters
+	(*ModelCard_QuantitativeAnalysis)(nil),                                       // 115: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis
+	(*ModelCard_ModelCardConsiderations)(nil),                                    // 116: cyclonedx.v1_6.ModelCard.ModelCardConsiderations
+	(*ModelCard_ModelParameters_Approach)(nil),                                   // 117: cyclonedx.v1_6.ModelCard.ModelParameters.Approach
+	(*ModelCard_ModelParameters_Datasets)(nil),                                   // 118: cyclonedx.v1_6.ModelCard.ModelParameters.Datasets
+	(*ModelCard_ModelParameters_MachineLearningInputOutputParameters)(nil),       // 119: cyclonedx.v1_6.ModelCard.ModelParameters.MachineLearningInputOutputParameters
+	(*ModelCard_QuantitativeAnalysis_PerformanceMetrics)(nil),                    // 120: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics
+	(*ModelCard_QuantitativeAnalysis_PerformanceMetrics_ConfidenceInterval)(nil), // 121: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics.ConfidenceInterval
+	(*ModelCard_ModelCardConsiderations_EthicalConsiderations)(nil),              // 122: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EthicalConsiderations
+	(*ModelCard_ModelCardConsiderations_FairnessAssessments)(nil),                // 123: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.FairnessAssessments
+	(*ModelCard_ModelCardConsiderations_EnvironmentalConsiderations)(nil),        // 124: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnvironmentalConsiderations
+	(*ModelCard_ModelCardConsiderations_EnergyConsumption)(nil),                  // 125: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption
+	(*ModelCard_ModelCardConsiderations_EnergyMeasure)(nil),                      // 126: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure
+	(*ModelCard_ModelCardConsiderations_Co2Measure)(nil),                         // 127: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure
+	(*ModelCard_ModelCardConsiderations_EnergyProviderType)(nil),                 // 128: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType
+	(*CryptoProperties_AlgorithmProperties)(nil),                                 // 129: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties
+	(*CryptoProperties_CertificateProperties)(nil),                               // 130: cyclonedx.v1_6.CryptoProperties.CertificateProperties
+	(*CryptoProperties_RelatedCryptoMaterialProperties)(nil),                     // 131: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties
+	(*CryptoProperties_ProtocolProperties)(nil),                                  // 132: cyclonedx.v1_6.CryptoProperties.ProtocolProperties
+	(*CryptoProperties_CipherSuite)(nil),                                         // 133: cyclonedx.v1_6.CryptoProperties.CipherSuite
+	(*CryptoProperties_SecuredBy)(nil),                                           // 134: cyclonedx.v1_6.CryptoProperties.SecuredBy
+	(*CryptoProperties_ProtocolProperties_Ikev2TransformTypes)(nil),              // 135: cyclonedx.v1_6.CryptoProperties.ProtocolProperties.Ikev2TransformTypes
+	(*Declarations_Assessor)(nil),                                                // 136: cyclonedx.v1_6.Declarations.Assessor
+	(*Declarations_Attestation)(nil),                                             // 137: cyclonedx.v1_6.Declarations.Attestation
+	(*Declarations_Claim)(nil),                                                   // 138: cyclonedx.v1_6.Declarations.Claim
+	(*Declarations_Evidence)(nil),                                                // 139: cyclonedx.v1_6.Declarations.Evidence
+	(*Declarations_Targets)(nil),                                                 // 140: cyclonedx.v1_6.Declarations.Targets
+	(*Declarations_Affirmation)(nil),                                             // 141: cyclonedx.v1_6.Declarations.Affirmation
+	(*Declarations_Attestation_AttestationMap)(nil),                              // 142: cyclonedx.v1_6.Declarations.Attestation.AttestationMap
+	(*Declarations_Attestation_AttestationMap_Conformance)(nil),                  // 143: cyclonedx.v1_6.Declarations.Attestation.AttestationMap.Conformance
+	(*Declarations_Attestation_AttestationMap_Confidence)(nil),                   // 144: cyclonedx.v1_6.Declarations.Attestation.AttestationMap.Confidence
+	(*Declarations_Evidence_Data)(nil),                                           // 145: cyclonedx.v1_6.Declarations.Evidence.Data
+	(*Declarations_Evidence_Data_Contents)(nil),                                  // 146: cyclonedx.v1_6.Declarations.Evidence.Data.Contents
+	(*Declarations_Affirmation_Signatory)(nil),                                   // 147: cyclonedx.v1_6.Declarations.Affirmation.Signatory
+	(*Definition_Standard)(nil),                                                  // 148: cyclonedx.v1_6.Definition.Standard
+	(*Definition_Standard_Requirement)(nil),                                      // 149: cyclonedx.v1_6.Definition.Standard.Requirement
+	(*Definition_Standard_Level)(nil),                                            // 150: cyclonedx.v1_6.Definition.Standard.Level
+	(*timestamppb.Timestamp)(nil),                                                // 151: google.protobuf.Timestamp
+}
+var file_bom_1_6_proto_depIdxs = []int32{
+	56,  // 0: cyclonedx.v1_6.Bom.metadata:type_name -> cyclonedx.v1_6.Metadata
+	44,  // 1: cyclonedx.v1_6.Bom.components:type_name -> cyclonedx.v1_6.Component
+	63,  // 2: cyclonedx.v1_6.Bom.services:type_name -> cyclonedx.v1_6.Service
+	48,  // 3: cyclonedx.v1_6.Bom.external_references:type_name -> cyclonedx.v1_6.ExternalReference
+	46,  // 4: cyclonedx.v1_6.Bom.dependencies:type_name -> cyclonedx.v1_6.Dependency
+	67,  // 5: cyclonedx.v1_6.Bom.compositions:type_name -> cyclonedx.v1_6.Composition
+	75,  // 6: cyclonedx.v1_6.Bom.vulnerabilities:type_name -> cyclonedx.v1_6.Vulnerability
+	84,  // 7: cyclonedx.v1_6.Bom.annotations:type_name -> cyclonedx.v1_6.Annotation
+	66,  // 8: cyclonedx.v1_6.Bom.properties:type_name -> cyclonedx.v1_6.Property
+	85,  // 9: cyclonedx.v1_6.Bom.formulation:type_name -> cyclonedx.v1_6.Formula
+	105, // 10: cyclonedx.v1_6.Bom.declarations:type_name -> cyclonedx.v1_6.Declarations
+	106, // 11: cyclonedx.v1_6.Bom.definitions:type_name -> cyclonedx.v1_6.Definition
+	50,  // 12: cyclonedx.v1_6.Commit.author:type_name -> cyclonedx.v1_6.IdentifiableAction
+	50,  // 13: cyclonedx.v1_6.Commit.committer:type_name -> cyclonedx.v1_6.IdentifiableAction
+	0,   // 14: cyclonedx.v1_6.Component.type:type_name -> cyclonedx.v1_6.Classification
+	59,  // 15: cyclonedx.v1_6.Component.supplier:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	9,   // 16: cyclonedx.v1_6.Component.scope:type_name -> cyclonedx.v1_6.Scope
+	49,  // 17: cyclonedx.v1_6.Component.hashes:type_name -> cyclonedx.v1_6.Hash
+	53,  // 18: cyclonedx.v1_6.Component.licenses:type_name -> cyclonedx.v1_6.LicenseChoice
+	64,  // 19: cyclonedx.v1_6.Component.swid:type_name -> cyclonedx.v1_6.Swid
+	62,  // 20: cyclonedx.v1_6.Component.pedigree:type_name -> cyclonedx.v1_6.Pedigree
+	48,  // 21: cyclonedx.v1_6.Component.external_references:type_name -> cyclonedx.v1_6.ExternalReference
+	44,  // 22: cyclonedx.v1_6.Component.components:type_name -> cyclonedx.v1_6.Component
+	66,  // 23: cyclonedx.v1_6.Component.properties:type_name -> cyclonedx.v1_6.Property
+	69,  // 24: cyclonedx.v1_6.Component.evidence:type_name -> cyclonedx.v1_6.Evidence
+	74,  // 25: cyclonedx.v1_6.Component.releaseNotes:type_name -> cyclonedx.v1_6.ReleaseNotes
+	103, // 26: cyclonedx.v1_6.Component.modelCard:type_name -> cyclonedx.v1_6.ModelCard
+	100, // 27: cyclonedx.v1_6.Component.data:type_name -> cyclonedx.v1_6.ComponentData
+	104, // 28: cyclonedx.v1_6.Component.cryptoProperties:type_name -> cyclonedx.v1_6.CryptoProperties
+	59,  // 29: cyclonedx.v1_6.Component.manufacturer:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	58,  // 30: cyclonedx.v1_6.Component.authors:type_name -> cyclonedx.v1_6.OrganizationalContact
+	1,   // 31: cyclonedx.v1_6.DataClassification.flow:type_name -> cyclonedx.v1_6.DataFlow
+	46,  // 32: cyclonedx.v1_6.Dependency.dependencies:type_name -> cyclonedx.v1_6.Dependency
+	41,  // 33: cyclonedx.v1_6.Diff.text:type_name -> cyclonedx.v1_6.AttachedText
+	2,   // 34: cyclonedx.v1_6.ExternalReference.type:type_name -> cyclonedx.v1_6.ExternalReferenceType
+	49,  // 35: cyclonedx.v1_6.ExternalReference.hashes:type_name -> cyclonedx.v1_6.Hash
+	3,   // 36: cyclonedx.v1_6.Hash.alg:type_name -> cyclonedx.v1_6.HashAlg
+	151, // 37: cyclonedx.v1_6.IdentifiableAction.timestamp:type_name -> google.protobuf.Timestamp
+	4,   // 38: cyclonedx.v1_6.Issue.type:type_name -> cyclonedx.v1_6.IssueClassification
+	52,  // 39: cyclonedx.v1_6.Issue.source:type_name -> cyclonedx.v1_6.Source
+	54,  // 40: cyclonedx.v1_6.LicenseChoice.license:type_name -> cyclonedx.v1_6.License
+	5,   // 41: cyclonedx.v1_6.LicenseChoice.acknowledgement:type_name -> cyclonedx.v1_6.LicenseAcknowledgementEnumeration
+	41,  // 42: cyclonedx.v1_6.License.text:type_name -> cyclonedx.v1_6.AttachedText
+	55,  // 43: cyclonedx.v1_6.License.licensing:type_name -> cyclonedx.v1_6.Licensing
+	66,  // 44: cyclonedx.v1_6.License.properties:type_name -> cyclonedx.v1_6.Property
+	5,   // 45: cyclonedx.v1_6.License.acknowledgement:type_name -> cyclonedx.v1_6.LicenseAcknowledgementEnumeration
+	107, // 46: cyclonedx.v1_6.Licensing.licensor:type_name -> cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact
+	107, // 47: cyclonedx.v1_6.Licensing.licensee:type_name -> cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact
+	107, // 48: cyclonedx.v1_6.Licensing.purchaser:type_name -> cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact
+	6,   // 49: cyclonedx.v1_6.Licensing.licenseTypes:type_name -> cyclonedx.v1_6.LicensingTypeEnum
+	151, // 50: cyclonedx.v1_6.Licensing.lastRenewal:type_name -> google.protobuf.Timestamp
+	151, // 51: cyclonedx.v1_6.Licensing.expiration:type_name -> google.protobuf.Timestamp
+	151, // 52: cyclonedx.v1_6.Metadata.timestamp:type_name -> google.protobuf.Timestamp
+	65,  // 53: cyclonedx.v1_6.Metadata.tools:type_name -> cyclonedx.v1_6.Tool
+	58,  // 54: cyclonedx.v1_6.Metadata.authors:type_name -> cyclonedx.v1_6.OrganizationalContact
+	44,  // 55: cyclonedx.v1_6.Metadata.component:type_name -> cyclonedx.v1_6.Component
+	59,  // 56: cyclonedx.v1_6.Metadata.manufacture:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	59,  // 57: cyclonedx.v1_6.Metadata.supplier:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	53,  // 58: cyclonedx.v1_6.Metadata.licenses:type_name -> cyclonedx.v1_6.LicenseChoice
+	66,  // 59: cyclonedx.v1_6.Metadata.properties:type_name -> cyclonedx.v1_6.Property
+	57,  // 60: cyclonedx.v1_6.Metadata.lifecycles:type_name -> cyclonedx.v1_6.Lifecycles
+	59,  // 61: cyclonedx.v1_6.Metadata.manufacturer:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	7,   // 62: cyclonedx.v1_6.Lifecycles.phase:type_name -> cyclonedx.v1_6.LifecyclePhase
+	58,  // 63: cyclonedx.v1_6.OrganizationalEntity.contact:type_name -> cyclonedx.v1_6.OrganizationalContact
+	60,  // 64: cyclonedx.v1_6.OrganizationalEntity.address:type_name -> cyclonedx.v1_6.PostalAddressType
+	8,   // 65: cyclonedx.v1_6.Patch.type:type_name -> cyclonedx.v1_6.PatchClassification
+	47,  // 66: cyclonedx.v1_6.Patch.diff:type_name -> cyclonedx.v1_6.Diff
+	51,  // 67: cyclonedx.v1_6.Patch.resolves:type_name -> cyclonedx.v1_6.Issue
+	44,  // 68: cyclonedx.v1_6.Pedigree.ancestors:type_name -> cyclonedx.v1_6.Component
+	44,  // 69: cyclonedx.v1_6.Pedigree.descendants:type_name -> cyclonedx.v1_6.Component
+	44,  // 70: cyclonedx.v1_6.Pedigree.variants:type_name -> cyclonedx.v1_6.Component
+	43,  // 71: cyclonedx.v1_6.Pedigree.commits:type_name -> cyclonedx.v1_6.Commit
+	61,  // 72: cyclonedx.v1_6.Pedigree.patches:type_name -> cyclonedx.v1_6.Patch
+	59,  // 73: cyclonedx.v1_6.Service.provider:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	45,  // 74: cyclonedx.v1_6.Service.data:type_name -> cyclonedx.v1_6.DataClassification
+	53,  // 75: cyclonedx.v1_6.Service.licenses:type_name -> cyclonedx.v1_6.LicenseChoice
+	48,  // 76: cyclonedx.v1_6.Service.external_references:type_name -> cyclonedx.v1_6.ExternalReference
+	63,  // 77: cyclonedx.v1_6.Service.services:type_name -> cyclonedx.v1_6.Service
+	66,  // 78: cyclonedx.v1_6.Service.properties:type_name -> cyclonedx.v1_6.Property
+	74,  // 79: cyclonedx.v1_6.Service.releaseNotes:type_name -> cyclonedx.v1_6.ReleaseNotes
+	41,  // 80: cyclonedx.v1_6.Swid.text:type_name -> cyclonedx.v1_6.AttachedText
+	49,  // 81: cyclonedx.v1_6.Tool.hashes:type_name -> cyclonedx.v1_6.Hash
+	48,  // 82: cyclonedx.v1_6.Tool.external_references:type_name -> cyclonedx.v1_6.ExternalReference
+	44,  // 83: cyclonedx.v1_6.Tool.components:type_name -> cyclonedx.v1_6.Component
+	63,  // 84: cyclonedx.v1_6.Tool.services:type_name -> cyclonedx.v1_6.Service
+	10,  // 85: cyclonedx.v1_6.Composition.aggregate:type_name -> cyclonedx.v1_6.Aggregate
+	53,  // 86: cyclonedx.v1_6.Evidence.licenses:type_name -> cyclonedx.v1_6.LicenseChoice
+	68,  // 87: cyclonedx.v1_6.Evidence.copyright:type_name -> cyclonedx.v1_6.EvidenceCopyright
+	70,  // 88: cyclonedx.v1_6.Evidence.identity:type_name -> cyclonedx.v1_6.EvidenceIdentity
+	72,  // 89: cyclonedx.v1_6.Evidence.occurrences:type_name -> cyclonedx.v1_6.EvidenceOccurrences
+	73,  // 90: cyclonedx.v1_6.Evidence.callstack:type_name -> cyclonedx.v1_6.Callstack
+	11,  // 91: cyclonedx.v1_6.EvidenceIdentity.field:type_name -> cyclonedx.v1_6.EvidenceFieldType
+	71,  // 92: cyclonedx.v1_6.EvidenceIdentity.methods:type_name -> cyclonedx.v1_6.EvidenceMethods
+	12,  // 93: cyclonedx.v1_6.EvidenceMethods.technique:type_name -> cyclonedx.v1_6.EvidenceTechnique
+	108, // 94: cyclonedx.v1_6.Callstack.frames:type_name -> cyclonedx.v1_6.Callstack.Frames
+	151, // 95: cyclonedx.v1_6.ReleaseNotes.timestamp:type_name -> google.protobuf.Timestamp
+	51,  // 96: cyclonedx.v1_6.ReleaseNotes.resolves:type_name -> cyclonedx.v1_6.Issue
+	109, // 97: cyclonedx.v1_6.ReleaseNotes.notes:type_name -> cyclonedx.v1_6.ReleaseNotes.Note
+	66,  // 98: cyclonedx.v1_6.ReleaseNotes.properties:type_name -> cyclonedx.v1_6.Property
+	52,  // 99: cyclonedx.v1_6.Vulnerability.source:type_name -> cyclonedx.v1_6.Source
+	76,  // 100: cyclonedx.v1_6.Vulnerability.references:type_name -> cyclonedx.v1_6.VulnerabilityReference
+	77,  // 101: cyclonedx.v1_6.Vulnerability.ratings:type_name -> cyclonedx.v1_6.VulnerabilityRating
+	78,  // 102: cyclonedx.v1_6.Vulnerability.advisories:type_name -> cyclonedx.v1_6.Advisory
+	151, // 103: cyclonedx.v1_6.Vulnerability.created:type_name -> google.protobuf.Timestamp
+	151, // 104: cyclonedx.v1_6.Vulnerability.published:type_name -> google.protobuf.Timestamp
+	151, // 105: cyclonedx.v1_6.Vulnerability.updated:type_name -> google.protobuf.Timestamp
+	79,  // 106: cyclonedx.v1_6.Vulnerability.credits:type_name -> cyclonedx.v1_6.VulnerabilityCredits
+	65,  // 107: cyclonedx.v1_6.Vulnerability.tools:type_name -> cyclonedx.v1_6.Tool
+	80,  // 108: cyclonedx.v1_6.Vulnerability.analysis:type_name -> cyclonedx.v1_6.VulnerabilityAnalysis
+	81,  // 109: cyclonedx.v1_6.Vulnerability.affects:type_name -> cyclonedx.v1_6.VulnerabilityAffects
+	66,  // 110: cyclonedx.v1_6.Vulnerability.properties:type_name -> cyclonedx.v1_6.Property
+	151, // 111: cyclonedx.v1_6.Vulnerability.rejected:type_name -> google.protobuf.Timestamp
+	83,  // 112: cyclonedx.v1_6.Vulnerability.proofOfConcept:type_name -> cyclonedx.v1_6.ProofOfConcept
+	52,  // 113: cyclonedx.v1_6.VulnerabilityReference.source:type_name -> cyclonedx.v1_6.Source
+	52,  // 114: cyclonedx.v1_6.VulnerabilityRating.source:type_name -> cyclonedx.v1_6.Source
+	13,  // 115: cyclonedx.v1_6.VulnerabilityRating.severity:type_name -> cyclonedx.v1_6.Severity
+	14,  // 116: cyclonedx.v1_6.VulnerabilityRating.method:type_name -> cyclonedx.v1_6.ScoreMethod
+	59,  // 117: cyclonedx.v1_6.VulnerabilityCredits.organizations:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	58,  // 118: cyclonedx.v1_6.VulnerabilityCredits.individuals:type_name -> cyclonedx.v1_6.OrganizationalContact
+	17,  // 119: cyclonedx.v1_6.VulnerabilityAnalysis.state:type_name -> cyclonedx.v1_6.ImpactAnalysisState
+	15,  // 120: cyclonedx.v1_6.VulnerabilityAnalysis.justification:type_name -> cyclonedx.v1_6.ImpactAnalysisJustification
+	16,  // 121: cyclonedx.v1_6.VulnerabilityAnalysis.response:type_name -> cyclonedx.v1_6.VulnerabilityResponse
+	151, // 122: cyclonedx.v1_6.VulnerabilityAnalysis.firstIssued:type_name -> google.protobuf.Timestamp
+	151, // 123: cyclonedx.v1_6.VulnerabilityAnalysis.lastUpdated:type_name -> google.protobuf.Timestamp
+	82,  // 124: cyclonedx.v1_6.VulnerabilityAffects.versions:type_name -> cyclonedx.v1_6.VulnerabilityAffectedVersions
+	18,  // 125: cyclonedx.v1_6.VulnerabilityAffectedVersions.status:type_name -> cyclonedx.v1_6.VulnerabilityAffectedStatus
+	41,  // 126: cyclonedx.v1_6.ProofOfConcept.supportingMaterial:type_name -> cyclonedx.v1_6.AttachedText
+	110, // 127: cyclonedx.v1_6.Annotation.annotator:type_name -> cyclonedx.v1_6.Annotation.Annotator
+	151, // 128: cyclonedx.v1_6.Annotation.timestamp:type_name -> google.protobuf.Timestamp
+	44,  // 129: cyclonedx.v1_6.Formula.components:type_name -> cyclonedx.v1_6.Component
+	63,  // 130: cyclonedx.v1_6.Formula.services:type_name -> cyclonedx.v1_6.Service
+	86,  // 131: cyclonedx.v1_6.Formula.workflows:type_name -> cyclonedx.v1_6.Workflow
+	66,  // 132: cyclonedx.v1_6.Formula.properties:type_name -> cyclonedx.v1_6.Property
+	96,  // 133: cyclonedx.v1_6.Workflow.resourceReferences:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	87,  // 134: cyclonedx.v1_6.Workflow.tasks:type_name -> cyclonedx.v1_6.Task
+	46,  // 135: cyclonedx.v1_6.Workflow.taskDependencies:type_name -> cyclonedx.v1_6.Dependency
+	19,  // 136: cyclonedx.v1_6.Workflow.taskTypes:type_name -> cyclonedx.v1_6.TaskType
+	92,  // 137: cyclonedx.v1_6.Workflow.trigger:type_name -> cyclonedx.v1_6.Trigger
+	88,  // 138: cyclonedx.v1_6.Workflow.steps:type_name -> cyclonedx.v1_6.Step
+	94,  // 139: cyclonedx.v1_6.Workflow.inputs:type_name -> cyclonedx.v1_6.InputType
+	95,  // 140: cyclonedx.v1_6.Workflow.outputs:type_name -> cyclonedx.v1_6.OutputType
+	151, // 141: cyclonedx.v1_6.Workflow.timeStart:type_name -> google.protobuf.Timestamp
+	151, // 142: cyclonedx.v1_6.Workflow.timeEnd:type_name -> google.protobuf.Timestamp
+	90,  // 143: cyclonedx.v1_6.Workflow.workspaces:type_name -> cyclonedx.v1_6.Workspace
+	46,  // 144: cyclonedx.v1_6.Workflow.runtimeTopology:type_name -> cyclonedx.v1_6.Dependency
+	66,  // 145: cyclonedx.v1_6.Workflow.properties:type_name -> cyclonedx.v1_6.Property
+	96,  // 146: cyclonedx.v1_6.Task.resourceReferences:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	19,  // 147: cyclonedx.v1_6.Task.taskTypes:type_name -> cyclonedx.v1_6.TaskType
+	92,  // 148: cyclonedx.v1_6.Task.trigger:type_name -> cyclonedx.v1_6.Trigger
+	88,  // 149: cyclonedx.v1_6.Task.steps:type_name -> cyclonedx.v1_6.Step
+	94,  // 150: cyclonedx.v1_6.Task.inputs:type_name -> cyclonedx.v1_6.InputType
+	95,  // 151: cyclonedx.v1_6.Task.outputs:type_name -> cyclonedx.v1_6.OutputType
+	151, // 152: cyclonedx.v1_6.Task.timeStart:type_name -> google.protobuf.Timestamp
+	151, // 153: cyclonedx.v1_6.Task.timeEnd:type_name -> google.protobuf.Timestamp
+	90,  // 154: cyclonedx.v1_6.Task.workspaces:type_name -> cyclonedx.v1_6.Workspace
+	46,  // 155: cyclonedx.v1_6.Task.runtimeTopology:type_name -> cyclonedx.v1_6.Dependency
+	66,  // 156: cyclonedx.v1_6.Task.properties:type_name -> cyclonedx.v1_6.Property
+	89,  // 157: cyclonedx.v1_6.Step.commands:type_name -> cyclonedx.v1_6.Command
+	66,  // 158: cyclonedx.v1_6.Step.properties:type_name -> cyclonedx.v1_6.Property
+	66,  // 159: cyclonedx.v1_6.Command.properties:type_name -> cyclonedx.v1_6.Property
+	96,  // 160: cyclonedx.v1_6.Workspace.resourceReferences:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	22,  // 161: cyclonedx.v1_6.Workspace.accessMode:type_name -> cyclonedx.v1_6.Workspace.AccessMode
+	91,  // 162: cyclonedx.v1_6.Workspace.volume:type_name -> cyclonedx.v1_6.Volume
+	66,  // 163: cyclonedx.v1_6.Workspace.properties:type_name -> cyclonedx.v1_6.Property
+	23,  // 164: cyclonedx.v1_6.Volume.mode:type_name -> cyclonedx.v1_6.Volume.VolumeMode
+	66,  // 165: cyclonedx.v1_6.Volume.properties:type_name -> cyclonedx.v1_6.Property
+	96,  // 166: cyclonedx.v1_6.Trigger.resourceReferences:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	24,  // 167: cyclonedx.v1_6.Trigger.type:type_name -> cyclonedx.v1_6.Trigger.TriggerType
+	93,  // 168: cyclonedx.v1_6.Trigger.event:type_name -> cyclonedx.v1_6.Event
+	97,  // 169: cyclonedx.v1_6.Trigger.conditions:type_name -> cyclonedx.v1_6.Condition
+	151, // 170: cyclonedx.v1_6.Trigger.timeActivated:type_name -> google.protobuf.Timestamp
+	94,  // 171: cyclonedx.v1_6.Trigger.inputs:type_name -> cyclonedx.v1_6.InputType
+	95,  // 172: cyclonedx.v1_6.Trigger.outputs:type_name -> cyclonedx.v1_6.OutputType
+	66,  // 173: cyclonedx.v1_6.Trigger.properties:type_name -> cyclonedx.v1_6.Property
+	151, // 174: cyclonedx.v1_6.Event.timeReceived:type_name -> google.protobuf.Timestamp
+	41,  // 175: cyclonedx.v1_6.Event.data:type_name -> cyclonedx.v1_6.AttachedText
+	96,  // 176: cyclonedx.v1_6.Event.source:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	96,  // 177: cyclonedx.v1_6.Event.target:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	66,  // 178: cyclonedx.v1_6.Event.properties:type_name -> cyclonedx.v1_6.Property
+	96,  // 179: cyclonedx.v1_6.InputType.source:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	96,  // 180: cyclonedx.v1_6.InputType.target:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	96,  // 181: cyclonedx.v1_6.InputType.resource:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	98,  // 182: cyclonedx.v1_6.InputType.parameters:type_name -> cyclonedx.v1_6.Parameter
+	99,  // 183: cyclonedx.v1_6.InputType.environmentVars:type_name -> cyclonedx.v1_6.EnvironmentVars
+	41,  // 184: cyclonedx.v1_6.InputType.data:type_name -> cyclonedx.v1_6.AttachedText
+	66,  // 185: cyclonedx.v1_6.InputType.properties:type_name -> cyclonedx.v1_6.Property
+	25,  // 186: cyclonedx.v1_6.OutputType.type:type_name -> cyclonedx.v1_6.OutputType.OutputTypeType
+	96,  // 187: cyclonedx.v1_6.OutputType.source:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	96,  // 188: cyclonedx.v1_6.OutputType.target:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	96,  // 189: cyclonedx.v1_6.OutputType.resource:type_name -> cyclonedx.v1_6.ResourceReferenceChoice
+	41,  // 190: cyclonedx.v1_6.OutputType.data:type_name -> cyclonedx.v1_6.AttachedText
+	99,  // 191: cyclonedx.v1_6.OutputType.environmentVars:type_name -> cyclonedx.v1_6.EnvironmentVars
+	66,  // 192: cyclonedx.v1_6.OutputType.properties:type_name -> cyclonedx.v1_6.Property
+	48,  // 193: cyclonedx.v1_6.ResourceReferenceChoice.externalReference:type_name -> cyclonedx.v1_6.ExternalReference
+	66,  // 194: cyclonedx.v1_6.Condition.properties:type_name -> cyclonedx.v1_6.Property
+	66,  // 195: cyclonedx.v1_6.EnvironmentVars.property:type_name -> cyclonedx.v1_6.Property
+	20,  // 196: cyclonedx.v1_6.ComponentData.type:type_name -> cyclonedx.v1_6.ComponentDataType
+	111, // 197: cyclonedx.v1_6.ComponentData.contents:type_name -> cyclonedx.v1_6.ComponentData.ComponentDataContents
+	102, // 198: cyclonedx.v1_6.ComponentData.graphics:type_name -> cyclonedx.v1_6.GraphicsCollection
+	101, // 199: cyclonedx.v1_6.ComponentData.governance:type_name -> cyclonedx.v1_6.DataGovernance
+	112, // 200: cyclonedx.v1_6.DataGovernance.custodians:type_name -> cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty
+	112, // 201: cyclonedx.v1_6.DataGovernance.stewards:type_name -> cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty
+	112, // 202: cyclonedx.v1_6.DataGovernance.owners:type_name -> cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty
+	113, // 203: cyclonedx.v1_6.GraphicsCollection.collection:type_name -> cyclonedx.v1_6.GraphicsCollection.Graphic
+	114, // 204: cyclonedx.v1_6.ModelCard.modelParameters:type_name -> cyclonedx.v1_6.ModelCard.ModelParameters
+	115, // 205: cyclonedx.v1_6.ModelCard.quantitativeAnalysis:type_name -> cyclonedx.v1_6.ModelCard.QuantitativeAnalysis
+	116, // 206: cyclonedx.v1_6.ModelCard.considerations:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations
+	30,  // 207: cyclonedx.v1_6.CryptoProperties.assetType:type_name -> cyclonedx.v1_6.CryptoProperties.CryptoAssetType
+	129, // 208: cyclonedx.v1_6.CryptoProperties.algorithmProperties:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties
+	130, // 209: cyclonedx.v1_6.CryptoProperties.certificateProperties:type_name -> cyclonedx.v1_6.CryptoProperties.CertificateProperties
+	131, // 210: cyclonedx.v1_6.CryptoProperties.relatedCryptoMaterialProperties:type_name -> cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties
+	132, // 211: cyclonedx.v1_6.CryptoProperties.protocolProperties:type_name -> cyclonedx.v1_6.CryptoProperties.ProtocolProperties
+	136, // 212: cyclonedx.v1_6.Declarations.assessors:type_name -> cyclonedx.v1_6.Declarations.Assessor
+	137, // 213: cyclonedx.v1_6.Declarations.attestations:type_name -> cyclonedx.v1_6.Declarations.Attestation
+	138, // 214: cyclonedx.v1_6.Declarations.claims:type_name -> cyclonedx.v1_6.Declarations.Claim
+	139, // 215: cyclonedx.v1_6.Declarations.evidence:type_name -> cyclonedx.v1_6.Declarations.Evidence
+	140, // 216: cyclonedx.v1_6.Declarations.targets:type_name -> cyclonedx.v1_6.Declarations.Targets
+	141, // 217: cyclonedx.v1_6.Declarations.affirmation:type_name -> cyclonedx.v1_6.Declarations.Affirmation
+	148, // 218: cyclonedx.v1_6.Definition.standards:type_name -> cyclonedx.v1_6.Definition.Standard
+	59,  // 219: cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	58,  // 220: cyclonedx.v1_6.Licensing.OrganizationalEntityOrContact.individual:type_name -> cyclonedx.v1_6.OrganizationalContact
+	41,  // 221: cyclonedx.v1_6.ReleaseNotes.Note.text:type_name -> cyclonedx.v1_6.AttachedText
+	59,  // 222: cyclonedx.v1_6.Annotation.Annotator.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	58,  // 223: cyclonedx.v1_6.Annotation.Annotator.individual:type_name -> cyclonedx.v1_6.OrganizationalContact
+	44,  // 224: cyclonedx.v1_6.Annotation.Annotator.component:type_name -> cyclonedx.v1_6.Component
+	63,  // 225: cyclonedx.v1_6.Annotation.Annotator.service:type_name -> cyclonedx.v1_6.Service
+	41,  // 226: cyclonedx.v1_6.ComponentData.ComponentDataContents.attachment:type_name -> cyclonedx.v1_6.AttachedText
+	66,  // 227: cyclonedx.v1_6.ComponentData.ComponentDataContents.properties:type_name -> cyclonedx.v1_6.Property
+	59,  // 228: cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	58,  // 229: cyclonedx.v1_6.DataGovernance.DataGovernanceResponsibleParty.contact:type_name -> cyclonedx.v1_6.OrganizationalContact
+	41,  // 230: cyclonedx.v1_6.GraphicsCollection.Graphic.image:type_name -> cyclonedx.v1_6.AttachedText
+	117, // 231: cyclonedx.v1_6.ModelCard.ModelParameters.approach:type_name -> cyclonedx.v1_6.ModelCard.ModelParameters.Approach
+	118, // 232: cyclonedx.v1_6.ModelCard.ModelParameters.datasets:type_name -> cyclonedx.v1_6.ModelCard.ModelParameters.Datasets
+	119, // 233: cyclonedx.v1_6.ModelCard.ModelParameters.inputs:type_name -> cyclonedx.v1_6.ModelCard.ModelParameters.MachineLearningInputOutputParameters
+	119, // 234: cyclonedx.v1_6.ModelCard.ModelParameters.outputs:type_name -> cyclonedx.v1_6.ModelCard.ModelParameters.MachineLearningInputOutputParameters
+	120, // 235: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.performanceMetrics:type_name -> cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics
+	102, // 236: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.graphics:type_name -> cyclonedx.v1_6.GraphicsCollection
+	122, // 237: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.ethicalConsiderations:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EthicalConsiderations
+	123, // 238: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.fairnessAssessments:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.FairnessAssessments
+	124, // 239: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.environmentalConsiderations:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnvironmentalConsiderations
+	21,  // 240: cyclonedx.v1_6.ModelCard.ModelParameters.Approach.type:type_name -> cyclonedx.v1_6.ModelParameterApproachType
+	100, // 241: cyclonedx.v1_6.ModelCard.ModelParameters.Datasets.dataset:type_name -> cyclonedx.v1_6.ComponentData
+	121, // 242: cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics.confidenceInterval:type_name -> cyclonedx.v1_6.ModelCard.QuantitativeAnalysis.PerformanceMetrics.ConfidenceInterval
+	125, // 243: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnvironmentalConsiderations.energyConsumptions:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption
+	66,  // 244: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnvironmentalConsiderations.properties:type_name -> cyclonedx.v1_6.Property
+	26,  // 245: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.activity:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyActivity
+	128, // 246: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.energyProviders:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType
+	126, // 247: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.activityEnergyCost:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure
+	127, // 248: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.co2CostEquivalent:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure
+	127, // 249: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.co2CostOffset:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure
+	66,  // 250: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyConsumption.properties:type_name -> cyclonedx.v1_6.Property
+	28,  // 251: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure.unit:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure.EnergyUnit
+	29,  // 252: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure.unit:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.Co2Measure.Co2Unit
+	59,  // 253: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	27,  // 254: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType.energySource:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergySource
+	126, // 255: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType.energyProvided:type_name -> cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyMeasure
+	48,  // 256: cyclonedx.v1_6.ModelCard.ModelCardConsiderations.EnergyProviderType.externalReferences:type_name -> cyclonedx.v1_6.ExternalReference
+	31,  // 257: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.primitive:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoPrimitive
+	32,  // 258: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.executionEnvironment:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoExecutionEnvironment
+	33,  // 259: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.implementationPlatform:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoImplementationPlatform
+	34,  // 260: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.certificationLevel:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoCertificationLevel
+	35,  // 261: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.mode:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmMode
+	36,  // 262: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.padding:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoAlgorithmPadding
+	37,  // 263: cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.cryptoFunctions:type_name -> cyclonedx.v1_6.CryptoProperties.AlgorithmProperties.CryptoFunction
+	151, // 264: cyclonedx.v1_6.CryptoProperties.CertificateProperties.notValidBefore:type_name -> google.protobuf.Timestamp
+	151, // 265: cyclonedx.v1_6.CryptoProperties.CertificateProperties.notValidAfter:type_name -> google.protobuf.Timestamp
+	38,  // 266: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.type:type_name -> cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialType
+	39,  // 267: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.state:type_name -> cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.RelatedCryptoMaterialState
+	151, // 268: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.creationDate:type_name -> google.protobuf.Timestamp
+	151, // 269: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.activationDate:type_name -> google.protobuf.Timestamp
+	151, // 270: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.updateDate:type_name -> google.protobuf.Timestamp
+	151, // 271: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.expirationDate:type_name -> google.protobuf.Timestamp
+	134, // 272: cyclonedx.v1_6.CryptoProperties.RelatedCryptoMaterialProperties.securedBy:type_name -> cyclonedx.v1_6.CryptoProperties.SecuredBy
+	40,  // 273: cyclonedx.v1_6.CryptoProperties.ProtocolProperties.type:type_name -> cyclonedx.v1_6.CryptoProperties.ProtocolProperties.CryptoProtocolType
+	133, // 274: cyclonedx.v1_6.CryptoProperties.ProtocolProperties.cipherSuites:type_name -> cyclonedx.v1_6.CryptoProperties.CipherSuite
+	135, // 275: cyclonedx.v1_6.CryptoProperties.ProtocolProperties.ikev2TransformTypes:type_name -> cyclonedx.v1_6.CryptoProperties.ProtocolProperties.Ikev2TransformTypes
+	59,  // 276: cyclonedx.v1_6.Declarations.Assessor.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	142, // 277: cyclonedx.v1_6.Declarations.Attestation.map:type_name -> cyclonedx.v1_6.Declarations.Attestation.AttestationMap
+	48,  // 278: cyclonedx.v1_6.Declarations.Claim.externalReferences:type_name -> cyclonedx.v1_6.ExternalReference
+	145, // 279: cyclonedx.v1_6.Declarations.Evidence.data:type_name -> cyclonedx.v1_6.Declarations.Evidence.Data
+	151, // 280: cyclonedx.v1_6.Declarations.Evidence.created:type_name -> google.protobuf.Timestamp
+	151, // 281: cyclonedx.v1_6.Declarations.Evidence.expires:type_name -> google.protobuf.Timestamp
+	58,  // 282: cyclonedx.v1_6.Declarations.Evidence.author:type_name -> cyclonedx.v1_6.OrganizationalContact
+	58,  // 283: cyclonedx.v1_6.Declarations.Evidence.reviewer:type_name -> cyclonedx.v1_6.OrganizationalContact
+	59,  // 284: cyclonedx.v1_6.Declarations.Targets.organizations:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	44,  // 285: cyclonedx.v1_6.Declarations.Targets.components:type_name -> cyclonedx.v1_6.Component
+	63,  // 286: cyclonedx.v1_6.Declarations.Targets.services:type_name -> cyclonedx.v1_6.Service
+	147, // 287: cyclonedx.v1_6.Declarations.Affirmation.signatories:type_name -> cyclonedx.v1_6.Declarations.Affirmation.Signatory
+	143, // 288: cyclonedx.v1_6.Declarations.Attestation.AttestationMap.conformance:type_name -> cyclonedx.v1_6.Declarations.Attestation.AttestationMap.Conformance
+	144, // 289: cyclonedx.v1_6.Declarations.Attestation.AttestationMap.confidence:type_name -> cyclonedx.v1_6.Declarations.Attestation.AttestationMap.Confidence
+	146, // 290: cyclonedx.v1_6.Declarations.Evidence.Data.contents:type_name -> cyclonedx.v1_6.Declarations.Evidence.Data.Contents
+	45,  // 291: cyclonedx.v1_6.Declarations.Evidence.Data.classification:type_name -> cyclonedx.v1_6.DataClassification
+	101, // 292: cyclonedx.v1_6.Declarations.Evidence.Data.governance:type_name -> cyclonedx.v1_6.DataGovernance
+	41,  // 293: cyclonedx.v1_6.Declarations.Evidence.Data.Contents.attachment:type_name -> cyclonedx.v1_6.AttachedText
+	59,  // 294: cyclonedx.v1_6.Declarations.Affirmation.Signatory.organization:type_name -> cyclonedx.v1_6.OrganizationalEntity
+	48,  // 295: cyclonedx.v1_6.Declarations.Affirmation.Signatory.externalReference:type_name -> cyclonedx.v1_6.ExternalReference
+	149, // 296: cyclonedx.v1_6.Definition.Standard.requirements:type_name -> cyclonedx.v1_6.Definition.Standard.Requirement
+	150, // 297: cyclonedx.v1_6.Definition.Standard.levels:type_name -> cyclonedx.v1_6.Definition.Standard.Level
+	48,  // 298: cyclonedx.v1_6.Definition.Standard.externalReferences:type_name -> cyclonedx.v1_6.ExternalReference
+	66,  // 299: cyclonedx.v1_6.Definition.Standard.Requirement.properties:type_name -> cyclonedx.v1_6.Property
+	48,  // 300: cyclonedx.v1_6.Definition.Standard.Requirement.externalReferences:type_name -> cyclonedx.v1_6.ExternalReference
+	301, // [301:301] is the sub-list for method output_type
+	301, // [301:301] is the sub-list for method input_type
+	301, // [301:301] is the sub-list for extension type_name
+	301, // [301:301] is the sub-list for extension extendee
+	0,   // [0:301] is the sub-list for field type_name
+}
+
+func init() { file_bom_1_6_proto_init() }
+func file_bom_1_6_proto_init() {
+	if File_bom_1_6_proto != nil {
+		return
+	}
+	file_bom_1_6_proto_msgTypes[0].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[1].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[2].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[3].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[6].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[7].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[9].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[10].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[11].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[12].OneofWrappers = []any{
+		(*LicenseChoice_License)(nil),
+		(*LicenseChoice_Expression)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[13].OneofWrappers = []any{
+		(*License_Id)(nil),
+		(*License_Name)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[14].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[15].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[16].OneofWrappers = []any{
+		(*Lifecycles_Phase)(nil),
+		(*Lifecycles_Name)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[17].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[18].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[19].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[20].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[21].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[22].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[23].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[24].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[25].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[26].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[28].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[29].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[30].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[31].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[33].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[34].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[36].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[37].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[39].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[41].OneofWrappers = []any{
+		(*VulnerabilityAffectedVersions_Version)(nil),
+		(*VulnerabilityAffectedVersions_Range)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[42].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[43].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[44].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[45].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[46].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[47].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[48].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[49].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[50].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[51].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[52].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[53].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[54].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[55].OneofWrappers = []any{
+		(*ResourceReferenceChoice_Ref)(nil),
+		(*ResourceReferenceChoice_ExternalReference)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[56].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[57].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[58].OneofWrappers = []any{
+		(*EnvironmentVars_Property)(nil),
+		(*EnvironmentVars_Value)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[59].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[61].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[62].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[63].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[64].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[66].OneofWrappers = []any{
+		(*Licensing_OrganizationalEntityOrContact_Organization)(nil),
+		(*Licensing_OrganizationalEntityOrContact_Individual)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[67].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[68].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[69].OneofWrappers = []any{
+		(*Annotation_Annotator_Organization)(nil),
+		(*Annotation_Annotator_Individual)(nil),
+		(*Annotation_Annotator_Component)(nil),
+		(*Annotation_Annotator_Service)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[70].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[71].OneofWrappers = []any{
+		(*DataGovernance_DataGovernanceResponsibleParty_Organization)(nil),
+		(*DataGovernance_DataGovernanceResponsibleParty_Contact)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[72].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[73].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[74].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[75].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[76].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[77].OneofWrappers = []any{
+		(*ModelCard_ModelParameters_Datasets_Dataset)(nil),
+		(*ModelCard_ModelParameters_Datasets_Ref)(nil),
+	}
+	file_bom_1_6_proto_msgTypes[78].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[79].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[80].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[81].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[82].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[84].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[87].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[88].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[89].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[90].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[91].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[92].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[93].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[94].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[95].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[96].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[97].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[98].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[100].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[101].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[102].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[103].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[104].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[105].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[106].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[107].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[108].OneofWrappers = []any{}
+	file_bom_1_6_proto_msgTypes[109].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bom_1_6_proto_rawDesc), len(file_bom_1_6_proto_rawDesc)),
+			NumEnums:      41,
+			NumMessages:   110,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_bom_1_6_proto_goTypes,
+		DependencyIndexes: file_bom_1_6_proto_depIdxs,
+		EnumInfos:         file_bom_1_6_proto_enumTypes,
+		MessageInfos:      file_bom_1_6_proto_msgTypes,
+	}.Build()
+	File_bom_1_6_proto = out.File
+	file_bom_1_6_proto_goTypes = nil
+	file_bom_1_6_proto_depIdxs = nil
+}
@@ -0,0 +1,5 @@
+// Package cyclonedxpb contains the Go bindings for the CycloneDX 1.6
+// protobuf schema.
+package cyclonedxpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative bom-1.6.proto